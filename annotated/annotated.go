@@ -0,0 +1,128 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package annotated implements per-element metadata for collections.
+// An Annotated[T, M] pairs a value with metadata such as a timestamp,
+// a source or a score, and the helpers in this package adapt predicates
+// and mapping functions so they operate on either part of the pair:
+//
+//	events := list.NewList[annotated.Annotated[string, time.Time]]()
+//	events.Add(annotated.WithMeta("login", time.Now()))
+//
+//	recent := events.Filter(annotated.ByMeta[string](func(t time.Time) bool {
+//	  return time.Since(t) < time.Hour
+//	}))
+//	names := slices.Collect(annotated.Values(recent))
+//
+// This avoids declaring ad-hoc tuple structs throughout a pipeline.
+package annotated
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// Annotated pairs a value with its metadata.
+type Annotated[T, M any] struct {
+	Value T
+	Meta  M
+}
+
+// WithMeta returns v annotated with the metadata m.
+//
+// example usage:
+//
+//	WithMeta("gopher", 0.9)
+//
+// output:
+//
+//	gopher [0.9]
+func WithMeta[T, M any](v T, m M) Annotated[T, M] {
+	return Annotated[T, M]{Value: v, Meta: m}
+}
+
+// String implements the Stringer interface.
+func (a Annotated[T, M]) String() string {
+	return fmt.Sprintf("%v [%v]", a.Value, a.Meta)
+}
+
+// Annotate computes the metadata of every element of s with f,
+// adds the annotated elements to dst and returns dst.
+//
+// example usage:
+//
+//	words := list.NewList([]string{"go", "gopher"})
+//	Annotate(words, list.NewList[Annotated[string, int]](), func(s string) int { return len(s) })
+//
+// output:
+//
+//	[go [2] gopher [6]]
+func Annotate[C collection.Collection[Annotated[T, M]], T, M any](s collection.Collection[T], dst C, f func(T) M) C {
+	for v := range s.Values() {
+		dst.Add(Annotated[T, M]{Value: v, Meta: f(v)})
+	}
+	return dst
+}
+
+// Values returns an iterator over the values of an annotated collection, stripping the metadata.
+func Values[T, M any](c collection.Collection[Annotated[T, M]]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for a := range c.Values() {
+			if !yield(a.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Metas returns an iterator over the metadata of an annotated collection.
+func Metas[T, M any](c collection.Collection[Annotated[T, M]]) iter.Seq[M] {
+	return func(yield func(M) bool) {
+		for a := range c.Values() {
+			if !yield(a.Meta) {
+				return
+			}
+		}
+	}
+}
+
+// ByValue adapts a predicate on values to a predicate on annotated values.
+//
+// example usage:
+//
+//	l.Filter(ByValue[string, float64](func(s string) bool { return s != "" }))
+func ByValue[T, M any](f func(T) bool) func(Annotated[T, M]) bool {
+	return func(a Annotated[T, M]) bool { return f(a.Value) }
+}
+
+// ByMeta adapts a predicate on metadata to a predicate on annotated values.
+//
+// example usage:
+//
+//	l.Filter(ByMeta[string](func(score float64) bool { return score > 0.5 }))
+func ByMeta[T, M any](f func(M) bool) func(Annotated[T, M]) bool {
+	return func(a Annotated[T, M]) bool { return f(a.Meta) }
+}
+
+// MapValue applies f to the value and keeps the metadata.
+// Pass it to collection.Map or collection.Mapped to project the values
+// of an annotated collection without losing their metadata.
+//
+// example usage:
+//
+//	collection.Map(l, MapValue[string, string, float64](strings.ToUpper))
+func MapValue[T, U, M any](f func(T) U) func(Annotated[T, M]) Annotated[U, M] {
+	return func(a Annotated[T, M]) Annotated[U, M] {
+		return Annotated[U, M]{Value: f(a.Value), Meta: a.Meta}
+	}
+}
+
+// MapMeta applies f to the metadata and keeps the value.
+func MapMeta[T, M, N any](f func(M) N) func(Annotated[T, M]) Annotated[T, N] {
+	return func(a Annotated[T, M]) Annotated[T, N] {
+		return Annotated[T, N]{Value: a.Value, Meta: f(a.Meta)}
+	}
+}
@@ -0,0 +1,58 @@
+package annotated
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/list"
+)
+
+func scored() *list.List[Annotated[string, float64]] {
+	return list.NewList([]Annotated[string, float64]{
+		WithMeta("go", 0.9),
+		WithMeta("cobol", 0.2),
+		WithMeta("rust", 0.7),
+	})
+}
+
+func TestAnnotate(t *testing.T) {
+	words := list.NewList([]string{"go", "gopher"})
+	got := Annotate(words, list.NewList[Annotated[string, int]](), func(s string) int { return len(s) })
+	if !slices.Equal(slices.Collect(Metas(got)), []int{2, 6}) {
+		t.Errorf("Annotate() = %v, want metadata [2 6]", got)
+	}
+	if got.ToSlice()[0].String() != "go [2]" {
+		t.Errorf("String() = %q, want %q", got.ToSlice()[0].String(), "go [2]")
+	}
+}
+
+func TestFilters(t *testing.T) {
+	tests := []struct {
+		name string
+		got  *list.List[Annotated[string, float64]]
+		want []string
+	}{
+		{name: "by meta", got: scored().Filter(ByMeta[string](func(s float64) bool { return s > 0.5 })), want: []string{"go", "rust"}},
+		{name: "by value", got: scored().Filter(ByValue[string, float64](func(s string) bool { return len(s) > 2 })), want: []string{"cobol", "rust"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(Values(tt.got)); !slices.Equal(got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapValueAndMeta(t *testing.T) {
+	upper := collection.Map(scored(), MapValue[string, string, float64](strings.ToUpper))
+	if upper[0] != WithMeta("GO", 0.9) {
+		t.Errorf("MapValue() = %v, want GO [0.9]", upper[0])
+	}
+	ranked := collection.Map(scored(), MapMeta[string](func(s float64) bool { return s > 0.5 }))
+	if ranked[1] != WithMeta("cobol", false) {
+		t.Errorf("MapMeta() = %v, want cobol [false]", ranked[1])
+	}
+}
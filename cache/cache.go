@@ -0,0 +1,62 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package cache implements bounded in-memory caches built on List and a
+// map of list nodes, giving O(1) lookups and updates:
+//
+//   - LRU evicts the least recently used entry when full,
+//   - LFU evicts the least frequently used one, the least recently used
+//     among those in case of a tie.
+//
+// Entries can also expire after a time to live, and a callback can be
+// notified of every eviction, i.e. to release the resources of a value:
+//
+//	sessions := cache.NewLRU[string, *Session](10_000, 30*time.Minute)
+//	sessions.OnEvict(func(id string, s *Session) { s.Close() })
+//	sessions.Put(id, s)
+//	s, ok := sessions.Get(id)
+//
+// Caches are safe for concurrent use. Eviction callbacks are called
+// without holding the lock of the cache, so they may use it.
+package cache
+
+import (
+	"time"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// item is a cached entry.
+type item[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time // zero if the entry never expires
+	hits    int       // number of accesses, used by LFU
+}
+
+func (it *item[K, V]) entry() collection.Entry[K, V] {
+	return collection.Entry[K, V]{Key: it.key, Value: it.value}
+}
+
+func (it *item[K, V]) expired(now time.Time) bool {
+	return !it.expires.IsZero() && !now.Before(it.expires)
+}
+
+// expiry returns the expiration time of an entry written now.
+func expiry(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(ttl)
+}
+
+// notify calls the eviction callback for each evicted item.
+func notify[K comparable, V any](f func(K, V), evicted []*item[K, V]) {
+	if f == nil {
+		return
+	}
+	for _, it := range evicted {
+		f(it.key, it.value)
+	}
+}
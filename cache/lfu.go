@@ -0,0 +1,315 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/list"
+)
+
+// LFU is a cache of bounded size evicting the least frequently used entry,
+// the least recently used one among entries used as often. Get and Put
+// count as uses, Peek and Contains do not.
+//
+// Entries are kept in one list per number of uses, so that a use moves an
+// entry to the next list in O(1).
+type LFU[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	items    map[K]*list.Node[*item[K, V]]
+	buckets  map[int]*list.List[*item[K, V]] // entries by number of uses, most recently used first
+	minHits  int                             // smallest number of uses, if its bucket exists
+	onEvict  func(K, V)
+	now      func() time.Time
+}
+
+// NewLFU returns an empty cache holding at most capacity entries, each
+// of them expiring ttl after it was last put, or never if ttl is 0.
+// It panics if capacity is less than 1.
+//
+// example usage:
+//
+//	c := NewLFU[string, int](2, 0)
+//	c.Put("a", 1)
+//	c.Put("b", 2)
+//	c.Get("a")
+//	c.Get("b")
+//	c.Get("b")
+//	c.Put("c", 3)
+//
+// output:
+//
+//	LFU(string, int) [b:2 c:3]
+func NewLFU[K comparable, V any](capacity int, ttl time.Duration) *LFU[K, V] {
+	if capacity < 1 {
+		panic("cache: capacity must be at least 1")
+	}
+	return &LFU[K, V]{
+		capacity: capacity,
+		ttl:      ttl,
+		items:    make(map[K]*list.Node[*item[K, V]]),
+		buckets:  make(map[int]*list.List[*item[K, V]]),
+		now:      time.Now,
+	}
+}
+
+// All returns an iterator over a snapshot of the entries that have not
+// expired, from the most to the least frequently used.
+func (c *LFU[K, V]) All() iter.Seq2[K, V] {
+	entries := c.snapshot()
+	return func(yield func(K, V) bool) {
+		for _, e := range entries {
+			if !yield(e.Key, e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Capacity returns the maximum number of entries in the cache.
+func (c *LFU[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Clear removes all the entries, without calling the eviction callback.
+func (c *LFU[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.items)
+	clear(c.buckets)
+}
+
+// Contains returns true if the cache holds an unexpired entry for the key.
+// It does not count as a use of the entry.
+func (c *LFU[K, V]) Contains(k K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, ok := c.items[k]
+	return ok && !n.Value().expired(c.now())
+}
+
+// Delete removes the entry of the key, without calling the eviction
+// callback, and returns true if it was present.
+func (c *LFU[K, V]) Delete(k K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, ok := c.items[k]
+	if ok {
+		c.remove(n)
+	}
+	return ok
+}
+
+// Entries returns an iterator over a snapshot of the entries, see All.
+func (c *LFU[K, V]) Entries() iter.Seq[collection.Entry[K, V]] {
+	entries := c.snapshot()
+	return func(yield func(collection.Entry[K, V]) bool) {
+		for _, e := range entries {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Get returns the value of the key and counts a use of it, or returns
+// false if the cache holds no unexpired entry for the key.
+func (c *LFU[K, V]) Get(k K) (V, bool) {
+	c.mu.Lock()
+	n, ok := c.items[k]
+	if !ok {
+		c.mu.Unlock()
+		return *new(V), false
+	}
+	it := n.Value()
+	if it.expired(c.now()) {
+		c.remove(n)
+		f := c.onEvict
+		c.mu.Unlock()
+		notify(f, []*item[K, V]{it})
+		return *new(V), false
+	}
+	c.use(n)
+	c.mu.Unlock()
+	return it.value, true
+}
+
+// Hits returns the number of uses of the key, or 0 if the cache does not contain it.
+func (c *LFU[K, V]) Hits(k K) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n, ok := c.items[k]; ok {
+		return n.Value().hits
+	}
+	return 0
+}
+
+// Keys returns an iterator over a snapshot of the keys, see All.
+func (c *LFU[K, V]) Keys() iter.Seq[K] {
+	entries := c.snapshot()
+	return func(yield func(K) bool) {
+		for _, e := range entries {
+			if !yield(e.Key) {
+				return
+			}
+		}
+	}
+}
+
+// Length returns the number of entries in the cache,
+// including the expired ones not purged yet.
+func (c *LFU[K, V]) Length() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// OnEvict sets a function called with the entries evicted because the
+// cache is full or because they expired.
+func (c *LFU[K, V]) OnEvict(f func(K, V)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onEvict = f
+}
+
+// Peek returns the value of the key like Get, without counting a use.
+func (c *LFU[K, V]) Peek(k K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n, ok := c.items[k]; ok && !n.Value().expired(c.now()) {
+		return n.Value().value, true
+	}
+	return *new(V), false
+}
+
+// Purge removes the expired entries, calling the eviction callback for
+// each of them, and returns their number.
+func (c *LFU[K, V]) Purge() int {
+	c.mu.Lock()
+	now := c.now()
+	var evicted []*item[K, V]
+	for _, n := range c.items {
+		if n.Value().expired(now) {
+			evicted = append(evicted, n.Value())
+			c.remove(n)
+		}
+	}
+	f := c.onEvict
+	c.mu.Unlock()
+	notify(f, evicted)
+	return len(evicted)
+}
+
+// Put sets the value of the key, counts a use of it and resets its time
+// to live. When the cache is full, the least frequently used entry is evicted.
+func (c *LFU[K, V]) Put(k K, v V) {
+	c.mu.Lock()
+	now := c.now()
+	if n, ok := c.items[k]; ok {
+		it := n.Value()
+		it.value, it.expires = v, expiry(now, c.ttl)
+		c.use(n)
+		c.mu.Unlock()
+		return
+	}
+	var evicted []*item[K, V]
+	if len(c.items) >= c.capacity {
+		last := c.bucket(c.leastHits()).LastNode()
+		evicted = append(evicted, last.Value())
+		c.remove(last)
+	}
+	c.items[k] = c.bucket(1).AddFirstNode(&item[K, V]{key: k, value: v, expires: expiry(now, c.ttl), hits: 1})
+	c.minHits = 1
+	f := c.onEvict
+	c.mu.Unlock()
+	notify(f, evicted)
+}
+
+// Values returns an iterator over a snapshot of the values, see All.
+func (c *LFU[K, V]) Values() iter.Seq[V] {
+	entries := c.snapshot()
+	return func(yield func(V) bool) {
+		for _, e := range entries {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// implement the Stringer interface
+func (c *LFU[K, V]) String() string {
+	return fmt.Sprintf("LFU(%T, %T) %v", *new(K), *new(V), formatEntries(c.snapshot()))
+}
+
+// bucket returns the list of the entries used hits times, creating it
+// if needed. The lock must be held.
+func (c *LFU[K, V]) bucket(hits int) *list.List[*item[K, V]] {
+	b, ok := c.buckets[hits]
+	if !ok {
+		b = list.NewList[*item[K, V]]()
+		c.buckets[hits] = b
+	}
+	return b
+}
+
+// leastHits returns the smallest number of uses of an entry. The lock
+// must be held and the cache must not be empty.
+func (c *LFU[K, V]) leastHits() int {
+	if _, ok := c.buckets[c.minHits]; !ok {
+		// the bucket was emptied by Delete or by an expiration
+		c.minHits = slices.Min(slices.Collect(maps.Keys(c.buckets)))
+	}
+	return c.minHits
+}
+
+// remove removes the node of an item. The lock must be held.
+func (c *LFU[K, V]) remove(n *list.Node[*item[K, V]]) {
+	it := n.Value()
+	delete(c.items, it.key)
+	b := c.buckets[it.hits]
+	b.RemoveNode(n)
+	if b.IsEmpty() {
+		delete(c.buckets, it.hits)
+	}
+}
+
+// use counts a use of the item of the node, moving it to the next bucket.
+// The lock must be held.
+func (c *LFU[K, V]) use(n *list.Node[*item[K, V]]) {
+	it := n.Value()
+	c.remove(n)
+	if it.hits == c.minHits {
+		if _, ok := c.buckets[it.hits]; !ok {
+			c.minHits++
+		}
+	}
+	it.hits++
+	c.items[it.key] = c.bucket(it.hits).AddFirstNode(it)
+}
+
+// snapshot returns the unexpired entries, most frequently used first.
+func (c *LFU[K, V]) snapshot() []collection.Entry[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	entries := make([]collection.Entry[K, V], 0, len(c.items))
+	for _, hits := range slices.Backward(slices.Sorted(maps.Keys(c.buckets))) {
+		for it := range c.buckets[hits].Values() {
+			if !it.expired(now) {
+				entries = append(entries, it.entry())
+			}
+		}
+	}
+	return entries
+}
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLFU_Eviction(t *testing.T) {
+	tests := []struct {
+		name        string
+		ops         func(c *LFU[string, int])
+		want        []string
+		wantEvicted []string
+	}{
+		{name: "least used evicted", ops: func(c *LFU[string, int]) { c.Get("a"); c.Get("c"); c.Put("d", 4) }, want: []string{"c", "a", "d"}, wantEvicted: []string{"b"}},
+		{name: "tie broken by recency", ops: func(c *LFU[string, int]) { c.Put("d", 4) }, want: []string{"d", "c", "b"}, wantEvicted: []string{"a"}},
+		{name: "new entries start over", ops: func(c *LFU[string, int]) {
+			c.Get("a")
+			c.Get("b")
+			c.Get("c")
+			c.Put("d", 4)
+			c.Put("e", 5)
+		}, want: []string{"c", "b", "e"}, wantEvicted: []string{"a", "d"}},
+		{name: "delete of least used", ops: func(c *LFU[string, int]) {
+			c.Get("a")
+			c.Get("b")
+			c.Delete("c")
+			c.Put("d", 4)
+			c.Get("d")
+			c.Get("d")
+			c.Put("e", 5)
+		}, want: []string{"d", "b", "e"}, wantEvicted: []string{"a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewLFU[string, int](3, 0)
+			var evicted []string
+			c.OnEvict(func(k string, _ int) { evicted = append(evicted, k) })
+			c.Put("a", 1)
+			c.Put("b", 2)
+			c.Put("c", 3)
+			tt.ops(c)
+			if got := slices.Collect(c.Keys()); !slices.Equal(got, tt.want) {
+				t.Errorf("Keys() = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(evicted, tt.wantEvicted) {
+				t.Errorf("evicted %v, want %v", evicted, tt.wantEvicted)
+			}
+		})
+	}
+}
+
+func TestLFU_Hits(t *testing.T) {
+	c := NewLFU[string, int](2, 0)
+	c.Put("a", 1)
+	c.Put("a", 2)
+	c.Get("a")
+	c.Peek("a")
+	if c.Hits("a") != 3 || c.Hits("z") != 0 {
+		t.Errorf("Hits(a) = %v, want 3", c.Hits("a"))
+	}
+	if got := c.String(); got != "LFU(string, int) [a:2]" {
+		t.Errorf("String() = %v", got)
+	}
+}
+
+func TestLFU_TTL(t *testing.T) {
+	clock := time.Unix(0, 0)
+	c := NewLFU[string, int](2, time.Minute)
+	c.now = func() time.Time { return clock }
+	c.Put("a", 1)
+	c.Get("a")
+	c.Put("b", 2)
+	clock = clock.Add(2 * time.Minute)
+	c.Put("a", 10) // resets the time to live of a
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("b did not expire")
+	}
+	c.Put("c", 3)
+	c.Put("d", 4)
+	if got := slices.Collect(c.Keys()); !slices.Equal(got, []string{"a", "d"}) {
+		t.Errorf("Keys() = %v, want [a d]", got)
+	}
+	clock = clock.Add(2 * time.Minute)
+	if n := c.Purge(); n != 2 || c.Length() != 0 {
+		t.Errorf("Purge() = %v, Length() = %v", n, c.Length())
+	}
+}
+
+func TestLFU_Concurrent(t *testing.T) {
+	c := NewLFU[int, int](64, 0)
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				c.Put(w*1000+i, i)
+				c.Get(w*1000 + i/2)
+			}
+		}()
+	}
+	wg.Wait()
+	if c.Length() != 64 || len(slices.Collect(c.Entries())) != 64 {
+		t.Errorf("Length() = %v, want 64", c.Length())
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/list"
+)
+
+// LRU is a cache of bounded size evicting the least recently used entry.
+// Get and Put count as uses, Peek and Contains do not.
+type LRU[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	items    map[K]*list.Node[*item[K, V]]
+	order    *list.List[*item[K, V]] // most recently used first
+	onEvict  func(K, V)
+	now      func() time.Time
+}
+
+// NewLRU returns an empty cache holding at most capacity entries, each
+// of them expiring ttl after it was last put, or never if ttl is 0.
+// It panics if capacity is less than 1.
+//
+// example usage:
+//
+//	c := NewLRU[string, int](2, 0)
+//	c.Put("a", 1)
+//	c.Put("b", 2)
+//	c.Get("a")
+//	c.Put("c", 3)
+//
+// output:
+//
+//	LRU(string, int) [c:3 a:1]
+func NewLRU[K comparable, V any](capacity int, ttl time.Duration) *LRU[K, V] {
+	if capacity < 1 {
+		panic("cache: capacity must be at least 1")
+	}
+	return &LRU[K, V]{
+		capacity: capacity,
+		ttl:      ttl,
+		items:    make(map[K]*list.Node[*item[K, V]]),
+		order:    list.NewList[*item[K, V]](),
+		now:      time.Now,
+	}
+}
+
+// All returns an iterator over a snapshot of the entries that have not
+// expired, from the most to the least recently used.
+func (c *LRU[K, V]) All() iter.Seq2[K, V] {
+	entries := c.snapshot()
+	return func(yield func(K, V) bool) {
+		for _, e := range entries {
+			if !yield(e.Key, e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Capacity returns the maximum number of entries in the cache.
+func (c *LRU[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Clear removes all the entries, without calling the eviction callback.
+func (c *LRU[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.items)
+	c.order = list.NewList[*item[K, V]]()
+}
+
+// Contains returns true if the cache holds an unexpired entry for the key.
+// It does not count as a use of the entry.
+func (c *LRU[K, V]) Contains(k K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, ok := c.items[k]
+	return ok && !n.Value().expired(c.now())
+}
+
+// Delete removes the entry of the key, without calling the eviction
+// callback, and returns true if it was present.
+func (c *LRU[K, V]) Delete(k K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, ok := c.items[k]
+	if ok {
+		delete(c.items, k)
+		c.order.RemoveNode(n)
+	}
+	return ok
+}
+
+// Entries returns an iterator over a snapshot of the entries, see All.
+func (c *LRU[K, V]) Entries() iter.Seq[collection.Entry[K, V]] {
+	entries := c.snapshot()
+	return func(yield func(collection.Entry[K, V]) bool) {
+		for _, e := range entries {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Get returns the value of the key and marks it as the most recently used,
+// or returns false if the cache holds no unexpired entry for the key.
+func (c *LRU[K, V]) Get(k K) (V, bool) {
+	c.mu.Lock()
+	n, ok := c.items[k]
+	if !ok {
+		c.mu.Unlock()
+		return *new(V), false
+	}
+	it := n.Value()
+	if it.expired(c.now()) {
+		c.remove(n)
+		f := c.onEvict
+		c.mu.Unlock()
+		notify(f, []*item[K, V]{it})
+		return *new(V), false
+	}
+	c.order.MoveToFront(n)
+	c.mu.Unlock()
+	return it.value, true
+}
+
+// Keys returns an iterator over a snapshot of the keys, see All.
+func (c *LRU[K, V]) Keys() iter.Seq[K] {
+	entries := c.snapshot()
+	return func(yield func(K) bool) {
+		for _, e := range entries {
+			if !yield(e.Key) {
+				return
+			}
+		}
+	}
+}
+
+// Length returns the number of entries in the cache,
+// including the expired ones not purged yet.
+func (c *LRU[K, V]) Length() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// OnEvict sets a function called with the entries evicted because the
+// cache is full or because they expired.
+func (c *LRU[K, V]) OnEvict(f func(K, V)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onEvict = f
+}
+
+// Peek returns the value of the key like Get, without marking it as used.
+func (c *LRU[K, V]) Peek(k K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n, ok := c.items[k]; ok && !n.Value().expired(c.now()) {
+		return n.Value().value, true
+	}
+	return *new(V), false
+}
+
+// Purge removes the expired entries, calling the eviction callback for
+// each of them, and returns their number.
+func (c *LRU[K, V]) Purge() int {
+	c.mu.Lock()
+	now := c.now()
+	var evicted []*item[K, V]
+	for _, n := range c.items {
+		if n.Value().expired(now) {
+			evicted = append(evicted, n.Value())
+			c.remove(n)
+		}
+	}
+	f := c.onEvict
+	c.mu.Unlock()
+	notify(f, evicted)
+	return len(evicted)
+}
+
+// Put sets the value of the key, marks it as the most recently used and
+// resets its time to live. When the cache is full, the least recently used
+// entry is evicted.
+func (c *LRU[K, V]) Put(k K, v V) {
+	c.mu.Lock()
+	now := c.now()
+	if n, ok := c.items[k]; ok {
+		it := n.Value()
+		it.value, it.expires = v, expiry(now, c.ttl)
+		c.order.MoveToFront(n)
+		c.mu.Unlock()
+		return
+	}
+	c.items[k] = c.order.AddFirstNode(&item[K, V]{key: k, value: v, expires: expiry(now, c.ttl)})
+	var evicted []*item[K, V]
+	if len(c.items) > c.capacity {
+		last := c.order.LastNode()
+		evicted = append(evicted, last.Value())
+		c.remove(last)
+	}
+	f := c.onEvict
+	c.mu.Unlock()
+	notify(f, evicted)
+}
+
+// Values returns an iterator over a snapshot of the values, see All.
+func (c *LRU[K, V]) Values() iter.Seq[V] {
+	entries := c.snapshot()
+	return func(yield func(V) bool) {
+		for _, e := range entries {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// implement the Stringer interface
+func (c *LRU[K, V]) String() string {
+	return fmt.Sprintf("LRU(%T, %T) %v", *new(K), *new(V), formatEntries(c.snapshot()))
+}
+
+// remove removes the node of an item. The lock must be held.
+func (c *LRU[K, V]) remove(n *list.Node[*item[K, V]]) {
+	delete(c.items, n.Value().key)
+	c.order.RemoveNode(n)
+}
+
+// snapshot returns the unexpired entries, most recently used first.
+func (c *LRU[K, V]) snapshot() []collection.Entry[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	entries := make([]collection.Entry[K, V], 0, len(c.items))
+	for it := range c.order.Values() {
+		if !it.expired(now) {
+			entries = append(entries, it.entry())
+		}
+	}
+	return entries
+}
+
+// formatEntries formats entries as [k:v k:v].
+func formatEntries[K comparable, V any](entries []collection.Entry[K, V]) string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, e := range entries {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(e.String())
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLRU_Eviction(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  func(c *LRU[string, int])
+		want []string
+	}{
+		{name: "oldest evicted", ops: func(c *LRU[string, int]) { c.Put("d", 4) }, want: []string{"d", "c", "b"}},
+		{name: "get refreshes", ops: func(c *LRU[string, int]) { c.Get("a"); c.Put("d", 4) }, want: []string{"d", "a", "c"}},
+		{name: "put refreshes", ops: func(c *LRU[string, int]) { c.Put("a", 10); c.Put("d", 4) }, want: []string{"d", "a", "c"}},
+		{name: "peek does not refresh", ops: func(c *LRU[string, int]) { c.Peek("a"); c.Contains("a"); c.Put("d", 4) }, want: []string{"d", "c", "b"}},
+		{name: "delete makes room", ops: func(c *LRU[string, int]) { c.Delete("b"); c.Put("d", 4) }, want: []string{"d", "c", "a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewLRU[string, int](3, 0)
+			var evicted []string
+			c.OnEvict(func(k string, _ int) { evicted = append(evicted, k) })
+			c.Put("a", 1)
+			c.Put("b", 2)
+			c.Put("c", 3)
+			tt.ops(c)
+			if got := slices.Collect(c.Keys()); !slices.Equal(got, tt.want) {
+				t.Errorf("Keys() = %v, want %v", got, tt.want)
+			}
+			wantEvicted := 1
+			if tt.name == "delete makes room" {
+				wantEvicted = 0
+			}
+			if len(evicted) != wantEvicted {
+				t.Errorf("evicted %v, want %v entries", evicted, wantEvicted)
+			}
+		})
+	}
+}
+
+func TestLRU_TTL(t *testing.T) {
+	clock := time.Unix(0, 0)
+	c := NewLRU[string, int](10, time.Minute)
+	c.now = func() time.Time { return clock }
+	var evicted []string
+	c.OnEvict(func(k string, _ int) { evicted = append(evicted, k) })
+	c.Put("a", 1)
+	c.Put("b", 2)
+	clock = clock.Add(30 * time.Second)
+	c.Put("b", 20) // resets the time to live of b
+	clock = clock.Add(45 * time.Second)
+	if _, ok := c.Get("a"); ok || c.Contains("a") {
+		t.Errorf("a did not expire")
+	}
+	if v, ok := c.Get("b"); !ok || v != 20 {
+		t.Errorf("Get(b) = %v, %v, want 20, true", v, ok)
+	}
+	c.Put("c", 3)
+	clock = clock.Add(time.Minute)
+	if c.Length() != 2 || len(slices.Collect(c.Values())) != 0 {
+		t.Errorf("Length() = %v, Values() = %v", c.Length(), slices.Collect(c.Values()))
+	}
+	if n := c.Purge(); n != 2 || c.Length() != 0 {
+		t.Errorf("Purge() = %v, Length() = %v", n, c.Length())
+	}
+	slices.Sort(evicted)
+	if !slices.Equal(evicted, []string{"a", "b", "c"}) {
+		t.Errorf("evicted %v", evicted)
+	}
+}
+
+func TestLRU_String(t *testing.T) {
+	c := NewLRU[string, int](2, 0)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a")
+	c.Put("c", 3)
+	if got := c.String(); got != "LRU(string, int) [c:3 a:1]" {
+		t.Errorf("String() = %v", got)
+	}
+	c.Clear()
+	if c.Length() != 0 || c.Contains("c") {
+		t.Errorf("Clear() left %v", c)
+	}
+}
+
+func TestLRU_Concurrent(t *testing.T) {
+	c := NewLRU[int, int](64, 0)
+	c.OnEvict(func(k, _ int) { c.Contains(k) }) // callbacks may use the cache
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				c.Put(w*1000+i, i)
+				c.Get(w*1000 + i/2)
+			}
+		}()
+	}
+	wg.Wait()
+	if c.Length() != 64 {
+		t.Errorf("Length() = %v, want 64", c.Length())
+	}
+}
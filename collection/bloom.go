@@ -0,0 +1,127 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// bloom.go defines the options of the set functions Diff, Intersect and
+// SubsetOf, and the Bloom filter they can use instead of a hash set of
+// their second collection.
+
+package collection
+
+import "math"
+
+// SetOption configures the hash set built by Diff, Intersect and SubsetOf.
+type SetOption func(*setConfig)
+
+type setConfig struct {
+	falsePositiveRate float64 // 0 when no Bloom filter is used
+}
+
+// WithBloomFilter replaces the hash set of the second collection with a
+// Bloom filter with the given false positive rate, i.e. 0.01, of about
+// 10 bits per element. Only the elements of the first collection the filter
+// does not rule out are put in a hash set, then checked against a second
+// pass over the second collection. When the second collection is large
+// and most elements of the first one are absent from it, this uses a
+// fraction of the memory and time of a hash set of the second collection.
+// The results are unchanged: a false positive only costs a hash set entry.
+// Both collections are iterated twice. The option is ignored for elements
+// other than strings and numbers, whose hashing would cost more than it
+// saves. It panics if the rate is not between 0 and 1 exclusive.
+//
+// example usage:
+//
+//	missing := Diff(expected, actual, WithBloomFilter(0.01))
+func WithBloomFilter(falsePositiveRate float64) SetOption {
+	if !(falsePositiveRate > 0 && falsePositiveRate < 1) {
+		panic("collection: Bloom filter false positive rate must be between 0 and 1")
+	}
+	return func(c *setConfig) { c.falsePositiveRate = falsePositiveRate }
+}
+
+// lookup returns a function reporting whether an element of s1 is an
+// element of s2, backed by a hash set of s2 or, if configured by opts,
+// by a Bloom filter of s2 and a hash set of the elements of s1 it does
+// not rule out.
+func lookup[T comparable](s1, s2 Collection[T], opts []SetOption) func(T) bool {
+	var c setConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	hasher, ok := basicHasher[T]()
+	if c.falsePositiveRate == 0 || !ok {
+		present := toSet(s2)
+		return func(v T) bool { _, ok := present[v]; return ok }
+	}
+	filter := newBloomFilter(s2.Length(), c.falsePositiveRate, hasher)
+	for v := range s2.Values() {
+		filter.add(v)
+	}
+	// the candidates are marked as they are found in s2
+	candidates := make(map[T]bool)
+	for v := range s1.Values() {
+		if filter.mayContain(v) {
+			candidates[v] = false
+		}
+	}
+	if len(candidates) > 0 {
+		for v := range s2.Values() {
+			if _, ok := candidates[v]; ok {
+				candidates[v] = true
+			}
+		}
+	}
+	return func(v T) bool { return candidates[v] }
+}
+
+// bloomFilter is a Bloom filter: mayContain returns true for every value
+// added, and false for most other values.
+type bloomFilter[T comparable] struct {
+	bits   []uint64
+	m      uint64 // number of bits
+	k      uint64 // number of probes per value
+	hasher Hasher[T]
+}
+
+// newBloomFilter returns a Bloom filter sized for n values at the given
+// false positive rate, with m = -n ln(p) / ln(2)² bits and k = m/n ln(2)
+// probes per value.
+func newBloomFilter[T comparable](n int, falsePositiveRate float64, hasher Hasher[T]) *bloomFilter[T] {
+	n = max(n, 1)
+	m := uint64(math.Ceil(-float64(n) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	m = max(m, 64)
+	k := uint64(max(math.Round(float64(m)/float64(n)*math.Ln2), 1))
+	return &bloomFilter[T]{
+		bits:   make([]uint64, (m+63)/64),
+		m:      m,
+		k:      k,
+		hasher: hasher,
+	}
+}
+
+func (b *bloomFilter[T]) add(v T) {
+	h1, h2 := b.hashes(v)
+	for i := range b.k {
+		bit := (h1 + i*h2) % b.m
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+func (b *bloomFilter[T]) mayContain(v T) bool {
+	h1, h2 := b.hashes(v)
+	for i := range b.k {
+		bit := (h1 + i*h2) % b.m
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// hashes derives the two hashes of the probe sequence h1 + i*h2 from a
+// single hash of the value, h2 being odd so that the probes never collapse
+// onto h1.
+func (b *bloomFilter[T]) hashes(v T) (uint64, uint64) {
+	h := b.hasher.Hash(v)
+	return h, (h>>32 | h<<32) | 1
+}
@@ -0,0 +1,136 @@
+package collection
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestBloomFilter(t *testing.T) {
+	const n, rate = 10_000, 0.01
+	b := newBloomFilter(n, rate, DefaultHasher[int]())
+	for i := range n {
+		b.add(i * 2)
+	}
+	for i := range n {
+		if !b.mayContain(i * 2) {
+			t.Fatalf("mayContain(%d) = false for an added value", i*2)
+		}
+	}
+	positives := 0
+	for i := range n {
+		if b.mayContain(i*2 + 1) {
+			positives++
+		}
+	}
+	if got := float64(positives) / n; got > 2*rate {
+		t.Errorf("false positive rate = %v, want about %v", got, rate)
+	}
+}
+
+func TestWithBloomFilter(t *testing.T) {
+	a := make([]string, 0, 1000)
+	b := make([]string, 0, 500)
+	for i := range 1000 {
+		a = append(a, fmt.Sprint(i))
+		if i%2 == 0 {
+			b = append(b, fmt.Sprint(i))
+		}
+	}
+	tests := []struct {
+		name string
+		a, b []string
+	}{
+		{name: "half", a: a, b: b},
+		{name: "empty b", a: a, b: nil},
+		{name: "empty a", a: nil, b: b},
+		{name: "same", a: b, b: b},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s1, s2 := NewMockCollection(tt.a), NewMockCollection(tt.b)
+			opt := WithBloomFilter(0.05)
+			if got, want := Diff(s1, s2, opt).(*MockCollection[string]).items, Diff(s1, s2).(*MockCollection[string]).items; !slices.Equal(got, want) {
+				t.Errorf("Diff() = %v, want %v", got, want)
+			}
+			if got, want := Intersect(s1, s2, opt).(*MockCollection[string]).items, Intersect(s1, s2).(*MockCollection[string]).items; !slices.Equal(got, want) {
+				t.Errorf("Intersect() = %v, want %v", got, want)
+			}
+			if got, want := SubsetOf(s1, s2, opt), SubsetOf(s1, s2); got != want {
+				t.Errorf("SubsetOf() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSubsetOf(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []int
+		want bool
+	}{
+		{name: "subset", a: []int{2, 4, 4}, b: []int{1, 2, 3, 4}, want: true},
+		{name: "not subset", a: []int{2, 5}, b: []int{1, 2, 3, 4}, want: false},
+		{name: "empty", a: nil, b: []int{1}, want: true},
+		{name: "empty b", a: []int{1}, b: nil, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SubsetOf(NewMockCollection(tt.a), NewMockCollection(tt.b)); got != tt.want {
+				t.Errorf("SubsetOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithBloomFilter_CompositeType(t *testing.T) {
+	type point struct{ x, y int }
+	s1 := NewMockCollection([]point{{1, 2}, {3, 4}})
+	s2 := NewMockCollection([]point{{3, 4}})
+	if got := Diff(s1, s2, WithBloomFilter(0.01)).(*MockCollection[point]).items; !slices.Equal(got, []point{{1, 2}}) {
+		t.Errorf("Diff() = %v, want [{1 2}]", got)
+	}
+}
+
+// BenchmarkDiff looks up a few thousand elements, mostly absent,
+// in a collection of a million elements.
+func BenchmarkDiff(b *testing.B) {
+	large := make([]int, 1<<20)
+	for i := range large {
+		large[i] = i * 2
+	}
+	small := make([]int, 1<<12)
+	for i := range small {
+		small[i] = i*100 + 1
+		if i%100 == 0 {
+			small[i]--
+		}
+	}
+	s1, s2 := NewMockCollection(small), NewMockCollection(large)
+	b.Run("hash set", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			Diff(s1, s2)
+		}
+	})
+	b.Run("bloom filter", func(b *testing.B) {
+		opt := WithBloomFilter(0.01)
+		b.ReportAllocs()
+		for range b.N {
+			Diff(s1, s2, opt)
+		}
+	})
+}
+
+func TestWithBloomFilterPanics(t *testing.T) {
+	for _, rate := range []float64{0, 1, -0.5, 2} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("WithBloomFilter(%v) did not panic", rate)
+				}
+			}()
+			WithBloomFilter(rate)
+		}()
+	}
+}
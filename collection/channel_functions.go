@@ -0,0 +1,139 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// channel_functions.go defines the package functions bridging collections
+// to channels, for integration with goroutine pipelines.
+
+package collection
+
+import (
+	"context"
+
+	"github.com/charbz/gophers/seq"
+)
+
+// ChanOption configures the channel returned by ToChannel and Pipe.
+type ChanOption func(*chanConfig)
+
+type chanConfig struct {
+	buffer int
+}
+
+// WithBuffer sets the number of values buffered by the channel,
+// letting the sender run ahead of slow receivers. It defaults to 0.
+func WithBuffer(n int) ChanOption {
+	return func(c *chanConfig) { c.buffer = n }
+}
+
+// ToChannel sends the elements of the collection to the returned channel
+// from a new goroutine, and closes the channel once all the elements were
+// sent or the context is done. The channel must be drained, or the context
+// cancelled, for the goroutine to exit.
+//
+// example usage:
+//
+//	jobs := ToChannel(ctx, NewSequence(pending), WithBuffer(16))
+//	for range 4 {
+//	  go func() {
+//	    for job := range jobs {
+//	      job.Run()
+//	    }
+//	  }()
+//	}
+func ToChannel[T any](ctx context.Context, s Collection[T], opts ...ChanOption) <-chan T {
+	return seq.ToChan(ctx, s.Values(), chanBuffer(opts))
+}
+
+// Pipe sends the result of applying f to each element of the collection
+// to the returned channel, like ToChannel. f runs on the sending goroutine,
+// one element at a time, as the receivers consume the channel.
+//
+// example usage:
+//
+//	urls := Pipe(ctx, NewSequence(pages), func(p Page) string { return p.URL })
+//	for u := range urls {
+//	  crawl(u)
+//	}
+func Pipe[T, K any](ctx context.Context, s Collection[T], f func(T) K, opts ...ChanOption) <-chan K {
+	return seq.ToChan(ctx, seq.Map(s.Values(), f), chanBuffer(opts))
+}
+
+// FanOut sends the elements of the collection to n returned channels in
+// turn, round-robin, from a new goroutine, and closes them once all the
+// elements were sent or the context is done, see seq.FanOut. Every channel
+// must be drained, or the context cancelled. It panics if n is less than 1.
+//
+// example usage:
+//
+//	for _, jobs := range FanOut(ctx, queue, 4) {
+//	  go func() {
+//	    for job := range jobs {
+//	      job.Run()
+//	    }
+//	  }()
+//	}
+func FanOut[T any](ctx context.Context, s Collection[T], n int, opts ...ChanOption) []<-chan T {
+	return seq.FanOut(ctx, s.Values(), n, chanBuffer(opts))
+}
+
+// FanIn returns a new collection of the same type as c holding the values
+// received from all the given channels, in the order they are received,
+// once every channel is closed. If the context is done first, it returns
+// the values received so far and the context's error.
+//
+// example usage:
+//
+//	results, err := FanIn(ctx, NewSequence[Result](), workerResults...)
+func FanIn[T any](ctx context.Context, c Collection[T], chs ...<-chan T) (Collection[T], error) {
+	type received struct {
+		value T
+		ok    bool // false once the channel is closed
+	}
+	result := c.New()
+	merged := make(chan received)
+	for _, ch := range chs {
+		go func() {
+			for {
+				var r received
+				select {
+				case r.value, r.ok = <-ch:
+				case <-ctx.Done():
+					return
+				}
+				select {
+				case merged <- r:
+				case <-ctx.Done():
+					return
+				}
+				if !r.ok {
+					return
+				}
+			}
+		}()
+	}
+	// the channels are counted as they close, so that a context done
+	// after the last one closed does not fail a complete result
+	for open := len(chs); open > 0; {
+		select {
+		case r := <-merged:
+			if !r.ok {
+				open--
+				continue
+			}
+			result.Add(r.value)
+		case <-ctx.Done():
+			return result, ctx.Err()
+		}
+	}
+	return result, nil
+}
+
+// chanBuffer returns the buffer size configured by opts.
+func chanBuffer(opts []ChanOption) int {
+	var c chanConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return max(c.buffer, 0)
+}
@@ -0,0 +1,98 @@
+package collection
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestToChannel(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []int
+		buffer int
+	}{
+		{name: "unbuffered", input: []int{1, 2, 3}},
+		{name: "buffered", input: []int{1, 2, 3}, buffer: 8},
+		{name: "empty", input: nil, buffer: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := ToChannel(context.Background(), NewMockCollection(tt.input), WithBuffer(tt.buffer))
+			if cap(ch) != tt.buffer {
+				t.Errorf("cap() = %v, want %v", cap(ch), tt.buffer)
+			}
+			var got []int
+			for v := range ch {
+				got = append(got, v)
+			}
+			if !slices.Equal(got, tt.input) {
+				t.Errorf("ToChannel() = %v, want %v", got, tt.input)
+			}
+		})
+	}
+}
+
+func TestPipe(t *testing.T) {
+	var got []string
+	for v := range Pipe(context.Background(), NewMockCollection([]int{1, 2}), func(i int) string { return string(rune('a' + i)) }) {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []string{"b", "c"}) {
+		t.Errorf("Pipe() = %v, want [b c]", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := Pipe(ctx, NewMockCollection([]int{1, 2, 3, 4}), func(i int) int { return i * 2 })
+	if v := <-ch; v != 2 {
+		t.Errorf("first value = %v, want 2", v)
+	}
+	cancel()
+	for range ch {
+	}
+}
+
+func TestFanOutFanIn(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7}
+	outs := FanOut(context.Background(), NewMockCollection(input), 3, WithBuffer(1))
+	if len(outs) != 3 || cap(outs[0]) != 1 {
+		t.Fatalf("FanOut() = %d channels of capacity %d, want 3 of capacity 1", len(outs), cap(outs[0]))
+	}
+	got, err := FanIn(context.Background(), NewMockCollection[int](), outs...)
+	if err != nil {
+		t.Fatalf("FanIn() error = %v", err)
+	}
+	if values := slices.Sorted(got.Values()); !slices.Equal(values, input) {
+		t.Errorf("FanIn() = %v, want %v", values, input)
+	}
+}
+
+// expiredContext is a context whose deadline has passed
+// without its Done channel being selected yet.
+type expiredContext struct {
+	context.Context
+}
+
+func (expiredContext) Err() error {
+	return context.DeadlineExceeded
+}
+
+func TestFanInComplete(t *testing.T) {
+	ch := make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	close(ch)
+	got, err := FanIn(expiredContext{context.Background()}, NewMockCollection[int](), ch)
+	if err != nil || got.Length() != 2 {
+		t.Errorf("FanIn() = %v, %v, want 2 elements, nil", got.Length(), err)
+	}
+}
+
+func TestFanInCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got, err := FanIn(ctx, NewMockCollection[int](), make(chan int))
+	if err != context.Canceled || got.Length() != 0 {
+		t.Errorf("FanIn() = %v, %v, want empty, %v", got.Length(), err, context.Canceled)
+	}
+}
@@ -41,4 +41,42 @@ var (
 	IndexOutOfBoundsError = &CollectionError{
 		code: 102, msg: "index out of bounds",
 	}
+	UnknownCollectionError = &CollectionError{
+		code: 103, msg: "unknown collection type",
+	}
+	FullCollectionError = &CollectionError{
+		code: 104, msg: "invalid operation on a full collection",
+	}
+	ReadOnlyCollectionError = &CollectionError{
+		code: 105, msg: "invalid operation on a read-only collection",
+	}
+	PatchMismatchError = &CollectionError{
+		code: 106, msg: "patch does not apply to the collection",
+	}
+	KeyOutOfRangeError = &CollectionError{
+		code: 107, msg: "key out of the range of the view",
+	}
 )
+
+// Pair holds two values of possibly different types,
+// i.e. the corresponding elements of two zipped collections.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// String implements the Stringer interface.
+func (p Pair[A, B]) String() string {
+	return fmt.Sprintf("(%v, %v)", p.First, p.Second)
+}
+
+// Entry is a key/value pair, i.e. an entry of a dictionary.
+type Entry[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// String implements the Stringer interface.
+func (e Entry[K, V]) String() string {
+	return fmt.Sprintf("%v:%v", e.Key, e.Value)
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// edit_script.go defines DiffOrdered, computing the edit script turning an
+// ordered collection into another, and ApplyPatch, replaying it.
+
+package collection
+
+import (
+	"fmt"
+	"slices"
+)
+
+// EditKind is the kind of an Edit.
+type EditKind int
+
+const (
+	// Keep keeps an element of the first collection.
+	Keep EditKind = iota
+	// Delete deletes an element of the first collection.
+	Delete
+	// Insert inserts an element of the second collection.
+	Insert
+)
+
+// Edit is an operation of an edit script, see DiffOrdered.
+// Value is the element kept, deleted or inserted.
+type Edit[T any] struct {
+	Kind  EditKind
+	Value T
+}
+
+// implement the Stringer interface, in the unified diff format
+func (e Edit[T]) String() string {
+	switch e.Kind {
+	case Delete:
+		return fmt.Sprintf("-%v", e.Value)
+	case Insert:
+		return fmt.Sprintf("+%v", e.Value)
+	}
+	return fmt.Sprintf(" %v", e.Value)
+}
+
+// DiffOrdered returns a shortest edit script turning a into b, using f as an
+// equality function: applying its edits in order to the elements of a, see
+// ApplyPatch, yields the elements of b. The kept elements form a longest
+// common subsequence of a and b, and deletions come before insertions when
+// both apply at the same position. It implements the Myers algorithm, in
+// O((n+m)d) time and memory for collections of n and m elements differing
+// by d edits.
+//
+// example usage:
+//
+//	a := NewSequence([]string{"a","b","c"})
+//	b := NewSequence([]string{"a","c","d"})
+//	DiffOrdered(a, b, func(x, y string) bool { return x == y })
+//
+// output:
+//
+//	[ a -b  c +d]
+func DiffOrdered[T any](a, b OrderedCollection[T], f func(T, T) bool) []Edit[T] {
+	x, y := slices.Collect(a.Values()), slices.Collect(b.Values())
+	n, m := len(x), len(y)
+	offset := n + m + 1
+	// v[offset+k] is the furthest index in x reached on diagonal k = i - j,
+	// trace[d] is v before the round of d edits.
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, slices.Clone(v))
+		for k := -d; k <= d; k += 2 {
+			var i int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				i = v[offset+k+1]
+			} else {
+				i = v[offset+k-1] + 1
+			}
+			j := i - k
+			for i < n && j < m && f(x[i], y[j]) {
+				i, j = i+1, j+1
+			}
+			v[offset+k] = i
+			if i >= n && j >= m {
+				return backtrack(x, y, trace, offset)
+			}
+		}
+	}
+	return nil // not reached, n+m edits always suffice
+}
+
+// backtrack walks the trace of DiffOrdered back from the end of x and y,
+// and returns the edit script in order.
+func backtrack[T any](x, y []T, trace [][]int, offset int) []Edit[T] {
+	var edits []Edit[T]
+	i, j := len(x), len(y)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v, k := trace[d], i-j
+		prev := k - 1
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prev = k + 1
+		}
+		prevI := v[offset+prev]
+		prevJ := prevI - prev
+		for i > prevI && j > prevJ {
+			i, j = i-1, j-1
+			edits = append(edits, Edit[T]{Keep, x[i]})
+		}
+		if d == 0 {
+			break
+		}
+		if i == prevI {
+			j--
+			edits = append(edits, Edit[T]{Insert, y[j]})
+		} else {
+			i--
+			edits = append(edits, Edit[T]{Delete, x[i]})
+		}
+	}
+	slices.Reverse(edits)
+	return edits
+}
+
+// ApplyPatch applies the edit script returned by DiffOrdered to a, and
+// returns a new collection of the same type as a holding the result.
+// It returns PatchMismatchError if the script does not keep or delete
+// exactly as many elements as a holds, i.e. if it was computed from
+// another collection.
+//
+// example usage:
+//
+//	edits := DiffOrdered(a, b, eq)
+//	// send a and edits to another process
+//	c, err := ApplyPatch(a, edits) // c holds the elements of b
+func ApplyPatch[T any](a OrderedCollection[T], edits []Edit[T]) (OrderedCollection[T], error) {
+	result := a.NewOrdered()
+	next, stop := Pull(a)
+	defer stop()
+	for _, e := range edits {
+		switch e.Kind {
+		case Keep, Delete:
+			v, ok := next()
+			if !ok {
+				return nil, PatchMismatchError
+			}
+			if e.Kind == Keep {
+				result.Add(v)
+			}
+		case Insert:
+			result.Add(e.Value)
+		}
+	}
+	if _, ok := next(); ok {
+		return nil, PatchMismatchError
+	}
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package collection
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestDiffOrdered(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want string
+	}{
+		{name: "example", a: []string{"a", "b", "c"}, b: []string{"a", "c", "d"}, want: "[ a -b  c +d]"},
+		{name: "equal", a: []string{"a", "b"}, b: []string{"a", "b"}, want: "[ a  b]"},
+		{name: "replace", a: []string{"a"}, b: []string{"b"}, want: "[-a +b]"},
+		{name: "empty a", a: nil, b: []string{"a", "b"}, want: "[+a +b]"},
+		{name: "empty b", a: []string{"a", "b"}, b: nil, want: "[-a -b]"},
+		{name: "both empty", a: nil, b: nil, want: "[]"},
+		{name: "myers", a: []string{"a", "b", "c", "a", "b", "b", "a"}, b: []string{"c", "b", "a", "b", "a", "c"}, want: "[-a -b  c +b  a  b -b  a +c]"},
+	}
+	eq := func(x, y string) bool { return x == y }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := NewMockOrderedCollection(tt.a), NewMockOrderedCollection(tt.b)
+			edits := DiffOrdered(a, b, eq)
+			if got := fmt.Sprint(edits); got != tt.want {
+				t.Errorf("DiffOrdered() = %v, want %v", got, tt.want)
+			}
+			got, err := ApplyPatch(a, edits)
+			if err != nil || !slices.Equal(slices.Collect(got.Values()), tt.b) {
+				t.Errorf("ApplyPatch() = %v, %v, want %v", got, err, tt.b)
+			}
+		})
+	}
+}
+
+// lcsLength returns the length of the longest common subsequence of a and b.
+func lcsLength(a, b []int) int {
+	prev, curr := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				curr[j+1] = prev[j] + 1
+			} else {
+				curr[j+1] = max(prev[j+1], curr[j])
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func TestDiffOrderedShortest(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	random := func() []int {
+		s := make([]int, r.Intn(30))
+		for i := range s {
+			s[i] = r.Intn(5)
+		}
+		return s
+	}
+	for range 500 {
+		x, y := random(), random()
+		a, b := NewMockOrderedCollection(x), NewMockOrderedCollection(y)
+		edits := DiffOrdered(a, b, func(i, j int) bool { return i == j })
+		kept := 0
+		for _, e := range edits {
+			if e.Kind == Keep {
+				kept++
+			}
+		}
+		if want := lcsLength(x, y); kept != want || len(edits) != len(x)+len(y)-want {
+			t.Fatalf("DiffOrdered(%v, %v) = %v, kept %d, want %d", x, y, edits, kept, want)
+		}
+		got, err := ApplyPatch(a, edits)
+		if err != nil || !slices.Equal(slices.Collect(got.Values()), y) {
+			t.Fatalf("ApplyPatch(%v, %v) = %v, %v, want %v", x, edits, got, err, y)
+		}
+	}
+}
+
+func TestApplyPatchMismatch(t *testing.T) {
+	edits := DiffOrdered(NewMockOrderedCollection([]int{1, 2}), NewMockOrderedCollection([]int{2, 3}), func(i, j int) bool { return i == j })
+	for _, a := range [][]int{{1}, {1, 2, 3}} {
+		if _, err := ApplyPatch(NewMockOrderedCollection(a), edits); err != PatchMismatchError {
+			t.Errorf("ApplyPatch(%v) error = %v, want %v", a, err, PatchMismatchError)
+		}
+	}
+}
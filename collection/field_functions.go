@@ -0,0 +1,76 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// field_functions.go defines the package functions projecting the fields of
+// collections of structs, i.e. to extract a column of values:
+//
+//	ids := PluckIDs(users)
+//	rows := SelectFields(users,
+//	  func(u User) string { return u.Name },
+//	  func(u User) int { return u.Age },
+//	)
+
+package collection
+
+// MapField returns a slice of the values of a field of the elements of the
+// collection, in iteration order. It is Map named for its most common use,
+// the field being read by the given accessor.
+//
+// example usage:
+//
+//	users := NewSequence([]User{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}})
+//	MapField(users, func(u User) string { return u.Name })
+//
+// output:
+//
+//	[Alice,Bob]
+func MapField[T, F any](s Collection[T], field func(T) F) []F {
+	return Map(s, field)
+}
+
+// SelectFields returns a slice of pairs of the values of two fields of the
+// elements of the collection, in iteration order. The pairs can be split
+// back into two columns with Unzip.
+//
+// example usage:
+//
+//	users := NewSequence([]User{{Name: "Alice", Age: 30}, {Name: "Bob", Age: 25}})
+//	SelectFields(users, func(u User) string { return u.Name }, func(u User) int { return u.Age })
+//
+// output:
+//
+//	[(Alice, 30),(Bob, 25)]
+func SelectFields[T, A, B any](s Collection[T], first func(T) A, second func(T) B) []Pair[A, B] {
+	return Map(s, func(v T) Pair[A, B] {
+		return Pair[A, B]{first(v), second(v)}
+	})
+}
+
+// PluckIDs returns a slice of the IDs of the elements of the collection,
+// for elements with an ID method.
+//
+// example usage:
+//
+//	func (u User) ID() int { return u.id }
+//
+//	PluckIDs(users)
+//
+// output:
+//
+//	[1,2]
+func PluckIDs[T interface{ ID() K }, K any](s Collection[T]) []K {
+	return Map(s, T.ID)
+}
+
+// PluckKeys returns a slice of the keys of the elements of the collection,
+// for elements with a Key method.
+func PluckKeys[T interface{ Key() K }, K any](s Collection[T]) []K {
+	return Map(s, T.Key)
+}
+
+// PluckNames returns a slice of the names of the elements of the collection,
+// for elements with a Name method.
+func PluckNames[T interface{ Name() string }](s Collection[T]) []string {
+	return Map(s, T.Name)
+}
@@ -0,0 +1,41 @@
+package collection
+
+import (
+	"slices"
+	"testing"
+)
+
+type testUser struct {
+	id   int
+	name string
+	age  int
+}
+
+func (u testUser) ID() int      { return u.id }
+func (u testUser) Key() string  { return "user:" + u.name }
+func (u testUser) Name() string { return u.name }
+
+func TestFieldProjections(t *testing.T) {
+	users := NewMockOrderedCollection([]testUser{{1, "Alice", 30}, {2, "Bob", 25}})
+	empty := NewMockOrderedCollection[testUser]()
+
+	if got := MapField(users, func(u testUser) int { return u.age }); !slices.Equal(got, []int{30, 25}) {
+		t.Errorf("MapField() = %v, want [30 25]", got)
+	}
+	if got := MapField(empty, func(u testUser) int { return u.age }); len(got) != 0 {
+		t.Errorf("MapField() on empty = %v, want []", got)
+	}
+	got := SelectFields(users, testUser.Name, func(u testUser) int { return u.age })
+	if want := []Pair[string, int]{{"Alice", 30}, {"Bob", 25}}; !slices.Equal(got, want) {
+		t.Errorf("SelectFields() = %v, want %v", got, want)
+	}
+	if got := PluckIDs(users); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("PluckIDs() = %v, want [1 2]", got)
+	}
+	if got := PluckKeys(users); !slices.Equal(got, []string{"user:Alice", "user:Bob"}) {
+		t.Errorf("PluckKeys() = %v, want [user:Alice user:Bob]", got)
+	}
+	if got := PluckNames(users); !slices.Equal(got, []string{"Alice", "Bob"}) {
+		t.Errorf("PluckNames() = %v, want [Alice Bob]", got)
+	}
+}
@@ -14,8 +14,37 @@ package collection
 
 import (
 	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charbz/gophers/seq"
 )
 
+// AverageBy returns the arithmetic mean of the values returned by the
+// projection function for each element, i.e. the average of a field.
+// If the collection is empty, it returns 0 and an error.
+//
+// example usage:
+//
+//	orders := NewSequence([]Order{{ID: 1, Total: 20}, {ID: 2, Total: 35}})
+//	AverageBy(orders, func(o Order) float64 { return o.Total })
+//
+// output:
+//
+//	27.5, nil
+func AverageBy[T any, K Number](s Collection[T], f func(T) K) (float64, error) {
+	if s.Length() == 0 {
+		return 0, EmptyCollectionError
+	}
+	var sum float64
+	for v := range s.Values() {
+		sum += float64(f(v))
+	}
+	return sum / float64(s.Length()), nil
+}
+
 // Count returns the number of elements in the collection that satisfy the predicate function.
 //
 // example usage:
@@ -27,16 +56,12 @@ import (
 //
 //	3
 func Count[T any](s Collection[T], f func(T) bool) int {
-	count := 0
-	for v := range s.Values() {
-		if f(v) {
-			count++
-		}
-	}
-	return count
+	return seq.Count(s.Values(), f)
 }
 
 // Diff returns a new collection containing elements that are present in the first collection but not in the second.
+// Every occurrence of such an element in s1 is kept. It runs in O(n+m), looking up a hash set of s2,
+// or a Bloom filter of s2, see WithBloomFilter.
 //
 // example usage:
 //
@@ -47,15 +72,8 @@ func Count[T any](s Collection[T], f func(T) bool) int {
 // output:
 //
 //	[1,3,5]
-func Diff[T comparable](s1 Collection[T], s2 Collection[T]) Collection[T] {
-	return FilterNot(s1, func(t T) bool {
-		for v := range s2.Values() {
-			if v == t {
-				return true
-			}
-		}
-		return false
-	})
+func Diff[T comparable](s1 Collection[T], s2 Collection[T], opts ...SetOption) Collection[T] {
+	return FilterNot(s1, lookup(s1, s2, opts))
 }
 
 // DiffFunc is similar to Diff but applies to non-comparable types.
@@ -74,12 +92,7 @@ func Diff[T comparable](s1 Collection[T], s2 Collection[T]) Collection[T] {
 //	[1,3,5]
 func DiffFunc[T any](s1 Collection[T], s2 Collection[T], f func(T, T) bool) Collection[T] {
 	return FilterNot(s1, func(t T) bool {
-		for v := range s2.Values() {
-			if f(t, v) {
-				return true
-			}
-		}
-		return false
+		return seq.Exists(s2.Values(), func(v T) bool { return f(t, v) })
 	})
 }
 
@@ -122,13 +135,7 @@ func Distinct[T any](s Collection[T], f func(T, T) bool) Collection[T] {
 //
 //	[2,4,6]
 func Filter[T any](s Collection[T], f func(T) bool) Collection[T] {
-	result := s.New()
-	for v := range s.Values() {
-		if f(v) {
-			result.Add(v)
-		}
-	}
-	return result
+	return FilterInto(s, s.New(), f)
 }
 
 // FilterNot returns the complement of the Filter function.
@@ -145,6 +152,64 @@ func FilterNot[T any](s Collection[T], f func(T) bool) Collection[T] {
 	return Filter(s, func(t T) bool { return !f(t) })
 }
 
+// FoldErr applies the function f to each element of the collection, starting
+// from init, and stops at the first error, i.e. to accumulate over elements
+// that may be invalid. It returns the accumulator before the failing element
+// along with the error, or the final accumulator and nil.
+//
+// example usage:
+//
+//	c := NewSequence([]string{"10","20","x","40"})
+//	FoldErr(c, 0, func(total int, s string) (int, error) {
+//	  n, err := strconv.Atoi(s)
+//	  return total + n, err
+//	})
+//
+// output:
+//
+//	30, strconv.Atoi: parsing "x": invalid syntax
+func FoldErr[T, K any](s Collection[T], init K, f func(K, T) (K, error)) (K, error) {
+	return seq.FoldErr(s.Values(), init, f)
+}
+
+// FoldWhile applies the function f to each element of the collection, starting
+// from init, and stops as soon as f returns false. This avoids a full traversal
+// for searches that need an accumulator, such as summing values until a budget is exceeded.
+//
+// example usage:
+//
+//	c := NewSequence([]int{40,30,20,10})
+//	FoldWhile(c, 0, func(total int, price int) (int, bool) {
+//	  if total + price > 80 {
+//	    return total, false
+//	  }
+//	  return total + price, true
+//	})
+//
+// output:
+//
+//	70
+func FoldWhile[T, K any](s Collection[T], init K, f func(K, T) (K, bool)) K {
+	return seq.FoldWhile(s.Values(), init, f)
+}
+
+// FoldMap maps every element of the collection with f and combines the results
+// pairwise with combine, in iteration order. It is suited to monoid-like
+// accumulations such as sums, concatenations or merges of partial results.
+// If the collection is empty, FoldMap returns the zero value of M.
+//
+// example usage:
+//
+//	words := NewSequence([]string{"go", "is", "fun"})
+//	FoldMap(words, func(w string) int { return len(w) }, func(a, b int) int { return a + b })
+//
+// output:
+//
+//	7
+func FoldMap[T, M any](s Collection[T], f func(T) M, combine func(M, M) M) M {
+	return seq.FoldMap(s.Values(), f, combine)
+}
+
 // ForAll tests whether a predicate holds for all elements of this sequence.
 //
 // example usage:
@@ -156,12 +221,7 @@ func FilterNot[T any](s Collection[T], f func(T) bool) Collection[T] {
 //
 //	true
 func ForAll[T any](s Collection[T], f func(T) bool) bool {
-	for v := range s.Values() {
-		if !f(v) {
-			return false
-		}
-	}
-	return true
+	return seq.ForAll(s.Values(), f)
 }
 
 // GroupBy takes a collection and a grouping function as input and returns a map
@@ -188,7 +248,83 @@ func GroupBy[T any, K comparable](s Collection[T], f func(T) K) map[K]Collection
 	return m
 }
 
+// GroupByContext is similar to GroupBy but reports a "collection.GroupBy"
+// span to the tracer of the context, see WithTracer, with the number of
+// elements and groups as attributes.
+//
+// example usage:
+//
+//	ctx = WithTracer(ctx, tracer)
+//	byCustomer := GroupByContext(ctx, orders, func(o Order) string { return o.Customer })
+func GroupByContext[T any, K comparable](ctx context.Context, s Collection[T], f func(T) K) map[K]Collection[T] {
+	_, span := StartSpan(ctx, "collection.GroupBy")
+	m := GroupBy(s, f)
+	span.SetAttributes(
+		Attribute{Key: "length", Value: s.Length()},
+		Attribute{Key: "groups", Value: len(m)},
+	)
+	span.End(nil)
+	return m
+}
+
+// GroupMap groups the elements of the collection by the key function and maps
+// each element with mapVal, preserving the iteration order of the collection
+// within each group.
+//
+// example usage:
+//
+//	words := NewSequence([]string{"go", "gopher", "rust", "zig"})
+//	GroupMap(words,
+//	  func(w string) byte { return w[0] },
+//	  func(w string) int { return len(w) },
+//	)
+//
+// output:
+//
+//	{g:[2,6], r:[4], z:[3]}
+func GroupMap[T any, K comparable, V any](s Collection[T], key func(T) K, mapVal func(T) V) map[K][]V {
+	m := make(map[K][]V)
+	for v := range s.Values() {
+		k := key(v)
+		m[k] = append(m[k], mapVal(v))
+	}
+	return m
+}
+
+// GroupMapReduce groups the elements of the collection by the key function, maps
+// each element with mapVal and reduces the mapped values of each group with reduce,
+// in a single pass. It is equivalent to calling GroupBy, then Map and Reduce on each
+// group, without materializing the intermediate per-group collections.
+//
+// example usage:
+//
+//	words := NewSequence([]string{"go", "gopher", "rust", "ruby", "zig"})
+//	GroupMapReduce(words,
+//	  func(w string) byte { return w[0] },
+//	  func(w string) int { return len(w) },
+//	  func(a, b int) int { return a + b },
+//	)
+//
+// output:
+//
+//	{g:8, r:8, z:3}
+func GroupMapReduce[T any, K comparable, V any](s Collection[T], key func(T) K, mapVal func(T) V, reduce func(V, V) V) map[K]V {
+	m := make(map[K]V)
+	for v := range s.Values() {
+		k := key(v)
+		if acc, ok := m[k]; ok {
+			m[k] = reduce(acc, mapVal(v))
+		} else {
+			m[k] = mapVal(v)
+		}
+	}
+	return m
+}
+
 // Intersect returns a new collection containing elements that are present in both input collections.
+// Every occurrence of such an element in s1 is kept, in the order of s1, however many times it
+// occurs in s2. It runs in O(n+m), looking up a hash set of s2, or a Bloom
+// filter of s2, see WithBloomFilter.
 //
 // example usage:
 //
@@ -199,15 +335,8 @@ func GroupBy[T any, K comparable](s Collection[T], f func(T) K) map[K]Collection
 // output:
 //
 //	[2,4,6]
-func Intersect[T comparable](s1 Collection[T], s2 Collection[T]) Collection[T] {
-	return Filter(s1, func(t T) bool {
-		for v := range s2.Values() {
-			if v == t {
-				return true
-			}
-		}
-		return false
-	})
+func Intersect[T comparable](s1 Collection[T], s2 Collection[T], opts ...SetOption) Collection[T] {
+	return Filter(s1, lookup(s1, s2, opts))
 }
 
 // IntersectFunc is similar to Intersect but applies to non-comparable types.
@@ -226,12 +355,7 @@ func Intersect[T comparable](s1 Collection[T], s2 Collection[T]) Collection[T] {
 //	[2,4,6]
 func IntersectFunc[T any](s1 Collection[T], s2 Collection[T], f func(T, T) bool) Collection[T] {
 	return Filter(s1, func(t T) bool {
-		for v := range s2.Values() {
-			if f(t, v) {
-				return true
-			}
-		}
-		return false
+		return seq.Exists(s2.Values(), func(v T) bool { return f(t, v) })
 	})
 }
 
@@ -249,63 +373,88 @@ func IntersectFunc[T any](s1 Collection[T], s2 Collection[T], f func(T, T) bool)
 //
 //	[5,3,6]
 func Map[T, K any](s Collection[T], f func(T) K) []K {
-	k := make([]K, 0, s.Length())
-	for v := range s.Values() {
-		k = append(k, f(v))
-	}
-	return k
+	return slices.AppendSeq(make([]K, 0, s.Length()), seq.Map(s.Values(), f))
 }
 
-// MaxBy returns the element in the collection that has the maximum value
-// according to a comparison function.
+// MaxBy returns the element in the collection for which the projection
+// function returns the greatest key, i.e. the struct with the greatest field.
+// If the collection is empty, it returns an error.
 //
 // example usage:
 //
-//	c := NewSequence([]int{1,2,3,4,5,6})
-//	MaxBy(c, func(a int, b int) int { return a - b })
+//	c := NewSequence([]string{"go","gopher","zig"})
+//	MaxBy(c, func(s string) int { return len(s) })
 //
 // output:
 //
-//	6
+//	"gopher", nil
 func MaxBy[T any, K cmp.Ordered](s Collection[T], f func(T) K) (T, error) {
-	if s.Length() == 0 {
-		return *new(T), EmptyCollectionError
-	}
-	maxElement := s.Random()
-	maxValue := f(maxElement)
-	for v := range s.Values() {
-		if f(v) > maxValue {
-			maxElement = v
-			maxValue = f(v)
-		}
+	v, ok := seq.MaxBy(s.Values(), f)
+	if !ok {
+		return v, EmptyCollectionError
 	}
-	return maxElement, nil
+	return v, nil
 }
 
-// MinBy returns the element in the collection that has the minimum value
-// according to a comparison function.
+// MinBy returns the element in the collection for which the projection
+// function returns the smallest key. If the collection is empty, it returns an error.
 //
 // example usage:
 //
-//	c := NewSequence([]int{1,2,3,4,5,6})
-//	MinBy(c, func(a int, b int) int { return a - b })
+//	c := NewSequence([]string{"go","gopher","zig"})
+//	MinBy(c, func(s string) int { return len(s) })
 //
 // output:
 //
-//	1
+//	"go", nil
 func MinBy[T any, K cmp.Ordered](s Collection[T], f func(T) K) (T, error) {
-	if s.Length() == 0 {
-		return *new(T), EmptyCollectionError
+	v, ok := seq.MinBy(s.Values(), f)
+	if !ok {
+		return v, EmptyCollectionError
 	}
-	minElement := s.Random()
-	minValue := f(minElement)
+	return v, nil
+}
+
+// MkString renders the elements of the collection with fmt's %v verb,
+// separated by sep, i.e. for diagnostics.
+//
+// example usage:
+//
+//	c := NewSequence([]string{"a","b","c"})
+//	MkString(c, ", ")
+//
+// output:
+//
+//	"a, b, c"
+func MkString[T any](s Collection[T], sep string) string {
+	return MkStringf(s, "", sep, "", "%v")
+}
+
+// MkStringf renders the elements of the collection formatted by format,
+// a fmt verb applied to each element, separated by sep and enclosed
+// between prefix and suffix.
+//
+// example usage:
+//
+//	c := NewSequence([]float64{1,2.5})
+//	MkStringf(c, "[", " | ", "]", "%.2f")
+//
+// output:
+//
+//	"[1.00 | 2.50]"
+func MkStringf[T any](s Collection[T], prefix, sep, suffix, format string) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	first := true
 	for v := range s.Values() {
-		if f(v) < minValue {
-			minElement = v
-			minValue = f(v)
+		if !first {
+			b.WriteString(sep)
 		}
+		first = false
+		fmt.Fprintf(&b, format, v)
 	}
-	return minElement, nil
+	b.WriteString(suffix)
+	return b.String()
 }
 
 // Partition takes a partitioning function as input and returns two collections,
@@ -335,6 +484,81 @@ func Partition[T any](s Collection[T], f func(T) bool) (Collection[T], Collectio
 	return match, noMatch
 }
 
+// SubsetOf returns true if every element of s1 is present in s2. It runs
+// in O(n+m), looking up a hash set of s2, or a Bloom filter
+// of s2, see WithBloomFilter.
+//
+// example usage:
+//
+//	c1 := NewSequence([]int{2,4,4})
+//	c2 := NewSequence([]int{1,2,3,4})
+//	SubsetOf(c1, c2)
+//
+// output:
+//
+//	true
+func SubsetOf[T comparable](s1 Collection[T], s2 Collection[T], opts ...SetOption) bool {
+	return ForAll(s1, lookup(s1, s2, opts))
+}
+
+// Union returns a new collection containing the elements of s1 followed by
+// the elements of s2 that are not present in s1, i.e. s1 concatenated with
+// Diff(s2, s1). Duplicates within s1 or within s2 are kept, use Distinct
+// on the result for a set union. It runs in O(n+m), looking up a hash set of s1.
+//
+// example usage:
+//
+//	c1 := NewSequence([]int{1,2,2,3})
+//	c2 := NewSequence([]int{3,4,4,5})
+//	Union(c1, c2)
+//
+// output:
+//
+//	[1,2,2,3,4,4,5]
+func Union[T comparable](s1 Collection[T], s2 Collection[T]) Collection[T] {
+	return UnionInto(s1, s2, s1.New())
+}
+
+// UnionFunc is similar to Union but applies to non-comparable types.
+// It takes two collections (s1, s2) and an "equality" function as an argument such as
+// func(a T, b T) bool {return a == b}
+// and returns a new collection containing the elements of s1 followed by the
+// elements of s2 that are not present in s1. It runs in O(n*m).
+//
+// example usage:
+//
+//	c1 := NewSequence([]int{1,2,3})
+//	c2 := NewSequence([]int{3,4,5})
+//	UnionFunc(c1, c2, func(a int, b int) bool { return a == b })
+//
+// output:
+//
+//	[1,2,3,4,5]
+func UnionFunc[T any](s1 Collection[T], s2 Collection[T], f func(T, T) bool) Collection[T] {
+	return UnionFuncInto(s1, s2, s1.New(), f)
+}
+
+// Unzip splits a collection of pairs into a slice of the first values
+// and a slice of the second values, in iteration order.
+//
+// example usage:
+//
+//	c := NewSequence([]Pair[string, int]{{"go", 2009}, {"zig", 2016}})
+//	Unzip(c)
+//
+// output:
+//
+//	[go,zig], [2009,2016]
+func Unzip[A, B any](s Collection[Pair[A, B]]) ([]A, []B) {
+	first := make([]A, 0, s.Length())
+	second := make([]B, 0, s.Length())
+	for p := range s.Values() {
+		first = append(first, p.First)
+		second = append(second, p.Second)
+	}
+	return first, second
+}
+
 // Reduce takes a collection of type T, a reducing function func(K, T) K,
 // and an initial value of type K as parameters. It applies the reducing
 // function to each element and returns the resulting value K.
@@ -351,9 +575,67 @@ func Partition[T any](s Collection[T], f func(T) bool) (Collection[T], Collectio
 //
 //	21
 func Reduce[T, K any](s Collection[T], f func(K, T) K, init K) K {
-	accumulator := init
+	return seq.Reduce(s.Values(), f, init)
+}
+
+// ReduceUntil is similar to Reduce but stops traversing the collection as soon
+// as the accumulated value satisfies the until predicate.
+//
+// example usage:
+//
+//	numbers := NewCollection([]int{5,4,3,2,1})
+//
+//	ReduceUntil(numbers, func(accumulator int, number int) int {
+//	  return accumulator + number
+//	}, 0, func(accumulator int) bool { return accumulator > 6 })
+//
+// output:
+//
+//	9
+func ReduceUntil[T, K any](s Collection[T], f func(K, T) K, init K, until func(K) bool) K {
+	return seq.ReduceUntil(s.Values(), f, init, until)
+}
+
+// ScanLeft is similar to Reduce but returns a slice of the intermediate
+// results: init, followed by the accumulated value after each element.
+// The slice has one element more than the collection.
+//
+// example usage:
+//
+//	deposits := NewSequence([]int{10,5,20})
+//	ScanLeft(deposits, func(balance int, v int) int { return balance + v }, 100)
+//
+// output:
+//
+//	[100,110,115,135]
+func ScanLeft[T, K any](s Collection[T], f func(K, T) K, init K) []K {
+	return slices.AppendSeq(make([]K, 0, s.Length()+1), seq.Scan(s.Values(), f, init))
+}
+
+// SumBy returns the sum of the values returned by the projection function
+// for each element, i.e. the total of a field. It returns 0 for an empty collection.
+//
+// example usage:
+//
+//	orders := NewSequence([]Order{{ID: 1, Quantity: 2}, {ID: 2, Quantity: 5}})
+//	SumBy(orders, func(o Order) int { return o.Quantity })
+//
+// output:
+//
+//	7
+func SumBy[T any, K Number](s Collection[T], f func(T) K) K {
+	var sum K
+	for v := range s.Values() {
+		sum += f(v)
+	}
+	return sum
+}
+
+// toSet returns the set of the elements of the collection, for O(1) lookups.
+func toSet[T comparable](s Collection[T]) map[T]struct{} {
+	set := make(map[T]struct{}, s.Length())
 	for v := range s.Values() {
-		accumulator = f(accumulator, v)
+		set[v] = struct{}{}
 	}
-	return accumulator
+	return set
 }
@@ -1,7 +1,10 @@
 package collection
 
 import (
+	"errors"
+	"maps"
 	"slices"
+	"strings"
 	"testing"
 )
 
@@ -292,6 +295,12 @@ func TestIntersect(t *testing.T) {
 			b:    []int{2, 4, 6},
 			want: nil,
 		},
+		{
+			name: "duplicates kept from the first collection",
+			a:    []int{2, 1, 2, 3},
+			b:    []int{3, 2, 2, 2},
+			want: []int{2, 2, 3},
+		},
 		{
 			name: "empty slices",
 			a:    []int{},
@@ -508,3 +517,314 @@ func TestPartition(t *testing.T) {
 		})
 	}
 }
+
+func TestFoldWhile(t *testing.T) {
+	underBudget := func(total, price int) (int, bool) {
+		if total+price > 80 {
+			return total, false
+		}
+		return total + price, true
+	}
+	tests := []struct {
+		name     string
+		input    []int
+		expected int
+		visited  int
+	}{
+		{name: "stops when the budget is exceeded", input: []int{40, 30, 20, 10}, expected: 70, visited: 3},
+		{name: "traverses everything under budget", input: []int{10, 20, 30}, expected: 60, visited: 3},
+		{name: "empty collection", input: []int{}, expected: 0, visited: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			visited := 0
+			got := FoldWhile(NewMockCollection(tt.input), 0, func(total, price int) (int, bool) {
+				visited++
+				return underBudget(total, price)
+			})
+			if got != tt.expected {
+				t.Errorf("FoldWhile() = %v, want %v", got, tt.expected)
+			}
+			if visited != tt.visited {
+				t.Errorf("FoldWhile() visited %v elements, want %v", visited, tt.visited)
+			}
+		})
+	}
+}
+
+func TestFoldErr(t *testing.T) {
+	errNegative := errors.New("negative price")
+	tests := []struct {
+		name     string
+		input    []int
+		expected int
+		err      error
+		visited  int
+	}{
+		{name: "stops at the first error", input: []int{10, 20, -1, 40}, expected: 30, err: errNegative, visited: 3},
+		{name: "traverses every valid element", input: []int{10, 20, 30}, expected: 60, visited: 3},
+		{name: "empty collection", input: []int{}, expected: 0, visited: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			visited := 0
+			got, err := FoldErr(NewMockCollection(tt.input), 0, func(total, price int) (int, error) {
+				visited++
+				if price < 0 {
+					return -1, errNegative
+				}
+				return total + price, nil
+			})
+			if got != tt.expected || err != tt.err {
+				t.Errorf("FoldErr() = %v, %v, want %v, %v", got, err, tt.expected, tt.err)
+			}
+			if visited != tt.visited {
+				t.Errorf("FoldErr() visited %v elements, want %v", visited, tt.visited)
+			}
+		})
+	}
+}
+
+func TestReduceUntil(t *testing.T) {
+	sum := func(acc, curr int) int { return acc + curr }
+	tests := []struct {
+		name     string
+		input    []int
+		init     int
+		expected int
+	}{
+		{name: "stops once the predicate holds", input: []int{5, 4, 3, 2, 1}, init: 0, expected: 9},
+		{name: "predicate never holds", input: []int{1, 2}, init: 0, expected: 3},
+		{name: "predicate holds for init", input: []int{1, 2}, init: 10, expected: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ReduceUntil(NewMockCollection(tt.input), sum, tt.init, func(acc int) bool { return acc > 6 })
+			if got != tt.expected {
+				t.Errorf("ReduceUntil() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFoldMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected string
+	}{
+		{name: "combines in order", input: []string{"go", "is", "fun"}, expected: "GO-IS-FUN"},
+		{name: "single element", input: []string{"go"}, expected: "GO"},
+		{name: "empty collection", input: []string{}, expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FoldMap(NewMockCollection(tt.input), strings.ToUpper, func(a, b string) string { return a + "-" + b })
+			if got != tt.expected {
+				t.Errorf("FoldMap() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGroupMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected map[byte][]int
+	}{
+		{
+			name:     "lengths per first letter",
+			input:    []string{"go", "gopher", "rust", "zig"},
+			expected: map[byte][]int{'g': {2, 6}, 'r': {4}, 'z': {3}},
+		},
+		{
+			name:     "empty collection",
+			input:    []string{},
+			expected: map[byte][]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GroupMap(NewMockCollection(tt.input),
+				func(w string) byte { return w[0] },
+				func(w string) int { return len(w) },
+			)
+			if !maps.EqualFunc(got, tt.expected, slices.Equal) {
+				t.Errorf("GroupMap() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGroupMapReduce(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected map[byte]int
+	}{
+		{
+			name:     "sums lengths per first letter",
+			input:    []string{"go", "gopher", "rust", "ruby", "zig"},
+			expected: map[byte]int{'g': 8, 'r': 8, 'z': 3},
+		},
+		{
+			name:     "empty collection",
+			input:    []string{},
+			expected: map[byte]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GroupMapReduce(NewMockCollection(tt.input),
+				func(w string) byte { return w[0] },
+				func(w string) int { return len(w) },
+				func(a, b int) int { return a + b },
+			)
+			if !maps.Equal(got, tt.expected) {
+				t.Errorf("GroupMapReduce() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScanLeft(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		want  []int
+	}{
+		{name: "running totals", input: []int{10, 5, 20}, want: []int{100, 110, 115, 135}},
+		{name: "empty collection", input: []int{}, want: []int{100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScanLeft(NewMockCollection(tt.input), func(acc, v int) int { return acc + v }, 100)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ScanLeft() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type order struct {
+	id       string
+	quantity int
+	total    float64
+}
+
+func TestAggregatesBy(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       []order
+		wantMin     string
+		wantMax     string
+		wantSum     int
+		wantAverage float64
+		expectedErr error
+	}{
+		{
+			name:        "orders",
+			input:       []order{{"a", 2, 20}, {"b", 5, 35}, {"c", 1, 15}},
+			wantMin:     "c",
+			wantMax:     "b",
+			wantSum:     8,
+			wantAverage: 70.0 / 3,
+			expectedErr: nil,
+		},
+		{
+			name:        "empty collection",
+			input:       []order{},
+			wantSum:     0,
+			wantAverage: 0,
+			expectedErr: EmptyCollectionError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMockCollection(tt.input)
+			quantity := func(o order) int { return o.quantity }
+			if o, err := MinBy(c, quantity); o.id != tt.wantMin || err != tt.expectedErr {
+				t.Errorf("MinBy() = %v, %v, want %v, %v", o, err, tt.wantMin, tt.expectedErr)
+			}
+			if o, err := MaxBy(c, func(o order) float64 { return o.total }); o.id != tt.wantMax || err != tt.expectedErr {
+				t.Errorf("MaxBy() = %v, %v, want %v, %v", o, err, tt.wantMax, tt.expectedErr)
+			}
+			if sum := SumBy(c, quantity); sum != tt.wantSum {
+				t.Errorf("SumBy() = %v, want %v", sum, tt.wantSum)
+			}
+			if avg, err := AverageBy(c, func(o order) float64 { return o.total }); avg != tt.wantAverage || err != tt.expectedErr {
+				t.Errorf("AverageBy() = %v, %v, want %v, %v", avg, err, tt.wantAverage, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestUnion(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []int
+		b    []int
+		want []int
+	}{
+		{name: "overlapping", a: []int{1, 2, 3}, b: []int{3, 4, 5}, want: []int{1, 2, 3, 4, 5}},
+		{name: "duplicates kept", a: []int{1, 1, 2}, b: []int{2, 3, 3}, want: []int{1, 1, 2, 3, 3}},
+		{name: "empty first", a: nil, b: []int{1, 2}, want: []int{1, 2}},
+		{name: "empty second", a: []int{1, 2}, b: nil, want: []int{1, 2}},
+		{name: "empty", a: nil, b: nil, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Union(NewMockCollection(tt.a), NewMockCollection(tt.b)).(*MockCollection[int]).items
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Union() = %v, want %v", got, tt.want)
+			}
+			got = UnionFunc(NewMockCollection(tt.a), NewMockCollection(tt.b), func(a, b int) bool { return a == b }).(*MockCollection[int]).items
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("UnionFunc() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMkString(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		sep   string
+		want  string
+	}{
+		{name: "empty", input: []int{}, sep: ", ", want: ""},
+		{name: "single", input: []int{1}, sep: ", ", want: "1"},
+		{name: "several", input: []int{1, 2, 3}, sep: ", ", want: "1, 2, 3"},
+		{name: "no separator", input: []int{1, 2, 3}, sep: "", want: "123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MkString(NewMockCollection(tt.input), tt.sep); got != tt.want {
+				t.Errorf("MkString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMkStringf(t *testing.T) {
+	tests := []struct {
+		name                string
+		input               []float64
+		prefix, sep, suffix string
+		format              string
+		want                string
+	}{
+		{name: "empty", input: []float64{}, prefix: "[", sep: ", ", suffix: "]", format: "%v", want: "[]"},
+		{name: "format", input: []float64{1, 2.5}, prefix: "[", sep: " | ", suffix: "]", format: "%.2f", want: "[1.00 | 2.50]"},
+		{name: "compact", input: []float64{1, 2}, prefix: "{", sep: ",", suffix: "}", format: "%g", want: "{1,2}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MkStringf(NewMockCollection(tt.input), tt.prefix, tt.sep, tt.suffix, tt.format); got != tt.want {
+				t.Errorf("MkStringf() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
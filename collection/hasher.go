@@ -0,0 +1,260 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// hasher.go defines the Hasher protocol along with default implementations
+// backed by hash/maphash. All hashers are written in pure Go without build
+// tags or unsafe code, so they behave identically on every platform.
+
+package collection
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+// Hasher is a generic interface for hashing and comparing values of type T.
+// Implementations must guarantee that Equal(a, b) implies Hash(a) == Hash(b).
+type Hasher[T any] interface {
+	Hash(T) uint64
+	Equal(T, T) bool
+}
+
+type funcHasher[T any] struct {
+	hash  func(T) uint64
+	equal func(T, T) bool
+}
+
+func (h funcHasher[T]) Hash(v T) uint64 {
+	return h.hash(v)
+}
+
+func (h funcHasher[T]) Equal(a, b T) bool {
+	return h.equal(a, b)
+}
+
+// NewHasher returns a Hasher built from a hash function and an equality function.
+//
+// example usage:
+//
+//	h := NewHasher(
+//	  func(s string) uint64 { return uint64(len(s)) },
+//	  func(a, b string) bool { return a == b },
+//	)
+//	h.Hash("foo")
+//
+// output:
+//
+//	3
+func NewHasher[T any](hash func(T) uint64, equal func(T, T) bool) Hasher[T] {
+	return funcHasher[T]{hash: hash, equal: equal}
+}
+
+// StringHasher returns a Hasher for string types.
+func StringHasher[T ~string]() Hasher[T] {
+	seed := maphash.MakeSeed()
+	return funcHasher[T]{
+		hash:  func(v T) uint64 { return maphash.String(seed, string(v)) },
+		equal: func(a, b T) bool { return a == b },
+	}
+}
+
+// BytesHasher returns a Hasher for byte slices. Two slices are
+// considered equal if they hold the same bytes.
+func BytesHasher() Hasher[[]byte] {
+	seed := maphash.MakeSeed()
+	return funcHasher[[]byte]{
+		hash:  func(v []byte) uint64 { return maphash.Bytes(seed, v) },
+		equal: bytes.Equal,
+	}
+}
+
+// IntegerHasher returns a Hasher for integer types.
+func IntegerHasher[T Integer]() Hasher[T] {
+	seed := maphash.MakeSeed()
+	return funcHasher[T]{
+		hash: func(v T) uint64 {
+			var b [8]byte
+			binary.LittleEndian.PutUint64(b[:], uint64(v))
+			return maphash.Bytes(seed, b[:])
+		},
+		equal: func(a, b T) bool { return a == b },
+	}
+}
+
+// FloatHasher returns a Hasher for floating-point types.
+// Positive and negative zero hash to the same value since they compare equal.
+func FloatHasher[T Float]() Hasher[T] {
+	seed := maphash.MakeSeed()
+	return funcHasher[T]{
+		hash: func(v T) uint64 {
+			var b [8]byte
+			binary.LittleEndian.PutUint64(b[:], floatBits(float64(v)))
+			return maphash.Bytes(seed, b[:])
+		},
+		equal: func(a, b T) bool { return a == b },
+	}
+}
+
+// DeriveHasher returns a Hasher for any comparable type, including structs
+// and arrays made of comparable fields. The hash function is derived once
+// from the type's layout using reflection, equality uses the == operator.
+//
+// example usage:
+//
+//	type Point struct { X, Y int }
+//	h := DeriveHasher[Point]()
+//	h.Hash(Point{1, 2}) == h.Hash(Point{1, 2})
+//
+// output:
+//
+//	true
+func DeriveHasher[T comparable]() Hasher[T] {
+	seed := maphash.MakeSeed()
+	write := deriveWriter(reflect.TypeFor[T]())
+	return funcHasher[T]{
+		hash: func(v T) uint64 {
+			var h maphash.Hash
+			h.SetSeed(seed)
+			write(&h, reflect.ValueOf(&v).Elem())
+			return h.Sum64()
+		},
+		equal: func(a, b T) bool { return a == b },
+	}
+}
+
+// DefaultHasher returns the most efficient Hasher available for T,
+// falling back to DeriveHasher for composite types.
+func DefaultHasher[T comparable]() Hasher[T] {
+	if h, ok := basicHasher[T](); ok {
+		return h
+	}
+	return DeriveHasher[T]()
+}
+
+// basicHasher returns the Hasher of T if T is a string or numeric type,
+// or false for the types left to the slower DeriveHasher.
+func basicHasher[T comparable]() (Hasher[T], bool) {
+	var h any
+	switch any(*new(T)).(type) {
+	case string:
+		h = StringHasher[string]()
+	case int:
+		h = IntegerHasher[int]()
+	case int8:
+		h = IntegerHasher[int8]()
+	case int16:
+		h = IntegerHasher[int16]()
+	case int32:
+		h = IntegerHasher[int32]()
+	case int64:
+		h = IntegerHasher[int64]()
+	case uint:
+		h = IntegerHasher[uint]()
+	case uint8:
+		h = IntegerHasher[uint8]()
+	case uint16:
+		h = IntegerHasher[uint16]()
+	case uint32:
+		h = IntegerHasher[uint32]()
+	case uint64:
+		h = IntegerHasher[uint64]()
+	case uintptr:
+		h = IntegerHasher[uintptr]()
+	case float32:
+		h = FloatHasher[float32]()
+	case float64:
+		h = FloatHasher[float64]()
+	default:
+		return nil, false
+	}
+	return h.(Hasher[T]), true
+}
+
+// floatBits returns the bit pattern of f, mapping -0 to +0.
+func floatBits(f float64) uint64 {
+	if f == 0 {
+		return 0
+	}
+	return math.Float64bits(f)
+}
+
+// deriveWriter builds a function that feeds a value of type t into a maphash.Hash.
+func deriveWriter(t reflect.Type) func(*maphash.Hash, reflect.Value) {
+	switch t.Kind() {
+	case reflect.Bool:
+		return func(h *maphash.Hash, v reflect.Value) {
+			if v.Bool() {
+				h.WriteByte(1)
+			} else {
+				h.WriteByte(0)
+			}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(h *maphash.Hash, v reflect.Value) {
+			writeUint64(h, uint64(v.Int()))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(h *maphash.Hash, v reflect.Value) {
+			writeUint64(h, v.Uint())
+		}
+	case reflect.Float32, reflect.Float64:
+		return func(h *maphash.Hash, v reflect.Value) {
+			writeUint64(h, floatBits(v.Float()))
+		}
+	case reflect.Complex64, reflect.Complex128:
+		return func(h *maphash.Hash, v reflect.Value) {
+			c := v.Complex()
+			writeUint64(h, floatBits(real(c)))
+			writeUint64(h, floatBits(imag(c)))
+		}
+	case reflect.String:
+		return func(h *maphash.Hash, v reflect.Value) {
+			s := v.String()
+			writeUint64(h, uint64(len(s)))
+			h.WriteString(s)
+		}
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return func(h *maphash.Hash, v reflect.Value) {
+			writeUint64(h, uint64(v.Pointer()))
+		}
+	case reflect.Array:
+		elem := deriveWriter(t.Elem())
+		return func(h *maphash.Hash, v reflect.Value) {
+			for i := range v.Len() {
+				elem(h, v.Index(i))
+			}
+		}
+	case reflect.Struct:
+		fields := make([]func(*maphash.Hash, reflect.Value), t.NumField())
+		for i := range fields {
+			fields[i] = deriveWriter(t.Field(i).Type)
+		}
+		return func(h *maphash.Hash, v reflect.Value) {
+			for i, f := range fields {
+				f(h, v.Field(i))
+			}
+		}
+	case reflect.Interface:
+		return func(h *maphash.Hash, v reflect.Value) {
+			if v.IsNil() {
+				h.WriteByte(0)
+				return
+			}
+			e := v.Elem()
+			h.WriteString(e.Type().String())
+			deriveWriter(e.Type())(h, e)
+		}
+	default:
+		panic("collection: cannot derive a hasher for non-comparable type " + t.String())
+	}
+}
+
+func writeUint64(h *maphash.Hash, u uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], u)
+	h.Write(b[:])
+}
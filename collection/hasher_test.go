@@ -0,0 +1,134 @@
+package collection
+
+import (
+	"math"
+	"testing"
+)
+
+type hashPoint struct {
+	X, Y  int
+	Label string
+	tags  [2]bool
+}
+
+func TestStringHasher(t *testing.T) {
+	h := StringHasher[string]()
+	tests := []struct {
+		name  string
+		a     string
+		b     string
+		equal bool
+	}{
+		{name: "same strings", a: "foo", b: "foo", equal: true},
+		{name: "different strings", a: "foo", b: "bar", equal: false},
+		{name: "empty strings", a: "", b: "", equal: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := h.Equal(tt.a, tt.b); got != tt.equal {
+				t.Errorf("Equal() = %v, want %v", got, tt.equal)
+			}
+			if tt.equal && h.Hash(tt.a) != h.Hash(tt.b) {
+				t.Errorf("Hash() differs for equal values %q and %q", tt.a, tt.b)
+			}
+		})
+	}
+}
+
+func TestBytesHasher(t *testing.T) {
+	h := BytesHasher()
+	a, b := []byte("gophers"), []byte("gophers")
+	if !h.Equal(a, b) {
+		t.Errorf("Equal() = false, want true")
+	}
+	if h.Hash(a) != h.Hash(b) {
+		t.Errorf("Hash() differs for equal byte slices")
+	}
+}
+
+func TestIntegerHasher(t *testing.T) {
+	h := IntegerHasher[int64]()
+	seen := make(map[uint64]bool)
+	for i := int64(-500); i < 500; i++ {
+		seen[h.Hash(i)] = true
+	}
+	if len(seen) != 1000 {
+		t.Errorf("IntegerHasher produced %d distinct hashes, want 1000", len(seen))
+	}
+	if h.Hash(42) != h.Hash(42) {
+		t.Errorf("Hash() is not deterministic")
+	}
+}
+
+func TestFloatHasher(t *testing.T) {
+	h := FloatHasher[float64]()
+	if h.Hash(0.0) != h.Hash(math.Copysign(0, -1)) {
+		t.Errorf("Hash() differs for +0 and -0")
+	}
+	if h.Hash(1.5) == h.Hash(2.5) {
+		t.Errorf("Hash() collides for 1.5 and 2.5")
+	}
+}
+
+func TestDeriveHasher(t *testing.T) {
+	h := DeriveHasher[hashPoint]()
+	tests := []struct {
+		name  string
+		a     hashPoint
+		b     hashPoint
+		equal bool
+	}{
+		{
+			name:  "equal structs",
+			a:     hashPoint{X: 1, Y: 2, Label: "a", tags: [2]bool{true, false}},
+			b:     hashPoint{X: 1, Y: 2, Label: "a", tags: [2]bool{true, false}},
+			equal: true,
+		},
+		{
+			name:  "different exported field",
+			a:     hashPoint{X: 1, Y: 2, Label: "a"},
+			b:     hashPoint{X: 2, Y: 1, Label: "a"},
+			equal: false,
+		},
+		{
+			name:  "different unexported field",
+			a:     hashPoint{X: 1, tags: [2]bool{true, false}},
+			b:     hashPoint{X: 1, tags: [2]bool{false, true}},
+			equal: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := h.Equal(tt.a, tt.b); got != tt.equal {
+				t.Errorf("Equal() = %v, want %v", got, tt.equal)
+			}
+			if got := h.Hash(tt.a) == h.Hash(tt.b); got != tt.equal {
+				t.Errorf("Hash() equality = %v, want %v", got, tt.equal)
+			}
+		})
+	}
+}
+
+func TestDeriveHasher_Interface(t *testing.T) {
+	h := DeriveHasher[any]()
+	if h.Hash(1) != h.Hash(1) {
+		t.Errorf("Hash() differs for equal interface values")
+	}
+	if h.Hash(1) == h.Hash("1") {
+		t.Errorf("Hash() collides for int and string interface values")
+	}
+	if h.Hash(nil) != h.Hash(nil) {
+		t.Errorf("Hash() differs for nil interface values")
+	}
+}
+
+func TestDefaultHasher(t *testing.T) {
+	if _, ok := DefaultHasher[string]().(funcHasher[string]); !ok {
+		t.Errorf("DefaultHasher[string]() did not return a funcHasher")
+	}
+	h := DefaultHasher[hashPoint]()
+	p := hashPoint{X: 3, Y: 4}
+	if h.Hash(p) != h.Hash(p) {
+		t.Errorf("Hash() is not deterministic")
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// into_functions.go defines variants of the collection functions that write
+// their result into a destination collection provided by the caller.
+//
+// The destination is a type parameter, so the result keeps its concrete type
+// (i.e. *List[T]) without a runtime type assertion. Concrete collection types
+// use these functions to implement their method aliases:
+//
+//	func (l *List[T]) Filter(f func(T) bool) *List[T] {
+//	  return collection.FilterInto(l, NewList[T](), f)
+//	}
+
+package collection
+
+import (
+	"iter"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/charbz/gophers/seq"
+)
+
+// CollectInto adds every value yielded by s to dst and returns dst.
+// Combined with the iterator functions it appends results to an existing
+// collection without allocating an intermediate one, i.e. when reusing
+// a destination across loop iterations.
+//
+// example usage:
+//
+//	dst := NewSequence([]int{0})
+//	CollectInto(Filtered(NewSequence([]int{1,2,3,4}), isEven), dst)
+//
+// output:
+//
+//	[0,2,4]
+func CollectInto[C Collection[T], T any](s iter.Seq[T], dst C) C {
+	for v := range s {
+		dst.Add(v)
+	}
+	return dst
+}
+
+// Collect returns a new collection of type C holding the values yielded by s,
+// in order. The collection is created by calling the New method of the zero
+// value of C, so C must be a pointer type whose New method does not depend on
+// its receiver, such as *Sequence[T], *List[T] or *Set[T]. Use CollectInto
+// for collections configured at construction, i.e. ring buffers or queues.
+//
+// example usage:
+//
+//	evens := Collect[*Set[int]](Filtered(NewSequence([]int{1,2,2,4}), isEven))
+//
+// output:
+//
+//	Set(int) {2,4}
+func Collect[C Collection[T], T any](s iter.Seq[T]) C {
+	var zero C
+	return CollectInto(s, zero.New().(C))
+}
+
+// DistinctInto adds the unique elements of s to dst, using f as an equality
+// function, and returns dst. Elements already present in dst are skipped.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,1,2,3,3})
+//	DistinctInto(c, NewSequence[int](), func(a, b int) bool { return a == b })
+//
+// output:
+//
+//	[1,2,3]
+func DistinctInto[C Collection[T], T any](s Collection[T], dst C, f func(T, T) bool) C {
+	for v := range s.Values() {
+		if !seq.Exists(dst.Values(), func(t T) bool { return f(v, t) }) {
+			dst.Add(v)
+		}
+	}
+	return dst
+}
+
+// FilterInto adds the elements of s that satisfy the predicate function to dst
+// and returns dst.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3,4,5,6})
+//	FilterInto(c, NewSequence[int](), func(i int) bool { return i % 2 == 0 })
+//
+// output:
+//
+//	[2,4,6]
+func FilterInto[C Collection[T], T any](s Collection[T], dst C, f func(T) bool) C {
+	for v := range seq.Filter(s.Values(), f) {
+		dst.Add(v)
+	}
+	return dst
+}
+
+// PartitionInto adds the elements of s that satisfy the predicate function to
+// match, the rest of the elements to noMatch, and returns both collections.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3,4,5,6})
+//	PartitionInto(c, NewSequence[int](), NewSequence[int](), func(i int) bool { return i % 2 == 0 })
+//
+// output:
+//
+//	[2,4,6], [1,3,5]
+func PartitionInto[C Collection[T], T any](s Collection[T], match, noMatch C, f func(T) bool) (C, C) {
+	for v := range s.Values() {
+		if f(v) {
+			match.Add(v)
+		} else {
+			noMatch.Add(v)
+		}
+	}
+	return match, noMatch
+}
+
+// ReverseInto adds the elements of s to dst in reverse order and returns dst.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3})
+//	ReverseInto(c, NewSequence[int]())
+//
+// output:
+//
+//	[3,2,1]
+func ReverseInto[C Collection[T], T any](s OrderedCollection[T], dst C) C {
+	for _, v := range s.Backward() {
+		dst.Add(v)
+	}
+	return dst
+}
+
+// UnionInto adds the elements of s1 followed by the elements of s2 that are
+// not present in s1 to dst, and returns dst. It runs in O(n+m).
+//
+// example usage:
+//
+//	c1 := NewSequence([]int{1,2,2})
+//	c2 := NewSequence([]int{2,3,3})
+//	UnionInto(c1, c2, NewSequence[int]())
+//
+// output:
+//
+//	[1,2,2,3,3]
+func UnionInto[C Collection[T], T comparable](s1, s2 Collection[T], dst C) C {
+	present := toSet(s1)
+	for v := range s1.Values() {
+		dst.Add(v)
+	}
+	for v := range s2.Values() {
+		if _, ok := present[v]; !ok {
+			dst.Add(v)
+		}
+	}
+	return dst
+}
+
+// UnionFuncInto is similar to UnionInto but applies to non-comparable types,
+// using f as an equality function. It runs in O(n*m).
+func UnionFuncInto[C Collection[T], T any](s1, s2 Collection[T], dst C, f func(T, T) bool) C {
+	for v := range s1.Values() {
+		dst.Add(v)
+	}
+	for v := range s2.Values() {
+		if !seq.Exists(s1.Values(), func(t T) bool { return f(t, v) }) {
+			dst.Add(v)
+		}
+	}
+	return dst
+}
+
+// ShuffleInto adds the elements of s to dst in a random order and returns dst.
+// The elements are shuffled with the Fisher-Yates algorithm.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3,4,5})
+//	ShuffleInto(c, NewSequence[int]())
+//
+// possible output:
+//
+//	[4,2,5,1,3]
+func ShuffleInto[C Collection[T], T any](s Collection[T], dst C) C {
+	return sampleInto(s, dst, s.Length(), randStateOf(s))
+}
+
+// ShuffleIntoWith is like ShuffleInto, drawing from r instead of the
+// RandState of s, i.e. to shuffle deterministically in tests.
+//
+// example usage:
+//
+//	r := rand.New(rand.NewPCG(1, 2))
+//	ShuffleIntoWith(c, NewSequence[int](), r)
+func ShuffleIntoWith[C Collection[T], T any](s Collection[T], dst C, r *rand.Rand) C {
+	return sampleInto(s, dst, s.Length(), r)
+}
+
+// SampleInto adds n elements of s picked at random without replacement to
+// dst, in a random order, and returns dst. If n is greater than the length
+// of s, all the elements are added, as by ShuffleInto.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3,4,5})
+//	SampleInto(c, NewSequence[int](), 2)
+//
+// possible output:
+//
+//	[4,1]
+func SampleInto[C Collection[T], T any](s Collection[T], dst C, n int) C {
+	return sampleInto(s, dst, n, randStateOf(s))
+}
+
+// SampleIntoWith is like SampleInto, drawing from r instead of the
+// RandState of s, i.e. to sample deterministically in tests.
+func SampleIntoWith[C Collection[T], T any](s Collection[T], dst C, n int, r *rand.Rand) C {
+	return sampleInto(s, dst, n, r)
+}
+
+// sampleInto adds n elements of s picked by a partial Fisher-Yates shuffle
+// drawing from r to dst.
+func sampleInto[C Collection[T], T any](s Collection[T], dst C, n int, r interface{ IntN(int) int }) C {
+	values := slices.Collect(s.Values())
+	n = min(max(n, 0), len(values))
+	for i := range n {
+		j := i + r.IntN(len(values)-i)
+		values[i], values[j] = values[j], values[i]
+		dst.Add(values[i])
+	}
+	return dst
+}
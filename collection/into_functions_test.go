@@ -0,0 +1,148 @@
+package collection
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestFilterInto(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		dst   []int
+		want  []int
+	}{
+		{name: "filter into empty", input: []int{1, 2, 3, 4}, dst: nil, want: []int{2, 4}},
+		{name: "filter into existing", input: []int{1, 2, 3, 4}, dst: []int{0}, want: []int{0, 2, 4}},
+		{name: "filter empty", input: []int{}, dst: nil, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FilterInto(NewMockCollection(tt.input), NewMockCollection(tt.dst), func(i int) bool { return i%2 == 0 })
+			if !slices.Equal(got.items, tt.want) {
+				t.Errorf("FilterInto() = %v, want %v", got.items, tt.want)
+			}
+		})
+	}
+}
+
+func TestPartitionInto(t *testing.T) {
+	match, noMatch := PartitionInto(
+		NewMockCollection([]int{1, 2, 3, 4, 5}),
+		NewMockCollection[int](),
+		NewMockCollection[int](),
+		func(i int) bool { return i > 2 },
+	)
+	if !slices.Equal(match.items, []int{3, 4, 5}) || !slices.Equal(noMatch.items, []int{1, 2}) {
+		t.Errorf("PartitionInto() = %v %v, want [3 4 5] [1 2]", match.items, noMatch.items)
+	}
+}
+
+func TestDistinctInto(t *testing.T) {
+	got := DistinctInto(NewMockCollection([]int{1, 1, 2, 3, 3}), NewMockCollection([]int{3}), func(a, b int) bool { return a == b })
+	if !slices.Equal(got.items, []int{3, 1, 2}) {
+		t.Errorf("DistinctInto() = %v, want [3 1 2]", got.items)
+	}
+}
+
+func TestReverseInto(t *testing.T) {
+	got := ReverseInto(NewMockOrderedCollection([]int{1, 2, 3}), NewMockCollection[int]())
+	if !slices.Equal(got.items, []int{3, 2, 1}) {
+		t.Errorf("ReverseInto() = %v, want [3 2 1]", got.items)
+	}
+}
+
+func TestUnionInto(t *testing.T) {
+	a, b := NewMockCollection([]int{1, 2, 2}), NewMockCollection([]int{2, 3, 3})
+	if got := UnionInto(a, b, NewMockCollection[int]()); !slices.Equal(got.items, []int{1, 2, 2, 3, 3}) {
+		t.Errorf("UnionInto() = %v, want [1 2 2 3 3]", got.items)
+	}
+	got := UnionFuncInto(a, b, NewMockCollection[int](), func(x, y int) bool { return x == y })
+	if !slices.Equal(got.items, []int{1, 2, 2, 3, 3}) {
+		t.Errorf("UnionFuncInto() = %v, want [1 2 2 3 3]", got.items)
+	}
+}
+
+func TestShuffleInto(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	got := ShuffleInto(NewMockCollection(input), NewMockCollection[int]())
+	sorted := slices.Clone(got.items)
+	slices.Sort(sorted)
+	if !slices.Equal(sorted, input) {
+		t.Errorf("ShuffleInto() = %v, not a permutation of %v", got.items, input)
+	}
+}
+
+func TestSampleInto(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	tests := []struct {
+		name string
+		n    int
+		want int
+	}{
+		{name: "some", n: 3, want: 3},
+		{name: "all", n: 8, want: 8},
+		{name: "more than length", n: 10, want: 8},
+		{name: "none", n: 0, want: 0},
+		{name: "negative", n: -1, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SampleInto(NewMockCollection(input), NewMockCollection[int](), tt.n)
+			if len(got.items) != tt.want {
+				t.Fatalf("SampleInto(%d) = %v, want %d elements", tt.n, got.items, tt.want)
+			}
+			seen := map[int]bool{}
+			for _, v := range got.items {
+				if seen[v] || !slices.Contains(input, v) {
+					t.Fatalf("SampleInto(%d) = %v, not a sample without replacement of %v", tt.n, got.items, input)
+				}
+				seen[v] = true
+			}
+		})
+	}
+}
+
+func TestSampleIntoWith(t *testing.T) {
+	input := NewMockCollection([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	a := SampleIntoWith(input, NewMockCollection[int](), 4, rand.New(rand.NewPCG(1, 2)))
+	b := SampleIntoWith(input, NewMockCollection[int](), 4, rand.New(rand.NewPCG(1, 2)))
+	if !slices.Equal(a.items, b.items) {
+		t.Errorf("SampleIntoWith() with the same seed = %v and %v", a.items, b.items)
+	}
+	c := ShuffleIntoWith(input, NewMockCollection[int](), rand.New(rand.NewPCG(1, 2)))
+	d := ShuffleIntoWith(input, NewMockCollection[int](), rand.New(rand.NewPCG(1, 2)))
+	if !slices.Equal(c.items, d.items) {
+		t.Errorf("ShuffleIntoWith() with the same seed = %v and %v", c.items, d.items)
+	}
+}
+
+func TestCollectInto(t *testing.T) {
+	dst := NewMockCollection([]int{0})
+	for _, input := range [][]int{{1, 2}, {3, 4}} {
+		CollectInto(Filtered(NewMockCollection(input), func(i int) bool { return i%2 == 0 }), dst)
+	}
+	if !slices.Equal(dst.items, []int{0, 2, 4}) {
+		t.Errorf("CollectInto() = %v, want [0 2 4]", dst.items)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		want  []int
+	}{
+		{name: "values", input: []int{1, 2, 3, 4}, want: []int{2, 4}},
+		{name: "empty", input: nil, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Collect[*MockCollection[int]](Filtered(NewMockCollection(tt.input), func(i int) bool { return i%2 == 0 }))
+			if !slices.Equal(got.items, tt.want) {
+				t.Errorf("Collect() = %v, want %v", got.items, tt.want)
+			}
+		})
+	}
+}
@@ -7,7 +7,14 @@
 
 package collection
 
-import "iter"
+import (
+	"cmp"
+	"context"
+	"iter"
+	"time"
+
+	"github.com/charbz/gophers/seq"
+)
 
 // Concatenated returns an iterator that yields the elements of s1 and s2.
 //
@@ -26,14 +33,7 @@ import "iter"
 //	3
 //	4
 func Concatenated[T any](s1, s2 Collection[T]) iter.Seq[T] {
-	return func(yield func(T) bool) {
-		for v := range s1.Values() {
-			yield(v)
-		}
-		for v := range s2.Values() {
-			yield(v)
-		}
-	}
+	return seq.Concat(s1.Values(), s2.Values())
 }
 
 // Diffed returns an iterator that yields the elements of s1 that are not present in s2.
@@ -54,9 +54,8 @@ func Concatenated[T any](s1, s2 Collection[T]) iter.Seq[T] {
 func Diffed[T comparable](s1 OrderedCollection[T], s2 OrderedCollection[T]) iter.Seq[T] {
 	return func(yield func(T) bool) {
 		for v := range s1.Values() {
-			i, _ := Find(s2, func(t T) bool { return t == v })
-			if i == -1 {
-				yield(v)
+			if !seq.Contains(s2.Values(), v) && !yield(v) {
+				return
 			}
 		}
 	}
@@ -83,9 +82,8 @@ func Diffed[T comparable](s1 OrderedCollection[T], s2 OrderedCollection[T]) iter
 func DiffedFunc[T any](s1 OrderedCollection[T], s2 OrderedCollection[T], f func(T, T) bool) iter.Seq[T] {
 	return func(yield func(T) bool) {
 		for v := range s1.Values() {
-			i, _ := Find(s2, func(t T) bool { return f(v, t) })
-			if i == -1 {
-				yield(v)
+			if !seq.Exists(s2.Values(), func(t T) bool { return f(v, t) }) && !yield(v) {
+				return
 			}
 		}
 	}
@@ -106,15 +104,7 @@ func DiffedFunc[T any](s1 OrderedCollection[T], s2 OrderedCollection[T], f func(
 //	2
 //	3
 func Distincted[T comparable](s Collection[T]) iter.Seq[T] {
-	seen := make(map[T]bool)
-	return func(yield func(T) bool) {
-		for v := range s.Values() {
-			if !seen[v] {
-				seen[v] = true
-				yield(v)
-			}
-		}
-	}
+	return seq.Distinct(s.Values())
 }
 
 // DistinctedFunc is similar to Distincted but applies to non-comparable types.
@@ -135,22 +125,7 @@ func Distincted[T comparable](s Collection[T]) iter.Seq[T] {
 //	2
 //	3
 func DistinctedFunc[T any](s Collection[T], f func(T, T) bool) iter.Seq[T] {
-	s2 := s.New()
-	return func(yield func(T) bool) {
-		for v := range s.Values() {
-			match := false
-			for v2 := range s2.Values() {
-				if f(v, v2) {
-					match = true
-					break
-				}
-			}
-			if !match {
-				s2.Add(v)
-				yield(v)
-			}
-		}
-	}
+	return seq.DistinctFunc(s.Values(), f)
 }
 
 // Filtered returns an iterator that yields the elements of s
@@ -169,13 +144,7 @@ func DistinctedFunc[T any](s Collection[T], f func(T, T) bool) iter.Seq[T] {
 //	4
 //	6
 func Filtered[T any](s Collection[T], f func(T) bool) iter.Seq[T] {
-	return func(yield func(T) bool) {
-		for v := range s.Values() {
-			if f(v) {
-				yield(v)
-			}
-		}
-	}
+	return seq.Filter(s.Values(), f)
 }
 
 // Intersected returns an iterator that yields the elements of s1
@@ -197,8 +166,8 @@ func Intersected[T comparable](s1 Collection[T], s2 Collection[T]) iter.Seq[T] {
 	return func(yield func(T) bool) {
 		for v := range s1.Values() {
 			for v2 := range s2.Values() {
-				if v == v2 {
-					yield(v)
+				if v == v2 && !yield(v) {
+					return
 				}
 			}
 		}
@@ -226,8 +195,8 @@ func IntersectedFunc[T any](s1 Collection[T], s2 Collection[T], f func(T, T) boo
 	return func(yield func(T) bool) {
 		for v := range s1.Values() {
 			for v2 := range s2.Values() {
-				if f(v, v2) {
-					yield(v)
+				if f(v, v2) && !yield(v) {
+					return
 				}
 			}
 		}
@@ -250,11 +219,7 @@ func IntersectedFunc[T any](s1 Collection[T], s2 Collection[T], f func(T, T) boo
 //	4
 //	6
 func Mapped[T, K any](s Collection[T], f func(T) K) iter.Seq[K] {
-	return func(yield func(K) bool) {
-		for v := range s.Values() {
-			yield(f(v))
-		}
-	}
+	return seq.Map(s.Values(), f)
 }
 
 // Rejected returns an iterator that yields the elements of s
@@ -273,5 +238,191 @@ func Mapped[T, K any](s Collection[T], f func(T) K) iter.Seq[K] {
 //	3
 //	5
 func Rejected[T any](s Collection[T], f func(T) bool) iter.Seq[T] {
-	return Filtered(s, func(t T) bool { return !f(t) })
+	return seq.Reject(s.Values(), f)
+}
+
+// Merged returns an iterator that merges two sorted collections into
+// a single sorted stream. Both collections must already be sorted in
+// ascending order, when elements are equal those of s1 are yielded first.
+//
+// example usage:
+//
+//	a := NewList([]int{1,4,6})
+//	b := NewSequence([]int{2,3,7})
+//	for v := range Merged(a, b) {
+//		fmt.Println(v)
+//	}
+//
+// output:
+//
+//	1
+//	2
+//	3
+//	4
+//	6
+//	7
+func Merged[T cmp.Ordered](s1 OrderedCollection[T], s2 OrderedCollection[T]) iter.Seq[T] {
+	return MergedFunc(s1, s2, cmp.Compare[T])
+}
+
+// MergedFunc is similar to Merged but takes a comparison function
+// returning a negative number when a < b, zero when a == b and a
+// positive number when a > b. Both collections must be sorted by f.
+func MergedFunc[T any](s1 OrderedCollection[T], s2 OrderedCollection[T], f func(T, T) int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		next, stop := iter.Pull(s2.Values())
+		defer stop()
+		v2, ok := next()
+		for v := range s1.Values() {
+			for ok && f(v2, v) < 0 {
+				if !yield(v2) {
+					return
+				}
+				v2, ok = next()
+			}
+			if !yield(v) {
+				return
+			}
+		}
+		for ok {
+			if !yield(v2) {
+				return
+			}
+			v2, ok = next()
+		}
+	}
+}
+
+// Throttle returns an iterator that yields the elements of the collection at
+// most once per interval and stops once the context is done, see
+// seq.Throttle. It paces the consumption of a collection used as a job
+// queue, i.e. to stay below the rate limit of an external API.
+//
+// example usage:
+//
+//	for req := range Throttle(ctx, pending, 100*time.Millisecond) {
+//	  client.Send(req) // at most 10 requests per second
+//	}
+func Throttle[T any](ctx context.Context, s Collection[T], interval time.Duration) iter.Seq[T] {
+	return seq.Throttle(ctx, s.Values(), interval)
+}
+
+// Zipped returns an iterator over pairs of corresponding elements of s1 and s2.
+// The iteration stops when the shorter collection is exhausted.
+//
+// example usage:
+//
+//	a := NewList([]string{"a","b","c"})
+//	b := NewSequence([]int{1,2})
+//	for k, v := range Zipped(a, b) {
+//		fmt.Println(k, v)
+//	}
+//
+// output:
+//
+//	a 1
+//	b 2
+func Zipped[T, K any](s1 OrderedCollection[T], s2 OrderedCollection[K]) iter.Seq2[T, K] {
+	return seq.Zip(s1.Values(), s2.Values())
+}
+
+// ZippedLongest returns an iterator over pairs of corresponding elements of
+// s1 and s2. The iteration stops when both collections are exhausted, the
+// missing elements of the shorter one being replaced by fill1 or fill2.
+//
+// example usage:
+//
+//	expected := NewSequence([]string{"a","b","c"})
+//	actual := NewSequence([]string{"a","b"})
+//	for e, a := range ZippedLongest(expected, actual, "<missing>", "<missing>") {
+//		fmt.Println(e, a)
+//	}
+//
+// output:
+//
+//	a a
+//	b b
+//	c <missing>
+func ZippedLongest[T, K any](s1 OrderedCollection[T], s2 OrderedCollection[K], fill1 T, fill2 K) iter.Seq2[T, K] {
+	return seq.ZipLongest(s1.Values(), s2.Values(), fill1, fill2)
+}
+
+// Pairwise returns an iterator over pairs of consecutive elements (prev, curr).
+//
+// example usage:
+//
+//	a := NewList([]int{1,2,3})
+//	for prev, curr := range Pairwise(a) {
+//		fmt.Println(prev, curr)
+//	}
+//
+// output:
+//
+//	1 2
+//	2 3
+func Pairwise[T any](s OrderedCollection[T]) iter.Seq2[T, T] {
+	return seq.Pairwise(s.Values())
+}
+
+// Sliding returns an iterator over windows of size consecutive elements,
+// the start of each window being step elements after the start of the
+// previous one. The last window is shorter than size when the remaining
+// elements do not fill it. Each window is a new collection of the same type.
+// It panics if size or step is less than 1.
+//
+// example usage:
+//
+//	readings := NewSequence([]float64{1,2,3,4,5})
+//	for w := range Sliding(readings, 3, 1) {
+//		fmt.Println(Reduce(w, func(acc, v float64) float64 { return acc + v }, 0) / 3)
+//	}
+//
+// output:
+//
+//	2
+//	3
+//	4
+func Sliding[T any](s OrderedCollection[T], size, step int) iter.Seq[OrderedCollection[T]] {
+	return seq.Map(seq.Sliding(s.Values(), size, step), func(w []T) OrderedCollection[T] {
+		return s.NewOrdered(w)
+	})
+}
+
+// Grouped returns an iterator over consecutive chunks of n elements, the last
+// chunk holding the remaining elements. Each chunk is a new collection of the
+// same type. It panics if n is less than 1.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3,4,5})
+//	for chunk := range Grouped(c, 2) {
+//		fmt.Println(chunk)
+//	}
+//
+// output:
+//
+//	[1,2]
+//	[3,4]
+//	[5]
+func Grouped[T any](s OrderedCollection[T], n int) iter.Seq[OrderedCollection[T]] {
+	return Sliding(s, n, n)
+}
+
+// Pull converts the push-style Values iterator of a collection into a
+// pull-style iterator. It is a thin wrapper around iter.Pull: next returns
+// the next value and true, or the zero value and false once exhausted,
+// and stop must be called when the caller is done with the iterator.
+//
+// example usage:
+//
+//	a := NewList([]int{1,2,3})
+//	next, stop := Pull(a)
+//	defer stop()
+//	v, ok := next()
+//
+// output:
+//
+//	1, true
+func Pull[T any](s Collection[T]) (next func() (T, bool), stop func()) {
+	return iter.Pull(s.Values())
 }
@@ -1,8 +1,11 @@
 package collection
 
 import (
+	"context"
+	"iter"
 	"slices"
 	"testing"
+	"time"
 )
 
 func TestConcatenated(t *testing.T) {
@@ -373,3 +376,169 @@ func TestRejected(t *testing.T) {
 		})
 	}
 }
+
+func TestIterators_EarlyExit(t *testing.T) {
+	a := NewMockOrderedCollection([]int{1, 2, 3, 4, 5, 6})
+	b := NewMockOrderedCollection([]int{2, 4, 6})
+	eq := func(x, y int) bool { return x == y }
+	tests := []struct {
+		name string
+		seq  func(yield func(int) bool)
+		want []int
+	}{
+		{name: "concatenated", seq: Concatenated[int](a, b), want: []int{1, 2}},
+		{name: "diffed", seq: Diffed[int](a, b), want: []int{1, 3}},
+		{name: "diffed func", seq: DiffedFunc[int](a, b, eq), want: []int{1, 3}},
+		{name: "distincted", seq: Distincted[int](a), want: []int{1, 2}},
+		{name: "distincted func", seq: DistinctedFunc[int](a, eq), want: []int{1, 2}},
+		{name: "filtered", seq: Filtered[int](a, func(i int) bool { return i > 2 }), want: []int{3, 4}},
+		{name: "intersected", seq: Intersected[int](a, b), want: []int{2, 4}},
+		{name: "intersected func", seq: IntersectedFunc[int](a, b, eq), want: []int{2, 4}},
+		{name: "mapped", seq: Mapped[int](a, func(i int) int { return i * 10 }), want: []int{10, 20}},
+		{name: "rejected", seq: Rejected[int](a, func(i int) bool { return i < 3 }), want: []int{3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for v := range tt.seq {
+				got = append(got, v)
+				if len(got) == 2 {
+					break
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistincted_Reusable(t *testing.T) {
+	seq := Distincted[int](NewMockCollection([]int{1, 1, 2}))
+	first := slices.Collect(seq)
+	second := slices.Collect(seq)
+	if !slices.Equal(first, []int{1, 2}) || !slices.Equal(second, []int{1, 2}) {
+		t.Errorf("Distincted() = %v then %v, want [1 2] both times", first, second)
+	}
+}
+
+func TestPull(t *testing.T) {
+	next, stop := Pull[int](NewMockCollection([]int{1, 2}))
+	defer stop()
+	for _, want := range []int{1, 2} {
+		if v, ok := next(); !ok || v != want {
+			t.Errorf("next() = %v, %v, want %v, true", v, ok, want)
+		}
+	}
+	if _, ok := next(); ok {
+		t.Errorf("next() = _, true after exhaustion, want false")
+	}
+}
+
+func TestMerged(t *testing.T) {
+	tests := []struct {
+		name string
+		A    []int
+		B    []int
+		want []int
+	}{
+		{name: "interleaved", A: []int{1, 4, 6}, B: []int{2, 3, 7}, want: []int{1, 2, 3, 4, 6, 7}},
+		{name: "duplicates", A: []int{1, 2}, B: []int{1, 2}, want: []int{1, 1, 2, 2}},
+		{name: "A empty", A: []int{}, B: []int{1, 2}, want: []int{1, 2}},
+		{name: "B empty", A: []int{1, 2}, B: []int{}, want: []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Collect(Merged(NewMockOrderedCollection(tt.A), NewMockOrderedCollection(tt.B)))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Merged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZipped(t *testing.T) {
+	A := NewMockOrderedCollection([]string{"a", "b", "c"})
+	B := NewMockOrderedCollection([]int{1, 2})
+	var keys []string
+	var values []int
+	for k, v := range Zipped(A, B) {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	if !slices.Equal(keys, []string{"a", "b"}) || !slices.Equal(values, []int{1, 2}) {
+		t.Errorf("Zipped() = %v %v, want [a b] [1 2]", keys, values)
+	}
+}
+
+func TestPairwise(t *testing.T) {
+	var pairs [][2]int
+	for prev, curr := range Pairwise(NewMockOrderedCollection([]int{1, 2, 3, 4})) {
+		pairs = append(pairs, [2]int{prev, curr})
+		if curr == 3 {
+			break
+		}
+	}
+	if !slices.Equal(pairs, [][2]int{{1, 2}, {2, 3}}) {
+		t.Errorf("Pairwise() = %v, want [[1 2] [2 3]]", pairs)
+	}
+}
+
+func TestSlidingAndGrouped(t *testing.T) {
+	c := NewMockOrderedCollection([]int{1, 2, 3, 4, 5})
+	tests := []struct {
+		name    string
+		windows func() iter.Seq[OrderedCollection[int]]
+		want    [][]int
+	}{
+		{name: "sliding", windows: func() iter.Seq[OrderedCollection[int]] { return Sliding(c, 3, 1) }, want: [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}},
+		{name: "grouped", windows: func() iter.Seq[OrderedCollection[int]] { return Grouped(c, 2) }, want: [][]int{{1, 2}, {3, 4}, {5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]int
+			for w := range tt.windows() {
+				got = append(got, slices.Collect(w.Values()))
+			}
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZippedLongest(t *testing.T) {
+	var got []Pair[int, string]
+	for a, b := range ZippedLongest(NewMockOrderedCollection([]int{1, 2, 3}), NewMockOrderedCollection([]string{"a"}), -1, "?") {
+		got = append(got, Pair[int, string]{a, b})
+	}
+	if want := []Pair[int, string]{{1, "a"}, {2, "?"}, {3, "?"}}; !slices.Equal(got, want) {
+		t.Errorf("ZippedLongest() = %v, want %v", got, want)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	const interval = 50 * time.Millisecond
+	start := time.Now()
+	got := slices.Collect(Throttle(context.Background(), NewMockOrderedCollection([]int{1, 2, 3}), interval))
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Throttle() = %v, want [1 2 3]", got)
+	}
+	if elapsed := time.Since(start); elapsed < 3*interval || elapsed >= 4*interval {
+		t.Errorf("Throttle() yielded 3 elements in %v, want between %v and %v", elapsed, 3*interval, 4*interval)
+	}
+
+	// a consumer slower than the interval is never waited for,
+	// the context is still checked before each element
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got = nil
+	for v := range Throttle(ctx, NewMockOrderedCollection([]int{1, 2, 3}), time.Millisecond) {
+		got = append(got, v)
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+	}
+	if !slices.Equal(got, []int{1}) {
+		t.Errorf("Throttle() after cancel = %v, want [1]", got)
+	}
+}
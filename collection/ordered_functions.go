@@ -6,10 +6,70 @@
 
 package collection
 
-import "math/rand"
+import (
+	"iter"
+	"slices"
+
+	"github.com/charbz/gophers/seq"
+)
+
+// AdjacentDiff applies the function f to each pair of consecutive elements
+// (prev, curr) and returns a slice of the results, which has one element
+// less than the collection. It is typically used to compute deltas in time series.
+//
+// example usage:
+//
+//	readings := NewSequence([]int{10,12,11,15})
+//	AdjacentDiff(readings, func(prev int, curr int) int { return curr - prev })
+//
+// output:
+//
+//	[2,-1,4]
+func AdjacentDiff[T, R any](s OrderedCollection[T], f func(T, T) R) []R {
+	return slices.AppendSeq(make([]R, 0, max(s.Length()-1, 0)), seq.AdjacentDiff(s.Values(), f))
+}
+
+// AtOrElse returns the element at the given index,
+// or fallback if the index is out of bounds.
+//
+// example usage:
+//
+//	c := NewSequence([]string{"A","B"})
+//	AtOrElse(c, 1, "?")
+//	AtOrElse(c, 5, "?")
+//
+// output:
+//
+//	"B"
+//	"?"
+func AtOrElse[T any](s OrderedCollection[T], index int, fallback T) T {
+	if index < 0 || index >= s.Length() {
+		return fallback
+	}
+	return s.At(index)
+}
+
+// AtOrErr returns the element at the given index, or IndexOutOfBoundsError
+// instead of panicking if the index is out of bounds.
+//
+// example usage:
+//
+//	c := NewSequence([]string{"A","B"})
+//	AtOrErr(c, 5)
+//
+// output:
+//
+//	"", error 102: index out of bounds
+func AtOrErr[T any](s OrderedCollection[T], index int) (T, error) {
+	if index < 0 || index >= s.Length() {
+		return *new(T), IndexOutOfBoundsError
+	}
+	return s.At(index), nil
+}
 
 // Corresponds tests whether every element of this sequence relates to the corresponding
 // element of another sequence by satisfying a test predicate.
+// The two collections may be of different implementations, i.e. a List and a Sequence.
 //
 // example usage:
 //
@@ -24,14 +84,50 @@ func Corresponds[T, K any](s1 OrderedCollection[T], s2 OrderedCollection[K], f f
 	if s1.Length() != s2.Length() {
 		return false
 	}
-	for i, v := range s1.All() {
-		if !f(v, s2.At(i)) {
+	next, stop := iter.Pull(s2.Values())
+	defer stop()
+	for v := range s1.Values() {
+		v2, ok := next()
+		if !ok || !f(v, v2) {
 			return false
 		}
 	}
 	return true
 }
 
+// Equal returns true if both collections contain the same elements in the same order.
+// The two collections may be of different implementations, i.e. a List and a Sequence.
+//
+// example usage:
+//
+//	c1 := NewList([]int{1,2,3})
+//	c2 := NewSequence([]int{1,2,3})
+//	Equal(c1, c2)
+//
+// output:
+//
+//	true
+func Equal[T comparable](s1 OrderedCollection[T], s2 OrderedCollection[T]) bool {
+	return Corresponds(s1, s2, func(a, b T) bool { return a == b })
+}
+
+// EqualFunc is similar to Equal but applies to non-comparable types.
+// It takes two collections (s1, s2) and an "equality" function as an argument such as
+// func(a T, b T) bool {return a == b}
+//
+// example usage:
+//
+//	c1 := NewList([]string{"a","B"})
+//	c2 := NewSequence([]string{"A","b"})
+//	EqualFunc(c1, c2, strings.EqualFold)
+//
+// output:
+//
+//	true
+func EqualFunc[T any](s1 OrderedCollection[T], s2 OrderedCollection[T], f func(T, T) bool) bool {
+	return Corresponds(s1, s2, f)
+}
+
 // Drop returns a new sequence with the first n elements removed.
 //
 // example usage:
@@ -152,6 +248,26 @@ func Head[T any](s OrderedCollection[T]) (T, error) {
 	return s.At(0), nil
 }
 
+// IndexWhere returns the index of the first element at or after index from
+// that satisfies a predicate, or -1 if there is none.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3,4,5,6})
+//	IndexWhere(c, func(i int) bool { return i%2 == 0 }, 2)
+//
+// output:
+//
+//	3
+func IndexWhere[T any](s OrderedCollection[T], f func(T) bool, from int) int {
+	for i, v := range s.All() {
+		if i >= from && f(v) {
+			return i
+		}
+	}
+	return -1
+}
+
 // Init returns a collection containing all elements excluding the last one.
 //
 // example usage:
@@ -187,6 +303,26 @@ func Last[T any](s OrderedCollection[T]) (T, error) {
 	return s.At(s.Length() - 1), nil
 }
 
+// LastIndexWhere returns the index of the last element at or before index
+// end that satisfies a predicate, or -1 if there is none.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3,4,5,6})
+//	LastIndexWhere(c, func(i int) bool { return i%2 == 0 }, 4)
+//
+// output:
+//
+//	3
+func LastIndexWhere[T any](s OrderedCollection[T], f func(T) bool, end int) int {
+	for i, v := range s.Backward() {
+		if i <= end && f(v) {
+			return i
+		}
+	}
+	return -1
+}
+
 // ReduceRight takes a collection of type T, a reducing function func(K, T) K,
 // and an initial value of type K as parameters. It applies the reducing
 // function to each element in reverse order and returns the resulting value K.
@@ -207,6 +343,53 @@ func ReduceRight[T, K any](s OrderedCollection[T], f func(K, T) K, init K) K {
 	return accumulator
 }
 
+// ScanRight is similar to ReduceRight but returns a slice of the intermediate
+// results, in the order of the elements they end with: the i-th value
+// accumulates the elements from index i to the end, and the last one is init.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3})
+//	ScanRight(c, func(acc int, v int) int { return acc + v }, 0)
+//
+// output:
+//
+//	[6,5,3,0]
+func ScanRight[T, K any](s OrderedCollection[T], f func(K, T) K, init K) []K {
+	results := make([]K, 0, s.Length()+1)
+	results = append(results, init)
+	for _, v := range s.Backward() {
+		results = append(results, f(results[len(results)-1], v))
+	}
+	slices.Reverse(results)
+	return results
+}
+
+// SegmentLength returns the length of the longest segment of consecutive
+// elements starting at index from that satisfy a predicate.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3,4,5,6})
+//	SegmentLength(c, func(i int) bool { return i < 5 }, 1)
+//
+// output:
+//
+//	3
+func SegmentLength[T any](s OrderedCollection[T], f func(T) bool, from int) int {
+	n := 0
+	for i, v := range s.All() {
+		if i < from {
+			continue
+		}
+		if !f(v) {
+			break
+		}
+		n++
+	}
+	return n
+}
+
 // Reverse returns a new sequence with all elements in reverse order.
 //
 // example usage:
@@ -260,6 +443,44 @@ func SplitAt[T any](s OrderedCollection[T], n int) (OrderedCollection[T], Ordere
 	return s.Slice(0, n), s.Slice(n, s.Length())
 }
 
+// SpanWhile returns two new sequences containing the longest prefix of
+// elements satisfying a predicate and the rest of the elements, i.e. the
+// results of TakeWhile and DropWhile in a single pass. It is the span
+// operation of other collection libraries, Span being the tracing interface.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3,4,1,2})
+//	SpanWhile(c, func(i int) bool { return i < 3 })
+//
+// output:
+//
+//	[1,2], [3,4,1,2]
+func SpanWhile[T any](s OrderedCollection[T], f func(T) bool) (OrderedCollection[T], OrderedCollection[T]) {
+	n := SegmentLength(s, f, 0)
+	return s.Slice(0, n), s.Slice(n, s.Length())
+}
+
+// SliceOrErr returns the elements between the start and end indices, or
+// IndexOutOfBoundsError instead of panicking if the range is out of bounds.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3})
+//	SliceOrErr(c, 1, 3)
+//	SliceOrErr(c, 2, 5)
+//
+// output:
+//
+//	Seq(int) [2 3], nil
+//	nil, error 102: index out of bounds
+func SliceOrErr[T any](s OrderedCollection[T], start, end int) (OrderedCollection[T], error) {
+	if start < 0 || end > s.Length() || start > end {
+		return nil, IndexOutOfBoundsError
+	}
+	return s.Slice(start, end), nil
+}
+
 // Tail returns a new sequence containing all elements excluding the first one.
 //
 // example usage:
@@ -311,6 +532,21 @@ func TakeRight[T any](s OrderedCollection[T], n int) OrderedCollection[T] {
 	return s.Slice(max(s.Length()-n, 0), s.Length())
 }
 
+// TakeWhile returns a new sequence containing the longest prefix of
+// elements satisfying a predicate.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3,4,1,2})
+//	TakeWhile(c, func(i int) bool { return i < 3 })
+//
+// output:
+//
+//	[1,2]
+func TakeWhile[T any](s OrderedCollection[T], f func(T) bool) OrderedCollection[T] {
+	return s.Slice(0, SegmentLength(s, f, 0))
+}
+
 // Shuffle returns a new sequence with the elements randomly shuffled
 // This function makes use of the Fisher-Yates shuffle algorithm for optimal performance
 //
@@ -323,25 +559,7 @@ func TakeRight[T any](s OrderedCollection[T], n int) OrderedCollection[T] {
 //
 // [4,2,5,1,3]
 func Shuffle[T any](s OrderedCollection[T]) OrderedCollection[T] {
-	// Create a slice to hold indices for shuffling
-	indices := make([]int, s.Length())
-	for i := 0; i < s.Length(); i++ {
-		indices[i] = i
-	}
-
-	// Perform Fisher-Yates shuffle algorithm on the indices
-	for i := len(indices) - 1; i > 0; i-- {
-		j := rand.Intn(i + 1)
-		indices[i], indices[j] = indices[j], indices[i]
-	}
-
-	newCollection := s.NewOrdered()
-	// Add elements to the new collection in the shuffled order
-	for _, idx := range indices {
-		newCollection.Add(s.At(idx))
-	}
-
-	return newCollection
+	return ShuffleInto(s, s.NewOrdered())
 }
 
 // StartsWith checks if the elements of the second collection (s2) match the
@@ -360,9 +578,10 @@ func StartsWith[T comparable](s1 OrderedCollection[T], s2 OrderedCollection[T])
 	if s1.Length() < s2.Length() {
 		return false
 	}
-
-	for i, v := range s2.All() {
-		if v != s1.At(i) {
+	next, stop := iter.Pull(s1.Values())
+	defer stop()
+	for v := range s2.Values() {
+		if v1, _ := next(); v1 != v {
 			return false
 		}
 	}
@@ -387,12 +606,51 @@ func EndsWith[T comparable](s1 OrderedCollection[T], s2 OrderedCollection[T]) bo
 		return false
 	}
 
-	offset := s1.Length() - s2.Length()
-
-	for i, v := range s2.All() {
-		if s1.At(offset+i) != v {
+	next, stop := iter.Pull2(s1.Backward())
+	defer stop()
+	for _, v := range s2.Backward() {
+		if _, v1, _ := next(); v1 != v {
 			return false
 		}
 	}
 	return true
 }
+
+// Zip returns a slice of pairs of the corresponding elements of both
+// collections, stopping at the end of the shorter one. The pairs can be
+// stored in any collection, use Zipped to iterate without allocating.
+//
+// example usage:
+//
+//	names := NewSequence([]string{"go","zig","rust"})
+//	years := NewSequence([]int{2009,2016})
+//	Zip(names, years)
+//
+// output:
+//
+//	[(go, 2009) (zig, 2016)]
+func Zip[A, B any](s1 OrderedCollection[A], s2 OrderedCollection[B]) []Pair[A, B] {
+	pairs := make([]Pair[A, B], 0, min(s1.Length(), s2.Length()))
+	for a, b := range Zipped(s1, s2) {
+		pairs = append(pairs, Pair[A, B]{First: a, Second: b})
+	}
+	return pairs
+}
+
+// ZipWithIndex returns a slice of pairs of each element and its index.
+//
+// example usage:
+//
+//	c := NewSequence([]string{"a","b"})
+//	ZipWithIndex(c)
+//
+// output:
+//
+//	[(a, 0) (b, 1)]
+func ZipWithIndex[T any](s OrderedCollection[T]) []Pair[T, int] {
+	pairs := make([]Pair[T, int], 0, s.Length())
+	for i, v := range s.All() {
+		pairs = append(pairs, Pair[T, int]{First: v, Second: i})
+	}
+	return pairs
+}
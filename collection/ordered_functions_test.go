@@ -30,6 +30,31 @@ func TestCorresponds(t *testing.T) {
 	}
 }
 
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name  string
+		A     []int
+		B     []int
+		equal bool
+	}{
+		{name: "equal", A: []int{1, 2, 3}, B: []int{1, 2, 3}, equal: true},
+		{name: "different order", A: []int{1, 2, 3}, B: []int{3, 2, 1}, equal: false},
+		{name: "different length", A: []int{1, 2}, B: []int{1, 2, 3}, equal: false},
+		{name: "both empty", A: []int{}, B: []int{}, equal: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Equal(NewMockOrderedCollection(tt.A), NewMockOrderedCollection(tt.B)); got != tt.equal {
+				t.Errorf("Equal() = %v, want %v", got, tt.equal)
+			}
+			got := EqualFunc(NewMockOrderedCollection(tt.A), NewMockOrderedCollection(tt.B), func(a, b int) bool { return a == b })
+			if got != tt.equal {
+				t.Errorf("EqualFunc() = %v, want %v", got, tt.equal)
+			}
+		})
+	}
+}
+
 func TestDrop(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -564,6 +589,65 @@ func TestTakeRight(t *testing.T) {
 	}
 }
 
+func TestSpanWhile(t *testing.T) {
+	isLessThan3 := func(n int) bool { return n < 3 }
+	tests := []struct {
+		name       string
+		input      []int
+		wantPrefix []int
+		wantRest   []int
+	}{
+		{name: "prefix only", input: []int{1, 2, 3, 1, 2}, wantPrefix: []int{1, 2}, wantRest: []int{3, 1, 2}},
+		{name: "all match", input: []int{1, 2}, wantPrefix: []int{1, 2}, wantRest: []int{}},
+		{name: "first fails", input: []int{5, 1}, wantPrefix: []int{}, wantRest: []int{5, 1}},
+		{name: "empty slice", input: []int{}, wantPrefix: []int{}, wantRest: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, rest := SpanWhile(NewMockOrderedCollection(tt.input), isLessThan3)
+			if !slices.Equal(prefix.(*MockOrderedCollection[int]).items, tt.wantPrefix) ||
+				!slices.Equal(rest.(*MockOrderedCollection[int]).items, tt.wantRest) {
+				t.Errorf("SpanWhile() = %v, %v, want %v, %v", prefix, rest, tt.wantPrefix, tt.wantRest)
+			}
+			taken := TakeWhile(NewMockOrderedCollection(tt.input), isLessThan3)
+			if !slices.Equal(taken.(*MockOrderedCollection[int]).items, tt.wantPrefix) {
+				t.Errorf("TakeWhile() = %v, want %v", taken, tt.wantPrefix)
+			}
+		})
+	}
+}
+
+func TestIndexWhere(t *testing.T) {
+	isEven := func(n int) bool { return n%2 == 0 }
+	c := NewMockOrderedCollection([]int{1, 2, 4, 5, 6, 7})
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{name: "index from start", got: IndexWhere(c, isEven, 0), want: 1},
+		{name: "index from middle", got: IndexWhere(c, isEven, 3), want: 4},
+		{name: "index from negative", got: IndexWhere(c, isEven, -2), want: 1},
+		{name: "index past the end", got: IndexWhere(c, isEven, 6), want: -1},
+		{name: "last index to end", got: LastIndexWhere(c, isEven, 5), want: 4},
+		{name: "last index to middle", got: LastIndexWhere(c, isEven, 3), want: 2},
+		{name: "last index beyond the end", got: LastIndexWhere(c, isEven, 100), want: 4},
+		{name: "last index before start", got: LastIndexWhere(c, isEven, 0), want: -1},
+		{name: "segment from start", got: SegmentLength(c, isEven, 0), want: 0},
+		{name: "segment from middle", got: SegmentLength(c, isEven, 1), want: 2},
+		{name: "segment past the end", got: SegmentLength(c, isEven, 10), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
 func TestStartsWith(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -707,3 +791,125 @@ func TestShuffleDistribution(t *testing.T) {
 		}
 	}
 }
+
+func TestAdjacentDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []int
+		expected []int
+	}{
+		{name: "deltas", input: []int{10, 12, 11, 15}, expected: []int{2, -1, 4}},
+		{name: "single element", input: []int{10}, expected: []int{}},
+		{name: "empty", input: []int{}, expected: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AdjacentDiff(NewMockOrderedCollection(tt.input), func(prev, curr int) int { return curr - prev })
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("AdjacentDiff() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestZip(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        []string
+		b        []int
+		expected []Pair[string, int]
+	}{
+		{name: "same length", a: []string{"a", "b"}, b: []int{1, 2}, expected: []Pair[string, int]{{"a", 1}, {"b", 2}}},
+		{name: "shorter second", a: []string{"a", "b", "c"}, b: []int{1}, expected: []Pair[string, int]{{"a", 1}}},
+		{name: "empty", a: []string{}, b: []int{1}, expected: []Pair[string, int]{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Zip(NewMockOrderedCollection(tt.a), NewMockOrderedCollection(tt.b))
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("Zip() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestZipWithIndexAndUnzip(t *testing.T) {
+	pairs := ZipWithIndex(NewMockOrderedCollection([]string{"a", "b", "c"}))
+	if want := []Pair[string, int]{{"a", 0}, {"b", 1}, {"c", 2}}; !slices.Equal(pairs, want) {
+		t.Errorf("ZipWithIndex() = %v, want %v", pairs, want)
+	}
+	values, indices := Unzip(NewMockOrderedCollection(pairs))
+	if !slices.Equal(values, []string{"a", "b", "c"}) || !slices.Equal(indices, []int{0, 1, 2}) {
+		t.Errorf("Unzip() = %v, %v", values, indices)
+	}
+}
+
+func TestAtOrErr(t *testing.T) {
+	tests := []struct {
+		name          string
+		index         int
+		expectedValue int
+		expectedErr   error
+		expectedElse  int
+	}{
+		{name: "in bounds", index: 1, expectedValue: 2, expectedErr: nil, expectedElse: 2},
+		{name: "negative index", index: -1, expectedValue: 0, expectedErr: IndexOutOfBoundsError, expectedElse: -99},
+		{name: "past the end", index: 3, expectedValue: 0, expectedErr: IndexOutOfBoundsError, expectedElse: -99},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMockOrderedCollection([]int{1, 2, 3})
+			value, err := AtOrErr(c, tt.index)
+			if value != tt.expectedValue || err != tt.expectedErr {
+				t.Errorf("AtOrErr() = %v, %v, want %v, %v", value, err, tt.expectedValue, tt.expectedErr)
+			}
+			if got := AtOrElse(c, tt.index, -99); got != tt.expectedElse {
+				t.Errorf("AtOrElse() = %v, want %v", got, tt.expectedElse)
+			}
+		})
+	}
+}
+
+func TestSliceOrErr(t *testing.T) {
+	tests := []struct {
+		name        string
+		start, end  int
+		expected    []int
+		expectedErr error
+	}{
+		{name: "in bounds", start: 1, end: 3, expected: []int{2, 3}, expectedErr: nil},
+		{name: "empty range", start: 3, end: 3, expected: []int{}, expectedErr: nil},
+		{name: "end past the length", start: 2, end: 5, expected: nil, expectedErr: IndexOutOfBoundsError},
+		{name: "start after end", start: 2, end: 1, expected: nil, expectedErr: IndexOutOfBoundsError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SliceOrErr(NewMockOrderedCollection([]int{1, 2, 3}), tt.start, tt.end)
+			if err != tt.expectedErr {
+				t.Fatalf("SliceOrErr() error = %v, want %v", err, tt.expectedErr)
+			}
+			if err == nil && !slices.Equal(slices.Collect(got.Values()), tt.expected) {
+				t.Errorf("SliceOrErr() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScanRight(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{name: "suffixes", input: []string{"a", "b", "c"}, want: []string{"cba", "cb", "c", ""}},
+		{name: "empty collection", input: []string{}, want: []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScanRight(NewMockOrderedCollection(tt.input), func(acc, v string) string { return acc + v }, "")
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ScanRight() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
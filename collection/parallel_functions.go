@@ -0,0 +1,223 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// parallel_functions.go defines the package functions processing a collection concurrently.
+
+package collection
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// ParOption configures the worker pool of ParMap, ParFilter and ParForEach.
+type ParOption func(*parConfig)
+
+type parConfig struct {
+	workers   int
+	chunkSize int
+}
+
+// WithWorkers sets the number of goroutines processing the elements.
+// It defaults to runtime.GOMAXPROCS(0).
+func WithWorkers(n int) ParOption {
+	return func(c *parConfig) { c.workers = n }
+}
+
+// WithChunkSize sets the number of consecutive elements a worker processes
+// at once. Larger chunks lower the synchronization overhead for cheap
+// functions, smaller ones balance the load for uneven ones. It defaults to
+// a quarter of the elements per worker.
+func WithChunkSize(n int) ParOption {
+	return func(c *parConfig) { c.chunkSize = n }
+}
+
+// ParMap is similar to Map but applies f to the elements from a pool of
+// goroutines, for CPU-heavy functions. The results keep the order of the
+// elements. Once the context is done no new chunk of elements is started,
+// and ParMap returns the context's error. It reports a "collection.ParMap"
+// span to the tracer of the context, see WithTracer.
+//
+// example usage:
+//
+//	images := NewSequence(paths)
+//	thumbnails, err := ParMap(ctx, images, resize, WithWorkers(8))
+func ParMap[T, K any](ctx context.Context, s Collection[T], f func(T) K, opts ...ParOption) ([]K, error) {
+	values := slices.Collect(s.Values())
+	results := make([]K, len(values))
+	err := parallel(ctx, "collection.ParMap", len(values), opts, func(start, end int) {
+		for i := start; i < end; i++ {
+			results[i] = f(values[i])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ParFilter is similar to Filter but evaluates the predicate from a pool of
+// goroutines. The returned collection keeps the order of the elements.
+// Once the context is done no new chunk of elements is started,
+// and ParFilter returns the context's error. It reports a
+// "collection.ParFilter" span to the tracer of the context.
+//
+// example usage:
+//
+//	candidates := NewSequence([]int{1,2,3,4,5,6,7,8,9})
+//	ParFilter(ctx, candidates, isPrime)
+//
+// output:
+//
+//	[2,3,5,7], nil
+func ParFilter[T any](ctx context.Context, s Collection[T], f func(T) bool, opts ...ParOption) (Collection[T], error) {
+	values := slices.Collect(s.Values())
+	keep := make([]bool, len(values))
+	err := parallel(ctx, "collection.ParFilter", len(values), opts, func(start, end int) {
+		for i := start; i < end; i++ {
+			keep[i] = f(values[i])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	r := s.New()
+	for i, v := range values {
+		if keep[i] {
+			r.Add(v)
+		}
+	}
+	return r, nil
+}
+
+// ParForEach calls f on each element from a pool of goroutines, in no
+// particular order. Once the context is done no new chunk of elements is
+// started, and ParForEach returns the context's error. It reports a
+// "collection.ParForEach" span to the tracer of the context.
+//
+// example usage:
+//
+//	err := ParForEach(ctx, urls, func(u string) { warm(cache, u) }, WithWorkers(16), WithChunkSize(1))
+func ParForEach[T any](ctx context.Context, s Collection[T], f func(T), opts ...ParOption) error {
+	values := slices.Collect(s.Values())
+	return parallel(ctx, "collection.ParForEach", len(values), opts, func(start, end int) {
+		for _, v := range values[start:end] {
+			f(v)
+		}
+	})
+}
+
+// parallel calls f on consecutive ranges [start, end) covering n indices
+// from the pool of goroutines configured by opts, within a span named name.
+// It returns the context's error if the context was done before all the
+// ranges were processed.
+func parallel(ctx context.Context, name string, n int, opts []ParOption, f func(start, end int)) (err error) {
+	c := parConfig{workers: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	c.workers = max(c.workers, 1)
+	if c.chunkSize < 1 {
+		c.chunkSize = max(n/(4*c.workers), 1)
+	}
+	ctx, span := StartSpan(ctx, name)
+	span.SetAttributes(
+		Attribute{Key: "length", Value: n},
+		Attribute{Key: "workers", Value: c.workers},
+		Attribute{Key: "chunk_size", Value: c.chunkSize},
+	)
+	defer func() { span.End(err) }()
+	var (
+		wg        sync.WaitGroup
+		next      atomic.Int64
+		processed atomic.Int64
+	)
+	for range min(c.workers, (n+c.chunkSize-1)/c.chunkSize) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				start := int(next.Add(int64(c.chunkSize))) - c.chunkSize
+				if start >= n {
+					return
+				}
+				end := min(start+c.chunkSize, n)
+				f(start, end)
+				processed.Add(int64(end - start))
+			}
+		}()
+	}
+	wg.Wait()
+	if int(processed.Load()) < n {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// ForEachChunked splits the collection into consecutive chunks of chunkSize
+// elements, the last one possibly shorter, and calls f on each chunk from up
+// to workers goroutines. Per-batch resources such as transactions or clients
+// can thus be set up once per chunk rather than once per element.
+//
+// Chunks are sliced from the calling goroutine, in order, but may be
+// processed in any order. Once f returns an error no new chunk is started,
+// and ForEachChunked returns the first error after the running chunks complete.
+// It panics if chunkSize or workers is less than 1.
+//
+// example usage:
+//
+//	rows := NewSequence(records)
+//	err := ForEachChunked(rows, 500, 4, func(chunk OrderedCollection[Record]) error {
+//		tx := db.Begin()
+//		for r := range chunk.Values() {
+//			tx.Insert(r)
+//		}
+//		return tx.Commit()
+//	})
+func ForEachChunked[T any](s OrderedCollection[T], chunkSize, workers int, f func(OrderedCollection[T]) error) error {
+	if chunkSize < 1 || workers < 1 {
+		panic("collection: chunk size and workers must be at least 1")
+	}
+	var (
+		wg     sync.WaitGroup
+		once   sync.Once
+		err    error
+		chunks = make(chan OrderedCollection[T])
+		failed = make(chan struct{})
+	)
+	n := s.Length()
+	for range min(workers, (n+chunkSize-1)/chunkSize) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range chunks {
+				if e := f(chunk); e != nil {
+					once.Do(func() {
+						err = e
+						close(failed)
+					})
+				}
+			}
+		}()
+	}
+dispatch:
+	for start := 0; start < n; start += chunkSize {
+		select {
+		case <-failed:
+			break dispatch
+		default:
+		}
+		select {
+		case chunks <- s.Slice(start, min(start+chunkSize, n)):
+		case <-failed:
+			break dispatch
+		}
+	}
+	close(chunks)
+	wg.Wait()
+	return err
+}
@@ -0,0 +1,160 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestForEachChunked(t *testing.T) {
+	tests := []struct {
+		name      string
+		length    int
+		chunkSize int
+		workers   int
+		want      []int // sorted chunk lengths
+	}{
+		{name: "even chunks", length: 9, chunkSize: 3, workers: 2, want: []int{3, 3, 3}},
+		{name: "short last chunk", length: 7, chunkSize: 3, workers: 4, want: []int{1, 3, 3}},
+		{name: "single chunk", length: 2, chunkSize: 5, workers: 3, want: []int{2}},
+		{name: "empty", length: 0, chunkSize: 5, workers: 3, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := make([]int, tt.length)
+			for i := range s {
+				s[i] = i
+			}
+			var (
+				mu      sync.Mutex
+				lengths []int
+				seen    []int
+			)
+			err := ForEachChunked(NewMockOrderedCollection(s), tt.chunkSize, tt.workers, func(chunk OrderedCollection[int]) error {
+				mu.Lock()
+				defer mu.Unlock()
+				lengths = append(lengths, chunk.Length())
+				seen = slices.AppendSeq(seen, chunk.Values())
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("ForEachChunked() error = %v", err)
+			}
+			slices.Sort(lengths)
+			slices.Sort(seen)
+			if !slices.Equal(lengths, tt.want) {
+				t.Errorf("ForEachChunked() chunk lengths = %v, want %v", lengths, tt.want)
+			}
+			if !slices.Equal(seen, s) {
+				t.Errorf("ForEachChunked() visited %v, want %v", seen, s)
+			}
+		})
+	}
+}
+
+func TestForEachChunked_Workers(t *testing.T) {
+	var running, peak atomic.Int32
+	block := make(chan struct{})
+	go func() {
+		for running.Load() < 3 {
+		}
+		close(block)
+	}()
+	err := ForEachChunked(NewMockOrderedCollection(make([]int, 100)), 10, 3, func(chunk OrderedCollection[int]) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		<-block
+		return nil
+	})
+	if err != nil || peak.Load() != 3 {
+		t.Errorf("ForEachChunked() = %v, ran %v chunks at once, want 3", err, peak.Load())
+	}
+}
+
+func TestForEachChunked_Error(t *testing.T) {
+	failure := errors.New("commit failed")
+	var calls atomic.Int32
+	err := ForEachChunked(NewMockOrderedCollection(make([]int, 100)), 1, 1, func(chunk OrderedCollection[int]) error {
+		if calls.Add(1) == 3 {
+			return failure
+		}
+		return nil
+	})
+	if err != failure {
+		t.Errorf("ForEachChunked() error = %v, want %v", err, failure)
+	}
+	// with a single worker, at most one chunk is handed over after the failure
+	if n := calls.Load(); n > 4 {
+		t.Errorf("ForEachChunked() processed %v chunks after the error", n-3)
+	}
+}
+
+func TestParMap(t *testing.T) {
+	input := make([]int, 1000)
+	for i := range input {
+		input[i] = i
+	}
+	tests := []struct {
+		name string
+		opts []ParOption
+	}{
+		{name: "defaults", opts: nil},
+		{name: "single worker", opts: []ParOption{WithWorkers(1)}},
+		{name: "small chunks", opts: []ParOption{WithWorkers(7), WithChunkSize(3)}},
+		{name: "invalid options", opts: []ParOption{WithWorkers(-1), WithChunkSize(0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParMap(context.Background(), NewMockCollection(input), func(i int) int { return i * i }, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParMap() error = %v", err)
+			}
+			if want := Map(NewMockCollection(input), func(i int) int { return i * i }); !slices.Equal(got, want) {
+				t.Errorf("ParMap() did not preserve the order of the elements")
+			}
+		})
+	}
+}
+
+func TestParFilter(t *testing.T) {
+	got, err := ParFilter(context.Background(), NewMockCollection([]int{1, 2, 3, 4, 5, 6, 7, 8, 9}), func(i int) bool { return i%3 != 0 }, WithChunkSize(2))
+	if err != nil || !slices.Equal(slices.Collect(got.Values()), []int{1, 2, 4, 5, 7, 8}) {
+		t.Errorf("ParFilter() = %v, %v, want [1 2 4 5 7 8], nil", got, err)
+	}
+	if empty, err := ParFilter(context.Background(), NewMockCollection[int](), func(int) bool { return true }); err != nil || empty.Length() != 0 {
+		t.Errorf("ParFilter() on empty collection = %v, %v", empty, err)
+	}
+}
+
+func TestParForEach(t *testing.T) {
+	var sum atomic.Int64
+	err := ParForEach(context.Background(), NewMockCollection(make([]int, 500)), func(int) { sum.Add(1) }, WithWorkers(4))
+	if err != nil || sum.Load() != 500 {
+		t.Errorf("ParForEach() = %v, visited %v elements, want 500", err, sum.Load())
+	}
+}
+
+func TestParForEach_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var calls atomic.Int32
+	err := ParForEach(ctx, NewMockCollection(make([]int, 100)), func(int) {
+		if calls.Add(1) == 10 {
+			cancel()
+		}
+	}, WithWorkers(2), WithChunkSize(1))
+	if err != context.Canceled {
+		t.Errorf("ParForEach() error = %v, want %v", err, context.Canceled)
+	}
+	if n := calls.Load(); n > 12 {
+		t.Errorf("ParForEach() processed %v elements, want at most 12", n)
+	}
+	if _, err := ParMap(ctx, NewMockCollection([]int{1}), func(i int) int { return i }); err != context.Canceled {
+		t.Errorf("ParMap() with a done context error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// rand.go centralizes the randomness used by the collections, i.e. by Random
+// and ShuffleInto, behind a RandState that can be seeded and serialized, so
+// that failures involving random behavior can be reproduced exactly.
+//
+// Collections embed Randomized to hold their own RandState, and fall back to
+// the shared DefaultRandState otherwise:
+//
+//	seed := uint64(time.Now().UnixNano())
+//	t.Logf("seed %d", seed)
+//	l := list.NewList(values)
+//	l.SetRandState(collection.NewRandState(seed))
+//
+// Collections backed by a Go map, such as Set and Map, are not covered
+// since they follow the random iteration order of the map, nor are the
+// seeds of the hashers, see hasher.go: hash/maphash does not allow building
+// a seed from a value.
+
+package collection
+
+import (
+	"math/rand/v2"
+	"sync"
+)
+
+// RandState is a seedable pseudo-random number generator whose state can be
+// saved with MarshalBinary and restored with UnmarshalBinary, in order to
+// replay a sequence of random operations. It is safe for concurrent use.
+type RandState struct {
+	mu  sync.Mutex
+	src *rand.PCG
+	rnd *rand.Rand
+}
+
+// increment is the increment of the PCG generator, fixed so that
+// a RandState is fully determined by its seed.
+const increment = 0x9e3779b97f4a7c15
+
+var defaultRandState = NewRandState(rand.Uint64())
+
+// NewRandState returns a RandState seeded with seed. Two states created
+// with the same seed produce the same sequence of random values.
+//
+// example usage:
+//
+//	a, b := NewRandState(42), NewRandState(42)
+//	a.IntN(100) == b.IntN(100)
+//
+// output:
+//
+//	true
+func NewRandState(seed uint64) *RandState {
+	src := rand.NewPCG(seed, increment)
+	return &RandState{src: src, rnd: rand.New(src)}
+}
+
+// DefaultRandState returns the RandState shared by the collections
+// that do not hold their own. It is randomly seeded when the program
+// starts, and can be reseeded with Seed.
+func DefaultRandState() *RandState {
+	return defaultRandState
+}
+
+// IntN returns a pseudo-random number in [0, n). It panics if n <= 0.
+func (r *RandState) IntN(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.IntN(n)
+}
+
+// Seed resets the state as if it was created by NewRandState(seed).
+func (r *RandState) Seed(seed uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.src.Seed(seed, increment)
+}
+
+// Shuffle pseudo-randomizes the order of n elements using the
+// Fisher-Yates algorithm, calling swap to swap the elements at
+// indexes i and j.
+func (r *RandState) Shuffle(n int, swap func(i, j int)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Shuffle(n, swap)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface,
+// saving the current state of the generator.
+func (r *RandState) MarshalBinary() ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.src.MarshalBinary()
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface,
+// restoring a state saved by MarshalBinary. The random values produced
+// afterwards are the ones produced after the state was saved.
+//
+// example usage:
+//
+//	r := NewRandState(42)
+//	saved, _ := r.MarshalBinary()
+//	first := r.IntN(100)
+//	r.UnmarshalBinary(saved)
+//	r.IntN(100) == first
+//
+// output:
+//
+//	true
+func (r *RandState) UnmarshalBinary(data []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.src.UnmarshalBinary(data)
+}
+
+// Randomized holds the RandState of a collection. Collections embed
+// it to let their randomized operations be seeded individually.
+// The zero value uses DefaultRandState. SetRandState must not be called
+// concurrently with the operations of the collection.
+type Randomized struct {
+	state *RandState
+}
+
+// RandState returns the RandState of the collection,
+// or DefaultRandState if none was set.
+func (r *Randomized) RandState() *RandState {
+	if r.state == nil {
+		return defaultRandState
+	}
+	return r.state
+}
+
+// SetRandState sets the RandState used by the randomized operations of
+// the collection. A nil state restores the use of DefaultRandState.
+func (r *Randomized) SetRandState(s *RandState) {
+	r.state = s
+}
+
+// randStateOf returns the RandState of c if it embeds Randomized,
+// or DefaultRandState otherwise.
+func randStateOf[T any](c Collection[T]) *RandState {
+	if r, ok := c.(interface{ RandState() *RandState }); ok {
+		return r.RandState()
+	}
+	return defaultRandState
+}
@@ -0,0 +1,71 @@
+package collection
+
+import (
+	"slices"
+	"testing"
+)
+
+func draw(r *RandState, n int) []int {
+	values := make([]int, n)
+	for i := range values {
+		values[i] = r.IntN(1000)
+	}
+	return values
+}
+
+func TestRandState(t *testing.T) {
+	tests := []struct {
+		name string
+		b    func() *RandState
+		same bool
+	}{
+		{name: "same seed", b: func() *RandState { return NewRandState(42) }, same: true},
+		{name: "other seed", b: func() *RandState { return NewRandState(43) }, same: false},
+		{name: "reseeded", b: func() *RandState {
+			r := NewRandState(7)
+			r.IntN(10)
+			r.Seed(42)
+			return r
+		}, same: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := draw(NewRandState(42), 10), draw(tt.b(), 10)
+			if slices.Equal(a, b) != tt.same {
+				t.Errorf("draws %v and %v, want same = %v", a, b, tt.same)
+			}
+		})
+	}
+}
+
+func TestRandState_Marshal(t *testing.T) {
+	r := NewRandState(42)
+	r.IntN(10)
+	saved, err := r.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	want := draw(r, 10)
+	restored := NewRandState(0)
+	if err := restored.UnmarshalBinary(saved); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if got := draw(restored, 10); !slices.Equal(got, want) {
+		t.Errorf("replayed %v, want %v", got, want)
+	}
+	if err := restored.UnmarshalBinary([]byte("garbage")); err == nil {
+		t.Errorf("UnmarshalBinary(garbage) returned no error")
+	}
+}
+
+func TestRandomized(t *testing.T) {
+	var r Randomized
+	if r.RandState() != DefaultRandState() {
+		t.Errorf("zero Randomized does not use DefaultRandState")
+	}
+	state := NewRandState(1)
+	r.SetRandState(state)
+	if r.RandState() != state {
+		t.Errorf("RandState() did not return the state set")
+	}
+}
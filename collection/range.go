@@ -0,0 +1,42 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// range.go defines Range, the arithmetic progression behind the range
+// constructors of the collections, i.e. list.RangeList.
+
+package collection
+
+import "iter"
+
+// Range returns an iterator over the numbers from start up to end excluded,
+// separated by step, which may be negative to count down. The numbers are
+// computed as start + i*step, so that floating-point ranges do not
+// accumulate rounding errors, and the iteration stops before overflowing T.
+// It panics if step is 0.
+//
+// example usage:
+//
+//	slices.Collect(Range(0.0, 1.0, 0.25))
+//
+// output:
+//
+//	[0 0.25 0.5 0.75]
+func Range[T Number](start, end, step T) iter.Seq[T] {
+	if step == 0 {
+		panic("collection: range step must not be 0")
+	}
+	return func(yield func(T) bool) {
+		v := start
+		for i := T(1); (step > 0 && v < end) || (step < 0 && v > end); i++ {
+			if !yield(v) {
+				return
+			}
+			next := start + i*step
+			if (next > v) != (step > 0) {
+				return
+			}
+			v = next
+		}
+	}
+}
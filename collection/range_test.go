@@ -0,0 +1,53 @@
+package collection
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		name             string
+		start, end, step int
+		want             []int
+	}{
+		{name: "up", start: 0, end: 10, step: 3, want: []int{0, 3, 6, 9}},
+		{name: "down", start: 10, end: 0, step: -3, want: []int{10, 7, 4, 1}},
+		{name: "exact end", start: 0, end: 6, step: 2, want: []int{0, 2, 4}},
+		{name: "empty", start: 5, end: 5, step: 1, want: nil},
+		{name: "wrong direction", start: 0, end: 5, step: -1, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(Range(tt.start, tt.end, tt.step)); !slices.Equal(got, tt.want) {
+				t.Errorf("Range(%d, %d, %d) = %v, want %v", tt.start, tt.end, tt.step, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRange_Float(t *testing.T) {
+	step := 0.1
+	got := slices.Collect(Range(0, 1, step))
+	if len(got) != 10 || got[3] != 3*step || got[9] != 9*step {
+		t.Errorf("Range(0, 1, 0.1) = %v", got)
+	}
+}
+
+func TestRange_Overflow(t *testing.T) {
+	if got := slices.Collect(Range[int8](0, 127, 100)); !slices.Equal(got, []int8{0, 100}) {
+		t.Errorf("Range[int8](0, 127, 100) = %v, want [0 100]", got)
+	}
+	if got := slices.Collect(Range[uint8](200, 255, 50)); !slices.Equal(got, []uint8{200, 250}) {
+		t.Errorf("Range[uint8](200, 255, 50) = %v, want [200 250]", got)
+	}
+}
+
+func TestRange_ZeroStep(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Range() with a step of 0 did not panic")
+		}
+	}()
+	Range(0, 1, 0)
+}
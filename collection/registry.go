@@ -0,0 +1,102 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// registry.go implements a registry mapping names to collection constructors,
+// allowing collections to be built from configuration or serialized data
+// without knowing their concrete type at compile time. The snapshot package
+// records the name of a collection with NameOf and rebuilds it with Make,
+// see snapshot.SaveNamed and snapshot.LoadNamed.
+//
+// Go cannot instantiate a generic constructor at runtime, so constructors
+// are registered per element type. The list, sequence and set packages
+// register themselves for the builtin element types on init, and expose
+// a Register function for any other element type.
+
+package collection
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+)
+
+type registryKey struct {
+	name string
+	elem reflect.Type
+}
+
+var registry = struct {
+	sync.RWMutex
+	ctors map[registryKey]any
+	names map[reflect.Type]string
+}{
+	ctors: make(map[registryKey]any),
+	names: make(map[reflect.Type]string),
+}
+
+// Register makes a collection constructor available under the given name
+// for element type T. Registering the same name and element type twice
+// replaces the previous constructor.
+//
+// example usage:
+//
+//	Register("list", func(s ...[]int) *List[int] { return NewList(s...) })
+//	Make[int]("list", []int{1,2,3})
+//
+// output:
+//
+//	List(int) [1 2 3], nil
+func Register[T any, C Collection[T]](name string, ctor func(s ...[]T) C) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.ctors[registryKey{name, reflect.TypeFor[T]()}] = func(s ...[]T) Collection[T] { return ctor(s...) }
+	registry.names[reflect.TypeFor[C]()] = name
+}
+
+// Make returns a new collection built by the constructor registered under the
+// given name for element type T. It returns UnknownCollectionError if no such
+// constructor was registered.
+//
+// example usage:
+//
+//	c, err := Make[string]("set", []string{"a","b","a"})
+//	c.Length()
+//
+// output:
+//
+//	2
+func Make[T any](name string, s ...[]T) (Collection[T], error) {
+	registry.RLock()
+	ctor, ok := registry.ctors[registryKey{name, reflect.TypeFor[T]()}]
+	registry.RUnlock()
+	if !ok {
+		return nil, UnknownCollectionError
+	}
+	return ctor.(func(...[]T) Collection[T])(s...), nil
+}
+
+// NameOf returns the name under which the concrete type of c was registered.
+// It is the inverse of Make and lets serializers record the concrete type
+// of a collection so it can be rebuilt later.
+func NameOf[T any](c Collection[T]) (string, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	name, ok := registry.names[reflect.TypeOf(c)]
+	return name, ok
+}
+
+// Names returns the sorted names of all collections registered for element type T.
+func Names[T any]() []string {
+	elem := reflect.TypeFor[T]()
+	registry.RLock()
+	defer registry.RUnlock()
+	var names []string
+	for k := range registry.ctors {
+		if k.elem == elem {
+			names = append(names, k.name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,45 @@
+package collection
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	Register("mock", NewMockCollection[int])
+
+	c, err := Make[int]("mock", []int{1, 2}, []int{3})
+	if err != nil {
+		t.Fatalf("Make() error = %v", err)
+	}
+	if got := slices.Collect(c.Values()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Make() = %v, want [1 2 3]", got)
+	}
+	if name, ok := NameOf(c); !ok || name != "mock" {
+		t.Errorf("NameOf() = %v, %v, want mock, true", name, ok)
+	}
+	if !slices.Contains(Names[int](), "mock") {
+		t.Errorf("Names() = %v, want it to contain mock", Names[int]())
+	}
+}
+
+func TestRegistry_Unknown(t *testing.T) {
+	tests := []struct {
+		name string
+		make func() error
+	}{
+		{name: "unknown name", make: func() error { _, err := Make[int]("missing"); return err }},
+		{name: "unregistered element type", make: func() error { _, err := Make[complex64]("mock"); return err }},
+	}
+	Register("mock", NewMockCollection[int])
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.make(); err != UnknownCollectionError {
+				t.Errorf("Make() error = %v, want %v", err, UnknownCollectionError)
+			}
+		})
+	}
+	if _, ok := NameOf[string](NewMockCollection[string]()); ok {
+		t.Errorf("NameOf() = true for an unregistered collection type")
+	}
+}
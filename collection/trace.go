@@ -0,0 +1,91 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// trace.go defines the Tracer protocol through which heavyweight operations
+// report spans. The package does not depend on any tracing library: bind the
+// interfaces to OpenTelemetry, or to any other tracer, with a small adapter.
+
+package collection
+
+import "context"
+
+// Attribute is a key-value pair describing a span, i.e. the number of elements.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Span is an operation being traced.
+type Span interface {
+	// SetAttributes adds attributes to the span.
+	SetAttributes(attrs ...Attribute)
+	// End completes the span. err is the error the operation failed with, or nil.
+	End(err error)
+}
+
+// Tracer starts spans for the operations running with a context
+// carrying it, see WithTracer.
+//
+// example usage, with an adapter to OpenTelemetry:
+//
+//	type otelTracer struct{ trace.Tracer }
+//	type otelSpan struct{ trace.Span }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, collection.Span) {
+//	  ctx, span := t.Tracer.Start(ctx, name)
+//	  return ctx, otelSpan{span}
+//	}
+//
+//	func (s otelSpan) SetAttributes(attrs ...collection.Attribute) {
+//	  for _, a := range attrs {
+//	    s.Span.SetAttributes(attribute.String(a.Key, fmt.Sprint(a.Value)))
+//	  }
+//	}
+//
+//	func (s otelSpan) End(err error) {
+//	  if err != nil {
+//	    s.Span.RecordError(err)
+//	    s.Span.SetStatus(codes.Error, err.Error())
+//	  }
+//	  s.Span.End()
+//	}
+//
+//	ctx = collection.WithTracer(ctx, otelTracer{otel.Tracer("gophers")})
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+type tracerKey struct{}
+
+// WithTracer returns a copy of the context carrying the tracer. The operations
+// accepting a context, such as ParMap, GroupByContext or the SortContext methods,
+// report a span to the tracer of their context.
+func WithTracer(ctx context.Context, t Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, t)
+}
+
+// StartSpan starts a span named name with the tracer of the context, and
+// returns a context carrying the span along with the span. If the context
+// carries no tracer, it returns the context and a span doing nothing.
+// It lets the collection packages, and user-defined collections, report
+// their own operations.
+//
+// example usage:
+//
+//	ctx, span := StartSpan(ctx, "orders.Reconcile")
+//	span.SetAttributes(Attribute{Key: "length", Value: orders.Length()})
+//	err := reconcile(ctx, orders)
+//	span.End(err)
+func StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	if t, ok := ctx.Value(tracerKey{}).(Tracer); ok {
+		return t.Start(ctx, name)
+	}
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+
+func (noopSpan) End(error) {}
@@ -0,0 +1,104 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// recorder is a Tracer recording the spans it starts.
+type recorder struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+type recordedSpan struct {
+	name  string
+	attrs map[string]any
+	ended bool
+	err   error
+}
+
+func (r *recorder) Start(ctx context.Context, name string) (context.Context, Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := &recordedSpan{name: name, attrs: map[string]any{}}
+	r.spans = append(r.spans, s)
+	return ctx, s
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...Attribute) {
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *recordedSpan) End(err error) {
+	s.ended = true
+	s.err = err
+}
+
+func TestStartSpan(t *testing.T) {
+	ctx, span := StartSpan(context.Background(), "noop")
+	span.SetAttributes(Attribute{Key: "length", Value: 1})
+	span.End(nil)
+	if ctx != context.Background() {
+		t.Errorf("StartSpan() without a tracer changed the context")
+	}
+}
+
+func TestTracer(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := NewMockCollection([]int{1, 2, 3, 4})
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		run   func(ctx context.Context)
+		span  string
+		attrs map[string]any
+		err   error
+	}{
+		{
+			name:  "ParMap",
+			ctx:   context.Background(),
+			run:   func(ctx context.Context) { ParMap(ctx, s, func(i int) int { return i * 2 }, WithWorkers(2)) },
+			span:  "collection.ParMap",
+			attrs: map[string]any{"length": 4, "workers": 2, "chunk_size": 1},
+		},
+		{
+			name:  "ParForEach canceled",
+			ctx:   canceled,
+			run:   func(ctx context.Context) { ParForEach(ctx, s, func(int) {}, WithChunkSize(2)) },
+			span:  "collection.ParForEach",
+			attrs: map[string]any{"length": 4, "chunk_size": 2},
+			err:   context.Canceled,
+		},
+		{
+			name:  "GroupByContext",
+			ctx:   context.Background(),
+			run:   func(ctx context.Context) { GroupByContext(ctx, s, func(i int) bool { return i%2 == 0 }) },
+			span:  "collection.GroupBy",
+			attrs: map[string]any{"length": 4, "groups": 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{}
+			tt.run(WithTracer(tt.ctx, r))
+			if len(r.spans) != 1 {
+				t.Fatalf("recorded %v spans, want 1", len(r.spans))
+			}
+			span := r.spans[0]
+			if span.name != tt.span || !span.ended || !errors.Is(span.err, tt.err) {
+				t.Errorf("span = %v, ended %v, error %v, want %v, true, %v", span.name, span.ended, span.err, tt.span, tt.err)
+			}
+			for k, v := range tt.attrs {
+				if span.attrs[k] != v {
+					t.Errorf("attribute %v = %v, want %v", k, span.attrs[k], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// watermark.go defines the size watermarks through which queue-like
+// collections notify producers that they should slow down or resume,
+// i.e. to apply backpressure:
+//
+//	jobs := queue.NewQueue[Job]().
+//	  OnHighWatermark(1000, func(int) { producer.Pause() }).
+//	  OnLowWatermark(100, func(int) { producer.Resume() })
+
+package collection
+
+// Watermarks tracks the size of a collection against a high and a low
+// watermark. The high callback is called when the size grows to the high
+// watermark, then the low callback when it falls back to the low watermark,
+// which rearms the high callback: the callbacks alternate, so a size
+// oscillating around a watermark does not flood them. The low watermark
+// defaults to 0.
+//
+// Watermarks is meant to be a field of the collections, which call Update
+// whenever their size changes. It is not safe for concurrent use: the
+// collection must synchronize the calls, and should call the returned
+// callback without holding its lock.
+type Watermarks struct {
+	high, low     int
+	onHigh, onLow func(size int)
+	above         bool // the high watermark was reached since the low one
+}
+
+// SetHigh sets the high watermark and its callback.
+// It panics if size is less than 1 or not greater than the low watermark.
+func (w *Watermarks) SetHigh(size int, f func(size int)) {
+	if size < 1 || size <= w.low {
+		panic("collection: high watermark must be positive and greater than the low watermark")
+	}
+	w.high, w.onHigh = size, f
+}
+
+// SetLow sets the low watermark and its callback.
+// It panics if size is negative or not less than the high watermark.
+func (w *Watermarks) SetLow(size int, f func(size int)) {
+	if size < 0 || (w.high > 0 && size >= w.high) {
+		panic("collection: low watermark must be non-negative and less than the high watermark")
+	}
+	w.low, w.onLow = size, f
+}
+
+// Update records the new size of the collection, and returns the callback
+// to call with it if a watermark was crossed, or nil otherwise.
+//
+// example usage:
+//
+//	c.mu.Lock()
+//	c.items = append(c.items, v)
+//	n := len(c.items)
+//	f := c.watermarks.Update(n)
+//	c.mu.Unlock()
+//	if f != nil {
+//	  f(n)
+//	}
+func (w *Watermarks) Update(size int) func(size int) {
+	switch {
+	case w.high == 0:
+		return nil
+	case !w.above && size >= w.high:
+		w.above = true
+		return w.onHigh
+	case w.above && size <= w.low:
+		w.above = false
+		return w.onLow
+	}
+	return nil
+}
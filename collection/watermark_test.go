@@ -0,0 +1,62 @@
+package collection
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestWatermarks_Update(t *testing.T) {
+	tests := []struct {
+		name      string
+		high, low int
+		sizes     []int
+		want      []string
+	}{
+		{name: "unset", sizes: []int{1, 5, 0}, want: nil},
+		{name: "high then low", high: 3, low: 1, sizes: []int{1, 2, 3, 2, 1, 0}, want: []string{"high 3", "low 1"}},
+		{name: "oscillating", high: 3, low: 1, sizes: []int{3, 2, 3, 4, 2, 1, 2, 3}, want: []string{"high 3", "low 1", "high 3"}},
+		{name: "jumps", high: 3, low: 1, sizes: []int{10, 0, 10}, want: []string{"high 10", "low 0", "high 10"}},
+		{name: "default low", high: 2, sizes: []int{2, 1, 2, 0}, want: []string{"high 2", "low 0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			var w Watermarks
+			if tt.high > 0 {
+				w.SetHigh(tt.high, func(n int) { got = append(got, fmt.Sprintf("high %d", n)) })
+				w.SetLow(tt.low, func(n int) { got = append(got, fmt.Sprintf("low %d", n)) })
+			}
+			for _, n := range tt.sizes {
+				if f := w.Update(n); f != nil {
+					f(n)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("callbacks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatermarks_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		set  func(w *Watermarks)
+	}{
+		{name: "zero high", set: func(w *Watermarks) { w.SetHigh(0, nil) }},
+		{name: "negative low", set: func(w *Watermarks) { w.SetLow(-1, nil) }},
+		{name: "low above high", set: func(w *Watermarks) { w.SetHigh(5, nil); w.SetLow(5, nil) }},
+		{name: "high below low", set: func(w *Watermarks) { w.SetLow(5, nil); w.SetHigh(3, nil) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", tt.name)
+				}
+			}()
+			tt.set(new(Watermarks))
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package collectiontest provides a differential testing harness for
+// Collection implementations. It runs the same randomized sequence of
+// operations against two implementations and reports the first step at
+// which their results or observable state differ:
+//
+//	func TestMyList(t *testing.T) {
+//	  ops := collectiontest.OrderedOps[int, collection.OrderedCollection[int]](
+//	    func(r *rand.Rand) int { return r.Intn(100) },
+//	  )
+//	  collectiontest.Check(t,
+//	    func() collection.OrderedCollection[int] { return sequence.NewSequence[int]() },
+//	    func() collection.OrderedCollection[int] { return mylist.New[int]() },
+//	    ops, collectiontest.Options[int]{Steps: 1000},
+//	  )
+//	}
+//
+// Runs are reproducible: the same seed always produces the same operations
+// with the same arguments.
+package collectiontest
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// Op is a named operation applied to a collection under test.
+// Apply must draw all its random arguments from r, in the same order for
+// every implementation, and returns an observable result that is compared
+// across implementations. A panic raised by Apply is captured and compared
+// like a result.
+type Op[C any] struct {
+	Name  string
+	Apply func(c C, r *rand.Rand) any
+}
+
+// Options configures a differential run.
+type Options[T any] struct {
+	// Seed initializes the random source, runs with the same seed are identical.
+	Seed int64
+	// Steps is the number of operations to run, it defaults to 100.
+	Steps int
+	// Equal compares the values of both collections after each step.
+	// It defaults to reflect.DeepEqual, which requires both implementations
+	// to iterate in the same order.
+	Equal func(a, b []T) bool
+}
+
+// Panic records a panic raised by an operation.
+type Panic struct {
+	Value any
+}
+
+// implement the Stringer interface
+func (p Panic) String() string {
+	return fmt.Sprintf("panic(%v)", p.Value)
+}
+
+// Mismatch describes the first divergence between two implementations.
+type Mismatch struct {
+	Seed    int64
+	Step    int
+	History []string
+	Reason  string
+}
+
+// Error implements the error interface.
+func (m *Mismatch) Error() string {
+	return fmt.Sprintf("seed %d, step %d: %s\nhistory: %s", m.Seed, m.Step, m.Reason, strings.Join(m.History, ", "))
+}
+
+// Run applies Steps randomly chosen operations to a collection created by
+// newA and one created by newB, comparing the result of every operation
+// as well as the length and values of both collections after each step.
+// It returns a *Mismatch describing the first divergence, or nil.
+func Run[T any, C collection.Collection[T]](newA, newB func() C, ops []Op[C], opts Options[T]) error {
+	if len(ops) == 0 {
+		return nil
+	}
+	if opts.Steps <= 0 {
+		opts.Steps = 100
+	}
+	if opts.Equal == nil {
+		opts.Equal = func(a, b []T) bool { return reflect.DeepEqual(a, b) }
+	}
+	a, b := newA(), newB()
+	r := rand.New(rand.NewSource(opts.Seed))
+	history := make([]string, 0, opts.Steps)
+	for step := range opts.Steps {
+		op := ops[r.Intn(len(ops))]
+		seed := r.Int63()
+		history = append(history, op.Name)
+		mismatch := func(format string, args ...any) error {
+			return &Mismatch{Seed: opts.Seed, Step: step, History: history, Reason: op.Name + ": " + fmt.Sprintf(format, args...)}
+		}
+		ra := apply(op, a, rand.New(rand.NewSource(seed)))
+		rb := apply(op, b, rand.New(rand.NewSource(seed)))
+		if !reflect.DeepEqual(ra, rb) {
+			return mismatch("results differ: %v != %v", ra, rb)
+		}
+		if a.Length() != b.Length() {
+			return mismatch("lengths differ: %d != %d", a.Length(), b.Length())
+		}
+		va, vb := slices.Collect(a.Values()), slices.Collect(b.Values())
+		if !opts.Equal(va, vb) {
+			return mismatch("values differ: %v != %v", va, vb)
+		}
+	}
+	return nil
+}
+
+// Check is similar to Run but reports a mismatch as a test failure.
+func Check[T any, C collection.Collection[T]](t testing.TB, newA, newB func() C, ops []Op[C], opts Options[T]) {
+	t.Helper()
+	if err := Run(newA, newB, ops, opts); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// CollectionOps returns operations exercising the Collection interface,
+// drawing new elements from gen. Random is not included since its
+// result is not deterministic.
+func CollectionOps[T any, C collection.Collection[T]](gen func(*rand.Rand) T) []Op[C] {
+	return []Op[C]{
+		{Name: "Add", Apply: func(c C, r *rand.Rand) any {
+			c.Add(gen(r))
+			return nil
+		}},
+		{Name: "Length", Apply: func(c C, r *rand.Rand) any {
+			return c.Length()
+		}},
+		{Name: "New", Apply: func(c C, r *rand.Rand) any {
+			s := []T{gen(r), gen(r)}
+			return c.New(s).Length()
+		}},
+	}
+}
+
+// OrderedOps returns the operations of CollectionOps along with operations
+// exercising the OrderedCollection interface. Indices passed to At are
+// occasionally out of bounds, so implementations must agree on panics.
+func OrderedOps[T any, C collection.OrderedCollection[T]](gen func(*rand.Rand) T) []Op[C] {
+	return append(CollectionOps[T, C](gen),
+		Op[C]{Name: "At", Apply: func(c C, r *rand.Rand) any {
+			return c.At(r.Intn(c.Length()+2) - 1)
+		}},
+		Op[C]{Name: "All", Apply: func(c C, r *rand.Rand) any {
+			var pairs [][2]any
+			for i, v := range c.All() {
+				pairs = append(pairs, [2]any{i, v})
+			}
+			return pairs
+		}},
+		Op[C]{Name: "Backward", Apply: func(c C, r *rand.Rand) any {
+			var pairs [][2]any
+			for i, v := range c.Backward() {
+				pairs = append(pairs, [2]any{i, v})
+			}
+			return pairs
+		}},
+		Op[C]{Name: "NewOrdered", Apply: func(c C, r *rand.Rand) any {
+			s := []T{gen(r), gen(r)}
+			return slices.Collect(c.NewOrdered(s).Values())
+		}},
+		Op[C]{Name: "Slice", Apply: func(c C, r *rand.Rand) any {
+			start := r.Intn(c.Length() + 1)
+			end := start + r.Intn(c.Length()-start+1)
+			return slices.Collect(c.Slice(start, end).Values())
+		}},
+	)
+}
+
+// apply runs the operation, capturing a panic as its result.
+func apply[C any](op Op[C], c C, r *rand.Rand) (result any) {
+	defer func() {
+		if p := recover(); p != nil {
+			result = Panic{Value: p}
+		}
+	}()
+	return op.Apply(c, r)
+}
@@ -0,0 +1,82 @@
+package collectiontest
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/list"
+	"github.com/charbz/gophers/sequence"
+	"github.com/charbz/gophers/set"
+)
+
+// lossySequence is a Sequence that silently drops the value 13.
+type lossySequence struct {
+	*sequence.Sequence[int]
+}
+
+func (s lossySequence) Add(v int) {
+	if v != 13 {
+		s.Sequence.Add(v)
+	}
+}
+
+func intGen(r *rand.Rand) int { return r.Intn(20) }
+
+func newSequence() collection.OrderedCollection[int] { return sequence.NewSequence[int]() }
+
+func newList() collection.OrderedCollection[int] { return list.NewList[int]() }
+
+func newLossy() collection.OrderedCollection[int] {
+	return lossySequence{sequence.NewSequence[int]()}
+}
+
+func TestRun_ListAndSequenceAgree(t *testing.T) {
+	ops := OrderedOps[int, collection.OrderedCollection[int]](intGen)
+	for seed := range int64(5) {
+		Check(t, newSequence, newList, ops, Options[int]{Seed: seed, Steps: 500})
+	}
+}
+
+func TestRun_DetectsMismatch(t *testing.T) {
+	ops := OrderedOps[int, collection.OrderedCollection[int]](intGen)
+	err := Run(newSequence, newLossy, ops, Options[int]{Seed: 1, Steps: 1000})
+	var m *Mismatch
+	if !errors.As(err, &m) {
+		t.Fatalf("Run() = %v, want a *Mismatch", err)
+	}
+	if len(m.History) != m.Step+1 {
+		t.Errorf("History has %d entries, want %d", len(m.History), m.Step+1)
+	}
+	again := Run(newSequence, newLossy, ops, Options[int]{Seed: 1, Steps: 1000})
+	if again.Error() != err.Error() {
+		t.Errorf("Run() is not reproducible:\n%v\n%v", err, again)
+	}
+}
+
+func TestRun_CustomEqual(t *testing.T) {
+	ops := CollectionOps[int, collection.Collection[int]](intGen)
+	newSet := func() collection.Collection[int] { return set.NewSet[int]() }
+	newOrderedSet := func() collection.Collection[int] { return set.NewOrderedSet[int]() }
+	sameElements := func(a, b []int) bool {
+		return slices.Equal(slices.Sorted(slices.Values(a)), slices.Sorted(slices.Values(b)))
+	}
+	Check(t, newSet, newOrderedSet, ops, Options[int]{Steps: 300, Equal: sameElements})
+}
+
+func TestRun_ComparesPanics(t *testing.T) {
+	ops := []Op[collection.OrderedCollection[int]]{
+		{Name: "AtLength", Apply: func(c collection.OrderedCollection[int], r *rand.Rand) any {
+			return c.At(c.Length())
+		}},
+	}
+	if err := Run(newSequence, newList, ops, Options[int]{Steps: 10}); err != nil {
+		t.Errorf("Run() = %v, want nil for identical panics", err)
+	}
+	got := apply(ops[0], newSequence(), nil)
+	if got != (Panic{Value: collection.IndexOutOfBoundsError}) {
+		t.Errorf("apply() = %v, want %v", got, Panic{Value: collection.IndexOutOfBoundsError})
+	}
+}
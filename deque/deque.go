@@ -0,0 +1,275 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package deque implements a double-ended queue backed by a growable ring array.
+// A Deque combines the O(1) operations at both ends of a List with the O(1)
+// random access of a Sequence:
+//
+//	history := deque.NewDeque([]string{"b", "c"})
+//	history.PushFront("a")
+//	history.PushBack("d")
+//	history.At(1)      // b
+//	history.PopBack()  // d, nil
+//	history.PopFront() // a, nil
+package deque
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// minCapacity is the capacity of the ring array of a deque
+// when its first element is pushed.
+const minCapacity = 8
+
+// Deque is a double-ended queue. Pushing and popping at either end run in
+// amortized O(1), the ring array doubling in size when full, and At in O(1).
+// The zero value is an empty deque ready to use.
+// A Deque is not safe for concurrent use.
+type Deque[T any] struct {
+	elements []T // ring array, len(elements) is the capacity
+	head     int // index in elements of the front element
+	size     int
+	onRemove func(T)
+	collection.Randomized
+}
+
+// NewDeque returns a new deque holding the elements of the given slices.
+func NewDeque[T any](s ...[]T) *Deque[T] {
+	elements := slices.Concat(s...)
+	return &Deque[T]{elements: elements, size: len(elements)}
+}
+
+// The following methods implement
+// the Collection interface.
+
+// Add is an alias for PushBack.
+func (d *Deque[T]) Add(v T) {
+	d.PushBack(v)
+}
+
+// Length returns the number of elements in the deque.
+func (d *Deque[T]) Length() int {
+	return d.size
+}
+
+// New returns a new deque.
+func (d *Deque[T]) New(s ...[]T) collection.Collection[T] {
+	return NewDeque(s...)
+}
+
+// Random returns a random element from the deque.
+func (d *Deque[T]) Random() T {
+	if d.size == 0 {
+		panic(collection.EmptyCollectionError)
+	}
+	return d.At(d.RandState().IntN(d.size))
+}
+
+// Values returns an iterator over all elements of the deque, front to back.
+func (d *Deque[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range d.size {
+			if !yield(d.elements[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// The following methods implement
+// the OrderedCollection interface.
+
+// At returns the element at the given index, the front element being at index 0.
+func (d *Deque[T]) At(index int) T {
+	if index < 0 || index >= d.size {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	return d.elements[d.index(index)]
+}
+
+// All returns an iterator over all elements of the deque and their indices.
+func (d *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range d.size {
+			if !yield(i, d.elements[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over all elements of the deque and their
+// indices, back to front.
+func (d *Deque[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := d.size - 1; i >= 0; i-- {
+			if !yield(i, d.elements[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns a new deque containing the elements between the start and end indices.
+func (d *Deque[T]) Slice(start, end int) collection.OrderedCollection[T] {
+	if start < 0 || end > d.size || start > end {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	return NewDeque(d.ToSlice()[start:end])
+}
+
+// NewOrdered returns a new deque.
+func (d *Deque[T]) NewOrdered(s ...[]T) collection.OrderedCollection[T] {
+	return NewDeque(s...)
+}
+
+// Clear removes all the elements from the deque, keeping its capacity.
+func (d *Deque[T]) Clear() {
+	if d.onRemove != nil {
+		for _, v := range d.All() {
+			d.onRemove(v)
+		}
+	}
+	clear(d.elements)
+	d.head, d.size = 0, 0
+}
+
+// IsEmpty returns true if the deque is empty.
+func (d *Deque[T]) IsEmpty() bool {
+	return d.size == 0
+}
+
+// NonEmpty returns true if the deque is not empty.
+func (d *Deque[T]) NonEmpty() bool {
+	return d.size > 0
+}
+
+// OnRemove sets a function called with every element leaving the deque,
+// i.e. to release the resources held by the elements: it is called by
+// PopBack, PopFront and Clear, before they hand over the removed elements.
+// It returns the deque, so that it can be set along with its creation.
+//
+// example usage:
+//
+//	files := NewDeque[*os.File]().OnRemove(func(f *os.File) { f.Close() })
+func (d *Deque[T]) OnRemove(f func(T)) *Deque[T] {
+	d.onRemove = f
+	return d
+}
+
+// PeekBack returns the back element without removing it.
+func (d *Deque[T]) PeekBack() (T, error) {
+	if d.size == 0 {
+		return *new(T), collection.EmptyCollectionError
+	}
+	return d.elements[d.index(d.size-1)], nil
+}
+
+// PeekFront returns the front element without removing it.
+func (d *Deque[T]) PeekFront() (T, error) {
+	if d.size == 0 {
+		return *new(T), collection.EmptyCollectionError
+	}
+	return d.elements[d.head], nil
+}
+
+// PopBack removes and returns the back element.
+func (d *Deque[T]) PopBack() (T, error) {
+	if d.size == 0 {
+		return *new(T), collection.EmptyCollectionError
+	}
+	i := d.index(d.size - 1)
+	v := d.elements[i]
+	d.elements[i] = *new(T)
+	d.size--
+	d.removed(v)
+	return v, nil
+}
+
+// PopFront removes and returns the front element.
+func (d *Deque[T]) PopFront() (T, error) {
+	if d.size == 0 {
+		return *new(T), collection.EmptyCollectionError
+	}
+	v := d.elements[d.head]
+	d.elements[d.head] = *new(T)
+	d.head = d.index(1)
+	d.size--
+	d.removed(v)
+	return v, nil
+}
+
+// PushBack adds an element at the back of the deque.
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.elements[d.index(d.size)] = v
+	d.size++
+}
+
+// PushFront adds an element at the front of the deque.
+//
+// example usage:
+//
+//	d := NewDeque([]int{2,3})
+//	d.PushFront(1)
+//	d.PushBack(4)
+//
+// output:
+//
+//	Deque(int) [1 2 3 4]
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = d.index(len(d.elements) - 1)
+	d.elements[d.head] = v
+	d.size++
+}
+
+// Set replaces the element at the given index.
+func (d *Deque[T]) Set(index int, v T) {
+	if index < 0 || index >= d.size {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	d.elements[d.index(index)] = v
+}
+
+// ToSlice returns the elements of the deque as a slice, front to back.
+func (d *Deque[T]) ToSlice() []T {
+	s := make([]T, d.size)
+	n := copy(s, d.elements[d.head:min(d.head+d.size, len(d.elements))])
+	copy(s[n:], d.elements)
+	return s
+}
+
+// implement the Stringer interface
+func (d *Deque[T]) String() string {
+	return fmt.Sprintf("Deque(%T) %v", *new(T), d.ToSlice())
+}
+
+// removed calls the OnRemove function of the deque, if any,
+// with a value removed from the deque.
+func (d *Deque[T]) removed(v T) {
+	if d.onRemove != nil {
+		d.onRemove(v)
+	}
+}
+
+// index returns the position in the ring array of the element at the given index.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) % len(d.elements)
+}
+
+// grow doubles the capacity of the ring array if it is full,
+// moving the front element to the beginning of the new array.
+func (d *Deque[T]) grow() {
+	if d.size < len(d.elements) {
+		return
+	}
+	elements := make([]T, max(2*len(d.elements), minCapacity))
+	copy(elements, d.ToSlice())
+	d.elements, d.head = elements, 0
+}
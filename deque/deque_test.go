@@ -0,0 +1,124 @@
+package deque
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/collectiontest"
+	"github.com/charbz/gophers/sequence"
+)
+
+func TestDeque_MatchesSequence(t *testing.T) {
+	ops := collectiontest.OrderedOps[int, collection.OrderedCollection[int]](func(r *rand.Rand) int { return r.Intn(50) })
+	collectiontest.Check(t,
+		func() collection.OrderedCollection[int] { return sequence.NewSequence[int]() },
+		func() collection.OrderedCollection[int] { return NewDeque[int]() },
+		ops, collectiontest.Options[int]{Steps: 300},
+	)
+}
+
+func TestDeque_PushPop(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  func(d *Deque[int])
+		want []int
+	}{
+		{
+			name: "push front",
+			ops: func(d *Deque[int]) {
+				for i := range 20 {
+					d.PushFront(i)
+				}
+			},
+			want: []int{19, 18, 17, 16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+		},
+		{
+			name: "wrap around",
+			ops: func(d *Deque[int]) {
+				for i := range 6 {
+					d.PushBack(i)
+				}
+				d.PopFront()
+				d.PopFront()
+				for i := 6; i < 10; i++ {
+					d.PushBack(i)
+				}
+				d.PopBack()
+			},
+			want: []int{2, 3, 4, 5, 6, 7, 8},
+		},
+		{
+			name: "both ends",
+			ops: func(d *Deque[int]) {
+				d.PushBack(2)
+				d.PushFront(1)
+				d.PushBack(3)
+				d.PushFront(0)
+			},
+			want: []int{0, 1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDeque[int]()
+			tt.ops(d)
+			if got := d.ToSlice(); !slices.Equal(got, tt.want) {
+				t.Errorf("ToSlice() = %v, want %v", got, tt.want)
+			}
+			for i, v := range tt.want {
+				if d.At(i) != v {
+					t.Errorf("At(%v) = %v, want %v", i, d.At(i), v)
+				}
+			}
+			if got := slices.Collect(d.Values()); !slices.Equal(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeque_Empty(t *testing.T) {
+	d := NewDeque([]string{"a"})
+	if v, err := d.PopBack(); err != nil || v != "a" {
+		t.Errorf("PopBack() = %v, %v, want a, nil", v, err)
+	}
+	for name, f := range map[string]func() (string, error){
+		"PopFront":  d.PopFront,
+		"PopBack":   d.PopBack,
+		"PeekFront": d.PeekFront,
+		"PeekBack":  d.PeekBack,
+	} {
+		if _, err := f(); err != collection.EmptyCollectionError {
+			t.Errorf("%v() on empty deque error = %v", name, err)
+		}
+	}
+}
+
+func TestDeque_String(t *testing.T) {
+	d := NewDeque([]int{2, 3})
+	d.PushFront(1)
+	d.Set(2, 30)
+	if got := d.String(); got != "Deque(int) [1 2 30]" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestDeque_OnRemove(t *testing.T) {
+	var removed []int
+	d := NewDeque([]int{1, 2, 3, 4}).OnRemove(func(v int) { removed = append(removed, v) })
+	d.PopFront()
+	d.PopBack()
+	if !slices.Equal(removed, []int{1, 4}) {
+		t.Errorf("removed %v after popping, want [1 4]", removed)
+	}
+	d.Clear()
+	if !slices.Equal(removed, []int{1, 4, 2, 3}) {
+		t.Errorf("removed %v after Clear, want [1 4 2 3]", removed)
+	}
+	d.PopFront()
+	if len(removed) != 4 {
+		t.Errorf("popping an empty deque removed %v", removed)
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package dict
+
+import (
+	"sync"
+	"time"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// Loader reads the values missing from a BackedMap from a backing store,
+// i.e. a database or a remote service.
+type Loader[K comparable, V any] interface {
+	// Load returns the value of the key in the store,
+	// or false if the store does not contain it.
+	Load(k K) (V, bool, error)
+}
+
+// Writer persists the changes made to a BackedMap to a backing store.
+type Writer[K comparable, V any] interface {
+	// Write stores the given entries.
+	Write(entries []collection.Entry[K, V]) error
+	// Delete removes the given keys from the store.
+	Delete(keys []K) error
+}
+
+// BackedOption configures a BackedMap.
+type BackedOption func(*backedConfig)
+
+type backedConfig struct {
+	interval  time.Duration // 0 for write-through
+	batchSize int
+}
+
+// WithWriteBehind makes a BackedMap buffer its changes and flush them to
+// the Writer asynchronously, every interval or as soon as batchSize keys
+// are pending, whichever comes first. Successive changes to a key are
+// coalesced into the last one. Without this option, changes are written
+// through, before Set and Delete return.
+func WithWriteBehind(interval time.Duration, batchSize int) BackedOption {
+	return func(c *backedConfig) {
+		c.interval, c.batchSize = interval, max(batchSize, 1)
+	}
+}
+
+// change is a pending change of a key in write-behind mode.
+type change[V any] struct {
+	value   V
+	deleted bool
+}
+
+// BackedMap is a Map kept in sync with a backing store: lookups of missing
+// keys read through to a Loader, and changes are written to a Writer,
+// either synchronously or in asynchronous batches, see WithWriteBehind.
+// Both hooks are optional. It is safe for concurrent use.
+//
+// example usage:
+//
+//	users := dict.NewBackedMap[int, User](db, db, dict.WithWriteBehind(time.Second, 100))
+//	defer users.Close()
+//	u, ok, err := users.Get(42) // loaded from db on the first call only
+//	users.Set(42, u.Rename("gopher"))
+type BackedMap[K comparable, V any] struct {
+	mu      sync.Mutex
+	flushMu sync.Mutex // serializes the calls to the writer in write-behind mode
+	values  *Map[K, V]
+	loader  Loader[K, V]
+	writer  Writer[K, V]
+	config  backedConfig
+	pending map[K]change[V]
+	flushed map[K]change[V] // changes being written by Flush, outside of the lock
+	err     error           // last error of an asynchronous flush
+	full    chan struct{}   // signals that batchSize keys are pending
+	done    chan struct{}
+	stopped sync.WaitGroup
+	closed  bool
+}
+
+// NewBackedMap returns an empty BackedMap reading through to loader and
+// writing to writer, either of them can be nil. In write-behind mode, the
+// map must be closed to flush the last changes and stop its goroutine.
+func NewBackedMap[K comparable, V any](loader Loader[K, V], writer Writer[K, V], opts ...BackedOption) *BackedMap[K, V] {
+	b := &BackedMap[K, V]{values: NewMap[K, V](), loader: loader, writer: writer}
+	for _, opt := range opts {
+		opt(&b.config)
+	}
+	if b.writeBehind() {
+		b.pending = make(map[K]change[V])
+		b.full = make(chan struct{}, 1)
+		b.done = make(chan struct{})
+		b.stopped.Add(1)
+		go b.run()
+	}
+	return b
+}
+
+// Close flushes the pending changes and stops the background goroutine in
+// write-behind mode. The map must not be modified after Close.
+func (b *BackedMap[K, V]) Close() error {
+	b.mu.Lock()
+	if b.closed || !b.writeBehind() {
+		b.closed = true
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+	close(b.done)
+	b.stopped.Wait()
+	return b.Flush()
+}
+
+// CompareAndSet atomically sets the value of the key to new if its current
+// value, loaded from the store if needed, is equal to old according to eq,
+// and returns true. It returns false, leaving the map and the store
+// untouched, if the key is missing or holds another value. Like Set, it
+// returns the error of a synchronous write.
+func (b *BackedMap[K, V]) CompareAndSet(k K, old, new V, eq func(a, b V) bool) (bool, error) {
+	if _, _, err := b.Get(k); err != nil {
+		return false, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.values.CompareAndSet(k, old, new, eq) {
+		return false, nil
+	}
+	return true, b.write(k, new)
+}
+
+// Delete removes the key from the map and from the store.
+func (b *BackedMap[K, V]) Delete(k K) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.values.Delete(k)
+	if b.writer == nil {
+		return nil
+	}
+	if !b.writeBehind() {
+		return b.writer.Delete([]K{k})
+	}
+	b.enqueue(k, change[V]{deleted: true})
+	return nil
+}
+
+// Flush writes the pending changes to the store in write-behind mode.
+// Changes failing to be written stay pending, to be retried by the next
+// flush, and the error is returned, as is the error of the last failed
+// asynchronous flush.
+func (b *BackedMap[K, V]) Flush() error {
+	if !b.writeBehind() {
+		return nil
+	}
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+	b.mu.Lock()
+	batch := b.pending
+	b.pending = make(map[K]change[V])
+	b.flushed = batch
+	err := b.err
+	b.err = nil
+	b.mu.Unlock()
+	if len(batch) == 0 {
+		return err
+	}
+	var entries []collection.Entry[K, V]
+	var deleted []K
+	for k, c := range batch {
+		if c.deleted {
+			deleted = append(deleted, k)
+		} else {
+			entries = append(entries, collection.Entry[K, V]{Key: k, Value: c.value})
+		}
+	}
+	var writeErr, deleteErr error
+	if len(entries) > 0 {
+		writeErr = b.writer.Write(entries)
+	}
+	if len(deleted) > 0 {
+		deleteErr = b.writer.Delete(deleted)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.flushed = nil
+	if writeErr == nil && deleteErr == nil {
+		return err
+	}
+	for k, c := range batch {
+		if _, changed := b.pending[k]; changed || (c.deleted && deleteErr == nil) || (!c.deleted && writeErr == nil) {
+			continue
+		}
+		b.pending[k] = c
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+	return deleteErr
+}
+
+// Get returns the value of the key. When the map does not contain the key,
+// it is loaded from the store and kept in the map, unless its deletion is
+// still pending in write-behind mode.
+func (b *BackedMap[K, V]) Get(k K) (V, bool, error) {
+	b.mu.Lock()
+	v, ok := b.values.Get(k)
+	deleted := b.deleted(k)
+	b.mu.Unlock()
+	if ok || deleted || b.loader == nil {
+		return v, ok, nil
+	}
+	// the store is read without holding the lock, a concurrent Set wins
+	v, ok, err := b.loader.Load(k)
+	if err != nil || !ok {
+		return *new(V), false, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if current, set := b.values.Get(k); set {
+		return current, true, nil
+	}
+	if b.deleted(k) {
+		// deleted while loading, the store has not caught up yet
+		return *new(V), false, nil
+	}
+	b.values.Set(k, v)
+	return v, true, nil
+}
+
+// Length returns the number of entries held in memory.
+func (b *BackedMap[K, V]) Length() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.values.Length()
+}
+
+// Pending returns the number of keys with changes not written to the store yet.
+func (b *BackedMap[K, V]) Pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending)
+}
+
+// Set sets the value of the key in the map and in the store.
+func (b *BackedMap[K, V]) Set(k K, v V) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.values.Set(k, v)
+	return b.write(k, v)
+}
+
+// ToMap returns a copy of the entries held in memory as a Go map.
+func (b *BackedMap[K, V]) ToMap() map[K]V {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.values.ToMap()
+}
+
+// write writes the value of the key to the store, or records it as
+// pending in write-behind mode. The lock must be held.
+func (b *BackedMap[K, V]) write(k K, v V) error {
+	if b.writer == nil {
+		return nil
+	}
+	if !b.writeBehind() {
+		return b.writer.Write([]collection.Entry[K, V]{{Key: k, Value: v}})
+	}
+	b.enqueue(k, change[V]{value: v})
+	return nil
+}
+
+// deleted returns true if the deletion of the key is pending or being
+// flushed, the store still holding its old value. The lock must be held.
+func (b *BackedMap[K, V]) deleted(k K) bool {
+	if c, ok := b.pending[k]; ok {
+		return c.deleted
+	}
+	c, ok := b.flushed[k]
+	return ok && c.deleted
+}
+
+// enqueue records a pending change. The lock must be held.
+func (b *BackedMap[K, V]) enqueue(k K, c change[V]) {
+	b.pending[k] = c
+	if len(b.pending) >= b.config.batchSize {
+		select {
+		case b.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// run flushes the pending changes until the map is closed.
+func (b *BackedMap[K, V]) run() {
+	defer b.stopped.Done()
+	ticker := time.NewTicker(b.config.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-ticker.C:
+		case <-b.full:
+		}
+		if err := b.Flush(); err != nil {
+			b.mu.Lock()
+			b.err = err
+			b.mu.Unlock()
+		}
+	}
+}
+
+func (b *BackedMap[K, V]) writeBehind() bool {
+	return b.config.interval > 0 && b.writer != nil
+}
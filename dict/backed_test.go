@@ -0,0 +1,247 @@
+package dict
+
+import (
+	"errors"
+	"maps"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// store is an in-memory backing store recording the calls it receives.
+type store struct {
+	mu      sync.Mutex
+	data    map[string]int
+	loads   int
+	batches int
+	fail    error
+}
+
+func newStore(data map[string]int) *store {
+	return &store{data: maps.Clone(data)}
+}
+
+func (s *store) Load(k string) (int, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loads++
+	v, ok := s.data[k]
+	return v, ok, s.fail
+}
+
+func (s *store) Write(entries []collection.Entry[string, int]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail != nil {
+		return s.fail
+	}
+	s.batches++
+	for _, e := range entries {
+		s.data[e.Key] = e.Value
+	}
+	return nil
+}
+
+func (s *store) Delete(keys []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail != nil {
+		return s.fail
+	}
+	s.batches++
+	for _, k := range keys {
+		delete(s.data, k)
+	}
+	return nil
+}
+
+func (s *store) snapshot() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.data)
+}
+
+func TestBackedMap_ReadThrough(t *testing.T) {
+	s := newStore(map[string]int{"a": 1})
+	b := NewBackedMap[string, int](s, nil)
+	for range 3 {
+		if v, ok, err := b.Get("a"); v != 1 || !ok || err != nil {
+			t.Errorf("Get(a) = %v, %v, %v, want 1, true, nil", v, ok, err)
+		}
+	}
+	if _, ok, _ := b.Get("z"); ok {
+		t.Errorf("Get(z) returned true")
+	}
+	if s.loads != 2 {
+		t.Errorf("store loaded %v times, want 2", s.loads)
+	}
+	s.fail = errors.New("unavailable")
+	if _, _, err := b.Get("y"); err != s.fail {
+		t.Errorf("Get(y) error = %v, want %v", err, s.fail)
+	}
+}
+
+func TestBackedMap_WriteThrough(t *testing.T) {
+	s := newStore(map[string]int{"a": 1})
+	b := NewBackedMap[string, int](nil, s)
+	b.Set("b", 2)
+	b.Set("b", 3)
+	b.Delete("a")
+	if got := s.snapshot(); !maps.Equal(got, map[string]int{"b": 3}) {
+		t.Errorf("store = %v, want map[b:3]", got)
+	}
+	if s.batches != 3 || b.Pending() != 0 {
+		t.Errorf("store received %v batches, want 3", s.batches)
+	}
+	s.fail = errors.New("read-only")
+	if err := b.Set("c", 4); err != s.fail {
+		t.Errorf("Set() error = %v, want %v", err, s.fail)
+	}
+}
+
+func TestBackedMap_WriteBehind(t *testing.T) {
+	tests := []struct {
+		name      string
+		batchSize int
+		changes   int
+	}{
+		{name: "flushed on close", batchSize: 100, changes: 10},
+		{name: "flushed when full", batchSize: 5, changes: 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStore(map[string]int{"gone": 0})
+			b := NewBackedMap[string, int](s, s, WithWriteBehind(time.Hour, tt.batchSize))
+			want := make(map[string]int)
+			for i := range tt.changes {
+				k := string(rune('a' + i%10))
+				b.Set(k, i)
+				want[k] = i
+			}
+			b.Delete("gone")
+			if err := b.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if got := s.snapshot(); !maps.Equal(got, want) {
+				t.Errorf("store = %v, want %v", got, want)
+			}
+			if !maps.Equal(b.ToMap(), want) {
+				t.Errorf("ToMap() = %v, want %v", b.ToMap(), want)
+			}
+		})
+	}
+}
+
+func TestBackedMap_FlushRetries(t *testing.T) {
+	s := newStore(map[string]int{})
+	b := NewBackedMap[string, int](nil, s, WithWriteBehind(time.Hour, 100))
+	defer b.Close()
+	b.Set("a", 1)
+	s.fail = errors.New("timeout")
+	if err := b.Flush(); err != s.fail {
+		t.Errorf("Flush() error = %v, want %v", err, s.fail)
+	}
+	b.Set("b", 2)
+	if b.Pending() != 2 {
+		t.Errorf("Pending() = %v, want 2", b.Pending())
+	}
+	s.mu.Lock()
+	s.fail = nil
+	s.mu.Unlock()
+	if err := b.Flush(); err != nil {
+		t.Errorf("Flush() error = %v", err)
+	}
+	if got := s.snapshot(); !maps.Equal(got, map[string]int{"a": 1, "b": 2}) {
+		t.Errorf("store = %v", got)
+	}
+}
+
+func TestBackedMap_PendingDelete(t *testing.T) {
+	s := newStore(map[string]int{"a": 1})
+	b := NewBackedMap[string, int](s, s, WithWriteBehind(time.Hour, 100))
+	defer b.Close()
+	if v, ok, _ := b.Get("a"); v != 1 || !ok {
+		t.Fatalf("Get(a) = %v, %v, want 1, true", v, ok)
+	}
+	b.Delete("a")
+	if v, ok, err := b.Get("a"); ok || err != nil {
+		t.Errorf("Get(a) after Delete = %v, %v, %v, want not found", v, ok, err)
+	}
+	s.mu.Lock()
+	s.fail = errors.New("timeout")
+	s.mu.Unlock()
+	b.Flush()
+	if _, ok, _ := b.Get("a"); ok {
+		t.Errorf("Get(a) after a failed flush returned true")
+	}
+	s.mu.Lock()
+	s.fail = nil
+	s.mu.Unlock()
+	if err := b.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if _, ok, _ := b.Get("a"); ok {
+		t.Errorf("Get(a) after the flush returned true")
+	}
+	if s.loads != 2 {
+		t.Errorf("store loaded %v times, want 2", s.loads)
+	}
+}
+
+func TestBackedMap_Concurrent(t *testing.T) {
+	s := newStore(map[string]int{"seed": 1})
+	b := NewBackedMap[string, int](s, s, WithWriteBehind(time.Millisecond, 8))
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				k := string(rune('a' + w))
+				b.Set(k, i)
+				b.Get("seed")
+			}
+		}()
+	}
+	wg.Wait()
+	if err := b.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := s.snapshot(); !maps.Equal(got, map[string]int{"seed": 1, "a": 199, "b": 199, "c": 199, "d": 199}) {
+		t.Errorf("store = %v", got)
+	}
+}
+
+func TestBackedMap_CompareAndSet(t *testing.T) {
+	s := newStore(map[string]int{"hits": 0})
+	b := NewBackedMap[string, int](s, s)
+	eq := func(a, b int) bool { return a == b }
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				for {
+					v, _, _ := b.Get("hits")
+					if ok, err := b.CompareAndSet("hits", v, v+1, eq); ok || err != nil {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if got := s.snapshot()["hits"]; got != 200 {
+		t.Errorf("store hits = %v, want 200", got)
+	}
+	if ok, _ := b.CompareAndSet("missing", 0, 1, eq); ok {
+		t.Errorf("CompareAndSet(missing) = true, want false")
+	}
+	s.fail = errors.New("read-only")
+	if _, err := b.CompareAndSet("hits", 200, 201, eq); err != s.fail {
+		t.Errorf("CompareAndSet() error = %v, want %v", err, s.fail)
+	}
+}
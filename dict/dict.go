@@ -0,0 +1,395 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package dict implements a generic dictionary, Map, wrapping a Go map
+// with convenience methods and iterators that integrate with the rest of
+// the library:
+//
+//	ages := dict.NewMap(map[string]int{"alice": 31, "bob": 17})
+//	adults := ages.Filter(func(_ string, age int) bool { return age >= 18 })
+//	names := list.NewList(slices.Sorted(adults.Keys()))
+//
+// A Map is not itself a Collection, its Values method yields the values
+// of the map. Use Entries to view it as a sequence of key/value entries,
+// and FromEntries to build a Map from one.
+package dict
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/seq"
+)
+
+// Map is an unordered dictionary of keys of type K and values of type V.
+// The zero value is an empty map ready to use, and a nil *Map reads as
+// an empty map.
+type Map[K comparable, V any] struct {
+	elements map[K]V
+	collection.Randomized
+}
+
+// NewMap returns a new Map holding the entries of the given Go maps.
+// When a key appears in several maps, the last value wins.
+func NewMap[K comparable, V any](m ...map[K]V) *Map[K, V] {
+	d := &Map[K, V]{elements: make(map[K]V)}
+	for _, entries := range m {
+		maps.Copy(d.elements, entries)
+	}
+	return d
+}
+
+// FromEntries returns a new Map holding the entries yielded by s.
+// When a key is yielded several times, the last value wins.
+//
+// example usage:
+//
+//	c := NewSequence([]collection.Entry[string, int]{{"a", 1}, {"b", 2}})
+//	FromEntries(c.Values())
+//
+// output:
+//
+//	Map(string, int) map[a:1 b:2]
+func FromEntries[K comparable, V any](s iter.Seq[collection.Entry[K, V]]) *Map[K, V] {
+	d := NewMap[K, V]()
+	for e := range s {
+		d.elements[e.Key] = e.Value
+	}
+	return d
+}
+
+// GroupBy groups the elements of a collection by the key function,
+// preserving the iteration order of the collection within each group.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3,4,5,6})
+//	GroupBy(c, func(i int) int { return i % 2 })
+//
+// output:
+//
+//	Map(int, []int) map[0:[2 4 6] 1:[1 3 5]]
+func GroupBy[T any, K comparable](c collection.Collection[T], key func(T) K) *Map[K, []T] {
+	d := NewMap[K, []T]()
+	for v := range c.Values() {
+		k := key(v)
+		d.elements[k] = append(d.elements[k], v)
+	}
+	return d
+}
+
+// MapValues returns a new Map with the same keys and the values transformed by f.
+//
+// example usage:
+//
+//	m := NewMap(map[string]string{"a": "go", "b": "gopher"})
+//	MapValues(m, func(s string) int { return len(s) })
+//
+// output:
+//
+//	Map(string, int) map[a:2 b:6]
+func MapValues[K comparable, V, U any](m *Map[K, V], f func(V) U) *Map[K, U] {
+	d := &Map[K, U]{elements: make(map[K]U, m.Length())}
+	for k, v := range m.items() {
+		d.elements[k] = f(v)
+	}
+	return d
+}
+
+// MapEntries returns a new Map holding the entries transformed by f.
+// When f maps several entries to the same key, resolve is called with the
+// key and two of their values, and its result is kept. The entries are
+// visited in no particular order, so resolve should not depend on the
+// order of its arguments.
+//
+// example usage:
+//
+//	m := NewMap(map[string]int{"go": 1, "Go": 2, "zig": 3})
+//	MapEntries(m,
+//	  func(k string, v int) (string, int) { return strings.ToLower(k), v },
+//	  func(_ string, a, b int) int { return a + b },
+//	)
+//
+// output:
+//
+//	Map(string, int) map[go:3 zig:3]
+func MapEntries[K comparable, V any, K2 comparable, V2 any](m *Map[K, V], f func(K, V) (K2, V2), resolve func(k K2, a, b V2) V2) *Map[K2, V2] {
+	return FilterEntries(m, func(k K, v V) (K2, V2, bool) {
+		k2, v2 := f(k, v)
+		return k2, v2, true
+	}, resolve)
+}
+
+// FilterEntries returns a new Map holding the entries transformed by f,
+// skipping the entries for which f returns false. Colliding keys are
+// resolved as in MapEntries.
+//
+// example usage:
+//
+//	m := NewMap(map[string]int{"a": 1, "b": -2, "c": 3})
+//	FilterEntries(m,
+//	  func(k string, v int) (int, string, bool) { return v, k, v > 0 },
+//	  func(_ int, a, b string) string { return min(a, b) },
+//	)
+//
+// output:
+//
+//	Map(int, string) map[1:a 3:c]
+func FilterEntries[K comparable, V any, K2 comparable, V2 any](m *Map[K, V], f func(K, V) (K2, V2, bool), resolve func(k K2, a, b V2) V2) *Map[K2, V2] {
+	d := NewMap[K2, V2]()
+	for k, v := range m.items() {
+		k2, v2, ok := f(k, v)
+		if !ok {
+			continue
+		}
+		if current, exists := d.elements[k2]; exists {
+			v2 = resolve(k2, current, v2)
+		}
+		d.elements[k2] = v2
+	}
+	return d
+}
+
+// All returns an iterator over all key/value pairs of the map, in no particular order.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return maps.All(m.items())
+}
+
+// Clone returns a copy of the map. This is a shallow clone.
+func (m *Map[K, V]) Clone() *Map[K, V] {
+	d := &Map[K, V]{elements: make(map[K]V, m.Length())}
+	maps.Copy(d.elements, m.items())
+	return d
+}
+
+// Contains returns true if the map contains the key.
+func (m *Map[K, V]) Contains(k K) bool {
+	_, ok := m.items()[k]
+	return ok
+}
+
+// CompareAndSet sets the value of the key to new if its current value is
+// equal to old according to eq, and returns true. It returns false, leaving
+// the map untouched, if the map does not contain the key or holds another value.
+//
+// example usage:
+//
+//	m := NewMap(map[string]int{"stock": 3})
+//	m.CompareAndSet("stock", 3, 2, func(a, b int) bool { return a == b })
+//
+// output:
+//
+//	true
+//	Map(string, int) map[stock:2]
+func (m *Map[K, V]) CompareAndSet(k K, old, new V, eq func(a, b V) bool) bool {
+	if v, ok := m.items()[k]; !ok || !eq(v, old) {
+		return false
+	}
+	m.elements[k] = new
+	return true
+}
+
+// Delete removes the key from the map.
+func (m *Map[K, V]) Delete(k K) {
+	delete(m.elements, k)
+}
+
+// Entries returns an iterator over all entries of the map, in no particular order.
+func (m *Map[K, V]) Entries() iter.Seq[collection.Entry[K, V]] {
+	return func(yield func(collection.Entry[K, V]) bool) {
+		for k, v := range m.items() {
+			if !yield(collection.Entry[K, V]{Key: k, Value: v}) {
+				return
+			}
+		}
+	}
+}
+
+// Filter returns a new map containing the entries that satisfy the predicate.
+func (m *Map[K, V]) Filter(f func(K, V) bool) *Map[K, V] {
+	d := NewMap[K, V]()
+	for k, v := range m.items() {
+		if f(k, v) {
+			d.elements[k] = v
+		}
+	}
+	return d
+}
+
+// FilterEntries returns a new map holding the entries transformed by f,
+// skipping the entries for which f returns false, see the FilterEntries
+// function, which also transforms them into different types.
+func (m *Map[K, V]) FilterEntries(f func(K, V) (K, V, bool), resolve func(k K, a, b V) V) *Map[K, V] {
+	return FilterEntries(m, f, resolve)
+}
+
+// FilterKeys returns a new map containing the entries whose key satisfies the predicate.
+//
+// example usage:
+//
+//	m := NewMap(map[string]int{"go": 1, "rust": 2, "zig": 3})
+//	m.FilterKeys(func(k string) bool { return len(k) < 4 })
+//
+// output:
+//
+//	Map(string, int) map[go:1 zig:3]
+func (m *Map[K, V]) FilterKeys(f func(K) bool) *Map[K, V] {
+	return m.Filter(func(k K, _ V) bool { return f(k) })
+}
+
+// Get returns the value of the key and true,
+// or the zero value and false if the map does not contain the key.
+func (m *Map[K, V]) Get(k K) (V, bool) {
+	v, ok := m.items()[k]
+	return v, ok
+}
+
+// GetOrElse returns the value of the key, or def if the map does not contain the key.
+func (m *Map[K, V]) GetOrElse(k K, def V) V {
+	if v, ok := m.items()[k]; ok {
+		return v
+	}
+	return def
+}
+
+// IsEmpty returns true if the map is empty.
+func (m *Map[K, V]) IsEmpty() bool {
+	return len(m.items()) == 0
+}
+
+// Keys returns an iterator over all keys of the map, in no particular order.
+func (m *Map[K, V]) Keys() iter.Seq[K] {
+	return maps.Keys(m.items())
+}
+
+// Length returns the number of entries in the map.
+func (m *Map[K, V]) Length() int {
+	return len(m.items())
+}
+
+// MapEntries returns a new map holding the entries transformed by f, see
+// the MapEntries function, which also transforms them into different types.
+func (m *Map[K, V]) MapEntries(f func(K, V) (K, V), resolve func(k K, a, b V) V) *Map[K, V] {
+	return MapEntries(m, f, resolve)
+}
+
+// MapValues returns a new map with the same keys and the values transformed by f.
+// To transform the values into a different type use the MapValues function.
+func (m *Map[K, V]) MapValues(f func(V) V) *Map[K, V] {
+	return MapValues(m, f)
+}
+
+// Merge returns a new map containing the entries of both maps. When a key
+// is present in both, resolve is called with the key, the value of the
+// current map and the value of the passed in map, and its result is kept.
+//
+// example usage:
+//
+//	a := NewMap(map[string]int{"go": 1, "zig": 2})
+//	b := NewMap(map[string]int{"go": 10, "rust": 3})
+//	a.Merge(b, func(_ string, x, y int) int { return x + y })
+//
+// output:
+//
+//	Map(string, int) map[go:11 rust:3 zig:2]
+func (m *Map[K, V]) Merge(m2 *Map[K, V], resolve func(k K, a, b V) V) *Map[K, V] {
+	d := m.Clone()
+	for k, v := range m2.items() {
+		if current, ok := d.elements[k]; ok {
+			v = resolve(k, current, v)
+		}
+		d.elements[k] = v
+	}
+	return d
+}
+
+// NonEmpty returns true if the map is not empty.
+func (m *Map[K, V]) NonEmpty() bool {
+	return len(m.items()) > 0
+}
+
+// Random returns a random entry from the map, drawn using the RandState of
+// the map. Go maps have no iteration order, so seeding the RandState does
+// not make the entry drawn reproducible.
+func (m *Map[K, V]) Random() collection.Entry[K, V] {
+	if len(m.items()) == 0 {
+		panic(collection.EmptyCollectionError)
+	}
+	n := m.RandState().IntN(len(m.elements))
+	for k, v := range m.elements {
+		if n == 0 {
+			return collection.Entry[K, V]{Key: k, Value: v}
+		}
+		n--
+	}
+	panic("unreachable")
+}
+
+// Set sets the value of the key.
+func (m *Map[K, V]) Set(k K, v V) {
+	if m.elements == nil {
+		m.elements = make(map[K]V)
+	}
+	m.elements[k] = v
+}
+
+// ToMap returns a copy of the map as a Go map.
+func (m *Map[K, V]) ToMap() map[K]V {
+	return maps.Clone(m.items())
+}
+
+// SortedKeys returns an iterator over the keys of the map in the order
+// defined by less. The keys are heapified rather than sorted when the
+// iteration starts, so breaking after the first few is cheap.
+//
+// example usage:
+//
+//	m := NewMap(map[string]int{"b": 2, "c": 3, "a": 1})
+//	for k := range m.SortedKeys(cmp.Less[string]) {
+//		fmt.Println(k, m.GetOrElse(k, 0))
+//	}
+//
+// output:
+//
+//	a 1
+//	b 2
+//	c 3
+func (m *Map[K, V]) SortedKeys(less func(a, b K) bool) iter.Seq[K] {
+	return seq.SortedBy(m.Keys(), less)
+}
+
+// SortedValues returns an iterator over the values of the map in the order
+// defined by less, heapified like SortedKeys.
+//
+// example usage:
+//
+//	latencies := NewMap(map[string]int{"eu": 40, "us": 95, "ap": 120})
+//	slices.Collect(seq.Take(latencies.SortedValues(func(a, b int) bool { return a > b }), 2))
+//
+// output:
+//
+//	[120 95]
+func (m *Map[K, V]) SortedValues(less func(a, b V) bool) iter.Seq[V] {
+	return seq.SortedBy(m.Values(), less)
+}
+
+// Values returns an iterator over all values of the map, in no particular order.
+func (m *Map[K, V]) Values() iter.Seq[V] {
+	return maps.Values(m.items())
+}
+
+// implement the Stringer interface
+func (m *Map[K, V]) String() string {
+	return fmt.Sprintf("Map(%T, %T) %v", *new(K), *new(V), m.items())
+}
+
+// items returns the entries of the map, or nil for a nil map.
+func (m *Map[K, V]) items() map[K]V {
+	if m == nil {
+		return nil
+	}
+	return m.elements
+}
@@ -0,0 +1,268 @@
+package dict
+
+import (
+	"bytes"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/set"
+)
+
+func TestNewMap(t *testing.T) {
+	m := NewMap(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 3, "c": 4})
+	want := map[string]int{"a": 1, "b": 3, "c": 4}
+	if !maps.Equal(m.ToMap(), want) {
+		t.Errorf("NewMap() = %v, want %v", m, want)
+	}
+	if m.String() != "Map(string, int) map[a:1 b:3 c:4]" {
+		t.Errorf("String() = %q", m.String())
+	}
+	if NewMap[string, int]().NonEmpty() {
+		t.Errorf("NewMap() without arguments is not empty")
+	}
+}
+
+func TestMap_Access(t *testing.T) {
+	m := NewMap(map[string]int{"go": 1})
+	m.Set("zig", 2)
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "get present", got: m.GetOrElse("zig", -1), want: 2},
+		{name: "get missing", got: m.GetOrElse("rust", -1), want: -1},
+		{name: "contains present", got: m.Contains("go"), want: true},
+		{name: "contains missing", got: m.Contains("rust"), want: false},
+		{name: "length", got: m.Length(), want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+	if v, ok := m.Get("go"); !ok || v != 1 {
+		t.Errorf("Get(go) = %v, %v, want 1, true", v, ok)
+	}
+	m.Delete("go")
+	if _, ok := m.Get("go"); ok || m.Length() != 1 {
+		t.Errorf("Delete(go) left %v", m)
+	}
+}
+
+func TestMap_CompareAndSet(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+	tests := []struct {
+		name     string
+		key      string
+		old, new int
+		want     bool
+		value    int
+	}{
+		{name: "equal", key: "a", old: 1, new: 5, want: true, value: 5},
+		{name: "changed", key: "a", old: 2, new: 5, want: false, value: 1},
+		{name: "missing", key: "z", old: 0, new: 5, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMap(map[string]int{"a": 1})
+			if got := m.CompareAndSet(tt.key, tt.old, tt.new, eq); got != tt.want {
+				t.Errorf("CompareAndSet() = %v, want %v", got, tt.want)
+			}
+			if v := m.GetOrElse(tt.key, 0); v != tt.value {
+				t.Errorf("value = %v, want %v", v, tt.value)
+			}
+		})
+	}
+}
+
+func TestMap_Iterators(t *testing.T) {
+	m := NewMap(map[string]int{"a": 1, "b": 2, "c": 3})
+	if got := slices.Sorted(m.Keys()); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Keys() = %v, want [a b c]", got)
+	}
+	if got := slices.Sorted(m.Values()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Values() = %v, want [1 2 3]", got)
+	}
+	if got := maps.Collect(m.All()); !maps.Equal(got, m.ToMap()) {
+		t.Errorf("All() = %v, want %v", got, m.ToMap())
+	}
+	entries := slices.SortedFunc(m.Entries(), func(a, b collection.Entry[string, int]) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	want := []collection.Entry[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}}
+	if !slices.Equal(entries, want) {
+		t.Errorf("Entries() = %v, want %v", entries, want)
+	}
+	if got := FromEntries(slices.Values(entries)); !maps.Equal(got.ToMap(), m.ToMap()) {
+		t.Errorf("FromEntries() = %v, want %v", got, m)
+	}
+	e := m.Random()
+	if v, ok := m.Get(e.Key); !ok || v != e.Value {
+		t.Errorf("Random() = %v, not an entry of the map", e)
+	}
+}
+
+func TestMap_RandState(t *testing.T) {
+	m := NewMap(map[string]int{"a": 1, "b": 2, "c": 3})
+	state := collection.NewRandState(42)
+	m.SetRandState(state)
+	before, _ := state.MarshalBinary()
+	e := m.Random()
+	if v, ok := m.Get(e.Key); !ok || v != e.Value {
+		t.Errorf("Random() = %v, not an entry of the map", e)
+	}
+	if after, _ := state.MarshalBinary(); bytes.Equal(before, after) {
+		t.Errorf("Random() did not draw from the RandState of the map")
+	}
+}
+
+func TestMap_Transforms(t *testing.T) {
+	m := NewMap(map[string]int{"go": 1, "rust": 2, "zig": 3})
+	tests := []struct {
+		name string
+		got  *Map[string, int]
+		want map[string]int
+	}{
+		{name: "filter", got: m.Filter(func(k string, v int) bool { return v > 1 && k != "zig" }), want: map[string]int{"rust": 2}},
+		{name: "filter keys", got: m.FilterKeys(func(k string) bool { return len(k) < 4 }), want: map[string]int{"go": 1, "zig": 3}},
+		{name: "map values", got: m.MapValues(func(v int) int { return v * 10 }), want: map[string]int{"go": 10, "rust": 20, "zig": 30}},
+		{
+			name: "merge",
+			got:  m.Merge(NewMap(map[string]int{"go": 10, "c": 4}), func(_ string, a, b int) int { return a + b }),
+			want: map[string]int{"go": 11, "rust": 2, "zig": 3, "c": 4},
+		},
+		{name: "clone", got: m.Clone(), want: map[string]int{"go": 1, "rust": 2, "zig": 3}},
+		{
+			name: "map entries",
+			got:  m.MapEntries(func(k string, v int) (string, int) { return k[:1], v }, func(_ string, a, b int) int { return a + b }),
+			want: map[string]int{"g": 1, "r": 2, "z": 3},
+		},
+		{
+			name: "map entries with collisions",
+			got:  m.MapEntries(func(k string, v int) (string, int) { return "all", v }, func(_ string, a, b int) int { return a + b }),
+			want: map[string]int{"all": 6},
+		},
+		{
+			name: "filter entries",
+			got: m.FilterEntries(func(k string, v int) (string, int, bool) {
+				return strings.ToUpper(k), -v, v != 2
+			}, func(_ string, a, b int) int { return a + b }),
+			want: map[string]int{"GO": -1, "ZIG": -3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !maps.Equal(tt.got.ToMap(), tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+	if !maps.Equal(m.ToMap(), map[string]int{"go": 1, "rust": 2, "zig": 3}) {
+		t.Errorf("transforms modified the original map: %v", m)
+	}
+	lengths := MapValues(NewMap(map[int]string{1: "go", 2: "gopher"}), func(s string) int { return len(s) })
+	if !maps.Equal(lengths.ToMap(), map[int]int{1: 2, 2: 6}) {
+		t.Errorf("MapValues() = %v, want map[1:2 2:6]", lengths)
+	}
+	byLength := MapEntries(m, func(k string, v int) (int, []string) { return len(k), []string{k} }, func(_ int, a, b []string) []string {
+		return slices.Sorted(slices.Values(slices.Concat(a, b)))
+	})
+	if want := map[int][]string{2: {"go"}, 3: {"zig"}, 4: {"rust"}}; !maps.EqualFunc(byLength.ToMap(), want, slices.Equal) {
+		t.Errorf("MapEntries() = %v, want %v", byLength, want)
+	}
+	inverted := FilterEntries(NewMap(map[string]int{"a": 1, "b": -2, "c": 3, "d": 1}), func(k string, v int) (int, string, bool) {
+		return v, k, v > 0
+	}, func(_ int, a, b string) string { return min(a, b) })
+	if want := map[int]string{1: "a", 3: "c"}; !maps.Equal(inverted.ToMap(), want) {
+		t.Errorf("FilterEntries() = %v, want %v", inverted, want)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	c := set.NewOrderedSet([]int{1, 2, 3, 4, 5, 6})
+	got := GroupBy(c, func(i int) int { return i % 3 })
+	want := map[int][]int{0: {3, 6}, 1: {1, 4}, 2: {2, 5}}
+	if !maps.EqualFunc(got.ToMap(), want, slices.Equal) {
+		t.Errorf("GroupBy() = %v, want %v", got, want)
+	}
+}
+
+func TestMap_SortedKeysAndValues(t *testing.T) {
+	m := NewMap(map[string]int{"eu": 40, "us": 95, "ap": 120})
+	if got := slices.Collect(m.SortedKeys(func(a, b string) bool { return a < b })); !slices.Equal(got, []string{"ap", "eu", "us"}) {
+		t.Errorf("SortedKeys() = %v, want [ap eu us]", got)
+	}
+	var top []int
+	for v := range m.SortedValues(func(a, b int) bool { return a > b }) {
+		if top = append(top, v); len(top) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(top, []int{120, 95}) {
+		t.Errorf("SortedValues() first values = %v, want [120 95]", top)
+	}
+}
+
+func TestMap_ZeroValue(t *testing.T) {
+	var m Map[string, int]
+	m.Set("a", 1)
+	if v, ok := m.Get("a"); !ok || v != 1 || m.Length() != 1 {
+		t.Errorf("zero value after Set = %v, want map[a:1]", &m)
+	}
+
+	var n *Map[string, int]
+	if n.Length() != 0 || !n.IsEmpty() || n.Contains("a") || n.GetOrElse("a", -1) != -1 {
+		t.Errorf("nil map does not read as empty")
+	}
+	if keys := slices.Collect(n.Keys()); len(keys) != 0 {
+		t.Errorf("Keys() = %v, want none", keys)
+	}
+}
+
+func TestMap_EmptyReceivers(t *testing.T) {
+	other := NewMap(map[string]int{"a": 1})
+	sum := func(_ string, a, b int) int { return a + b }
+	tests := []struct {
+		name string
+		m    func() *Map[string, int]
+	}{
+		{name: "zero value", m: func() *Map[string, int] { return new(Map[string, int]) }},
+		{name: "nil", m: func() *Map[string, int] { return nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clone := tt.m().Clone()
+			clone.Set("b", 2)
+			if !maps.Equal(clone.ToMap(), map[string]int{"b": 2}) {
+				t.Errorf("Clone() then Set() = %v", clone)
+			}
+			if got := tt.m().Merge(other, sum); !maps.Equal(got.ToMap(), other.ToMap()) {
+				t.Errorf("Merge() = %v, want %v", got, other)
+			}
+			if got := other.Merge(tt.m(), sum); !maps.Equal(got.ToMap(), other.ToMap()) {
+				t.Errorf("Merge() into other = %v, want %v", got, other)
+			}
+			if got := tt.m().Filter(func(string, int) bool { return true }); got.Length() != 0 {
+				t.Errorf("Filter() = %v, want empty", got)
+			}
+			if got := tt.m().MapValues(func(v int) int { return v }); got.Length() != 0 {
+				t.Errorf("MapValues() = %v, want empty", got)
+			}
+			if tt.m().CompareAndSet("a", 0, 1, func(a, b int) bool { return a == b }) {
+				t.Errorf("CompareAndSet() on an empty map returned true")
+			}
+			defer func() {
+				if r := recover(); r != collection.EmptyCollectionError {
+					t.Errorf("Random() panicked with %v, want %v", r, collection.EmptyCollectionError)
+				}
+			}()
+			tt.m().Random()
+		})
+	}
+}
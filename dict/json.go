@@ -0,0 +1,34 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package dict
+
+import "encoding/json"
+
+// MarshalJSON encodes the map as a JSON object. As with Go maps, the keys
+// must be strings, integers or implement encoding.TextMarshaler.
+//
+// example usage:
+//
+//	json.Marshal(NewMap(map[string]int{"go": 1, "zig": 2}))
+//
+// output:
+//
+//	{"go":1,"zig":2}
+func (m *Map[K, V]) MarshalJSON() ([]byte, error) {
+	if m.elements == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m.elements)
+}
+
+// UnmarshalJSON decodes a JSON object into the map, replacing its contents.
+func (m *Map[K, V]) UnmarshalJSON(data []byte) error {
+	elements := make(map[K]V)
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+	m.elements = elements
+	return nil
+}
@@ -0,0 +1,44 @@
+package dict
+
+import (
+	"encoding/json"
+	"maps"
+	"testing"
+)
+
+func TestMap_JSON(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *Map[string, int]
+		want string
+	}{
+		{name: "non-empty map", m: NewMap(map[string]int{"zig": 2, "go": 1}), want: `{"go":1,"zig":2}`},
+		{name: "empty map", m: NewMap[string, int](), want: `{}`},
+		{name: "zero value", m: &Map[string, int]{}, want: `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.m)
+			if err != nil || string(data) != tt.want {
+				t.Fatalf("Marshal() = %s, %v, want %s", data, err, tt.want)
+			}
+			decoded := NewMap(map[string]int{"stale": 0})
+			if err := json.Unmarshal(data, decoded); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !maps.Equal(decoded.ToMap(), tt.m.ToMap()) {
+				t.Errorf("Unmarshal() = %v, want %v", decoded, tt.m)
+			}
+		})
+	}
+}
+
+func TestMap_JSONIntKeys(t *testing.T) {
+	var m Map[int, []string]
+	if err := json.Unmarshal([]byte(`{"1":["a"],"2":["b","c"]}`), &m); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got, _ := m.Get(2); len(got) != 2 {
+		t.Errorf("Unmarshal() = %v, want map[1:[a] 2:[b c]]", &m)
+	}
+}
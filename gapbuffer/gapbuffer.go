@@ -0,0 +1,272 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package gapbuffer implements a sequence optimized for edits near a cursor.
+// A GapBuffer keeps its elements in an array with a gap of free slots at the
+// cursor, so inserting and deleting at the cursor run in amortized O(1),
+// and moving the cursor by d positions costs O(d). It fits text editors,
+// input lines and other workloads where edits cluster around a moving point:
+//
+//	line := gapbuffer.NewGapBuffer([]rune("helo"))
+//	line.MoveTo(3)
+//	line.Insert('l')          // hel|lo
+//	line.End()
+//	line.Insert([]rune("!")...)
+//	string(line.ToSlice())    // hello!
+package gapbuffer
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// minGap is the size of the gap when a gap buffer first grows.
+const minGap = 16
+
+// GapBuffer is a sequence of elements with a cursor. The cursor sits between
+// two elements, at a position from 0, before the first element, to Length(),
+// after the last one. The zero value is an empty buffer ready to use.
+// A GapBuffer is not safe for concurrent use.
+type GapBuffer[T any] struct {
+	elements []T // elements[gapStart:gapEnd] is the gap
+	gapStart int // position of the cursor
+	gapEnd   int
+	collection.Randomized
+}
+
+// NewGapBuffer returns a gap buffer holding the elements of the given
+// slices, with the cursor after the last element.
+func NewGapBuffer[T any](s ...[]T) *GapBuffer[T] {
+	elements := slices.Concat(s...)
+	return &GapBuffer[T]{elements: elements, gapStart: len(elements), gapEnd: len(elements)}
+}
+
+// The following methods implement
+// the Collection interface.
+
+// Add appends an element after the last one, leaving the cursor
+// where it is unless it was after the last element.
+func (b *GapBuffer[T]) Add(v T) {
+	cursor := b.gapStart
+	atEnd := cursor == b.Length()
+	b.MoveTo(b.Length())
+	b.Insert(v)
+	if !atEnd {
+		b.MoveTo(cursor)
+	}
+}
+
+// Length returns the number of elements in the buffer.
+func (b *GapBuffer[T]) Length() int {
+	return len(b.elements) - (b.gapEnd - b.gapStart)
+}
+
+// New returns a new gap buffer.
+func (b *GapBuffer[T]) New(s ...[]T) collection.Collection[T] {
+	return NewGapBuffer(s...)
+}
+
+// Random returns a random element from the buffer.
+func (b *GapBuffer[T]) Random() T {
+	if b.Length() == 0 {
+		panic(collection.EmptyCollectionError)
+	}
+	return b.At(b.RandState().IntN(b.Length()))
+}
+
+// Values returns an iterator over all elements of the buffer.
+func (b *GapBuffer[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range b.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// The following methods implement
+// the OrderedCollection interface.
+
+// At returns the element at the given index.
+func (b *GapBuffer[T]) At(index int) T {
+	if index < 0 || index >= b.Length() {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	return b.elements[b.physical(index)]
+}
+
+// All returns an iterator over all elements of the buffer and their indices.
+func (b *GapBuffer[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range b.elements[:b.gapStart] {
+			if !yield(i, v) {
+				return
+			}
+		}
+		for i, v := range b.elements[b.gapEnd:] {
+			if !yield(b.gapStart+i, v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over all elements of the buffer and their
+// indices in reverse order.
+func (b *GapBuffer[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := b.Length() - 1; i >= 0; i-- {
+			if !yield(i, b.elements[b.physical(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns a new gap buffer containing the elements between the start and end indices.
+func (b *GapBuffer[T]) Slice(start, end int) collection.OrderedCollection[T] {
+	if start < 0 || end > b.Length() || start > end {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	return NewGapBuffer(b.ToSlice()[start:end])
+}
+
+// NewOrdered returns a new gap buffer.
+func (b *GapBuffer[T]) NewOrdered(s ...[]T) collection.OrderedCollection[T] {
+	return NewGapBuffer(s...)
+}
+
+// Cursor returns the position of the cursor, the index of the element after it.
+func (b *GapBuffer[T]) Cursor() int {
+	return b.gapStart
+}
+
+// Delete removes up to n elements after the cursor and returns them.
+//
+// example usage:
+//
+//	b := NewGapBuffer([]int{1,2,3,4,5})
+//	b.MoveTo(1)
+//	b.Delete(3)
+//
+// output:
+//
+//	[2 3 4]
+//	GapBuffer(int) [1 5]
+func (b *GapBuffer[T]) Delete(n int) []T {
+	n = min(max(n, 0), len(b.elements)-b.gapEnd)
+	deleted := slices.Clone(b.elements[b.gapEnd : b.gapEnd+n])
+	clear(b.elements[b.gapEnd : b.gapEnd+n])
+	b.gapEnd += n
+	return deleted
+}
+
+// Backspace removes up to n elements before the cursor and returns them.
+func (b *GapBuffer[T]) Backspace(n int) []T {
+	n = min(max(n, 0), b.gapStart)
+	deleted := slices.Clone(b.elements[b.gapStart-n : b.gapStart])
+	clear(b.elements[b.gapStart-n : b.gapStart])
+	b.gapStart -= n
+	return deleted
+}
+
+// End moves the cursor after the last element.
+func (b *GapBuffer[T]) End() {
+	b.MoveTo(b.Length())
+}
+
+// Home moves the cursor before the first element.
+func (b *GapBuffer[T]) Home() {
+	b.MoveTo(0)
+}
+
+// Insert inserts elements at the cursor, leaving the cursor after them.
+func (b *GapBuffer[T]) Insert(v ...T) {
+	b.grow(len(v))
+	copy(b.elements[b.gapStart:], v)
+	b.gapStart += len(v)
+}
+
+// IsEmpty returns true if the buffer is empty.
+func (b *GapBuffer[T]) IsEmpty() bool {
+	return b.Length() == 0
+}
+
+// Move moves the cursor by offset positions, backward if offset is
+// negative, stopping at either end of the buffer. It returns the new position.
+func (b *GapBuffer[T]) Move(offset int) int {
+	b.MoveTo(min(max(b.gapStart+offset, 0), b.Length()))
+	return b.gapStart
+}
+
+// MoveTo moves the cursor to the given position, in O(distance).
+// It panics if the position is out of bounds.
+func (b *GapBuffer[T]) MoveTo(position int) {
+	if position < 0 || position > b.Length() {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	switch {
+	case position < b.gapStart:
+		n := b.gapStart - position
+		copy(b.elements[b.gapEnd-n:b.gapEnd], b.elements[position:b.gapStart])
+		clear(b.elements[position:min(b.gapStart, b.gapEnd-n)])
+		b.gapStart, b.gapEnd = position, b.gapEnd-n
+	case position > b.gapStart:
+		n := position - b.gapStart
+		copy(b.elements[b.gapStart:], b.elements[b.gapEnd:b.gapEnd+n])
+		clear(b.elements[max(b.gapEnd, b.gapStart+n) : b.gapEnd+n])
+		b.gapStart, b.gapEnd = position, b.gapEnd+n
+	}
+}
+
+// NonEmpty returns true if the buffer is not empty.
+func (b *GapBuffer[T]) NonEmpty() bool {
+	return b.Length() > 0
+}
+
+// Set replaces the element at the given index.
+// If the index is out of bounds, it returns an error.
+func (b *GapBuffer[T]) Set(index int, v T) error {
+	if index < 0 || index >= b.Length() {
+		return collection.IndexOutOfBoundsError
+	}
+	b.elements[b.physical(index)] = v
+	return nil
+}
+
+// ToSlice returns the elements of the buffer as a new slice.
+func (b *GapBuffer[T]) ToSlice() []T {
+	return slices.Concat(b.elements[:b.gapStart], b.elements[b.gapEnd:])
+}
+
+// implement the Stringer interface
+func (b *GapBuffer[T]) String() string {
+	return fmt.Sprintf("GapBuffer(%T) %v", *new(T), b.ToSlice())
+}
+
+// physical returns the position in the array of the element at the given index.
+func (b *GapBuffer[T]) physical(index int) int {
+	if index < b.gapStart {
+		return index
+	}
+	return index + b.gapEnd - b.gapStart
+}
+
+// grow makes room for at least n elements in the gap, doubling the array
+// when it is too small.
+func (b *GapBuffer[T]) grow(n int) {
+	if b.gapEnd-b.gapStart >= n {
+		return
+	}
+	size := max(2*len(b.elements), len(b.elements)+n, minGap)
+	elements := make([]T, size)
+	copy(elements, b.elements[:b.gapStart])
+	tail := len(b.elements) - b.gapEnd
+	copy(elements[size-tail:], b.elements[b.gapEnd:])
+	b.elements, b.gapEnd = elements, size-tail
+}
@@ -0,0 +1,143 @@
+package gapbuffer
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/collectiontest"
+	"github.com/charbz/gophers/sequence"
+)
+
+func TestGapBuffer_MatchesSequence(t *testing.T) {
+	ops := collectiontest.OrderedOps[int, collection.OrderedCollection[int]](func(r *rand.Rand) int { return r.Intn(50) })
+	collectiontest.Check(t,
+		func() collection.OrderedCollection[int] { return sequence.NewSequence[int]() },
+		func() collection.OrderedCollection[int] {
+			b := NewGapBuffer([]int{})
+			b.Home()
+			return b
+		},
+		ops, collectiontest.Options[int]{Steps: 300},
+	)
+}
+
+func TestGapBuffer_Edits(t *testing.T) {
+	tests := []struct {
+		name       string
+		edit       func(b *GapBuffer[rune]) []rune
+		want       string
+		wantCursor int
+		wantResult string
+	}{
+		{
+			name: "insert in the middle",
+			edit: func(b *GapBuffer[rune]) []rune {
+				b.MoveTo(3)
+				b.Insert('l')
+				return nil
+			},
+			want:       "hello world",
+			wantCursor: 4,
+		},
+		{
+			name: "delete after the cursor",
+			edit: func(b *GapBuffer[rune]) []rune {
+				b.Home()
+				return b.Delete(5)
+			},
+			want:       "world",
+			wantCursor: 0,
+			wantResult: "helo ",
+		},
+		{
+			name: "backspace before the cursor",
+			edit: func(b *GapBuffer[rune]) []rune {
+				return b.Backspace(100)
+			},
+			want:       "",
+			wantCursor: 0,
+			wantResult: "helo world",
+		},
+		{
+			name: "move and replace",
+			edit: func(b *GapBuffer[rune]) []rune {
+				b.Move(-5)
+				b.Backspace(1)
+				b.Insert([]rune("_")...)
+				b.Move(100)
+				b.Insert('!')
+				return nil
+			},
+			want:       "helo_world!",
+			wantCursor: 11,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewGapBuffer([]rune("helo world"))
+			result := tt.edit(b)
+			if got := string(b.ToSlice()); got != tt.want {
+				t.Errorf("buffer = %q, want %q", got, tt.want)
+			}
+			if b.Cursor() != tt.wantCursor {
+				t.Errorf("Cursor() = %v, want %v", b.Cursor(), tt.wantCursor)
+			}
+			if string(result) != tt.wantResult {
+				t.Errorf("edit returned %q, want %q", string(result), tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestGapBuffer_RandomEdits(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	b := NewGapBuffer[int]()
+	var want []int
+	cursor := 0
+	for step := range 2000 {
+		switch r.Intn(5) {
+		case 0, 1:
+			b.Insert(step)
+			want = slices.Insert(want, cursor, step)
+			cursor++
+		case 2:
+			n := r.Intn(3)
+			b.Delete(n)
+			want = slices.Delete(want, cursor, min(cursor+n, len(want)))
+		case 3:
+			n := min(r.Intn(3), cursor)
+			b.Backspace(n)
+			want = slices.Delete(want, cursor-n, cursor)
+			cursor -= n
+		case 4:
+			cursor = r.Intn(len(want) + 1)
+			b.MoveTo(cursor)
+		}
+		if b.Cursor() != cursor || !slices.Equal(b.ToSlice(), want) {
+			t.Fatalf("step %v: buffer = %v, cursor %v, want %v, cursor %v", step, b, b.Cursor(), want, cursor)
+		}
+	}
+	// the gap must hold no stale references
+	for i := b.gapStart; i < b.gapEnd; i++ {
+		if b.elements[i] != 0 {
+			t.Fatalf("gap slot %v holds %v", i, b.elements[i])
+		}
+	}
+}
+
+func TestGapBuffer_AddKeepsCursor(t *testing.T) {
+	b := NewGapBuffer([]int{1, 2, 3})
+	b.MoveTo(1)
+	b.Add(4)
+	if b.Cursor() != 1 || !slices.Equal(b.ToSlice(), []int{1, 2, 3, 4}) {
+		t.Errorf("Add() = %v, cursor %v, want [1 2 3 4], cursor 1", b, b.Cursor())
+	}
+	if err := b.Set(4, 0); err != collection.IndexOutOfBoundsError {
+		t.Errorf("Set() out of bounds error = %v", err)
+	}
+	if got := b.String(); got != "GapBuffer(int) [1 2 3 4]" {
+		t.Errorf("String() = %q", got)
+	}
+}
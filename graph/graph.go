@@ -0,0 +1,273 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package graph implements a directed graph with path and cycle queries,
+// i.e. to analyze the dependencies between packages, tasks or services:
+//
+//	deps := graph.NewGraph[string]()
+//	deps.AddEdge("app", "db")
+//	deps.AddEdge("db", "config")
+//	deps.HasPath("app", "config") // true
+//	deps.FindCycles()             // [], no circular dependency
+//
+// Vertices and edges are iterated in insertion order, so the results of the
+// queries are deterministic.
+package graph
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// Graph is a directed graph of vertices of type K. Adding a vertex or an
+// edge runs in O(1). The zero value is not ready to use, a Graph must be
+// created with NewGraph.
+type Graph[K comparable] struct {
+	vertices []K
+	index    map[K]int // position of each vertex in vertices
+	edges    [][]int   // edges[i] holds the targets of vertex i, in insertion order
+	size     int       // number of edges
+}
+
+// NewGraph returns a new graph holding the given vertices, without edges.
+//
+// example usage:
+//
+//	g := NewGraph([]string{"a", "b"})
+//	g.AddEdge("a", "b")
+//
+// output:
+//
+//	Graph(string) map[a:[b] b:[]]
+func NewGraph[K comparable](s ...[]K) *Graph[K] {
+	g := &Graph[K]{index: make(map[K]int)}
+	for _, vertices := range s {
+		for _, v := range vertices {
+			g.AddVertex(v)
+		}
+	}
+	return g
+}
+
+// AddEdge adds an edge from one vertex to another, adding the vertices
+// to the graph if needed. Adding an existing edge has no effect.
+func (g *Graph[K]) AddEdge(from, to K) {
+	i, j := g.vertex(from), g.vertex(to)
+	if !slices.Contains(g.edges[i], j) {
+		g.edges[i] = append(g.edges[i], j)
+		g.size++
+	}
+}
+
+// AddVertex adds a vertex to the graph, if it is not already present.
+func (g *Graph[K]) AddVertex(v K) {
+	g.vertex(v)
+}
+
+// AllPaths returns an iterator over the paths from one vertex to another
+// of at most maxDepth edges, each path listing its vertices from a to b.
+// A path never visits a vertex twice. The paths are found lazily by a
+// depth-first search, so stopping the iteration stops the search.
+//
+// example usage:
+//
+//	g := NewGraph[string]()
+//	g.AddEdge("a", "b")
+//	g.AddEdge("b", "c")
+//	g.AddEdge("a", "c")
+//	for path := range g.AllPaths("a", "c", 5) {
+//	  fmt.Println(path)
+//	}
+//
+// output:
+//
+//	[a b c]
+//	[a c]
+func (g *Graph[K]) AllPaths(a, b K, maxDepth int) iter.Seq[[]K] {
+	return func(yield func([]K) bool) {
+		from, ok := g.index[a]
+		to, found := g.index[b]
+		if !ok || !found {
+			return
+		}
+		if from == to {
+			yield([]K{a})
+			return
+		}
+		path := []int{from}
+		onPath := make([]bool, len(g.vertices))
+		onPath[from] = true
+		var search func(i int) bool
+		search = func(i int) bool {
+			if len(path) > maxDepth {
+				return true
+			}
+			for _, j := range g.edges[i] {
+				if onPath[j] {
+					continue
+				}
+				path = append(path, j)
+				if j == to {
+					if !yield(g.keys(path)) {
+						return false
+					}
+				} else {
+					onPath[j] = true
+					if !search(j) {
+						return false
+					}
+					onPath[j] = false
+				}
+				path = path[:len(path)-1]
+			}
+			return true
+		}
+		search(from)
+	}
+}
+
+// Contains returns true if the graph contains the vertex.
+func (g *Graph[K]) Contains(v K) bool {
+	_, ok := g.index[v]
+	return ok
+}
+
+// Edges returns the number of edges of the graph.
+func (g *Graph[K]) Edges() int {
+	return g.size
+}
+
+// FindCycles returns the elementary cycles of the graph, i.e. the circular
+// dependencies. Each cycle lists its vertices once, starting from the vertex
+// added first to the graph, the edge back to that vertex being implied. A
+// self-loop is a cycle of one vertex. The number of cycles may grow
+// exponentially with the size of the graph, use HasPath to only test for one.
+//
+// example usage:
+//
+//	g := NewGraph[string]()
+//	g.AddEdge("a", "b")
+//	g.AddEdge("b", "c")
+//	g.AddEdge("c", "a")
+//	g.AddEdge("c", "c")
+//	g.FindCycles()
+//
+// output:
+//
+//	[[a b c] [c]]
+func (g *Graph[K]) FindCycles() [][]K {
+	var cycles [][]K
+	onPath := make([]bool, len(g.vertices))
+	// every cycle is found once, from its vertex of lowest index,
+	// by a search restricted to the vertices of higher index
+	for start := range g.vertices {
+		path := []int{start}
+		onPath[start] = true
+		var search func(i int)
+		search = func(i int) {
+			for _, j := range g.edges[i] {
+				switch {
+				case j == start:
+					cycles = append(cycles, g.keys(path))
+				case j > start && !onPath[j]:
+					path = append(path, j)
+					onPath[j] = true
+					search(j)
+					onPath[j] = false
+					path = path[:len(path)-1]
+				}
+			}
+		}
+		search(start)
+		onPath[start] = false
+	}
+	return cycles
+}
+
+// HasPath returns true if b can be reached from a by following edges.
+// A vertex of the graph always reaches itself. It runs in O(V+E).
+func (g *Graph[K]) HasPath(a, b K) bool {
+	from, ok := g.index[a]
+	to, found := g.index[b]
+	if !ok || !found {
+		return false
+	}
+	visited := make([]bool, len(g.vertices))
+	visited[from] = true
+	queue := []int{from}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		if i == to {
+			return true
+		}
+		for _, j := range g.edges[i] {
+			if !visited[j] {
+				visited[j] = true
+				queue = append(queue, j)
+			}
+		}
+	}
+	return false
+}
+
+// Length returns the number of vertices of the graph.
+func (g *Graph[K]) Length() int {
+	return len(g.vertices)
+}
+
+// Neighbors returns an iterator over the targets of the edges from v,
+// in insertion order.
+func (g *Graph[K]) Neighbors(v K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		i, ok := g.index[v]
+		if !ok {
+			return
+		}
+		for _, j := range g.edges[i] {
+			if !yield(g.vertices[j]) {
+				return
+			}
+		}
+	}
+}
+
+// Vertices returns an iterator over the vertices of the graph, in insertion order.
+func (g *Graph[K]) Vertices() iter.Seq[K] {
+	return slices.Values(g.vertices)
+}
+
+// implement the Stringer interface
+func (g *Graph[K]) String() string {
+	var sb strings.Builder
+	for i, v := range g.vertices {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		fmt.Fprintf(&sb, "%v:%v", v, g.keys(g.edges[i]))
+	}
+	return fmt.Sprintf("Graph(%T) map[%v]", *new(K), sb.String())
+}
+
+// vertex returns the index of v, adding it to the graph if needed.
+func (g *Graph[K]) vertex(v K) int {
+	if i, ok := g.index[v]; ok {
+		return i
+	}
+	g.index[v] = len(g.vertices)
+	g.vertices = append(g.vertices, v)
+	g.edges = append(g.edges, nil)
+	return len(g.vertices) - 1
+}
+
+// keys returns the vertices at the given indices.
+func (g *Graph[K]) keys(indices []int) []K {
+	keys := make([]K, len(indices))
+	for i, j := range indices {
+		keys[i] = g.vertices[j]
+	}
+	return keys
+}
@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// newDeps returns the graph a -> b -> c -> d, a -> c, c -> a, d -> d, and e.
+func newDeps() *Graph[string] {
+	g := NewGraph([]string{"a", "b", "c", "d", "e"})
+	for _, e := range [][2]string{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"a", "c"}, {"c", "a"}, {"d", "d"}, {"a", "b"}} {
+		g.AddEdge(e[0], e[1])
+	}
+	return g
+}
+
+func TestGraph_Build(t *testing.T) {
+	g := newDeps()
+	if g.Length() != 5 || g.Edges() != 6 {
+		t.Errorf("Length() = %v, Edges() = %v, want 5, 6", g.Length(), g.Edges())
+	}
+	if got := g.String(); got != "Graph(string) map[a:[b c] b:[c] c:[d a] d:[d] e:[]]" {
+		t.Errorf("String() = %v", got)
+	}
+	if got := slices.Collect(g.Neighbors("c")); !slices.Equal(got, []string{"d", "a"}) {
+		t.Errorf("Neighbors(c) = %v", got)
+	}
+	if got := slices.Collect(g.Neighbors("z")); len(got) != 0 || g.Contains("z") {
+		t.Errorf("Neighbors(z) = %v of a missing vertex", got)
+	}
+}
+
+func TestGraph_HasPath(t *testing.T) {
+	g := newDeps()
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "a", b: "d", want: true},
+		{a: "d", b: "a", want: false},
+		{a: "c", b: "b", want: true},
+		{a: "e", b: "e", want: true},
+		{a: "a", b: "e", want: false},
+		{a: "a", b: "z", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"->"+tt.b, func(t *testing.T) {
+			if got := g.HasPath(tt.a, tt.b); got != tt.want {
+				t.Errorf("HasPath(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraph_AllPaths(t *testing.T) {
+	g := newDeps()
+	tests := []struct {
+		name     string
+		a, b     string
+		maxDepth int
+		want     string
+	}{
+		{name: "all", a: "a", b: "d", maxDepth: 10, want: "[[a b c d] [a c d]]"},
+		{name: "depth limited", a: "a", b: "d", maxDepth: 2, want: "[[a c d]]"},
+		{name: "depth zero", a: "a", b: "d", maxDepth: 0, want: "[]"},
+		{name: "through a cycle", a: "b", b: "a", maxDepth: 10, want: "[[b c a]]"},
+		{name: "unreachable", a: "d", b: "a", maxDepth: 10, want: "[]"},
+		{name: "same vertex", a: "e", b: "e", maxDepth: 10, want: "[[e]]"},
+		{name: "missing vertex", a: "a", b: "z", maxDepth: 10, want: "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Collect(g.AllPaths(tt.a, tt.b, tt.maxDepth))
+			if fmt.Sprint(got) != tt.want {
+				t.Errorf("AllPaths(%v, %v, %v) = %v, want %v", tt.a, tt.b, tt.maxDepth, got, tt.want)
+			}
+		})
+	}
+	for path := range g.AllPaths("a", "d", 10) {
+		if !slices.Equal(path, []string{"a", "b", "c", "d"}) {
+			t.Errorf("AllPaths() yielded %v first", path)
+		}
+		break
+	}
+}
+
+func TestGraph_FindCycles(t *testing.T) {
+	if got := fmt.Sprint(newDeps().FindCycles()); got != "[[a b c] [a c] [d]]" {
+		t.Errorf("FindCycles() = %v", got)
+	}
+	acyclic := NewGraph[int]()
+	acyclic.AddEdge(1, 2)
+	acyclic.AddEdge(2, 3)
+	acyclic.AddEdge(1, 3)
+	if got := acyclic.FindCycles(); len(got) != 0 {
+		t.Errorf("FindCycles() of an acyclic graph = %v", got)
+	}
+}
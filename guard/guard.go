@@ -0,0 +1,230 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package guard implements a wrapper enforcing limits on a collection:
+// a maximum length, a maximum element size and a maximum mutation rate.
+// It is meant to keep in-memory queues and buffers fed by untrusted or
+// bursty input from growing without bound:
+//
+//	queue := guard.New[[]byte](list.NewList[[]byte](), guard.Limits[[]byte]{
+//	  MaxLength:      10_000,
+//	  MaxElementSize: 1 << 20,
+//	  Measure:        func(b []byte) int { return len(b) },
+//	  Rate:           500,
+//	  Burst:          50,
+//	})
+//	if err := queue.TryAdd(payload); err != nil {
+//	  var rate *guard.RateError
+//	  if errors.As(err, &rate) {
+//	    w.Header().Set("Retry-After", fmt.Sprint(rate.RetryAfter.Seconds()))
+//	  }
+//	  ...
+//	}
+//
+// A Guarded collection is safe for concurrent use.
+package guard
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// Limits configures a Guarded collection. Zero values disable the corresponding limit.
+type Limits[T any] struct {
+	// MaxLength is the maximum number of elements in the collection.
+	MaxLength int
+	// MaxElementSize is the maximum size of an element, as returned by Measure.
+	MaxElementSize int
+	// Measure returns the size of an element, it is required when MaxElementSize is set.
+	Measure func(T) int
+	// Rate is the maximum sustained number of mutations per second.
+	Rate float64
+	// Burst is the number of mutations allowed at once above Rate, it defaults to 1.
+	Burst int
+}
+
+// LengthError is returned when adding an element to a collection at its maximum length.
+type LengthError struct {
+	Max int
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("guard: maximum length of %d reached", e.Max)
+}
+
+// SizeError is returned when adding an element larger than the maximum element size.
+type SizeError struct {
+	Max  int
+	Size int
+}
+
+func (e *SizeError) Error() string {
+	return fmt.Sprintf("guard: element size %d exceeds the maximum of %d", e.Size, e.Max)
+}
+
+// RateError is returned when a mutation exceeds the mutation rate.
+// RetryAfter is the time after which the mutation would be allowed.
+type RateError struct {
+	Rate       float64
+	RetryAfter time.Duration
+}
+
+func (e *RateError) Error() string {
+	return fmt.Sprintf("guard: rate of %g mutations per second exceeded, retry after %v", e.Rate, e.RetryAfter)
+}
+
+// Guarded wraps a collection and enforces Limits on its mutations.
+// It implements the Collection interface.
+type Guarded[T any, C collection.Collection[T]] struct {
+	mu     sync.Mutex
+	c      C
+	limits Limits[T]
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// New returns a Guarded collection wrapping c. The element type
+// cannot be inferred and must be given, i.e. New[int](list.NewList[int](), limits).
+// It panics if MaxElementSize is set without Measure.
+func New[T any, C collection.Collection[T]](c C, limits Limits[T]) *Guarded[T, C] {
+	if limits.MaxElementSize > 0 && limits.Measure == nil {
+		panic("guard: MaxElementSize requires a Measure function")
+	}
+	if limits.Burst <= 0 {
+		limits.Burst = 1
+	}
+	g := &Guarded[T, C]{c: c, limits: limits, tokens: float64(limits.Burst), now: time.Now}
+	g.last = g.now()
+	return g
+}
+
+// The following methods implement
+// the Collection interface.
+
+// Add adds a value to the underlying collection.
+// It panics with the limit error if a limit is exceeded, use TryAdd to handle it.
+func (g *Guarded[T, C]) Add(v T) {
+	if err := g.TryAdd(v); err != nil {
+		panic(err)
+	}
+}
+
+// Length returns the number of elements in the underlying collection.
+func (g *Guarded[T, C]) Length() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.c.Length()
+}
+
+// New returns a new collection of the underlying type, which is not guarded.
+func (g *Guarded[T, C]) New(s ...[]T) collection.Collection[T] {
+	return g.c.New(s...)
+}
+
+// Random returns a random element of the underlying collection.
+func (g *Guarded[T, C]) Random() T {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.c.Random()
+}
+
+// Values returns an iterator over a snapshot of the values of the underlying collection.
+func (g *Guarded[T, C]) Values() iter.Seq[T] {
+	g.mu.Lock()
+	values := slices.Collect(g.c.Values())
+	g.mu.Unlock()
+	return slices.Values(values)
+}
+
+// Do runs f on the underlying collection while holding the lock,
+// counting it as one mutation against the rate limit. It is meant for
+// type specific mutations, i.e. removals, and does not check the
+// length and element size limits.
+//
+// example usage:
+//
+//	var job Job
+//	err := g.Do(func(l *list.List[Job]) { job, _ = l.Dequeue() })
+func (g *Guarded[T, C]) Do(f func(C)) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err := g.take(); err != nil {
+		return err
+	}
+	f(g.c)
+	return nil
+}
+
+// Limits returns the limits enforced by the collection.
+func (g *Guarded[T, C]) Limits() Limits[T] {
+	return g.limits
+}
+
+// TryAdd adds a value to the underlying collection, or returns a
+// *SizeError, *LengthError or *RateError if a limit would be exceeded.
+// Rejected values do not count against the rate limit.
+//
+// example usage:
+//
+//	g := New[int](list.NewList[int](), Limits[int]{MaxLength: 1})
+//	g.TryAdd(1)
+//	g.TryAdd(2)
+//
+// output:
+//
+//	nil
+//	guard: maximum length of 1 reached
+func (g *Guarded[T, C]) TryAdd(v T) error {
+	if g.limits.MaxElementSize > 0 {
+		if size := g.limits.Measure(v); size > g.limits.MaxElementSize {
+			return &SizeError{Max: g.limits.MaxElementSize, Size: size}
+		}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.limits.MaxLength > 0 && g.c.Length() >= g.limits.MaxLength {
+		return &LengthError{Max: g.limits.MaxLength}
+	}
+	if err := g.take(); err != nil {
+		return err
+	}
+	g.c.Add(v)
+	return nil
+}
+
+// Unwrap returns the underlying collection. Mutations made on it
+// directly are not guarded.
+func (g *Guarded[T, C]) Unwrap() C {
+	return g.c
+}
+
+// implement the Stringer interface
+func (g *Guarded[T, C]) String() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return fmt.Sprintf("Guarded(%v)", g.c)
+}
+
+// take consumes a token of the rate limiter, refilled at Rate tokens
+// per second up to Burst tokens. The lock must be held.
+func (g *Guarded[T, C]) take() error {
+	if g.limits.Rate <= 0 {
+		return nil
+	}
+	now := g.now()
+	g.tokens = min(float64(g.limits.Burst), g.tokens+now.Sub(g.last).Seconds()*g.limits.Rate)
+	g.last = now
+	if g.tokens < 1 {
+		wait := time.Duration((1 - g.tokens) / g.limits.Rate * float64(time.Second))
+		return &RateError{Rate: g.limits.Rate, RetryAfter: wait}
+	}
+	g.tokens--
+	return nil
+}
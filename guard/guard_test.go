@@ -0,0 +1,144 @@
+package guard
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/charbz/gophers/list"
+	"github.com/charbz/gophers/sequence"
+)
+
+// clock is a fake time source advanced manually.
+type clock struct {
+	t time.Time
+}
+
+func (c *clock) now() time.Time { return c.t }
+
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func guarded(limits Limits[string]) (*Guarded[string, *sequence.Sequence[string]], *clock) {
+	g := New[string](sequence.NewSequence[string](), limits)
+	c := &clock{t: time.Unix(0, 0)}
+	g.now, g.last = c.now, c.t
+	return g, c
+}
+
+func TestGuarded_TryAdd(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits Limits[string]
+		values []string
+		errs   []error
+		want   []string
+	}{
+		{
+			name:   "no limits",
+			limits: Limits[string]{},
+			values: []string{"a", "b", "c"},
+			errs:   []error{nil, nil, nil},
+			want:   []string{"a", "b", "c"},
+		},
+		{
+			name:   "max length",
+			limits: Limits[string]{MaxLength: 2},
+			values: []string{"a", "b", "c"},
+			errs:   []error{nil, nil, &LengthError{Max: 2}},
+			want:   []string{"a", "b"},
+		},
+		{
+			name:   "max element size",
+			limits: Limits[string]{MaxElementSize: 3, Measure: func(s string) int { return len(s) }},
+			values: []string{"go", "gopher", "zig"},
+			errs:   []error{nil, &SizeError{Max: 3, Size: 6}, nil},
+			want:   []string{"go", "zig"},
+		},
+		{
+			name:   "rate with burst",
+			limits: Limits[string]{Rate: 1, Burst: 2},
+			values: []string{"a", "b", "c"},
+			errs:   []error{nil, nil, &RateError{Rate: 1, RetryAfter: time.Second}},
+			want:   []string{"a", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := guarded(tt.limits)
+			for i, v := range tt.values {
+				err := g.TryAdd(v)
+				if (err == nil) != (tt.errs[i] == nil) || (err != nil && err.Error() != tt.errs[i].Error()) {
+					t.Errorf("TryAdd(%q) = %v, want %v", v, err, tt.errs[i])
+				}
+			}
+			if got := slices.Collect(g.Values()); !slices.Equal(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGuarded_RateRefill(t *testing.T) {
+	g, c := guarded(Limits[string]{Rate: 10})
+	if err := g.TryAdd("a"); err != nil {
+		t.Fatalf("TryAdd() = %v, want nil", err)
+	}
+	var rate *RateError
+	if err := g.TryAdd("b"); !errors.As(err, &rate) {
+		t.Fatalf("TryAdd() = %v, want a *RateError", err)
+	}
+	if rate.RetryAfter != 100*time.Millisecond {
+		t.Errorf("RetryAfter = %v, want 100ms", rate.RetryAfter)
+	}
+	c.advance(50 * time.Millisecond)
+	if err := g.Do(func(*sequence.Sequence[string]) {}); !errors.As(err, &rate) {
+		t.Errorf("Do() = %v, want a *RateError", err)
+	}
+	c.advance(50 * time.Millisecond)
+	if err := g.TryAdd("b"); err != nil {
+		t.Errorf("TryAdd() after refill = %v, want nil", err)
+	}
+	c.advance(time.Hour)
+	if err := g.TryAdd("c"); err != nil {
+		t.Errorf("TryAdd() after idle = %v, want nil", err)
+	}
+	if err := g.TryAdd("d"); err == nil {
+		t.Errorf("TryAdd() = nil, want the burst to be capped at 1")
+	}
+}
+
+func TestGuarded_RejectedDoNotConsumeRate(t *testing.T) {
+	g, _ := guarded(Limits[string]{MaxLength: 1, Rate: 1, Burst: 2})
+	g.Add("a")
+	var length *LengthError
+	if err := g.TryAdd("b"); !errors.As(err, &length) {
+		t.Fatalf("TryAdd() = %v, want a *LengthError", err)
+	}
+	if err := g.Do(func(s *sequence.Sequence[string]) { s.Pop() }); err != nil {
+		t.Errorf("Do() = %v, want nil", err)
+	}
+	if g.Length() != 0 {
+		t.Errorf("Length() = %v, want 0", g.Length())
+	}
+}
+
+func TestGuarded_AddPanics(t *testing.T) {
+	g := New[int](list.NewList[int](), Limits[int]{MaxLength: 1})
+	g.Add(1)
+	defer func() {
+		if _, ok := recover().(*LengthError); !ok {
+			t.Errorf("Add() beyond MaxLength did not panic with a *LengthError")
+		}
+	}()
+	g.Add(2)
+}
+
+func TestNew_RequiresMeasure(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New() with MaxElementSize and no Measure did not panic")
+		}
+	}()
+	New[int](list.NewList[int](), Limits[int]{MaxElementSize: 1})
+}
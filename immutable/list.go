@@ -0,0 +1,281 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package immutable implements persistent collections: operations deriving
+// a new version of a collection leave the original untouched and share as
+// much structure with it as possible, instead of copying it.
+//
+// Since a version never changes once created, it can be read from any
+// number of goroutines without locking:
+//
+//	base := immutable.NewList([]string{"b", "c"})
+//	withA := base.Prepend("a") // [a b c], shares b and c with base
+//	rest := withA.Tail()       // [b c], the very cells of base
+//
+// Add is the only method that modifies its receiver, see List.Add.
+package immutable
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// cell is a link of a list. Cells are never modified once linked.
+type cell[T any] struct {
+	value T
+	next  *cell[T]
+}
+
+// List is a persistent singly linked list. Prepend, Head and Tail run in
+// O(1) and share the whole list with the original, Updated and Drop share
+// the cells after the position they reach. Operations at the end of the
+// list, such as Append, copy the cells they traverse.
+// The zero value is an empty list.
+type List[T any] struct {
+	head *cell[T]
+	size int
+}
+
+// NewList returns a new list holding the elements of the given slices.
+func NewList[T any](s ...[]T) *List[T] {
+	l := new(List[T])
+	for _, v := range slices.Backward(slices.Concat(s...)) {
+		l.head = &cell[T]{value: v, next: l.head}
+		l.size++
+	}
+	return l
+}
+
+// The following methods implement
+// the Collection interface.
+
+// Add replaces the receiver with l.Append(v), in O(n). Versions derived
+// from the list before the call are not affected, since every derived list
+// has a header of its own even when it shares all the cells, but the receiver itself
+// changes, so Add must not be called concurrently with other methods
+// on the same *List. Prefer Append and Prepend.
+func (l *List[T]) Add(v T) {
+	*l = *l.Append(v)
+}
+
+// Length returns the number of elements in the list.
+func (l *List[T]) Length() int {
+	return l.size
+}
+
+// New returns a new immutable list.
+func (l *List[T]) New(s ...[]T) collection.Collection[T] {
+	return NewList(s...)
+}
+
+// Random returns a random element from the list.
+func (l *List[T]) Random() T {
+	if l.size == 0 {
+		panic(collection.EmptyCollectionError)
+	}
+	return l.At(collection.DefaultRandState().IntN(l.size))
+}
+
+// Values returns an iterator over all values in the list.
+func (l *List[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for c := l.head; c != nil; c = c.next {
+			if !yield(c.value) {
+				return
+			}
+		}
+	}
+}
+
+// The following methods implement
+// the OrderedCollection interface.
+
+// At returns the element at the given index, in O(index).
+func (l *List[T]) At(index int) T {
+	if index < 0 || index >= l.size {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	return l.cellAt(index).value
+}
+
+// All returns an iterator over all elements of the list and their indices.
+func (l *List[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for c := l.head; c != nil; c = c.next {
+			if !yield(i, c.value) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Backward returns an iterator over all elements of the list and their
+// indices in reverse order. It copies the elements into a slice first.
+func (l *List[T]) Backward() iter.Seq2[int, T] {
+	return slices.Backward(l.ToSlice())
+}
+
+// Slice returns a new list containing the elements between the start and
+// end indices. The cells after start are shared when end is the length of the list.
+func (l *List[T]) Slice(start, end int) collection.OrderedCollection[T] {
+	if start < 0 || end > l.size || start > end {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	return l.Drop(start).Take(end - start)
+}
+
+// NewOrdered returns a new immutable list.
+func (l *List[T]) NewOrdered(s ...[]T) collection.OrderedCollection[T] {
+	return NewList(s...)
+}
+
+// Append returns a new list with v added at the end, in O(n).
+func (l *List[T]) Append(v T) *List[T] {
+	return l.Concat(&List[T]{head: &cell[T]{value: v}, size: 1})
+}
+
+// Concat returns a new list holding the elements of l followed by the
+// elements of other. The cells of other are shared, the cells of l are copied.
+//
+// example usage:
+//
+//	a := NewList([]int{1,2})
+//	b := NewList([]int{3,4})
+//	a.Concat(b)
+//
+// output:
+//
+//	ImmutableList(int) [1 2 3 4]
+func (l *List[T]) Concat(other *List[T]) *List[T] {
+	head, last := l.copyPrefix(l.size)
+	if last == nil {
+		return &List[T]{head: other.head, size: other.size}
+	}
+	last.next = other.head
+	return &List[T]{head: head, size: l.size + other.size}
+}
+
+// Drop returns the list without its first n elements, in O(n),
+// sharing all the remaining cells.
+func (l *List[T]) Drop(n int) *List[T] {
+	n = min(max(n, 0), l.size)
+	return &List[T]{head: l.cellAt(n), size: l.size - n}
+}
+
+// Head returns the first element of the list.
+func (l *List[T]) Head() (T, error) {
+	if l.head == nil {
+		return *new(T), collection.EmptyCollectionError
+	}
+	return l.head.value, nil
+}
+
+// IsEmpty returns true if the list is empty.
+func (l *List[T]) IsEmpty() bool {
+	return l.size == 0
+}
+
+// NonEmpty returns true if the list is not empty.
+func (l *List[T]) NonEmpty() bool {
+	return l.size > 0
+}
+
+// Prepend returns a new list with v added at the beginning, in O(1),
+// sharing all the cells of l.
+func (l *List[T]) Prepend(v T) *List[T] {
+	return &List[T]{head: &cell[T]{value: v, next: l.head}, size: l.size + 1}
+}
+
+// Reverse returns a new list with the elements in reverse order.
+func (l *List[T]) Reverse() *List[T] {
+	r := new(List[T])
+	for v := range l.Values() {
+		r = r.Prepend(v)
+	}
+	return r
+}
+
+// Tail returns the list without its first element, in O(1),
+// sharing all the remaining cells. The tail of an empty list is empty.
+func (l *List[T]) Tail() *List[T] {
+	if l.head == nil {
+		return new(List[T])
+	}
+	return &List[T]{head: l.head.next, size: l.size - 1}
+}
+
+// Take returns a new list holding the first n elements, in O(n).
+// The cells are shared when n is at least the length of the list.
+func (l *List[T]) Take(n int) *List[T] {
+	if n >= l.size {
+		return &List[T]{head: l.head, size: l.size}
+	}
+	n = max(n, 0)
+	head, _ := l.copyPrefix(n)
+	return &List[T]{head: head, size: n}
+}
+
+// ToSlice returns the elements of the list as a slice.
+func (l *List[T]) ToSlice() []T {
+	return slices.AppendSeq(make([]T, 0, l.size), l.Values())
+}
+
+// Updated returns a new list with the element at the given index replaced
+// by v, in O(index). The cells after the index are shared.
+// It panics if the index is out of bounds.
+//
+// example usage:
+//
+//	l := NewList([]int{1,2,3})
+//	l.Updated(0, 10)
+//
+// output:
+//
+//	ImmutableList(int) [10 2 3]
+func (l *List[T]) Updated(index int, v T) *List[T] {
+	if index < 0 || index >= l.size {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	updated := &cell[T]{value: v, next: l.cellAt(index).next}
+	head, last := l.copyPrefix(index)
+	if last == nil {
+		return &List[T]{head: updated, size: l.size}
+	}
+	last.next = updated
+	return &List[T]{head: head, size: l.size}
+}
+
+// implement the Stringer interface
+func (l *List[T]) String() string {
+	return fmt.Sprintf("ImmutableList(%T) %v", *new(T), l.ToSlice())
+}
+
+// cellAt returns the cell at the given index, or nil at the end of the list.
+func (l *List[T]) cellAt(index int) *cell[T] {
+	c := l.head
+	for range index {
+		c = c.next
+	}
+	return c
+}
+
+// copyPrefix copies the first n cells of the list and returns the first
+// and last copies, the last one not being linked to anything yet.
+func (l *List[T]) copyPrefix(n int) (head, last *cell[T]) {
+	for c := l.head; n > 0; c, n = c.next, n-1 {
+		copied := &cell[T]{value: c.value}
+		if last == nil {
+			head = copied
+		} else {
+			last.next = copied
+		}
+		last = copied
+	}
+	return head, last
+}
@@ -0,0 +1,141 @@
+package immutable
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/collectiontest"
+	"github.com/charbz/gophers/list"
+)
+
+func TestList_MatchesList(t *testing.T) {
+	ops := collectiontest.OrderedOps[int, collection.OrderedCollection[int]](func(r *rand.Rand) int { return r.Intn(50) })
+	collectiontest.Check(t,
+		func() collection.OrderedCollection[int] { return list.NewList[int]() },
+		func() collection.OrderedCollection[int] { return NewList[int]() },
+		ops, collectiontest.Options[int]{Steps: 300},
+	)
+}
+
+func TestList_Persistence(t *testing.T) {
+	base := NewList([]int{1, 2, 3})
+	tests := []struct {
+		name    string
+		derive  func(*List[int]) *List[int]
+		want    []int
+		shareAt int // index in the derived list of the first cell shared with base, -1 if none
+	}{
+		{name: "prepend", derive: func(l *List[int]) *List[int] { return l.Prepend(0) }, want: []int{0, 1, 2, 3}, shareAt: 1},
+		{name: "append", derive: func(l *List[int]) *List[int] { return l.Append(4) }, want: []int{1, 2, 3, 4}, shareAt: -1},
+		{name: "tail", derive: (*List[int]).Tail, want: []int{2, 3}, shareAt: 0},
+		{name: "drop", derive: func(l *List[int]) *List[int] { return l.Drop(2) }, want: []int{3}, shareAt: 0},
+		{name: "take", derive: func(l *List[int]) *List[int] { return l.Take(2) }, want: []int{1, 2}, shareAt: -1},
+		{name: "updated", derive: func(l *List[int]) *List[int] { return l.Updated(1, 20) }, want: []int{1, 20, 3}, shareAt: 2},
+		{name: "updated head", derive: func(l *List[int]) *List[int] { return l.Updated(0, 10) }, want: []int{10, 2, 3}, shareAt: 1},
+		{name: "concat", derive: func(l *List[int]) *List[int] { return NewList([]int{0}).Concat(l) }, want: []int{0, 1, 2, 3}, shareAt: 1},
+		{name: "reverse", derive: (*List[int]).Reverse, want: []int{3, 2, 1}, shareAt: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			derived := tt.derive(base)
+			if got := derived.ToSlice(); !slices.Equal(got, tt.want) || derived.Length() != len(tt.want) {
+				t.Errorf("derived = %v with length %v, want %v", got, derived.Length(), tt.want)
+			}
+			if got := base.ToSlice(); !slices.Equal(got, []int{1, 2, 3}) {
+				t.Errorf("base modified to %v", got)
+			}
+			if tt.shareAt >= 0 {
+				shared := derived.cellAt(tt.shareAt)
+				if shared != base.cellAt(base.size-derived.size+tt.shareAt) {
+					t.Errorf("derived list does not share cells from index %d", tt.shareAt)
+				}
+			}
+		})
+	}
+}
+
+func TestList_Add(t *testing.T) {
+	l := NewList([]int{1})
+	snapshot := *l
+	l.Add(2)
+	if !slices.Equal(l.ToSlice(), []int{1, 2}) || !slices.Equal(snapshot.ToSlice(), []int{1}) {
+		t.Errorf("Add() = %v, snapshot %v", l, &snapshot)
+	}
+}
+
+func TestList_AddToDerived(t *testing.T) {
+	tests := []struct {
+		name   string
+		base   *List[int]
+		derive func(*List[int]) *List[int]
+		want   []int
+	}{
+		{name: "take all", base: NewList([]int{1, 2}), derive: func(l *List[int]) *List[int] { return l.Take(5) }, want: []int{1, 2}},
+		{name: "tail of empty", base: NewList[int](), derive: (*List[int]).Tail, want: []int{}},
+		{name: "concat to empty", base: NewList([]int{1, 2}), derive: func(l *List[int]) *List[int] { return NewList[int]().Concat(l) }, want: []int{1, 2}},
+		{name: "drop none", base: NewList([]int{1, 2}), derive: func(l *List[int]) *List[int] { return l.Drop(0) }, want: []int{1, 2}},
+		{name: "slice all", base: NewList([]int{1, 2}), derive: func(l *List[int]) *List[int] { return l.Slice(0, 2).(*List[int]) }, want: []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			derived := tt.derive(tt.base)
+			derived.Add(3)
+			if got := tt.base.ToSlice(); !slices.Equal(got, tt.want) {
+				t.Errorf("base modified to %v by Add on a derived list", got)
+			}
+			if got := derived.ToSlice(); !slices.Equal(got, append(tt.want, 3)) {
+				t.Errorf("derived = %v, want %v", got, append(tt.want, 3))
+			}
+		})
+	}
+}
+
+func TestList_Bounds(t *testing.T) {
+	l := NewList([]int{1, 2})
+	tests := []struct {
+		name string
+		call func()
+	}{
+		{name: "at", call: func() { l.At(2) }},
+		{name: "updated", call: func() { l.Updated(-1, 0) }},
+		{name: "slice", call: func() { l.Slice(1, 3) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != collection.IndexOutOfBoundsError {
+					t.Errorf("recovered %v, want %v", r, collection.IndexOutOfBoundsError)
+				}
+			}()
+			tt.call()
+		})
+	}
+	if _, err := NewList[int]().Head(); err != collection.EmptyCollectionError {
+		t.Errorf("Head() on empty list error = %v", err)
+	}
+	if got := l.String(); got != "ImmutableList(int) [1 2]" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestList_ConcurrentReaders(t *testing.T) {
+	base := NewList([]int{1, 2, 3})
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			derived := base.Prepend(i).Updated(2, i).Tail()
+			if derived.Length() != 3 || derived.At(1) != i {
+				t.Errorf("derived = %v", derived)
+			}
+		}()
+	}
+	wg.Wait()
+	if !slices.Equal(base.ToSlice(), []int{1, 2, 3}) {
+		t.Errorf("base modified to %v", base)
+	}
+}
@@ -0,0 +1,359 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package indexedlist implements a list combining the O(1) operations at
+// both ends of a List with fast random access and insertion.
+//
+// An IndexedList is a block-linked list: its elements are split into blocks
+// of about √n elements, each block being a Deque. At, Set, InsertAt and
+// RemoveAt locate the block holding an index in O(√n) and update it in
+// O(√n), while the operations at either end run in amortized O(1):
+//
+//	l := indexedlist.NewIndexedList(lines)
+//	l.InsertAt(l.Length()/2, "inserted")
+//	l.AddFirst("header")
+//	l.At(1_000)
+package indexedlist
+
+import (
+	"fmt"
+	"iter"
+	"math"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/deque"
+)
+
+// minBlockSize is the size of the blocks of small lists.
+const minBlockSize = 32
+
+// IndexedList is an ordered collection split into blocks of about √n
+// elements. The zero value is an empty list ready to use.
+// An IndexedList is not safe for concurrent use.
+type IndexedList[T any] struct {
+	blocks deque.Deque[*deque.Deque[T]] // never holds an empty block
+	size   int
+	collection.Randomized
+}
+
+// NewIndexedList returns a new list holding the elements of the given slices.
+func NewIndexedList[T any](s ...[]T) *IndexedList[T] {
+	l := new(IndexedList[T])
+	for _, slice := range s {
+		for _, v := range slice {
+			l.Add(v)
+		}
+	}
+	return l
+}
+
+// The following methods implement
+// the Collection interface.
+
+// Add adds a value to the end of the list.
+func (l *IndexedList[T]) Add(v T) {
+	last, err := l.blocks.PeekBack()
+	if err != nil || last.Length() >= l.blockSize() {
+		last = new(deque.Deque[T])
+		l.blocks.PushBack(last)
+	}
+	last.PushBack(v)
+	l.size++
+}
+
+// Length returns the number of elements in the list.
+func (l *IndexedList[T]) Length() int {
+	return l.size
+}
+
+// New returns a new indexed list.
+func (l *IndexedList[T]) New(s ...[]T) collection.Collection[T] {
+	return NewIndexedList(s...)
+}
+
+// Random returns a random element from the list.
+func (l *IndexedList[T]) Random() T {
+	if l.size == 0 {
+		panic(collection.EmptyCollectionError)
+	}
+	return l.At(l.RandState().IntN(l.size))
+}
+
+// Values returns an iterator over all elements of the list.
+func (l *IndexedList[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, block := range l.blocks.All() {
+			for _, v := range block.All() {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// The following methods implement
+// the OrderedCollection interface.
+
+// At returns the element at the given index in O(√n).
+// It panics if the index is out of bounds.
+func (l *IndexedList[T]) At(index int) T {
+	if index < 0 || index >= l.size {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	block, offset := l.locate(index)
+	return l.blocks.At(block).At(offset)
+}
+
+// All returns an iterator over all elements of the list and their indices.
+func (l *IndexedList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range l.Values() {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Backward returns an iterator over all elements of the list
+// and their indices in reverse order.
+func (l *IndexedList[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := l.size - 1
+		for _, block := range l.blocks.Backward() {
+			for _, v := range block.Backward() {
+				if !yield(i, v) {
+					return
+				}
+				i--
+			}
+		}
+	}
+}
+
+// NewOrdered returns a new indexed list.
+func (l *IndexedList[T]) NewOrdered(s ...[]T) collection.OrderedCollection[T] {
+	return NewIndexedList(s...)
+}
+
+// Slice returns a new list containing the elements between the start and end indices.
+func (l *IndexedList[T]) Slice(start, end int) collection.OrderedCollection[T] {
+	if start < 0 || end > l.size || start > end {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	r := NewIndexedList[T]()
+	for i, v := range l.All() {
+		if i >= end {
+			break
+		}
+		if i >= start {
+			r.Add(v)
+		}
+	}
+	return r
+}
+
+// The following methods are specific to the IndexedList type.
+
+// AddFirst adds a value to the beginning of the list.
+func (l *IndexedList[T]) AddFirst(v T) {
+	first, err := l.blocks.PeekFront()
+	if err != nil || first.Length() >= l.blockSize() {
+		first = new(deque.Deque[T])
+		l.blocks.PushFront(first)
+	}
+	first.PushFront(v)
+	l.size++
+}
+
+// Dequeue removes and returns the first element of the list.
+func (l *IndexedList[T]) Dequeue() (T, error) {
+	first, err := l.blocks.PeekFront()
+	if err != nil {
+		return *new(T), collection.EmptyCollectionError
+	}
+	v, _ := first.PopFront()
+	if first.IsEmpty() {
+		l.blocks.PopFront()
+	}
+	l.size--
+	return v, nil
+}
+
+// InsertAt inserts a value at the given index in O(√n), shifting the
+// following elements to the right. An index equal to the length of the
+// list appends the value. It panics if the index is out of bounds.
+//
+// example usage:
+//
+//	l := NewIndexedList([]int{1, 2, 4})
+//	l.InsertAt(2, 3)
+//
+// output:
+//
+//	IndexedList(int) [1 2 3 4]
+func (l *IndexedList[T]) InsertAt(index int, v T) {
+	if index < 0 || index > l.size {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	switch index {
+	case 0:
+		l.AddFirst(v)
+		return
+	case l.size:
+		l.Add(v)
+		return
+	}
+	b, offset := l.locate(index)
+	block := l.blocks.At(b)
+	insertAt(block, offset, v)
+	l.size++
+	if n := block.Length(); n > 2*l.blockSize() {
+		right := new(deque.Deque[T])
+		for block.Length() > n/2 {
+			v, _ := block.PopBack()
+			right.PushFront(v)
+		}
+		insertAt(&l.blocks, b+1, right)
+	}
+}
+
+// IsEmpty returns true if the list is empty.
+func (l *IndexedList[T]) IsEmpty() bool {
+	return l.size == 0
+}
+
+// NonEmpty returns true if the list is not empty.
+func (l *IndexedList[T]) NonEmpty() bool {
+	return l.size > 0
+}
+
+// Pop removes and returns the last element of the list.
+func (l *IndexedList[T]) Pop() (T, error) {
+	last, err := l.blocks.PeekBack()
+	if err != nil {
+		return *new(T), collection.EmptyCollectionError
+	}
+	v, _ := last.PopBack()
+	if last.IsEmpty() {
+		l.blocks.PopBack()
+	}
+	l.size--
+	return v, nil
+}
+
+// RemoveAt removes the element at the given index in O(√n) and returns it.
+// If the index is out of bounds, it returns an IndexOutOfBoundsError.
+func (l *IndexedList[T]) RemoveAt(index int) (T, error) {
+	if index < 0 || index >= l.size {
+		return *new(T), collection.IndexOutOfBoundsError
+	}
+	b, offset := l.locate(index)
+	block := l.blocks.At(b)
+	v := removeAt(block, offset)
+	l.size--
+	switch {
+	case block.IsEmpty():
+		removeAt(&l.blocks, b)
+	case b+1 < l.blocks.Length() && block.Length()+l.blocks.At(b+1).Length() <= l.blockSize():
+		next := removeAt(&l.blocks, b+1)
+		for _, w := range next.All() {
+			block.PushBack(w)
+		}
+	}
+	return v, nil
+}
+
+// Set replaces the element at the given index in O(√n).
+// If the index is out of bounds, it returns an error.
+func (l *IndexedList[T]) Set(index int, v T) error {
+	if index < 0 || index >= l.size {
+		return collection.IndexOutOfBoundsError
+	}
+	block, offset := l.locate(index)
+	l.blocks.At(block).Set(offset, v)
+	return nil
+}
+
+// ToSlice returns a slice containing all the elements of the list.
+func (l *IndexedList[T]) ToSlice() []T {
+	s := make([]T, 0, l.size)
+	for v := range l.Values() {
+		s = append(s, v)
+	}
+	return s
+}
+
+// implement the Stringer interface
+func (l *IndexedList[T]) String() string {
+	return fmt.Sprintf("IndexedList(%T) %v", *new(T), l.ToSlice())
+}
+
+// blockSize returns the target size of the blocks, about √n.
+// Blocks at the ends are filled up to it, blocks in the middle
+// are split when they grow past twice its size.
+func (l *IndexedList[T]) blockSize() int {
+	return max(minBlockSize, int(math.Sqrt(float64(l.size))))
+}
+
+// locate returns the position of the block holding the element at the
+// given index and the offset of the element in the block, walking the
+// blocks from whichever end of the list is closest. The index must be
+// in bounds.
+func (l *IndexedList[T]) locate(index int) (block, offset int) {
+	if index < l.size/2 {
+		for b, blk := range l.blocks.All() {
+			if index < blk.Length() {
+				return b, index
+			}
+			index -= blk.Length()
+		}
+	}
+	fromBack := l.size - 1 - index
+	for b, blk := range l.blocks.Backward() {
+		if fromBack < blk.Length() {
+			return b, blk.Length() - 1 - fromBack
+		}
+		fromBack -= blk.Length()
+	}
+	panic("unreachable")
+}
+
+// insertAt inserts v at index i of the deque, shifting
+// the elements between i and the closest end.
+func insertAt[T any](d *deque.Deque[T], i int, v T) {
+	if i < d.Length()/2 {
+		d.PushFront(v)
+		for j := 0; j < i; j++ {
+			d.Set(j, d.At(j+1))
+		}
+	} else {
+		d.PushBack(v)
+		for j := d.Length() - 1; j > i; j-- {
+			d.Set(j, d.At(j-1))
+		}
+	}
+	d.Set(i, v)
+}
+
+// removeAt removes and returns the element at index i of the deque,
+// shifting the elements between i and the closest end.
+func removeAt[T any](d *deque.Deque[T], i int) T {
+	v := d.At(i)
+	if i < d.Length()/2 {
+		for j := i; j > 0; j-- {
+			d.Set(j, d.At(j-1))
+		}
+		d.PopFront()
+	} else {
+		for j := i; j < d.Length()-1; j++ {
+			d.Set(j, d.At(j+1))
+		}
+		d.PopBack()
+	}
+	return v
+}
@@ -0,0 +1,219 @@
+package indexedlist
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/collectiontest"
+	"github.com/charbz/gophers/list"
+)
+
+// checkBlocks verifies the invariants of the blocks of l.
+func checkBlocks[T any](t *testing.T, l *IndexedList[T]) {
+	t.Helper()
+	total := 0
+	for b, block := range l.blocks.All() {
+		if block.IsEmpty() {
+			t.Fatalf("block %d is empty", b)
+		}
+		if block.Length() > 2*l.blockSize() {
+			t.Fatalf("block %d holds %d elements, want at most %d", b, block.Length(), 2*l.blockSize())
+		}
+		total += block.Length()
+	}
+	if total != l.size {
+		t.Fatalf("blocks hold %d elements, want %d", total, l.size)
+	}
+}
+
+func TestIndexedList_MatchesList(t *testing.T) {
+	ops := collectiontest.OrderedOps[int, collection.OrderedCollection[int]](func(r *rand.Rand) int { return r.Intn(50) })
+	collectiontest.Check(t,
+		func() collection.OrderedCollection[int] { return list.NewList[int]() },
+		func() collection.OrderedCollection[int] { return NewIndexedList[int]() },
+		ops, collectiontest.Options[int]{Steps: 500},
+	)
+}
+
+func TestIndexedList_RandomOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	l := NewIndexedList[int]()
+	var want []int
+	for i := range 20_000 {
+		switch op := r.Intn(6); {
+		case op == 0:
+			l.AddFirst(i)
+			want = slices.Insert(want, 0, i)
+		case op == 1:
+			l.Add(i)
+			want = append(want, i)
+		case op == 2 || op == 3:
+			index := r.Intn(len(want) + 1)
+			l.InsertAt(index, i)
+			want = slices.Insert(want, index, i)
+		case op == 4 && len(want) > 0:
+			index := r.Intn(len(want))
+			got, err := l.RemoveAt(index)
+			if err != nil || got != want[index] {
+				t.Fatalf("RemoveAt(%d) = %v, %v, want %v", index, got, err, want[index])
+			}
+			want = slices.Delete(want, index, index+1)
+		case op == 5 && len(want) > 0:
+			index := r.Intn(len(want))
+			if err := l.Set(index, i); err != nil {
+				t.Fatalf("Set(%d) error = %v", index, err)
+			}
+			want[index] = i
+		}
+		if len(want) > 0 {
+			index := r.Intn(len(want))
+			if got := l.At(index); got != want[index] {
+				t.Fatalf("At(%d) = %v, want %v", index, got, want[index])
+			}
+		}
+	}
+	checkBlocks(t, l)
+	if got := l.ToSlice(); !slices.Equal(got, want) {
+		t.Fatalf("ToSlice() = %v, want %v", got, want)
+	}
+	if l.blocks.Length() > 4*l.blockSize() {
+		t.Errorf("%d elements are split into %d blocks", l.Length(), l.blocks.Length())
+	}
+}
+
+func TestIndexedList_InsertAt(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		index int
+		want  []int
+	}{
+		{name: "empty", input: []int{}, index: 0, want: []int{9}},
+		{name: "first", input: []int{1, 2, 3}, index: 0, want: []int{9, 1, 2, 3}},
+		{name: "middle", input: []int{1, 2, 3}, index: 2, want: []int{1, 2, 9, 3}},
+		{name: "last", input: []int{1, 2, 3}, index: 3, want: []int{1, 2, 3, 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewIndexedList(tt.input)
+			l.InsertAt(tt.index, 9)
+			if got := l.ToSlice(); !slices.Equal(got, tt.want) {
+				t.Errorf("InsertAt(%d) = %v, want %v", tt.index, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("out of bounds", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != collection.IndexOutOfBoundsError {
+				t.Errorf("InsertAt(4) panicked with %v, want IndexOutOfBoundsError", r)
+			}
+		}()
+		NewIndexedList([]int{1, 2, 3}).InsertAt(4, 9)
+	})
+}
+
+func TestIndexedList_RemoveAt(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []int
+		index   int
+		want    int
+		wantErr error
+		rest    []int
+	}{
+		{name: "first", input: []int{1, 2, 3}, index: 0, want: 1, rest: []int{2, 3}},
+		{name: "middle", input: []int{1, 2, 3}, index: 1, want: 2, rest: []int{1, 3}},
+		{name: "last", input: []int{1, 2, 3}, index: 2, want: 3, rest: []int{1, 2}},
+		{name: "out of bounds", input: []int{1, 2, 3}, index: 3, wantErr: collection.IndexOutOfBoundsError, rest: []int{1, 2, 3}},
+		{name: "empty", input: []int{}, index: 0, wantErr: collection.IndexOutOfBoundsError, rest: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewIndexedList(tt.input)
+			got, err := l.RemoveAt(tt.index)
+			if got != tt.want || err != tt.wantErr {
+				t.Errorf("RemoveAt(%d) = %v, %v, want %v, %v", tt.index, got, err, tt.want, tt.wantErr)
+			}
+			if rest := l.ToSlice(); !slices.Equal(rest, tt.rest) {
+				t.Errorf("ToSlice() = %v, want %v", rest, tt.rest)
+			}
+		})
+	}
+}
+
+func TestIndexedList_Set(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []int
+		index   int
+		wantErr error
+		want    []int
+	}{
+		{name: "first", input: []int{1, 2, 3}, index: 0, want: []int{9, 2, 3}},
+		{name: "last", input: []int{1, 2, 3}, index: 2, want: []int{1, 2, 9}},
+		{name: "negative", input: []int{1, 2, 3}, index: -1, wantErr: collection.IndexOutOfBoundsError, want: []int{1, 2, 3}},
+		{name: "out of bounds", input: []int{1, 2, 3}, index: 3, wantErr: collection.IndexOutOfBoundsError, want: []int{1, 2, 3}},
+		{name: "empty", input: []int{}, index: 0, wantErr: collection.IndexOutOfBoundsError, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewIndexedList(tt.input)
+			if err := l.Set(tt.index, 9); err != tt.wantErr {
+				t.Errorf("Set(%d) error = %v, want %v", tt.index, err, tt.wantErr)
+			}
+			if got := l.ToSlice(); !slices.Equal(got, tt.want) {
+				t.Errorf("ToSlice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexedList_Ends(t *testing.T) {
+	var l IndexedList[int]
+	if _, err := l.Pop(); err != collection.EmptyCollectionError {
+		t.Errorf("Pop() on empty list returned %v, want EmptyCollectionError", err)
+	}
+	if _, err := l.Dequeue(); err != collection.EmptyCollectionError {
+		t.Errorf("Dequeue() on empty list returned %v, want EmptyCollectionError", err)
+	}
+	for i := range 100 {
+		l.Add(i)
+		l.AddFirst(-i)
+	}
+	checkBlocks(t, &l)
+	for i := 99; i >= 0; i-- {
+		if v, err := l.Pop(); v != i || err != nil {
+			t.Fatalf("Pop() = %v, %v, want %v", v, err, i)
+		}
+		if v, err := l.Dequeue(); v != -i || err != nil {
+			t.Fatalf("Dequeue() = %v, %v, want %v", v, err, -i)
+		}
+	}
+	if l.NonEmpty() || l.blocks.Length() != 0 {
+		t.Errorf("list is not empty after popping all elements: %v", &l)
+	}
+}
+
+func TestIndexedList_Backward(t *testing.T) {
+	l := NewIndexedList([]int{1, 2, 3})
+	var got []int
+	for i, v := range l.Backward() {
+		if l.At(i) != v {
+			t.Errorf("Backward() yielded %v at index %d, want %v", v, i, l.At(i))
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("Backward() = %v, want [3 2 1]", got)
+	}
+}
+
+func TestIndexedList_String(t *testing.T) {
+	l := NewIndexedList([]int{1, 2, 3})
+	if got := l.String(); got != "IndexedList(int) [1 2 3]" {
+		t.Errorf("String() = %v, want IndexedList(int) [1 2 3]", got)
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package lazy implements lazily evaluated pipelines over collections.
+// Unlike the collection methods, which materialize a new collection at
+// every step, the intermediate operations of a Stream only compose
+// iterators. No element is computed until a terminal operation such as
+// ToList, ToSequence, Reduce or First runs, and terminal operations stop
+// pulling from the source as soon as they have their answer:
+//
+//	s := lazy.Of(orders).
+//	  Filter(func(o Order) bool { return o.Paid }).
+//	  Take(10)
+//	totals := lazy.Map(s, func(o Order) float64 { return o.Total }).ToSequence()
+//
+// A Stream can be consumed several times when its source can, which is
+// the case for every collection in this library.
+package lazy
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/list"
+	"github.com/charbz/gophers/seq"
+	"github.com/charbz/gophers/sequence"
+)
+
+// Stream is a lazily evaluated pipeline of values of type T.
+type Stream[T any] struct {
+	values iter.Seq[T]
+}
+
+// Of returns a stream over the values of a collection.
+//
+// example usage:
+//
+//	Of(NewSequence([]int{1,2,3})).ToSlice()
+//
+// output:
+//
+//	[1 2 3]
+func Of[T any](c collection.Collection[T]) *Stream[T] {
+	return &Stream[T]{values: c.Values()}
+}
+
+// FromSeq returns a stream over the values of an iterator.
+func FromSeq[T any](s iter.Seq[T]) *Stream[T] {
+	return &Stream[T]{values: s}
+}
+
+// Map returns a stream that applies f to each value of s.
+//
+// example usage:
+//
+//	Map(Of(NewSequence([]string{"go","rust"})), func(s string) int { return len(s) }).ToSlice()
+//
+// output:
+//
+//	[2 4]
+func Map[T, U any](s *Stream[T], f func(T) U) *Stream[U] {
+	return &Stream[U]{values: seq.Map(s.values, f)}
+}
+
+// Distinct returns a stream that yields the first occurrence of each value of s.
+func Distinct[T comparable](s *Stream[T]) *Stream[T] {
+	return &Stream[T]{values: seq.Distinct(s.values)}
+}
+
+// Zip returns a stream of pairs of corresponding values of a and b.
+// The stream ends when the shorter of the two is exhausted.
+//
+// example usage:
+//
+//	a := Of(NewSequence([]string{"a","b","c"}))
+//	b := Of(NewSequence([]int{1,2}))
+//	Zip(a, b).ToSlice()
+//
+// output:
+//
+//	[(a, 1) (b, 2)]
+func Zip[A, B any](a *Stream[A], b *Stream[B]) *Stream[collection.Pair[A, B]] {
+	return &Stream[collection.Pair[A, B]]{
+		values: func(yield func(collection.Pair[A, B]) bool) {
+			for x, y := range seq.Zip(a.values, b.values) {
+				if !yield(collection.Pair[A, B]{First: x, Second: y}) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// Fold applies f to each value of the stream, starting from init,
+// and returns the accumulated result.
+//
+// example usage:
+//
+//	Fold(Of(NewSequence([]string{"go","is","fun"})), 0, func(acc int, s string) int { return acc + len(s) })
+//
+// output:
+//
+//	7
+func Fold[T, U any](s *Stream[T], init U, f func(U, T) U) U {
+	return seq.Reduce(s.values, f, init)
+}
+
+// The following methods are intermediate operations,
+// they return a new stream without evaluating any value.
+
+// DistinctFunc returns a stream that skips values equal to
+// a previously yielded value according to f.
+func (s *Stream[T]) DistinctFunc(f func(T, T) bool) *Stream[T] {
+	return &Stream[T]{values: seq.DistinctFunc(s.values, f)}
+}
+
+// Drop returns a stream that skips the first n values.
+func (s *Stream[T]) Drop(n int) *Stream[T] {
+	return &Stream[T]{values: seq.Drop(s.values, n)}
+}
+
+// DropRight returns a stream that skips the last n values. It holds back
+// n values at a time, see seq.DropLast, so the stream is not materialized.
+func (s *Stream[T]) DropRight(n int) *Stream[T] {
+	return &Stream[T]{values: seq.DropLast(s.values, n)}
+}
+
+// DropWhile returns a stream that skips leading values satisfying the predicate.
+func (s *Stream[T]) DropWhile(f func(T) bool) *Stream[T] {
+	return &Stream[T]{values: seq.DropWhile(s.values, f)}
+}
+
+// Filter returns a stream of the values that satisfy the predicate.
+func (s *Stream[T]) Filter(f func(T) bool) *Stream[T] {
+	return &Stream[T]{values: seq.Filter(s.values, f)}
+}
+
+// Reject returns a stream of the values that do not satisfy the predicate.
+func (s *Stream[T]) Reject(f func(T) bool) *Stream[T] {
+	return &Stream[T]{values: seq.Reject(s.values, f)}
+}
+
+// Take returns a stream of at most the first n values.
+func (s *Stream[T]) Take(n int) *Stream[T] {
+	return &Stream[T]{values: seq.Take(s.values, n)}
+}
+
+// TakeRight returns a stream of at most the last n values. Evaluating it
+// reads the whole source but only keeps n values in memory, see seq.LastN.
+//
+// example usage:
+//
+//	FromSeq(lines).TakeRight(10).ToSlice() // like tail -n 10
+func (s *Stream[T]) TakeRight(n int) *Stream[T] {
+	return &Stream[T]{values: seq.LastN(s.values, n)}
+}
+
+// TakeWhile returns a stream of the leading values satisfying the predicate.
+func (s *Stream[T]) TakeWhile(f func(T) bool) *Stream[T] {
+	return &Stream[T]{values: seq.TakeWhile(s.values, f)}
+}
+
+// The following methods are terminal operations,
+// they evaluate the pipeline.
+
+// Count returns the number of values in the stream.
+func (s *Stream[T]) Count() int {
+	return seq.Count(s.values, func(T) bool { return true })
+}
+
+// First returns the first value of the stream,
+// or an error if the stream is empty.
+//
+// example usage:
+//
+//	Of(NewSequence([]int{1,2,3,4})).Filter(func(i int) bool { return i > 2 }).First()
+//
+// output:
+//
+//	3, nil
+func (s *Stream[T]) First() (T, error) {
+	for v := range s.values {
+		return v, nil
+	}
+	return *new(T), collection.EmptyCollectionError
+}
+
+// ForEach calls f on each value of the stream.
+func (s *Stream[T]) ForEach(f func(T)) {
+	for v := range s.values {
+		f(v)
+	}
+}
+
+// Reduce combines the values of the stream from left to right using f,
+// starting from the first value. If the stream is empty, it returns
+// the zero value and an error.
+//
+// example usage:
+//
+//	Of(NewSequence([]int{1,2,3})).Reduce(func(a, b int) int { return a + b })
+//
+// output:
+//
+//	6, nil
+func (s *Stream[T]) Reduce(f func(T, T) T) (T, error) {
+	var (
+		acc     T
+		started bool
+	)
+	for v := range s.values {
+		if !started {
+			acc, started = v, true
+			continue
+		}
+		acc = f(acc, v)
+	}
+	if !started {
+		return acc, collection.EmptyCollectionError
+	}
+	return acc, nil
+}
+
+// ToList collects the values of the stream into a new List.
+func (s *Stream[T]) ToList() *list.List[T] {
+	return collection.CollectInto(s.values, list.NewList[T]())
+}
+
+// ToSequence collects the values of the stream into a new Sequence.
+func (s *Stream[T]) ToSequence() *sequence.Sequence[T] {
+	return collection.CollectInto(s.values, sequence.NewSequence[T]())
+}
+
+// ToSlice collects the values of the stream into a new slice.
+func (s *Stream[T]) ToSlice() []T {
+	return slices.Collect(s.values)
+}
+
+// Values returns an iterator over the values of the stream.
+func (s *Stream[T]) Values() iter.Seq[T] {
+	return s.values
+}
@@ -0,0 +1,106 @@
+package lazy
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/list"
+	"github.com/charbz/gophers/sequence"
+)
+
+func TestStream_Pipeline(t *testing.T) {
+	isEven := func(i int) bool { return i%2 == 0 }
+	src := sequence.NewSequence([]int{1, 2, 3, 4, 5, 6, 7, 8, 2, 4})
+	tests := []struct {
+		name string
+		got  *Stream[int]
+		want []int
+	}{
+		{name: "filter", got: Of(src).Filter(isEven), want: []int{2, 4, 6, 8, 2, 4}},
+		{name: "reject", got: Of(src).Reject(isEven), want: []int{1, 3, 5, 7}},
+		{name: "take", got: Of(src).Take(3), want: []int{1, 2, 3}},
+		{name: "drop", got: Of(src).Drop(7), want: []int{8, 2, 4}},
+		{name: "take while", got: Of(src).TakeWhile(func(i int) bool { return i < 4 }), want: []int{1, 2, 3}},
+		{name: "drop while", got: Of(src).DropWhile(func(i int) bool { return i < 7 }), want: []int{7, 8, 2, 4}},
+		{name: "distinct", got: Distinct(Of(src).Filter(isEven)), want: []int{2, 4, 6, 8}},
+		{name: "distinct func", got: Of(src).DistinctFunc(func(a, b int) bool { return a%3 == b%3 }), want: []int{1, 2, 3}},
+		{name: "map", got: Map(Of(src).Take(2), func(i int) int { return i * 10 }), want: []int{10, 20}},
+		{name: "take right", got: Of(src).TakeRight(3), want: []int{8, 2, 4}},
+		{name: "drop right", got: Of(src).DropRight(7), want: []int{1, 2, 3}},
+		{name: "filter take right", got: Of(src).Filter(isEven).TakeRight(2), want: []int{2, 4}},
+		{name: "chained", got: Of(src).Filter(isEven).Drop(1).Take(2), want: []int{4, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.ToSlice(); !slices.Equal(got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStream_Lazy(t *testing.T) {
+	calls := 0
+	s := Map(Of(list.NewList([]int{1, 2, 3, 4, 5})), func(i int) int {
+		calls++
+		return i * i
+	}).Filter(func(i int) bool { return i > 3 })
+	if calls != 0 {
+		t.Fatalf("Map() evaluated %d values before a terminal operation", calls)
+	}
+	v, err := s.First()
+	if err != nil || v != 4 {
+		t.Errorf("First() = %v, %v, want 4, nil", v, err)
+	}
+	if calls != 2 {
+		t.Errorf("First() evaluated %d values, want 2", calls)
+	}
+}
+
+func TestZip(t *testing.T) {
+	a := Of(list.NewList([]string{"a", "b", "c"}))
+	b := Of(sequence.NewSequence([]int{1, 2}))
+	got := Zip(a, b).ToSlice()
+	want := []collection.Pair[string, int]{{First: "a", Second: 1}, {First: "b", Second: 2}}
+	if !slices.Equal(got, want) {
+		t.Errorf("Zip() = %v, want %v", got, want)
+	}
+}
+
+func TestStream_Terminals(t *testing.T) {
+	s := Of(sequence.NewSequence([]int{1, 2, 3, 4}))
+	if got := s.ToList(); !slices.Equal(got.ToSlice(), []int{1, 2, 3, 4}) {
+		t.Errorf("ToList() = %v, want [1 2 3 4]", got)
+	}
+	if got := s.Filter(func(i int) bool { return i > 2 }).ToSequence(); !slices.Equal(got.ToSlice(), []int{3, 4}) {
+		t.Errorf("ToSequence() = %v, want [3 4]", got)
+	}
+	if got, err := s.Reduce(func(a, b int) int { return a + b }); err != nil || got != 10 {
+		t.Errorf("Reduce() = %v, %v, want 10, nil", got, err)
+	}
+	if got := Fold(s, "", func(acc string, i int) string { return acc + string(rune('0'+i)) }); got != "1234" {
+		t.Errorf("Fold() = %q, want %q", got, "1234")
+	}
+	if got := s.Count(); got != 4 {
+		t.Errorf("Count() = %v, want 4", got)
+	}
+	sum := 0
+	s.ForEach(func(i int) { sum += i })
+	if sum != 10 {
+		t.Errorf("ForEach() sum = %v, want 10", sum)
+	}
+}
+
+func TestStream_Empty(t *testing.T) {
+	s := FromSeq(slices.Values([]int{}))
+	if _, err := s.First(); err != collection.EmptyCollectionError {
+		t.Errorf("First() error = %v, want %v", err, collection.EmptyCollectionError)
+	}
+	if _, err := s.Reduce(func(a, b int) int { return a + b }); err != collection.EmptyCollectionError {
+		t.Errorf("Reduce() error = %v, want %v", err, collection.EmptyCollectionError)
+	}
+	if got := s.ToList().Length(); got != 0 {
+		t.Errorf("ToList() length = %v, want 0", got)
+	}
+}
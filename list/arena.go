@@ -0,0 +1,78 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package list
+
+// arena allocates the nodes of a list from chunks of consecutive nodes,
+// replacing one heap allocation per element with one per chunk.
+type arena[T any] struct {
+	chunkSize int
+	free      []Node[T] // unallocated nodes of the current chunk
+}
+
+// alloc returns a detached node holding v.
+func (a *arena[T]) alloc(v T) *Node[T] {
+	if len(a.free) == 0 {
+		a.free = make([]Node[T], a.chunkSize)
+	}
+	node := &a.free[0]
+	a.free = a.free[1:]
+	node.value = v
+	return node
+}
+
+// NewArenaList returns a list holding the elements of the given slices,
+// whose nodes are allocated in chunks of chunkSize nodes rather than one
+// at a time. It is an experimental mode for huge, short-lived lists where
+// the cost of garbage collection dominates: fewer, larger allocations are
+// cheaper to make and to scan, and Free releases the whole list at once.
+//
+// A chunk is only reclaimed once none of its nodes is reachable, so the
+// memory of removed elements is not reused, and a single node handle kept
+// from AddNode retains its entire chunk. Lists derived from an arena list,
+// i.e. by Clone or Filter, use regular allocations.
+// It panics if chunkSize is less than 1.
+//
+// example usage:
+//
+//	l := NewArenaList(4096, rows)
+//	defer l.Free()
+//	totals := GroupBy(l, func(r Row) string { return r.Region })
+func NewArenaList[T any](chunkSize int, s ...[]T) *List[T] {
+	if chunkSize < 1 {
+		panic("list: chunk size must be at least 1")
+	}
+	l := &List[T]{arena: &arena[T]{chunkSize: chunkSize}}
+	for _, slice := range s {
+		for _, v := range slice {
+			l.Add(v)
+		}
+	}
+	return l
+}
+
+// Free removes all the elements of the list at once, releasing the chunks
+// of an arena list to the garbage collector as soon as no node handle
+// refers to them. The list remains usable.
+func (l *List[T]) Free() {
+	if l.onRemove != nil {
+		for node := l.head; node != nil; node = node.next {
+			l.onRemove(node.value)
+		}
+	}
+	l.head, l.tail, l.size = nil, nil, 0
+	l.forgetPosition()
+	if l.arena != nil {
+		l.arena.free = nil
+	}
+}
+
+// newNode returns a detached node holding v, allocated
+// from the arena of the list if it has one.
+func (l *List[T]) newNode(v T) *Node[T] {
+	if l.arena == nil {
+		return &Node[T]{value: v}
+	}
+	return l.arena.alloc(v)
+}
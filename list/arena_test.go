@@ -0,0 +1,71 @@
+package list
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/collectiontest"
+)
+
+func TestArenaList_MatchesList(t *testing.T) {
+	ops := collectiontest.OrderedOps[int, collection.OrderedCollection[int]](func(r *rand.Rand) int { return r.Intn(50) })
+	collectiontest.Check(t,
+		func() collection.OrderedCollection[int] { return NewList[int]() },
+		func() collection.OrderedCollection[int] { return NewArenaList[int](3) },
+		ops, collectiontest.Options[int]{Steps: 500},
+	)
+}
+
+func TestArenaList_Allocations(t *testing.T) {
+	tests := []struct {
+		name string
+		new  func() *List[int]
+		max  float64
+	}{
+		{name: "arena", new: func() *List[int] { return NewArenaList[int](64) }, max: 3},
+		{name: "regular", new: func() *List[int] { return NewList[int]() }, max: 65},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(10, func() {
+				l := tt.new()
+				for i := range 64 {
+					l.Add(i)
+				}
+			})
+			if allocs > tt.max {
+				t.Errorf("adding 64 elements made %v allocations, want at most %v", allocs, tt.max)
+			}
+		})
+	}
+}
+
+func TestArenaList_Free(t *testing.T) {
+	l := NewArenaList(2, []int{1, 2, 3})
+	l.InsertAt(1, 9)
+	it := l.Iterator()
+	it.Next()
+	it.InsertAfter(8)
+	if got := l.ToSlice(); !slices.Equal(got, []int{1, 8, 9, 2, 3}) {
+		t.Errorf("ToSlice() = %v, want [1 8 9 2 3]", got)
+	}
+	l.Free()
+	if l.Length() != 0 || !l.IsEmpty() {
+		t.Errorf("Free() left %v", l)
+	}
+	l.Add(4)
+	if got := l.ToSlice(); !slices.Equal(got, []int{4}) {
+		t.Errorf("ToSlice() after Free = %v, want [4]", got)
+	}
+}
+
+func TestNewArenaList_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewArenaList(0) did not panic")
+		}
+	}()
+	NewArenaList[int](0)
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// batch.go defines the transactional updates of a SyncList: Txn and Batch
+// apply several mutations atomically, or none of them.
+
+package list
+
+import "github.com/charbz/gophers/collection"
+
+// Txn calls f with a working copy of the list while holding the lock.
+// If f returns nil, the copy replaces the contents of the list, otherwise
+// the error is returned and the list is left untouched, as it is when f
+// panics. Unlike Do, a multi-step update failing halfway is rolled back,
+// at the cost of copying the list. The OnRemove function of the list is
+// called with the elements removed by f once the update is committed.
+//
+// example usage:
+//
+//	err := accounts.Txn(func(l *List[int]) error {
+//	  if err := l.Set(0, balance-amount); err != nil {
+//	    return err
+//	  }
+//	  return l.Set(1, other+amount)
+//	})
+func (l *SyncList[T]) Txn(f func(*List[T]) error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	working := l.list.Clone()
+	var removed []T
+	working.OnRemove(func(v T) { removed = append(removed, v) })
+	if err := f(working); err != nil {
+		return err
+	}
+	l.list.replaceNodes(working)
+	for _, v := range removed {
+		l.list.removed(v)
+	}
+	l.notify()
+	return nil
+}
+
+// Batch records mutations of a SyncList without applying them, until
+// Commit applies them all atomically. It is built by SyncList.Batch and is
+// not safe for concurrent use, unlike the list it applies to.
+type Batch[T any] struct {
+	list *SyncList[T]
+	ops  []func(*List[T]) error
+}
+
+// Batch returns an empty batch of mutations of the list.
+//
+// example usage:
+//
+//	l := NewSyncList([]int{1,2,3})
+//	err := l.Batch().AddFirst(0).RemoveAt(5).Add(4).Commit()
+//
+// output:
+//
+//	error 102: index out of bounds
+//	SyncList(int) [1 2 3]
+func (l *SyncList[T]) Batch() *Batch[T] {
+	return &Batch[T]{list: l}
+}
+
+// Add records adding a value to the end of the list.
+func (b *Batch[T]) Add(v T) *Batch[T] {
+	return b.Do(func(l *List[T]) error {
+		l.Add(v)
+		return nil
+	})
+}
+
+// AddFirst records adding a value to the beginning of the list.
+func (b *Batch[T]) AddFirst(v T) *Batch[T] {
+	return b.Do(func(l *List[T]) error {
+		l.AddFirst(v)
+		return nil
+	})
+}
+
+// Commit applies the recorded mutations in order, atomically, see
+// SyncList.Txn. The first mutation returning an error rolls back all the
+// others, and its error is returned. The batch is emptied either way.
+func (b *Batch[T]) Commit() error {
+	ops := b.ops
+	b.ops = nil
+	return b.list.Txn(func(l *List[T]) error {
+		for _, op := range ops {
+			if err := op(l); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Dequeue records removing the first element, failing with
+// collection.EmptyCollectionError if the list is empty at that point.
+func (b *Batch[T]) Dequeue() *Batch[T] {
+	return b.Do(func(l *List[T]) error {
+		_, err := l.Dequeue()
+		return err
+	})
+}
+
+// Do records an arbitrary mutation. An error returned by f fails the commit.
+func (b *Batch[T]) Do(f func(*List[T]) error) *Batch[T] {
+	b.ops = append(b.ops, f)
+	return b
+}
+
+// InsertAt records inserting a value at the given index, failing with
+// collection.IndexOutOfBoundsError if the index is out of bounds at that point.
+func (b *Batch[T]) InsertAt(index int, v T) *Batch[T] {
+	return b.Do(func(l *List[T]) error {
+		if index < 0 || index > l.Length() {
+			return collection.IndexOutOfBoundsError
+		}
+		l.InsertAt(index, v)
+		return nil
+	})
+}
+
+// Length returns the number of recorded mutations.
+func (b *Batch[T]) Length() int {
+	return len(b.ops)
+}
+
+// Pop records removing the last element, failing with
+// collection.EmptyCollectionError if the list is empty at that point.
+func (b *Batch[T]) Pop() *Batch[T] {
+	return b.Do(func(l *List[T]) error {
+		_, err := l.Pop()
+		return err
+	})
+}
+
+// RemoveAt records removing the element at the given index, failing with
+// collection.IndexOutOfBoundsError if the index is out of bounds at that point.
+func (b *Batch[T]) RemoveAt(index int) *Batch[T] {
+	return b.Do(func(l *List[T]) error {
+		_, err := l.RemoveAt(index)
+		return err
+	})
+}
+
+// Rollback discards the recorded mutations.
+func (b *Batch[T]) Rollback() {
+	b.ops = nil
+}
+
+// Set records replacing the value at the given index, failing with
+// collection.IndexOutOfBoundsError if the index is out of bounds at that point.
+func (b *Batch[T]) Set(index int, v T) *Batch[T] {
+	return b.Do(func(l *List[T]) error {
+		return l.Set(index, v)
+	})
+}
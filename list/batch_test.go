@@ -0,0 +1,126 @@
+package list
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+)
+
+func TestSyncList_Txn(t *testing.T) {
+	errAbort := errors.New("abort")
+	tests := []struct {
+		name    string
+		f       func(*List[int]) error
+		want    []int
+		wantErr error
+	}{
+		{name: "commit", f: func(l *List[int]) error { l.Add(4); return l.Set(0, 0) }, want: []int{0, 2, 3, 4}},
+		{name: "rollback", f: func(l *List[int]) error { l.Add(4); l.Dequeue(); return errAbort }, want: []int{1, 2, 3}, wantErr: errAbort},
+		{name: "failing step", f: func(l *List[int]) error { l.Add(4); return l.Set(9, 0) }, want: []int{1, 2, 3}, wantErr: collection.IndexOutOfBoundsError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewSyncList([]int{1, 2, 3})
+			if err := l.Txn(tt.f); err != tt.wantErr {
+				t.Errorf("Txn() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := l.ToSlice(); !slices.Equal(got, tt.want) {
+				t.Errorf("Txn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncList_TxnPanic(t *testing.T) {
+	l := NewSyncList([]int{1, 2, 3})
+	func() {
+		defer func() { recover() }()
+		l.Txn(func(l *List[int]) error {
+			l.Add(4)
+			l.InsertAt(9, 0)
+			return nil
+		})
+	}()
+	if got := l.ToSlice(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("list after a panicking Txn = %v", got)
+	}
+	l.Add(4) // the lock was released
+}
+
+func TestBatch_Commit(t *testing.T) {
+	tests := []struct {
+		name    string
+		batch   func(*Batch[int]) *Batch[int]
+		want    []int
+		wantErr error
+	}{
+		{name: "all applied", batch: func(b *Batch[int]) *Batch[int] {
+			return b.AddFirst(0).Add(4).InsertAt(2, 9).RemoveAt(1).Set(0, -1)
+		}, want: []int{-1, 9, 2, 3, 4}},
+		{name: "out of bounds insert", batch: func(b *Batch[int]) *Batch[int] { return b.Add(4).InsertAt(9, 0) }, want: []int{1, 2, 3}, wantErr: collection.IndexOutOfBoundsError},
+		{name: "removing too many", batch: func(b *Batch[int]) *Batch[int] { return b.Pop().Dequeue().Pop().Dequeue() }, want: []int{1, 2, 3}, wantErr: collection.EmptyCollectionError},
+		{name: "steps see earlier ones", batch: func(b *Batch[int]) *Batch[int] { return b.Add(4).Set(3, 40) }, want: []int{1, 2, 3, 40}},
+		{name: "empty", batch: func(b *Batch[int]) *Batch[int] { return b }, want: []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewSyncList([]int{1, 2, 3})
+			b := tt.batch(l.Batch())
+			if got := l.ToSlice(); !slices.Equal(got, []int{1, 2, 3}) {
+				t.Errorf("list modified before Commit: %v", got)
+			}
+			if err := b.Commit(); err != tt.wantErr {
+				t.Errorf("Commit() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := l.ToSlice(); !slices.Equal(got, tt.want) {
+				t.Errorf("Commit() = %v, want %v", got, tt.want)
+			}
+			if b.Length() != 0 {
+				t.Errorf("Length() after Commit() = %v", b.Length())
+			}
+		})
+	}
+}
+
+func TestBatch_Rollback(t *testing.T) {
+	l := NewSyncList([]int{1})
+	b := l.Batch().Add(2).Add(3)
+	if b.Length() != 2 {
+		t.Errorf("Length() = %v, want 2", b.Length())
+	}
+	b.Rollback()
+	if err := b.Commit(); err != nil || l.Length() != 1 {
+		t.Errorf("Commit() after Rollback() = %v, %v", err, l)
+	}
+}
+
+func TestSyncList_TxnConcurrent(t *testing.T) {
+	// moving units between two counters keeps their sum constant
+	l := NewSyncList([]int{500, 500})
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				l.Txn(func(l *List[int]) error {
+					if err := l.Set(0, l.At(0)-1); err != nil {
+						return err
+					}
+					return l.Set(1, l.At(1)+1)
+				})
+				if s := l.ToSlice(); s[0]+s[1] != 1000 {
+					t.Errorf("inconsistent state %v", s)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if got := l.ToSlice(); !slices.Equal(got, []int{-1100, 2100}) {
+		t.Errorf("final state = %v", got)
+	}
+}
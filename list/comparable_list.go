@@ -7,6 +7,7 @@ package list
 import (
 	"cmp"
 	"iter"
+	"math/rand/v2"
 
 	"github.com/charbz/gophers/collection"
 )
@@ -14,7 +15,7 @@ import (
 // ComparableList is a list of comparable types.
 // it is similar to List, but with additional methods that do not require a
 // higher order function comparator to be provided as an argument:
-// Max(), Min(), Sum(), Distinct(), Diff(c), and Exists(v).
+// Max(), Min(), Sum(), Distinct(), Diff(c), Intersect(c), Union(c), and Exists(v).
 type ComparableList[T cmp.Ordered] struct {
 	List[T]
 }
@@ -40,6 +41,25 @@ func NewComparableList[T cmp.Ordered](s ...[]T) *ComparableList[T] {
 	return list
 }
 
+// BinarySearch searches a list sorted in increasing order for v, and
+// returns the position where v is found, or would be inserted to keep
+// the list sorted, and whether it was found. It makes O(log n) comparisons
+// but walks O(n) nodes, see List.BinarySearchFunc.
+//
+// example usage:
+//
+//	l := NewComparableList([]int{1,3,5})
+//	l.BinarySearch(3)
+//	l.BinarySearch(4)
+//
+// output:
+//
+//	1, true
+//	2, false
+func (l *ComparableList[T]) BinarySearch(v T) (int, bool) {
+	return l.BinarySearchFunc(v, cmp.Compare[T])
+}
+
 // Clone returns a copy of the list. This is a shallow clone.
 func (l *ComparableList[T]) Clone() *ComparableList[T] {
 	clone := &ComparableList[T]{}
@@ -76,7 +96,7 @@ func (l *ComparableList[T]) Contains(v T) bool {
 }
 
 // Corresponds is an alias for collection.Corresponds
-func (l *ComparableList[T]) Corresponds(s *ComparableList[T], f func(T, T) bool) bool {
+func (l *ComparableList[T]) Corresponds(s collection.OrderedCollection[T], f func(T, T) bool) bool {
 	return collection.Corresponds(l, s, f)
 }
 
@@ -100,8 +120,13 @@ func (l *ComparableList[T]) Distincted() iter.Seq[T] {
 }
 
 // Diff returns a new list containing the elements of the original list that are not in the other list.
+// Every occurrence of such an element is kept. It runs in O(n+m).
 func (l *ComparableList[T]) Diff(s *ComparableList[T]) *ComparableList[T] {
-	return collection.Diff(l, s).(*ComparableList[T])
+	present := s.valueSet()
+	return collection.FilterInto(l, NewComparableList[T](), func(v T) bool {
+		_, ok := present[v]
+		return !ok
+	})
 }
 
 // Diffed is an alias for collection.Diffed
@@ -114,21 +139,9 @@ func (l *ComparableList[T]) Exists(v T) bool {
 	return l.Contains(v)
 }
 
-// Equals returns true if the two lists are equal.
-func (l *ComparableList[T]) Equals(s *ComparableList[T]) bool {
-	if l.size != s.size {
-		return false
-	}
-	n1 := l.head
-	n2 := s.head
-	for n1 != nil && n2 != nil {
-		if n1.value != n2.value {
-			return false
-		}
-		n1 = n1.next
-		n2 = n2.next
-	}
-	return true
+// Equals returns true if the list holds the same elements as s in the same order.
+func (l *ComparableList[T]) Equals(s collection.OrderedCollection[T]) bool {
+	return collection.Equal(l, s)
 }
 
 // IndexOf returns the index of the first occurrence of the specified element in this list,
@@ -142,8 +155,24 @@ func (l *ComparableList[T]) IndexOf(v T) int {
 }
 
 // Intersect returns a new list containing the elements that are present in both lists.
+// Every occurrence of such an element in l is kept, in the order of l, however
+// many times it occurs in s. It runs in O(n+m).
+//
+// example usage:
+//
+//	a := NewComparableList([]int{1,2,2,3})
+//	b := NewComparableList([]int{2,3,3,4})
+//	a.Intersect(b)
+//
+// output:
+//
+//	List(int) [2 2 3]
 func (l *ComparableList[T]) Intersect(s *ComparableList[T]) *ComparableList[T] {
-	return collection.Intersect(l, s).(*ComparableList[T])
+	present := s.valueSet()
+	return collection.FilterInto(l, NewComparableList[T](), func(v T) bool {
+		_, ok := present[v]
+		return ok
+	})
 }
 
 // Intersected is an alias for collection.Intersected
@@ -161,16 +190,62 @@ func (l *ComparableList[T]) LastIndexOf(v T) int {
 	return -1
 }
 
+// Remove removes the first occurrence of the given value from the list in
+// place, and returns true if the list contained it.
+func (l *ComparableList[T]) Remove(v T) bool {
+	_, ok := l.RemoveFirst(func(val T) bool { return val == v })
+	return ok
+}
+
+// RemoveAllOf removes every occurrence of the given value from the list in
+// place, and returns the number of values removed.
+func (l *ComparableList[T]) RemoveAllOf(v T) int {
+	return l.RemoveAll(func(val T) bool { return val == v })
+}
+
 // Max returns the maximum element in the list.
 func (l *ComparableList[T]) Max() (T, error) {
 	return collection.MaxBy(l, func(v T) T { return v })
 }
 
+// MergeSorted merges the list with another sorted ordered collection and
+// returns a new sorted list. Both inputs must be sorted in ascending order.
+func (l *ComparableList[T]) MergeSorted(other collection.OrderedCollection[T]) *ComparableList[T] {
+	r := NewComparableList[T]()
+	for v := range collection.Merged(l, other) {
+		r.Add(v)
+	}
+	return r
+}
+
 // Min returns the minimum element in the list.
 func (l *ComparableList[T]) Min() (T, error) {
 	return collection.MinBy(l, func(v T) T { return v })
 }
 
+// Sample returns a new list of n elements picked at random without
+// replacement, in a random order, see List.Sample.
+func (l *ComparableList[T]) Sample(n int) *ComparableList[T] {
+	return collection.SampleInto(l, NewComparableList[T](), n)
+}
+
+// SampleWith is like Sample, drawing from r instead of the
+// RandState of the list.
+func (l *ComparableList[T]) SampleWith(n int, r *rand.Rand) *ComparableList[T] {
+	return collection.SampleIntoWith(l, NewComparableList[T](), n, r)
+}
+
+// Shuffle returns a new list with the elements in a random order.
+func (l *ComparableList[T]) Shuffle() *ComparableList[T] {
+	return collection.ShuffleInto(l, NewComparableList[T]())
+}
+
+// ShuffleWith is like Shuffle, drawing from r instead of the
+// RandState of the list.
+func (l *ComparableList[T]) ShuffleWith(r *rand.Rand) *ComparableList[T] {
+	return collection.ShuffleIntoWith(l, NewComparableList[T](), r)
+}
+
 // Sum returns the sum of the elements in the list.
 func (l *ComparableList[T]) Sum() T {
 	var sum T
@@ -180,12 +255,38 @@ func (l *ComparableList[T]) Sum() T {
 	return sum
 }
 
-// StartsWith returns true if the list starts with the given list.
-func (l *ComparableList[T]) StartsWith(other *ComparableList[T]) bool {
+// Union returns a new list containing the elements of l followed by the
+// elements of s that are not present in l. Duplicates within l or within s
+// are kept, call Distinct on the result for a set union. It runs in O(n+m).
+//
+// example usage:
+//
+//	a := NewComparableList([]int{1,2,2})
+//	b := NewComparableList([]int{2,3,3})
+//	a.Union(b)
+//
+// output:
+//
+//	List(int) [1 2 2 3 3]
+func (l *ComparableList[T]) Union(s *ComparableList[T]) *ComparableList[T] {
+	return collection.UnionInto(l, s, NewComparableList[T]())
+}
+
+// valueSet returns the set of the elements of the list.
+func (l *ComparableList[T]) valueSet() map[T]struct{} {
+	set := make(map[T]struct{}, l.size)
+	for v := range l.Values() {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+// StartsWith returns true if the list starts with the elements of other.
+func (l *ComparableList[T]) StartsWith(other collection.OrderedCollection[T]) bool {
 	return collection.StartsWith(l, other)
 }
 
-// EndsWith returns true if the list ends with the given list.
-func (l *ComparableList[T]) EndsWith(other *ComparableList[T]) bool {
+// EndsWith returns true if the list ends with the elements of other.
+func (l *ComparableList[T]) EndsWith(other collection.OrderedCollection[T]) bool {
 	return collection.EndsWith(l, other)
 }
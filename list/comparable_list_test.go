@@ -2,7 +2,10 @@ package list
 
 import (
 	"slices"
+	"strings"
 	"testing"
+
+	"github.com/charbz/gophers/sequence"
 )
 
 func TestComparableList_Contains(t *testing.T) {
@@ -476,3 +479,125 @@ func TestComparableList_EndsWith(t *testing.T) {
 		})
 	}
 }
+
+func TestComparableList_MixedCollections(t *testing.T) {
+	l := NewComparableList([]int{1, 3, 5})
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{name: "equals sequence", got: l.Equals(sequence.NewComparableSequence([]int{1, 3, 5})), want: true},
+		{name: "not equals sequence", got: l.Equals(sequence.NewComparableSequence([]int{1, 3})), want: false},
+		{name: "starts with sequence", got: l.StartsWith(sequence.NewSequence([]int{1, 3})), want: true},
+		{name: "ends with sequence", got: l.EndsWith(sequence.NewSequence([]int{3, 5})), want: true},
+		{name: "corresponds to sequence", got: l.Corresponds(sequence.NewSequence([]int{2, 6, 10}), func(a, b int) bool { return a*2 == b }), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComparableList_MergeSorted(t *testing.T) {
+	got := NewComparableList([]int{1, 4, 6}).MergeSorted(sequence.NewSequence([]int{2, 3, 7}))
+	if !slices.Equal(got.ToSlice(), []int{1, 2, 3, 4, 6, 7}) {
+		t.Errorf("MergeSorted() = %v, want [1 2 3 4 6 7]", got)
+	}
+}
+
+func TestComparableList_IntersectUnion(t *testing.T) {
+	tests := []struct {
+		name          string
+		slice1        []int
+		slice2        []int
+		wantIntersect []int
+		wantUnion     []int
+	}{
+		{name: "overlapping", slice1: []int{1, 2, 3}, slice2: []int{2, 3, 4}, wantIntersect: []int{2, 3}, wantUnion: []int{1, 2, 3, 4}},
+		{name: "duplicates", slice1: []int{1, 2, 2, 1}, slice2: []int{2, 2, 2, 5, 5}, wantIntersect: []int{2, 2}, wantUnion: []int{1, 2, 2, 1, 5, 5}},
+		{name: "disjoint", slice1: []int{1}, slice2: []int{2}, wantIntersect: []int{}, wantUnion: []int{1, 2}},
+		{name: "empty", slice1: []int{}, slice2: []int{}, wantIntersect: []int{}, wantUnion: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l1 := NewComparableList(tt.slice1)
+			l2 := NewComparableList(tt.slice2)
+			if got := l1.Intersect(l2).ToSlice(); !slices.Equal(got, tt.wantIntersect) {
+				t.Errorf("Intersect() = %v, want %v", got, tt.wantIntersect)
+			}
+			if got := l1.Union(l2).ToSlice(); !slices.Equal(got, tt.wantUnion) {
+				t.Errorf("Union() = %v, want %v", got, tt.wantUnion)
+			}
+			eq := func(a, b int) bool { return a == b }
+			if got := l1.List.Union(&l2.List, eq).ToSlice(); !slices.Equal(got, tt.wantUnion) {
+				t.Errorf("List.Union() = %v, want %v", got, tt.wantUnion)
+			}
+		})
+	}
+}
+
+func TestComparableList_BinarySearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []int
+		v         int
+		wantIndex int
+		wantFound bool
+	}{
+		{name: "empty", input: []int{}, v: 1, wantIndex: 0, wantFound: false},
+		{name: "first", input: []int{1, 3, 5, 7}, v: 1, wantIndex: 0, wantFound: true},
+		{name: "middle", input: []int{1, 3, 5, 7}, v: 5, wantIndex: 2, wantFound: true},
+		{name: "last", input: []int{1, 3, 5, 7}, v: 7, wantIndex: 3, wantFound: true},
+		{name: "missing", input: []int{1, 3, 5, 7}, v: 4, wantIndex: 2, wantFound: false},
+		{name: "before first", input: []int{1, 3, 5, 7}, v: 0, wantIndex: 0, wantFound: false},
+		{name: "after last", input: []int{1, 3, 5, 7}, v: 8, wantIndex: 4, wantFound: false},
+		{name: "duplicates", input: []int{1, 3, 3, 3, 7}, v: 3, wantIndex: 1, wantFound: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i, found := NewComparableList(tt.input).BinarySearch(tt.v)
+			if i != tt.wantIndex || found != tt.wantFound {
+				t.Errorf("BinarySearch(%d) = %d, %v, want %d, %v", tt.v, i, found, tt.wantIndex, tt.wantFound)
+			}
+			if wi, wf := slices.BinarySearch(tt.input, tt.v); wi != i || wf != found {
+				t.Errorf("BinarySearch(%d) = %d, %v, slices.BinarySearch = %d, %v", tt.v, i, found, wi, wf)
+			}
+		})
+	}
+}
+
+func TestList_BinarySearchFunc(t *testing.T) {
+	l := NewList([]string{"a", "bb", "ccc", "dddd"})
+	byLength := func(a, b string) int { return len(a) - len(b) }
+	for n := range 6 {
+		target := strings.Repeat("x", n)
+		i, found := l.BinarySearchFunc(target, byLength)
+		wi, wf := slices.BinarySearchFunc(l.ToSlice(), target, byLength)
+		if i != wi || found != wf {
+			t.Errorf("BinarySearchFunc(%q) = %d, %v, want %d, %v", target, i, found, wi, wf)
+		}
+	}
+}
+
+func TestComparableList_Remove(t *testing.T) {
+	l := NewComparableList([]int{1, 2, 3, 2, 2})
+	if !l.Remove(2) {
+		t.Errorf("Remove(2) = false, want true")
+	}
+	if l.Remove(5) {
+		t.Errorf("Remove(5) = true, want false")
+	}
+	if got, want := l.ToSlice(), []int{1, 3, 2, 2}; !slices.Equal(got, want) {
+		t.Errorf("Remove(2) left %v, want %v", got, want)
+	}
+	if n := l.RemoveAllOf(2); n != 2 {
+		t.Errorf("RemoveAllOf(2) = %d, want 2", n)
+	}
+	if got, want := l.ToSlice(), []int{1, 3}; !slices.Equal(got, want) {
+		t.Errorf("RemoveAllOf(2) left %v, want %v", got, want)
+	}
+}
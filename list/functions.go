@@ -0,0 +1,178 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// functions.go defines package functions transforming a List into a List of
+// a different element type. Go does not allow type parameters on methods,
+// so these cannot be written as methods on List[T]. Same-type variants are
+// available as methods, i.e. l.Map(f).
+
+package list
+
+import (
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/dict"
+)
+
+// Map applies f to each element of the list and returns a new list of the results.
+//
+// example usage:
+//
+//	names := NewList([]string{"Alice", "Bob"})
+//	Map(names, func(s string) int { return len(s) })
+//
+// output:
+//
+//	List(int) [5 3]
+func Map[T, U any](l *List[T], f func(T) U) *List[U] {
+	r := NewList[U]()
+	for v := range l.Values() {
+		r.Add(f(v))
+	}
+	return r
+}
+
+// FlatMap applies f to each element of the list and returns a new list
+// containing the elements of all resulting lists, in order.
+//
+// example usage:
+//
+//	l := NewList([]int{1, 2})
+//	FlatMap(l, func(i int) *List[string] { return NewList([]string{"a", "b"}[:i]) })
+//
+// output:
+//
+//	List(string) [a a b]
+func FlatMap[T, U any](l *List[T], f func(T) *List[U]) *List[U] {
+	r := NewList[U]()
+	for v := range l.Values() {
+		for u := range f(v).Values() {
+			r.Add(u)
+		}
+	}
+	return r
+}
+
+// Fold applies f to each element of the list, starting from init,
+// and returns the accumulated result.
+//
+// example usage:
+//
+//	words := NewList([]string{"go", "is", "fun"})
+//	Fold(words, 0, func(acc int, s string) int { return acc + len(s) })
+//
+// output:
+//
+//	7
+func Fold[T, U any](l *List[T], init U, f func(U, T) U) U {
+	return collection.Reduce(l, f, init)
+}
+
+// GroupBy groups the elements of the list by the key function into a Map
+// of lists, preserving the order of the elements within each group.
+//
+// example usage:
+//
+//	l := NewList([]int{1,2,3,4,5,6})
+//	GroupBy(l, func(i int) int { return i % 2 })
+//
+// output:
+//
+//	Map(int, *list.List[int]) map[0:List(int) [2 4 6] 1:List(int) [1 3 5]]
+func GroupBy[T any, K comparable](l *List[T], key func(T) K) *dict.Map[K, *List[T]] {
+	return GroupMap(l, key, func(v T) T { return v })
+}
+
+// GroupMap groups the elements of the list by the key function and maps
+// each element with f, preserving the order of the elements within each group.
+//
+// example usage:
+//
+//	words := NewList([]string{"go", "gopher", "rust"})
+//	GroupMap(words, func(w string) byte { return w[0] }, func(w string) int { return len(w) })
+//
+// output:
+//
+//	Map(uint8, *list.List[int]) map[103:List(int) [2 6] 114:List(int) [4]]
+func GroupMap[T any, K comparable, V any](l *List[T], key func(T) K, f func(T) V) *dict.Map[K, *List[V]] {
+	m := dict.NewMap[K, *List[V]]()
+	for v := range l.Values() {
+		k := key(v)
+		group, ok := m.Get(k)
+		if !ok {
+			group = NewList[V]()
+			m.Set(k, group)
+		}
+		group.Add(f(v))
+	}
+	return m
+}
+
+// GroupMapReduce groups the elements of the list by the key function, maps
+// each element with f and reduces the mapped values of each group with reduce,
+// in a single pass.
+//
+// example usage:
+//
+//	words := NewList([]string{"go", "gopher", "rust"})
+//	GroupMapReduce(words, func(w string) byte { return w[0] }, func(w string) int { return len(w) }, func(a, b int) int { return a + b })
+//
+// output:
+//
+//	Map(uint8, int) map[103:8 114:4]
+func GroupMapReduce[T any, K comparable, V any](l *List[T], key func(T) K, f func(T) V, reduce func(V, V) V) *dict.Map[K, V] {
+	return dict.NewMap(collection.GroupMapReduce(l, key, f, reduce))
+}
+
+// Reduce combines the elements of the list from left to right using f,
+// starting from the first element. If the list is empty, it returns
+// the zero value and an error.
+//
+// example usage:
+//
+//	Reduce(NewList([]int{1, 2, 3}), func(a, b int) int { return a + b })
+//
+// output:
+//
+//	6, nil
+func Reduce[T any](l *List[T], f func(T, T) T) (T, error) {
+	if l.head == nil {
+		return *new(T), collection.EmptyCollectionError
+	}
+	acc := l.head.value
+	for node := l.head.next; node != nil; node = node.next {
+		acc = f(acc, node.value)
+	}
+	return acc, nil
+}
+
+// ScanLeft applies f to each element of the list, starting from init, and
+// returns a new list of the intermediate results, init included.
+//
+// example usage:
+//
+//	words := NewList([]string{"go", "is", "fun"})
+//	ScanLeft(words, 0, func(acc int, s string) int { return acc + len(s) })
+//
+// output:
+//
+//	List(int) [0 2 4 7]
+func ScanLeft[T, U any](l *List[T], init U, f func(U, T) U) *List[U] {
+	return NewList(collection.ScanLeft(l, f, init))
+}
+
+// ScanRight applies f to each element of the list from right to left,
+// starting from init, and returns a new list of the intermediate results
+// in the order of the elements they end with, init being last.
+//
+// example usage:
+//
+//	words := NewList([]string{"go", "is", "fun"})
+//	ScanRight(words, 0, func(acc int, s string) int { return acc + len(s) })
+//
+// output:
+//
+//	List(int) [7 5 3 0]
+func ScanRight[T, U any](l *List[T], init U, f func(U, T) U) *List[U] {
+	return NewList(collection.ScanRight(l, f, init))
+}
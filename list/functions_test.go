@@ -0,0 +1,128 @@
+package list
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		want  []string
+	}{
+		{name: "maps to a different type", input: []int{1, 2, 3}, want: []string{"1", "2", "3"}},
+		{name: "empty", input: []int{}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Map(NewList(tt.input), strconv.Itoa)
+			if !slices.Equal(got.ToSlice(), tt.want) {
+				t.Errorf("Map() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	l := NewList([]int{1, 2, 0})
+	got := FlatMap(l, func(i int) *List[string] {
+		return NewList(slices.Repeat([]string{strconv.Itoa(i)}, i))
+	})
+	if !slices.Equal(got.ToSlice(), []string{"1", "2", "2"}) {
+		t.Errorf("FlatMap() = %v, want [1 2 2]", got)
+	}
+	if got.Length() != 3 {
+		t.Errorf("Length() = %v, want 3", got.Length())
+	}
+}
+
+func TestFoldAndReduce(t *testing.T) {
+	words := NewList([]string{"go", "is", "fun"})
+	if got := Fold(words, 0, func(acc int, s string) int { return acc + len(s) }); got != 7 {
+		t.Errorf("Fold() = %v, want 7", got)
+	}
+	if got, err := Reduce(words, func(a, b string) string { return a + " " + b }); err != nil || got != "go is fun" {
+		t.Errorf("Reduce() = %q, %v, want \"go is fun\", nil", got, err)
+	}
+	if _, err := Reduce(NewList[int](), func(a, b int) int { return a + b }); err == nil {
+		t.Errorf("Reduce() on an empty list did not return an error")
+	}
+}
+
+func TestList_SameTypeTransforms(t *testing.T) {
+	l := NewList([]int{1, 2, 3})
+	doubled := l.Map(func(i int) int { return i * 2 })
+	if !slices.Equal(doubled.ToSlice(), []int{2, 4, 6}) || !slices.Equal(l.ToSlice(), []int{1, 2, 3}) {
+		t.Errorf("Map() = %v (original %v), want [2 4 6] (original [1 2 3])", doubled, l)
+	}
+	pairs := l.FlatMap(func(i int) *List[int] { return NewList([]int{i, -i}) })
+	if !slices.Equal(pairs.ToSlice(), []int{1, -1, 2, -2, 3, -3}) {
+		t.Errorf("FlatMap() = %v, want [1 -1 2 -2 3 -3]", pairs)
+	}
+	if got := l.Fold(10, func(a, b int) int { return a + b }); got != 16 {
+		t.Errorf("Fold() = %v, want 16", got)
+	}
+	if got, _ := l.Reduce(func(a, b int) int { return a * b }); got != 6 {
+		t.Errorf("Reduce() = %v, want 6", got)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	l := NewList([]int{1, 2, 3, 4, 5, 6})
+	got := GroupBy(l, func(i int) int { return i % 2 })
+	if got.Length() != 2 {
+		t.Fatalf("GroupBy() = %v, want 2 groups", got)
+	}
+	for k, want := range map[int][]int{0: {2, 4, 6}, 1: {1, 3, 5}} {
+		if group, _ := got.Get(k); !slices.Equal(group.ToSlice(), want) {
+			t.Errorf("GroupBy()[%v] = %v, want %v", k, group, want)
+		}
+	}
+}
+
+func TestGroupMapAndReduce(t *testing.T) {
+	words := NewList([]string{"go", "gopher", "rust"})
+	first := func(w string) byte { return w[0] }
+	length := func(w string) int { return len(w) }
+	tests := []struct {
+		name    string
+		key     byte
+		want    []int
+		wantSum int
+	}{
+		{name: "two elements", key: 'g', want: []int{2, 6}, wantSum: 8},
+		{name: "one element", key: 'r', want: []int{4}, wantSum: 4},
+	}
+	grouped := GroupMap(words, first, length)
+	reduced := GroupMapReduce(words, first, length, func(a, b int) int { return a + b })
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if group, _ := grouped.Get(tt.key); !slices.Equal(group.ToSlice(), tt.want) {
+				t.Errorf("GroupMap()[%c] = %v, want %v", tt.key, group, tt.want)
+			}
+			if sum := reduced.GetOrElse(tt.key, -1); sum != tt.wantSum {
+				t.Errorf("GroupMapReduce()[%c] = %v, want %v", tt.key, sum, tt.wantSum)
+			}
+		})
+	}
+}
+
+func TestScanLeftAndRight(t *testing.T) {
+	words := NewList([]string{"go", "is", "fun"})
+	length := func(acc int, s string) int { return acc + len(s) }
+	if got := ScanLeft(words, 0, length).ToSlice(); !slices.Equal(got, []int{0, 2, 4, 7}) {
+		t.Errorf("ScanLeft() = %v, want [0 2 4 7]", got)
+	}
+	if got := ScanRight(words, 0, length).ToSlice(); !slices.Equal(got, []int{7, 5, 3, 0}) {
+		t.Errorf("ScanRight() = %v, want [7 5 3 0]", got)
+	}
+	concat := func(acc, s string) string { return acc + s }
+	if got := words.ScanLeft(">", concat).ToSlice(); !slices.Equal(got, []string{">", ">go", ">gois", ">goisfun"}) {
+		t.Errorf("List.ScanLeft() = %v", got)
+	}
+	if got := NewList[string]().ScanRight("<", concat).ToSlice(); !slices.Equal(got, []string{"<"}) {
+		t.Errorf("List.ScanRight() on empty list = %v, want [<]", got)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package list
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// GobEncode encodes the elements of the list with encoding/gob, which
+// lets a list, or a struct holding one, be persisted with gob when its
+// element type is gob-encodable.
+//
+// example usage:
+//
+//	var buf bytes.Buffer
+//	err := gob.NewEncoder(&buf).Encode(NewList([]int{1,2,3}))
+func (l *List[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(l.ToSlice())
+	return buf.Bytes(), err
+}
+
+// GobDecode decodes elements encoded by GobEncode into the list,
+// replacing its contents.
+func (l *List[T]) GobDecode(data []byte) error {
+	var s []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&s); err != nil {
+		return err
+	}
+	l.Free()
+	for _, v := range s {
+		l.Add(v)
+	}
+	return nil
+}
+
+// GobEncode encodes a snapshot of the list with encoding/gob.
+func (l *SyncList[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(l.ToSlice())
+	return buf.Bytes(), err
+}
+
+// GobDecode decodes elements encoded by GobEncode into the list,
+// replacing its contents.
+func (l *SyncList[T]) GobDecode(data []byte) error {
+	var decoded List[T]
+	if err := decoded.GobDecode(data); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.list.Free()
+	l.list.replaceNodes(&decoded)
+	l.notify()
+	return nil
+}
@@ -0,0 +1,50 @@
+package list
+
+import (
+	"bytes"
+	"encoding/gob"
+	"slices"
+	"testing"
+)
+
+func TestList_Gob(t *testing.T) {
+	type job struct {
+		ID   int
+		Name string
+	}
+	type state struct {
+		Pending *List[job]
+		Tags    *ComparableList[string]
+		Done    *SyncList[int]
+	}
+	tests := []struct {
+		name string
+		in   state
+	}{
+		{name: "non-empty", in: state{
+			Pending: NewList([]job{{1, "build"}, {2, "test"}}),
+			Tags:    NewComparableList([]string{"go", "zig"}),
+			Done:    NewSyncList([]int{7}),
+		}},
+		{name: "empty", in: state{Pending: NewList[job](), Tags: NewComparableList[string](), Done: NewSyncList[int]()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(tt.in); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			out := state{Pending: NewList([]job{{9, "stale"}}), Tags: NewComparableList([]string{"x"}), Done: NewSyncList([]int{9})}
+			if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			assertLinks(t, out.Pending, tt.in.Pending.ToSlice())
+			if !slices.Equal(out.Tags.ToSlice(), tt.in.Tags.ToSlice()) || !slices.Equal(out.Done.ToSlice(), tt.in.Done.ToSlice()) {
+				t.Errorf("Decode() = %v, %v, want %v, %v", out.Tags, out.Done, tt.in.Tags, tt.in.Done)
+			}
+		})
+	}
+	if err := NewList[int]().GobDecode([]byte("garbage")); err == nil {
+		t.Error("GobDecode() of garbage did not fail")
+	}
+}
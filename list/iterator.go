@@ -0,0 +1,177 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package list
+
+import "github.com/charbz/gophers/collection"
+
+// ListIterator traverses a List in both directions and modifies it along
+// the way, keeping the head, tail and size of the list consistent.
+//
+// The iterator has a cursor between two elements of the list: Next returns
+// the element after the cursor and moves the cursor past it, Prev does the
+// same backward. The element returned by the last call to Next or Prev is
+// the current element, which Remove, Set, InsertBefore and InsertAfter act on.
+//
+//	it := l.Iterator()
+//	for v, ok := it.Next(); ok; v, ok = it.Next() {
+//	  if v.Expired() {
+//	    it.Remove()
+//	  }
+//	}
+//
+// The list must only be modified through the iterator while it is in use.
+type ListIterator[T any] struct {
+	list    *List[T]
+	before  *Node[T] // node before the cursor, nil at the beginning
+	after   *Node[T] // node after the cursor, nil at the end
+	current *Node[T] // node last returned by Next or Prev, nil if none or removed
+}
+
+// Iterator returns an iterator with its cursor before the first element.
+func (l *List[T]) Iterator() *ListIterator[T] {
+	return &ListIterator[T]{list: l, after: l.head}
+}
+
+// IteratorAt returns an iterator with its cursor before the element at the
+// given index. An index equal to the length of the list places the cursor
+// after the last element, to iterate backward with Prev.
+// It panics if the index is out of bounds.
+func (l *List[T]) IteratorAt(index int) *ListIterator[T] {
+	if index < 0 || index > l.size {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	if index == l.size {
+		return &ListIterator[T]{list: l, before: l.tail}
+	}
+	after := l.nodeAt(index)
+	return &ListIterator[T]{list: l, before: after.prev, after: after}
+}
+
+// HasNext returns true if there is an element after the cursor.
+func (it *ListIterator[T]) HasNext() bool {
+	return it.after != nil
+}
+
+// HasPrev returns true if there is an element before the cursor.
+func (it *ListIterator[T]) HasPrev() bool {
+	return it.before != nil
+}
+
+// Next moves the cursor past the following element and returns it,
+// or returns false if the cursor is after the last element.
+func (it *ListIterator[T]) Next() (T, bool) {
+	if it.after == nil {
+		return *new(T), false
+	}
+	it.current = it.after
+	it.before, it.after = it.after, it.after.next
+	return it.current.value, true
+}
+
+// Prev moves the cursor back past the preceding element and returns it,
+// or returns false if the cursor is before the first element.
+func (it *ListIterator[T]) Prev() (T, bool) {
+	if it.before == nil {
+		return *new(T), false
+	}
+	it.current = it.before
+	it.before, it.after = it.before.prev, it.before
+	return it.current.value, true
+}
+
+// Remove removes the current element from the list and returns it, or
+// returns false if there is no current element, i.e. it was already removed.
+// The cursor stays between the neighbours of the removed element.
+func (it *ListIterator[T]) Remove() (T, bool) {
+	node := it.current
+	if node == nil {
+		return *new(T), false
+	}
+	if it.before == node {
+		it.before = node.prev
+	} else {
+		it.after = node.next
+	}
+	it.list.unlink(node)
+	it.list.removed(node.value)
+	it.current = nil
+	return node.value, true
+}
+
+// Set replaces the value of the current element,
+// or returns false if there is no current element.
+func (it *ListIterator[T]) Set(v T) bool {
+	if it.current == nil {
+		return false
+	}
+	it.current.value = v
+	return true
+}
+
+// InsertBefore inserts v before the current element, or at the cursor if
+// there is no current element. The inserted element is on the near side of
+// the cursor: it is returned by the following call to Prev, not to Next.
+//
+// example usage:
+//
+//	l := NewList([]int{1,3})
+//	it := l.Iterator()
+//	it.Next()
+//	it.Next()
+//	it.InsertBefore(2)
+//
+// output:
+//
+//	List(int) [1 2 3]
+func (it *ListIterator[T]) InsertBefore(v T) {
+	if it.current == nil {
+		it.before = it.list.insertBetween(it.before, it.after, v)
+		return
+	}
+	node := it.list.insertBetween(it.current.prev, it.current, v)
+	if it.after == it.current {
+		it.before = node
+	}
+}
+
+// InsertAfter inserts v after the current element, or at the cursor if
+// there is no current element. The inserted element is on the far side of
+// the cursor when moving forward: it is returned by the following call to Next.
+func (it *ListIterator[T]) InsertAfter(v T) {
+	if it.current == nil {
+		it.after = it.list.insertBetween(it.before, it.after, v)
+		return
+	}
+	node := it.list.insertBetween(it.current, it.current.next, v)
+	if it.before == it.current {
+		it.after = node
+	}
+}
+
+// insertBetween links a new node holding v between two adjacent nodes,
+// nil standing for either end of the list, and returns it.
+func (l *List[T]) insertBetween(prev, next *Node[T], v T) *Node[T] {
+	node := l.newNode(v)
+	l.link(prev, next, node)
+	return node
+}
+
+// link links a detached node between two adjacent nodes,
+// nil standing for either end of the list.
+func (l *List[T]) link(prev, next, node *Node[T]) {
+	node.prev, node.next = prev, next
+	if prev != nil {
+		prev.next = node
+	} else {
+		l.head = node
+	}
+	if next != nil {
+		next.prev = node
+	} else {
+		l.tail = node
+	}
+	l.size++
+	l.forgetPosition()
+}
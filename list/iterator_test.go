@@ -0,0 +1,128 @@
+package list
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestListIterator_NextPrev(t *testing.T) {
+	l := NewList([]int{1, 2, 3})
+	it := l.Iterator()
+	if _, ok := it.Prev(); ok {
+		t.Errorf("Prev() at the beginning returned true")
+	}
+	var forward []int
+	for v, ok := it.Next(); ok; v, ok = it.Next() {
+		forward = append(forward, v)
+	}
+	var backward []int
+	for v, ok := it.Prev(); ok; v, ok = it.Prev() {
+		backward = append(backward, v)
+	}
+	if !slices.Equal(forward, []int{1, 2, 3}) || !slices.Equal(backward, []int{3, 2, 1}) {
+		t.Errorf("forward = %v, backward = %v", forward, backward)
+	}
+	it = l.IteratorAt(1)
+	if v, _ := it.Next(); v != 2 {
+		t.Errorf("IteratorAt(1).Next() = %v, want 2", v)
+	}
+	if v, _ := l.IteratorAt(3).Prev(); v != 3 {
+		t.Errorf("IteratorAt(3).Prev() = %v, want 3", v)
+	}
+}
+
+func TestListIterator_Remove(t *testing.T) {
+	tests := []struct {
+		name   string
+		slice  []int
+		remove func(int) bool
+		want   []int
+	}{
+		{name: "head", slice: []int{1, 2, 3}, remove: func(v int) bool { return v == 1 }, want: []int{2, 3}},
+		{name: "tail", slice: []int{1, 2, 3}, remove: func(v int) bool { return v == 3 }, want: []int{1, 2}},
+		{name: "even", slice: []int{1, 2, 3, 4, 6}, remove: func(v int) bool { return v%2 == 0 }, want: []int{1, 3}},
+		{name: "all", slice: []int{1, 2, 3}, remove: func(int) bool { return true }, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewList(tt.slice)
+			it := l.Iterator()
+			for v, ok := it.Next(); ok; v, ok = it.Next() {
+				if tt.remove(v) {
+					if removed, ok := it.Remove(); !ok || removed != v {
+						t.Fatalf("Remove() = %v, %v, want %v, true", removed, ok, v)
+					}
+				}
+			}
+			assertLinks(t, l, tt.want)
+		})
+	}
+}
+
+func TestListIterator_RemoveBackward(t *testing.T) {
+	l := NewList([]int{1, 2, 3, 4})
+	it := l.IteratorAt(l.Length())
+	it.Prev()
+	it.Prev()
+	it.Remove()
+	if _, ok := it.Remove(); ok {
+		t.Errorf("second Remove() returned true")
+	}
+	if it.Set(9) {
+		t.Errorf("Set() after Remove() returned true")
+	}
+	if v, _ := it.Prev(); v != 2 {
+		t.Errorf("Prev() = %v, want 2", v)
+	}
+	assertLinks(t, l, []int{1, 2, 4})
+}
+
+func TestListIterator_Insert(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  func(it *ListIterator[int])
+		want []int
+	}{
+		{name: "before head", ops: func(it *ListIterator[int]) { it.Next(); it.InsertBefore(0) }, want: []int{0, 1, 2, 3}},
+		{name: "after tail", ops: func(it *ListIterator[int]) {
+			for it.HasNext() {
+				it.Next()
+			}
+			it.InsertAfter(4)
+		}, want: []int{1, 2, 3, 4}},
+		{name: "at start", ops: func(it *ListIterator[int]) { it.InsertAfter(0) }, want: []int{0, 1, 2, 3}},
+		{name: "after current", ops: func(it *ListIterator[int]) { it.Next(); it.InsertAfter(9); it.Next(); it.Set(8) }, want: []int{1, 8, 2, 3}},
+		{name: "before current backward", ops: func(it *ListIterator[int]) {
+			it.Next()
+			it.Next()
+			it.Prev()
+			it.InsertBefore(9)
+			it.Prev()
+			it.Set(8)
+		}, want: []int{1, 8, 2, 3}},
+		{name: "after removed", ops: func(it *ListIterator[int]) { it.Next(); it.Next(); it.Remove(); it.InsertBefore(9); it.InsertAfter(8) }, want: []int{1, 9, 8, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewList([]int{1, 2, 3})
+			tt.ops(l.Iterator())
+			assertLinks(t, l, tt.want)
+		})
+	}
+}
+
+func TestListIterator_InsertSkipsNothing(t *testing.T) {
+	l := NewList([]int{1, 2, 3})
+	it := l.Iterator()
+	var visited []int
+	for v, ok := it.Next(); ok; v, ok = it.Next() {
+		visited = append(visited, v)
+		if v < 10 {
+			it.InsertBefore(v * 10)
+		}
+	}
+	if !slices.Equal(visited, []int{1, 2, 3}) {
+		t.Errorf("visited = %v, want [1 2 3]", visited)
+	}
+	assertLinks(t, l, []int{10, 1, 20, 2, 30, 3})
+}
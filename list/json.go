@@ -0,0 +1,55 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package list
+
+import "encoding/json"
+
+// MarshalJSON encodes the list as a JSON array.
+//
+// example usage:
+//
+//	type Response struct {
+//	  IDs *List[int] `json:"ids"`
+//	}
+//	json.Marshal(Response{IDs: NewList([]int{1,2,3})})
+//
+// output:
+//
+//	{"ids":[1,2,3]}
+func (l *List[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.ToSlice())
+}
+
+// UnmarshalJSON decodes a JSON array into the list, replacing its contents.
+func (l *List[T]) UnmarshalJSON(data []byte) error {
+	var s []T
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	l.Free()
+	for _, v := range s {
+		l.Add(v)
+	}
+	return nil
+}
+
+// MarshalJSON encodes a snapshot of the list as a JSON array.
+func (l *SyncList[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.ToSlice())
+}
+
+// UnmarshalJSON decodes a JSON array into the list, replacing its contents.
+func (l *SyncList[T]) UnmarshalJSON(data []byte) error {
+	var decoded List[T]
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.list.Free()
+	l.list.replaceNodes(&decoded)
+	l.notify()
+	return nil
+}
@@ -0,0 +1,53 @@
+package list
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestList_JSON(t *testing.T) {
+	tests := []struct {
+		name string
+		list *List[int]
+		want string
+	}{
+		{name: "non-empty list", list: NewList([]int{1, 2, 3}), want: "[1,2,3]"},
+		{name: "empty list", list: NewList[int](), want: "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.list)
+			if err != nil || string(data) != tt.want {
+				t.Fatalf("Marshal() = %s, %v, want %s", data, err, tt.want)
+			}
+			decoded := NewList([]int{9})
+			if err := json.Unmarshal(data, decoded); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			assertLinks(t, decoded, tt.list.ToSlice())
+		})
+	}
+}
+
+func TestList_JSONEmbedded(t *testing.T) {
+	type payload struct {
+		Names *ComparableList[string] `json:"names"`
+		Jobs  *SyncList[int]          `json:"jobs"`
+	}
+	in := payload{Names: NewComparableList([]string{"go", "zig"}), Jobs: NewSyncList([]int{1})}
+	data, err := json.Marshal(in)
+	if want := `{"names":["go","zig"],"jobs":[1]}`; err != nil || string(data) != want {
+		t.Fatalf("Marshal() = %s, %v, want %s", data, err, want)
+	}
+	var out payload
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !slices.Equal(out.Names.ToSlice(), []string{"go", "zig"}) || !slices.Equal(out.Jobs.ToSlice(), []int{1}) {
+		t.Errorf("Unmarshal() = %v, %v", out.Names, out.Jobs)
+	}
+	if err := json.Unmarshal([]byte(`{"names":{}}`), &out); err == nil {
+		t.Error("Unmarshal() of an object into a list did not fail")
+	}
+}
@@ -15,23 +15,37 @@
 package list
 
 import (
+	"context"
 	"fmt"
 	"iter"
-	"math/rand"
+	"math/rand/v2"
+	"slices"
 
 	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/seq"
 )
 
+// Node is an element of a List. Nodes are internal to the list, except
+// for the handles returned by AddNode and AddFirstNode, see node.go.
 type Node[T any] struct {
 	value T
 	next  *Node[T]
 	prev  *Node[T]
 }
 
+// List is an ordered collection backed by a doubly linked list. The zero
+// value is an empty list ready to use, and a nil *List reads as an empty
+// list: Length, IsEmpty, NonEmpty, the iterators and ToSlice accept it.
 type List[T any] struct {
 	head *Node[T]
 	tail *Node[T]
 	size int
+	// arena allocates the nodes of lists created by NewArenaList.
+	arena    *arena[T]
+	onRemove func(T)
+	// position caches the last node reached by index, see CachePositions.
+	position *position[T]
+	collection.Randomized
 }
 
 func NewList[T any](s ...[]T) *List[T] {
@@ -47,12 +61,98 @@ func NewList[T any](s ...[]T) *List[T] {
 	return list
 }
 
+// NewListFromSeq returns a list holding the values yielded by s, in order.
+//
+// example usage:
+//
+//	NewListFromSeq(maps.Keys(map[string]int{"a": 1}))
+//
+// output:
+//
+//	List(string) [a]
+func NewListFromSeq[T any](s iter.Seq[T]) *List[T] {
+	return collection.CollectInto(s, NewList[T]())
+}
+
+// NewListFromChannel returns a list holding the values received from ch,
+// in order, once ch is closed. If the context is done first, it returns
+// the values received so far along with the context's error.
+//
+// example usage:
+//
+//	results := make(chan Result)
+//	go crawl(urls, results) // closes results when done
+//	l, err := NewListFromChannel(ctx, results)
+func NewListFromChannel[T any](ctx context.Context, ch <-chan T) (*List[T], error) {
+	list := NewList[T]()
+	for {
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				return list, nil
+			}
+			list.Add(v)
+		case <-ctx.Done():
+			return list, ctx.Err()
+		}
+	}
+}
+
+// Tabulate returns a list of n elements, the element at index i being f(i).
+//
+// example usage:
+//
+//	Tabulate(4, func(i int) int { return i * i })
+//
+// output:
+//
+//	List(int) [0 1 4 9]
+func Tabulate[T any](n int, f func(i int) T) *List[T] {
+	list := NewList[T]()
+	for i := range n {
+		list.Add(f(i))
+	}
+	return list
+}
+
+// Fill returns a list of n elements equal to v.
+//
+// example usage:
+//
+//	Fill(3, "go")
+//
+// output:
+//
+//	List(string) [go go go]
+func Fill[T any](n int, v T) *List[T] {
+	return Tabulate(n, func(int) T { return v })
+}
+
+// RangeList returns a list of the numbers from start up to end excluded,
+// separated by step, which may be negative to count down, see
+// collection.Range. It panics if step is 0.
+//
+// example usage:
+//
+//	RangeList(10, 0, -3)
+//
+// output:
+//
+//	List(int) [10 7 4 1]
+func RangeList[T collection.Number](start, end, step T) *List[T] {
+	list := NewList[T]()
+	for v := range collection.Range(start, end, step) {
+		list.Add(v)
+	}
+	return list
+}
+
 // The following methods implement
 // the Collection interface.
 
 // Add adds a value to the end of the list.
 func (l *List[T]) Add(v T) {
-	node := &Node[T]{value: v}
+	node := l.newNode(v)
 	if l.head == nil {
 		l.head = node
 		l.tail = node
@@ -64,8 +164,28 @@ func (l *List[T]) Add(v T) {
 	l.size++
 }
 
-// Length returns the number of nodes in the list.
+// AddFirst adds a value to the beginning of the list.
+func (l *List[T]) AddFirst(v T) {
+	node := l.newNode(v)
+	if l.head == nil {
+		l.head = node
+		l.tail = node
+	} else {
+		node.next = l.head
+		l.head.prev = node
+		l.head = node
+	}
+	l.size++
+	if l.position != nil {
+		l.position.index++
+	}
+}
+
+// Length returns the number of nodes in the list, 0 for a nil list.
 func (l *List[T]) Length() int {
+	if l == nil {
+		return 0
+	}
 	return l.size
 }
 
@@ -79,13 +199,13 @@ func (l *List[T]) Random() T {
 	if l.size == 0 {
 		return *new(T)
 	}
-	return l.At(rand.Intn(l.size))
+	return l.At(l.RandState().IntN(l.size))
 }
 
 // Values returns an iterator for all values in the list.
 func (l *List[T]) Values() iter.Seq[T] {
 	return func(yield func(T) bool) {
-		for node := l.head; node != nil; node = node.next {
+		for node := l.FirstNode(); node != nil; node = node.next {
 			if !yield(node.value) {
 				break
 			}
@@ -101,18 +221,34 @@ func (l *List[T]) At(index int) T {
 	if index < 0 || index >= l.size {
 		panic(collection.IndexOutOfBoundsError)
 	}
-	node := l.head
-	for i := 0; i < index; i++ {
-		node = node.next
+	return l.nodeAt(index).value
+}
+
+// nodeAt returns the node at the given index, walking from
+// whichever end of the list is closest. The index must be in bounds.
+func (l *List[T]) nodeAt(index int) *Node[T] {
+	if l.position != nil {
+		return l.cachedNodeAt(index)
+	}
+	if index < l.size/2 {
+		node := l.head
+		for i := 0; i < index; i++ {
+			node = node.next
+		}
+		return node
+	}
+	node := l.tail
+	for i := l.size - 1; i > index; i-- {
+		node = node.prev
 	}
-	return node.value
+	return node
 }
 
 // All returns an index/value iterator for all nodes in the list.
 func (l *List[T]) All() iter.Seq2[int, T] {
 	return func(yield func(int, T) bool) {
 		i := 0
-		for node := l.head; node != nil; node = node.next {
+		for node := l.FirstNode(); node != nil; node = node.next {
 			if !yield(i, node.value) {
 				break
 			}
@@ -124,8 +260,8 @@ func (l *List[T]) All() iter.Seq2[int, T] {
 // Backward returns an index/value iterator for all nodes in the list in reverse order.
 func (l *List[T]) Backward() iter.Seq2[int, T] {
 	return func(yield func(int, T) bool) {
-		i := l.size - 1
-		for node := l.tail; node != nil; node = node.prev {
+		i := l.Length() - 1
+		for node := l.LastNode(); node != nil; node = node.prev {
 			if !yield(i, node.value) {
 				break
 			}
@@ -136,36 +272,136 @@ func (l *List[T]) Backward() iter.Seq2[int, T] {
 
 // Slice returns a new list containing only the nodes between the start and end indices.
 func (l *List[T]) Slice(start, end int) collection.OrderedCollection[T] {
+	return l.slice(start, end)
+}
+
+// slice is the concrete implementation of Slice. It walks to the closest
+// end of the range from the closest end of the list, then copies exactly
+// end-start values.
+func (l *List[T]) slice(start, end int) *List[T] {
 	if start < 0 || end > l.size || start > end {
 		panic(collection.IndexOutOfBoundsError)
 	}
 	list := &List[T]{}
-	for i, v := range l.All() {
-		if i < start {
-			continue
-		}
-		if i >= start && i < end {
-			list.Add(v)
-		}
-		if i >= end {
-			break
+	if start == end {
+		return list
+	}
+	if start <= l.size-end {
+		node := l.nodeAt(start)
+		for i := start; i < end; i++ {
+			list.Add(node.value)
+			node = node.next
 		}
+		return list
+	}
+	node := l.nodeAt(end - 1)
+	for i := end; i > start; i-- {
+		list.AddFirst(node.value)
+		node = node.prev
 	}
 	return list
 }
 
+// unlink detaches the node from the list, updating its neighbours
+// as well as the head and tail pointers.
+func (l *List[T]) unlink(node *Node[T]) {
+	if node.prev != nil {
+		node.prev.next = node.next
+	} else {
+		l.head = node.next
+	}
+	if node.next != nil {
+		node.next.prev = node.prev
+	} else {
+		l.tail = node.prev
+	}
+	node.prev, node.next = nil, nil
+	l.size--
+	l.forgetPosition()
+}
+
+// removed calls the OnRemove function of the list, if any,
+// with a value removed from the list.
+func (l *List[T]) removed(v T) {
+	if l.onRemove != nil {
+		l.onRemove(v)
+	}
+}
+
+// detach moves all the nodes of the list into a new list in O(1),
+// leaving the list empty.
+func (l *List[T]) detach() *List[T] {
+	detached := &List[T]{head: l.head, tail: l.tail, size: l.size}
+	l.head, l.tail, l.size = nil, nil, 0
+	l.forgetPosition()
+	return detached
+}
+
+// prependAll moves all the nodes of other to the beginning of the list
+// in O(1), leaving other empty.
+func (l *List[T]) prependAll(other *List[T]) {
+	if other.size == 0 {
+		return
+	}
+	if l.size == 0 {
+		l.tail = other.tail
+	} else {
+		other.tail.next = l.head
+		l.head.prev = other.tail
+	}
+	l.head = other.head
+	l.size += other.size
+	other.head, other.tail, other.size = nil, nil, 0
+	l.forgetPosition()
+}
+
+// replaceNodes replaces the nodes of the list with the nodes of other in
+// O(1), leaving other empty. Unlike assigning *other to the list, it keeps
+// the OnRemove function and the position cache of the list. The previous
+// nodes are dropped without calling OnRemove.
+func (l *List[T]) replaceNodes(other *List[T]) {
+	l.head, l.tail, l.size = other.head, other.tail, other.size
+	other.head, other.tail, other.size = nil, nil, 0
+	l.forgetPosition()
+}
+
 // NewOrdered returns a new ordered collection.
 func (l *List[T]) NewOrdered(s ...[]T) collection.OrderedCollection[T] {
 	return NewList(s...)
 }
 
+// ToChannel sends the values of the list to the returned channel from a new
+// goroutine, see collection.ToChannel. The list must not be modified until
+// the channel is closed, use a SyncList otherwise.
+//
+// example usage:
+//
+//	for v := range NewList([]int{1,2,3}).ToChannel(ctx, collection.WithBuffer(2)) {
+//	  fmt.Println(v)
+//	}
+//
+// output:
+//
+//	1
+//	2
+//	3
+func (l *List[T]) ToChannel(ctx context.Context, opts ...collection.ChanOption) <-chan T {
+	return collection.ToChannel(ctx, l, opts...)
+}
+
 // ToSlice returns a slice containing all values in the list.
 func (l *List[T]) ToSlice() []T {
-	slice := make([]T, 0, l.size)
-	for v := range l.Values() {
-		slice = append(slice, v)
+	return l.AppendToSlice(make([]T, 0, l.Length()))
+}
+
+// AppendToSlice appends all values in the list to dst and returns the extended slice.
+// dst is grown at most once, making it suitable for reusing a buffer across calls.
+func (l *List[T]) AppendToSlice(dst []T) []T {
+	dst = slices.Grow(dst, l.Length())
+	for node := l.FirstNode(); node != nil; node = node.next {
+		dst = append(dst, node.value)
 	}
-	return slice
+	return dst
 }
 
 // Implement the Stringer interface.
@@ -186,6 +422,65 @@ func (l *List[T]) Apply(f func(T) T) *List[T] {
 	return l
 }
 
+// AtOrElse returns the element at the given index,
+// or fallback if the index is out of bounds.
+func (l *List[T]) AtOrElse(index int, fallback T) T {
+	if index < 0 || index >= l.size {
+		return fallback
+	}
+	return l.nodeAt(index).value
+}
+
+// AtOrErr returns the element at the given index.
+// If the index is out of bounds, it returns an error.
+func (l *List[T]) AtOrErr(index int) (T, error) {
+	if index < 0 || index >= l.size {
+		return *new(T), collection.IndexOutOfBoundsError
+	}
+	return l.nodeAt(index).value, nil
+}
+
+// BinarySearchFunc searches a list sorted in increasing order by cmp for
+// target, and returns the position where target is found, or would be
+// inserted to keep the list sorted, and whether it was found. It makes
+// O(log n) comparisons but walks O(n) nodes, since a list has no random
+// access; see ComparableList.BinarySearch for ordered types.
+//
+// example usage:
+//
+//	l := NewList([]string{"a", "bb", "ccc"})
+//	l.BinarySearchFunc("xx", func(a, b string) int { return len(a) - len(b) })
+//
+// output:
+//
+//	1, true
+func (l *List[T]) BinarySearchFunc(target T, cmp func(T, T) int) (int, bool) {
+	lo, hi := 0, l.Length()
+	node, i := l.FirstNode(), 0
+	seek := func(index int) {
+		for ; i < index; i++ {
+			node = node.next
+		}
+		for ; i > index; i-- {
+			node = node.prev
+		}
+	}
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		seek(mid)
+		if cmp(node.value, target) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	if lo == l.Length() {
+		return lo, false
+	}
+	seek(lo)
+	return lo, cmp(node.value, target) == 0
+}
+
 // Clone returns a copy of the list. This is a shallow clone.
 func (l *List[T]) Clone() *List[T] {
 	clone := &List[T]{}
@@ -223,7 +518,7 @@ func (l *List[T]) Contains(f func(T) bool) bool {
 }
 
 // Corresponds is an alias for collection.Corresponds
-func (l *List[T]) Corresponds(s *List[T], f func(T, T) bool) bool {
+func (l *List[T]) Corresponds(s collection.OrderedCollection[T], f func(T, T) bool) bool {
 	return collection.Corresponds(l, s, f)
 }
 
@@ -232,15 +527,18 @@ func (l *List[T]) Dequeue() (T, error) {
 	if l.size == 0 {
 		return *new(T), collection.EmptyCollectionError
 	}
-	element := l.head.value
-	l.head = l.head.next
-	l.size--
-	return element, nil
+	node := l.head
+	l.unlink(node)
+	l.removed(node.value)
+	return node.value, nil
 }
 
-// Diff is an alias for collection.DiffFunc
+// Diff returns a new list containing the elements of l that are not present in s,
+// using f as an equality function.
 func (l *List[T]) Diff(s *List[T], f func(T, T) bool) *List[T] {
-	return collection.DiffFunc(l, s, f).(*List[T])
+	return collection.FilterInto(l, NewList[T](), func(v T) bool {
+		return !s.Contains(func(t T) bool { return f(v, t) })
+	})
 }
 
 // Diffed is an alias for collection.Diffed
@@ -253,7 +551,7 @@ func (l *List[T]) Diffed(s *List[T], f func(T, T) bool) iter.Seq[T] {
 // and returns a new sequence containing all the unique elements.
 // If you don't want to pass an equality function use a ComparableList.
 func (l *List[T]) Distinct(f func(T, T) bool) *List[T] {
-	return collection.Distinct(l, f).(*List[T])
+	return collection.DistinctInto(l, NewList[T](), f)
 }
 
 // Distincted is an alias for collection.Distincted
@@ -261,19 +559,69 @@ func (l *List[T]) Distincted(f func(T, T) bool) iter.Seq[T] {
 	return collection.DistinctedFunc(l, f)
 }
 
-// Drop is an alias for collection.Drop
+// Drain returns an iterator that removes and yields the elements of the list,
+// from first to last. The contents of the list are swapped out when the
+// iteration starts, so elements added during the iteration are not drained.
+// Elements not yielded, because the loop stopped early or the context was
+// done, are put back at the beginning of the list.
+//
+// example usage:
+//
+//	l := NewList([]int{1,2,3,4})
+//	for v := range l.Drain(ctx) {
+//		if v == 2 {
+//			break
+//		}
+//	}
+//	l.ToSlice()
+//
+// output:
+//
+//	[3,4]
+func (l *List[T]) Drain(ctx context.Context) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		detached := l.detach()
+		defer l.prependAll(detached)
+		for detached.size > 0 && ctx.Err() == nil {
+			v, _ := detached.Dequeue()
+			l.removed(v)
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// DrainInto removes all the elements of the list, adds them to dst
+// from first to last and returns the number of elements moved.
+func (l *List[T]) DrainInto(dst collection.Collection[T]) int {
+	detached := l.detach()
+	for node := detached.head; node != nil; node = node.next {
+		l.removed(node.value)
+		dst.Add(node.value)
+	}
+	return detached.size
+}
+
+// Drop returns a new list with the first n elements removed.
 func (l *List[T]) Drop(n int) *List[T] {
-	return collection.Drop(l, n).(*List[T])
+	return l.slice(clamp(n, l.size), l.size)
 }
 
-// DropWhile is an alias for collection.DropWhile
+// DropWhile returns a new list with the longest prefix of elements
+// satisfying the predicate removed.
 func (l *List[T]) DropWhile(f func(T) bool) *List[T] {
-	return collection.DropWhile(l, f).(*List[T])
+	count := 0
+	for node := l.head; node != nil && f(node.value); node = node.next {
+		count++
+	}
+	return l.slice(count, l.size)
 }
 
-// DropRight is an alias for collection.DropRight
+// DropRight returns a new list with the last n elements removed.
+// It only walks the elements kept, from the head of the list.
 func (l *List[T]) DropRight(n int) *List[T] {
-	return collection.DropRight(l, n).(*List[T])
+	return l.slice(0, l.size-clamp(n, l.size))
 }
 
 // Enqueue appends an element to the list.
@@ -281,23 +629,11 @@ func (l *List[T]) Enqueue(v T) {
 	l.Add(v)
 }
 
-// Equals takes a list and an equality function as arguments
-// and returns true if the two sequences are equal.
+// Equals takes an ordered collection and an equality function as arguments
+// and returns true if the list holds the same elements in the same order.
 // If you prefer not to pass an equality function use a ComparableList.
-func (l *List[T]) Equals(s *List[T], f func(T, T) bool) bool {
-	if l.size != s.size {
-		return false
-	}
-	n1 := l.head
-	n2 := s.head
-	for n1 != nil && n2 != nil {
-		if !f(n1.value, n2.value) {
-			return false
-		}
-		n1 = n1.next
-		n2 = n2.next
-	}
-	return true
+func (l *List[T]) Equals(s collection.OrderedCollection[T], f func(T, T) bool) bool {
+	return collection.EqualFunc(l, s, f)
 }
 
 // Exists is an alias for Contains
@@ -305,9 +641,9 @@ func (l *List[T]) Exists(f func(T) bool) bool {
 	return l.Contains(f)
 }
 
-// Filter is an alias for collection.Filter
+// Filter returns a new list containing the elements that satisfy the predicate.
 func (l *List[T]) Filter(f func(T) bool) *List[T] {
-	return collection.Filter(l, f).(*List[T])
+	return collection.FilterInto(l, NewList[T](), f)
 }
 
 // Filtered is an alias for collection.Filtered
@@ -315,9 +651,9 @@ func (l *List[T]) Filtered(f func(T) bool) iter.Seq[T] {
 	return collection.Filtered(l, f)
 }
 
-// FilterNot is an alias for collection.FilterNot
+// FilterNot returns a new list containing the elements that do not satisfy the predicate.
 func (l *List[T]) FilterNot(f func(T) bool) *List[T] {
-	return collection.FilterNot(l, f).(*List[T])
+	return collection.FilterInto(l, NewList[T](), func(v T) bool { return !f(v) })
 }
 
 // Find is an alias for collection.Find
@@ -330,24 +666,69 @@ func (l *List[T]) FindLast(f func(T) bool) (int, T) {
 	return collection.FindLast(l, f)
 }
 
+// FlatMap is the same-type variant of the package function FlatMap.
+func (l *List[T]) FlatMap(f func(T) *List[T]) *List[T] {
+	return FlatMap(l, f)
+}
+
+// Fold is the same-type variant of the package function Fold.
+func (l *List[T]) Fold(init T, f func(T, T) T) T {
+	return Fold(l, init, f)
+}
+
 // ForAll is an alias for collection.ForAll
 func (l *List[T]) ForAll(f func(T) bool) bool {
 	return collection.ForAll(l, f)
 }
 
+// Grouped returns an iterator over consecutive lists of n elements,
+// the last list holding the remaining elements.
+// It panics if n is less than 1.
+func (l *List[T]) Grouped(n int) iter.Seq[*List[T]] {
+	return l.Sliding(n, n)
+}
+
 // Head is an alias for collection.Head
 func (l *List[T]) Head() (T, error) {
 	return collection.Head(l)
 }
 
-// Init is an alias for collection.Init
+// IndexWhere is an alias for collection.IndexWhere
+func (l *List[T]) IndexWhere(f func(T) bool, from int) int {
+	return collection.IndexWhere(l, f, from)
+}
+
+// Init returns a new list containing all elements excluding the last one.
 func (l *List[T]) Init() *List[T] {
-	return collection.Init(l).(*List[T])
+	return l.slice(0, max(l.size-1, 0))
 }
 
-// Intersect is an alias for collection.IntersectFunc
+// InsertAt inserts a value at the given index, shifting the following elements
+// to the right. An index equal to the length of the list appends the value.
+// It panics if the index is out of bounds.
+func (l *List[T]) InsertAt(index int, v T) {
+	if index < 0 || index > l.size {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	switch index {
+	case 0:
+		l.AddFirst(v)
+	case l.size:
+		l.Add(v)
+	default:
+		next := l.nodeAt(index)
+		node := l.newNode(v)
+		l.link(next.prev, next, node)
+		l.cachePosition(node, index)
+	}
+}
+
+// Intersect returns a new list containing the elements of l that are also present in s,
+// using f as an equality function.
 func (l *List[T]) Intersect(s *List[T], f func(T, T) bool) *List[T] {
-	return collection.IntersectFunc(l, s, f).(*List[T])
+	return collection.FilterInto(l, NewList[T](), func(v T) bool {
+		return s.Contains(func(t T) bool { return f(v, t) })
+	})
 }
 
 // Intersected is an alias for collection.Intersected
@@ -357,7 +738,7 @@ func (l *List[T]) Intersected(s *List[T], f func(T, T) bool) iter.Seq[T] {
 
 // IsEmpty returns true if the list is empty.
 func (l *List[T]) IsEmpty() bool {
-	return l.size == 0
+	return l.Length() == 0
 }
 
 // Last is an alias for collection.Last
@@ -365,9 +746,51 @@ func (l *List[T]) Last() (T, error) {
 	return collection.Last(l)
 }
 
+// LastIndexWhere is an alias for collection.LastIndexWhere
+func (l *List[T]) LastIndexWhere(f func(T) bool, end int) int {
+	return collection.LastIndexWhere(l, f, end)
+}
+
+// Map returns a new list with f applied to each element.
+// Unlike Apply, it does not modify the list.
+func (l *List[T]) Map(f func(T) T) *List[T] {
+	return Map(l, f)
+}
+
+// MkString is an alias for collection.MkString
+func (l *List[T]) MkString(sep string) string {
+	return collection.MkString(l, sep)
+}
+
+// MkStringf is an alias for collection.MkStringf
+func (l *List[T]) MkStringf(prefix, sep, suffix, format string) string {
+	return collection.MkStringf(l, prefix, sep, suffix, format)
+}
+
 // NonEmpty returns true if the list is not empty.
 func (l *List[T]) NonEmpty() bool {
-	return l.size > 0
+	return l.Length() > 0
+}
+
+// OnRemove sets a function called with every element leaving the list,
+// i.e. to release the resources held by the elements: it is called by Pop,
+// Dequeue, RemoveAt, RemoveNode, Drain, DrainInto, Free and the Remove
+// method of the iterators, before they hand over the removed elements.
+// Lists derived from the list, i.e. by Clone or Filter, do not inherit it.
+// It returns the list, so that it can be set along with its creation.
+//
+// example usage:
+//
+//	conns := NewList[net.Conn]().OnRemove(func(c net.Conn) { c.Close() })
+//	conns.Add(conn)
+//	conns.Free()
+//
+// output:
+//
+//	conn is closed
+func (l *List[T]) OnRemove(f func(T)) *List[T] {
+	l.onRemove = f
+	return l
 }
 
 // Pop removes and returns the last element of the list.
@@ -375,10 +798,10 @@ func (l *List[T]) Pop() (T, error) {
 	if l.size == 0 {
 		return *new(T), collection.EmptyCollectionError
 	}
-	element := l.tail.value
-	l.tail = l.tail.prev
-	l.size--
-	return element, nil
+	node := l.tail
+	l.unlink(node)
+	l.removed(node.value)
+	return node.value, nil
 }
 
 // Push appends an element to the list.
@@ -386,38 +809,277 @@ func (l *List[T]) Push(v T) {
 	l.Add(v)
 }
 
-// Partition is an alias for collection.Partition
+// Pairwise is an alias for collection.Pairwise
+func (l *List[T]) Pairwise() iter.Seq2[T, T] {
+	return collection.Pairwise(l)
+}
+
+// Partition returns two new lists, the first one containing the elements
+// that satisfy the predicate and the second one containing the rest.
 func (l *List[T]) Partition(f func(T) bool) (*List[T], *List[T]) {
-	left, right := collection.Partition(l, f)
-	return left.(*List[T]), right.(*List[T])
+	return collection.PartitionInto(l, NewList[T](), NewList[T](), f)
+}
+
+// Set replaces the value at the given index.
+// If the index is out of bounds, it returns an error.
+func (l *List[T]) Set(index int, v T) error {
+	if index < 0 || index >= l.size {
+		return collection.IndexOutOfBoundsError
+	}
+	l.nodeAt(index).value = v
+	return nil
 }
 
 // SplitAt splits the list at the given index.
 func (l *List[T]) SplitAt(n int) (*List[T], *List[T]) {
-	left := NewList[T]()
-	right := NewList[T]()
-	for i, v := range l.All() {
-		if i <= n {
-			left.Add(v)
-		} else {
-			right.Add(v)
+	k := min(max(n+1, 0), l.size)
+	return l.slice(0, k), l.slice(k, l.size)
+}
+
+// Span returns two new lists containing the longest prefix of elements
+// satisfying the predicate and the rest of the elements,
+// see collection.SpanWhile.
+func (l *List[T]) Span(f func(T) bool) (*List[T], *List[T]) {
+	n := l.SegmentLength(f, 0)
+	return l.slice(0, n), l.slice(n, l.size)
+}
+
+// SliceOrErr returns a new list containing the elements between the start
+// and end indices. If the range is out of bounds, it returns an error.
+func (l *List[T]) SliceOrErr(start, end int) (*List[T], error) {
+	if start < 0 || end > l.size || start > end {
+		return nil, collection.IndexOutOfBoundsError
+	}
+	return l.slice(start, end), nil
+}
+
+// Rotate rotates the list in place by n positions to the left, moving its
+// first n elements after its last one, or by -n positions to the right if
+// n is negative. n may exceed the length of the list, which is rotated by
+// n modulo its length. The nodes are relinked without copying the values,
+// after a walk of at most half the list to the new head.
+//
+// example usage:
+//
+//	l := NewList([]int{1,2,3,4,5})
+//	l.Rotate(2)
+//
+// output:
+//
+//	List(int) [3 4 5 1 2]
+func (l *List[T]) Rotate(n int) {
+	if l.size < 2 {
+		return
+	}
+	n %= l.size
+	if n < 0 {
+		n += l.size
+	}
+	if n == 0 {
+		return
+	}
+	head := l.nodeAt(n)
+	l.tail.next, l.head.prev = l.head, l.tail
+	l.head, l.tail = head, head.prev
+	l.head.prev, l.tail.next = nil, nil
+	l.forgetPosition()
+}
+
+// RotateRight rotates the list in place by n positions to the right,
+// moving its last n elements before its first one, see Rotate.
+//
+// example usage:
+//
+//	l := NewList([]int{1,2,3,4,5})
+//	l.RotateRight(2)
+//
+// output:
+//
+//	List(int) [4 5 1 2 3]
+func (l *List[T]) RotateRight(n int) {
+	l.Rotate(-(n % max(l.size, 1)))
+}
+
+// Cycle returns an iterator looping over the values of the list forever,
+// starting over from the first value after the last one, until the
+// consumer breaks out of the loop or the list becomes empty. The current
+// value may be removed while iterating, i.e. to drop a finished task from
+// a round-robin schedule.
+//
+// example usage:
+//
+//	for worker := range workers.Cycle() {
+//	  if !worker.Submit(next()) {
+//	    break
+//	  }
+//	}
+func (l *List[T]) Cycle() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		node := l.head
+		for node != nil {
+			next := node.next
+			if !yield(node.value) {
+				return
+			}
+			if next == nil || (next.prev == nil && next != l.head) {
+				next = l.head
+			}
+			node = next
 		}
 	}
-	return left, right
 }
 
-// Reverse is an alias for collection.Reverse
+// Reverse returns a new list with the elements in reverse order.
 func (l *List[T]) Reverse() *List[T] {
-	return collection.Reverse(l).(*List[T])
+	return collection.ReverseInto(l, NewList[T]())
 }
 
+// Shuffle returns a new list with the elements in a random order.
 func (l *List[T]) Shuffle() *List[T] {
-	return collection.Shuffle(l).(*List[T])
+	return collection.ShuffleInto(l, NewList[T]())
+}
+
+// ShuffleWith is like Shuffle, drawing from r instead of the RandState of
+// the list, i.e. to shuffle deterministically in tests.
+func (l *List[T]) ShuffleWith(r *rand.Rand) *List[T] {
+	return collection.ShuffleIntoWith(l, NewList[T](), r)
+}
+
+// Sample returns a new list of n elements picked at random without
+// replacement, in a random order. If n is greater than the length of
+// the list, all the elements are returned shuffled.
+//
+// example usage:
+//
+//	c := NewList([]int{1,2,3,4,5})
+//	c.Sample(2)
+//
+// possible output:
+//
+//	[4,1]
+func (l *List[T]) Sample(n int) *List[T] {
+	return collection.SampleInto(l, NewList[T](), n)
+}
+
+// SampleWith is like Sample, drawing from r instead of the RandState of
+// the list, i.e. to sample deterministically in tests.
+//
+// example usage:
+//
+//	c.SampleWith(2, rand.New(rand.NewPCG(1, 2)))
+func (l *List[T]) SampleWith(n int, r *rand.Rand) *List[T] {
+	return collection.SampleIntoWith(l, NewList[T](), n, r)
+}
+
+// Sliding returns an iterator over windows of size consecutive elements,
+// each starting step elements after the previous one, as new lists.
+// It panics if size or step is less than 1.
+//
+// example usage:
+//
+//	l := NewList([]int{1,2,3,4,5})
+//	for w := range l.Sliding(3, 2) {
+//		fmt.Println(w)
+//	}
+//
+// output:
+//
+//	List(int) [1 2 3]
+//	List(int) [3 4 5]
+func (l *List[T]) Sliding(size, step int) iter.Seq[*List[T]] {
+	return seq.Map(seq.Sliding(l.Values(), size, step), func(w []T) *List[T] {
+		return NewList(w)
+	})
+}
+
+// Reduce is the same-type variant of the package function Reduce.
+func (l *List[T]) Reduce(f func(T, T) T) (T, error) {
+	return Reduce(l, f)
+}
+
+// ScanLeft is the same-type variant of the package function ScanLeft.
+func (l *List[T]) ScanLeft(init T, f func(T, T) T) *List[T] {
+	return ScanLeft(l, init, f)
+}
+
+// ScanRight is the same-type variant of the package function ScanRight.
+func (l *List[T]) ScanRight(init T, f func(T, T) T) *List[T] {
+	return ScanRight(l, init, f)
+}
+
+// SegmentLength is an alias for collection.SegmentLength
+func (l *List[T]) SegmentLength(f func(T) bool, from int) int {
+	return collection.SegmentLength(l, f, from)
+}
+
+// RemoveAt removes and returns the value at the given index.
+// If the index is out of bounds, it returns the zero value and an error.
+func (l *List[T]) RemoveAt(index int) (T, error) {
+	if index < 0 || index >= l.size {
+		return *new(T), collection.IndexOutOfBoundsError
+	}
+	node := l.nodeAt(index)
+	next := node.next
+	l.unlink(node)
+	if next != nil {
+		l.cachePosition(next, index)
+	}
+	l.removed(node.value)
+	return node.value, nil
 }
 
-// Reject is an alias for collection.FilterNot
+// RemoveFirst removes the first value satisfying the predicate f and
+// returns it along with true, or the zero value and false if no value
+// satisfies f. The value is unlinked in place in O(1).
+//
+// example usage:
+//
+//	l := NewList([]int{1,2,3,4})
+//	l.RemoveFirst(func(v int) bool { return v%2 == 0 })
+//	fmt.Println(l)
+//
+// output:
+//
+//	2 true
+//	List(int) [1 3 4]
+func (l *List[T]) RemoveFirst(f func(T) bool) (T, bool) {
+	for node := l.head; node != nil; node = node.next {
+		if f(node.value) {
+			return l.RemoveNode(node), true
+		}
+	}
+	return *new(T), false
+}
+
+// RemoveAll removes every value satisfying the predicate f in place, in a
+// single pass over the list, and returns the number of values removed.
+//
+// example usage:
+//
+//	l := NewList([]int{1,2,3,4})
+//	l.RemoveAll(func(v int) bool { return v%2 == 0 })
+//	fmt.Println(l)
+//
+// output:
+//
+//	2
+//	List(int) [1 3]
+func (l *List[T]) RemoveAll(f func(T) bool) int {
+	n := 0
+	for node := l.head; node != nil; {
+		next := node.next
+		if f(node.value) {
+			l.RemoveNode(node)
+			n++
+		}
+		node = next
+	}
+	return n
+}
+
+// Reject is an alias for FilterNot
 func (l *List[T]) Reject(f func(T) bool) *List[T] {
-	return collection.FilterNot(l, f).(*List[T])
+	return l.FilterNot(f)
 }
 
 // Rejected is an alias for collection.Rejected
@@ -425,17 +1087,54 @@ func (l *List[T]) Rejected(f func(T) bool) iter.Seq[T] {
 	return collection.Rejected(l, f)
 }
 
-// Take is an alias for collection.Take
+// Take returns a new list containing the first n elements.
 func (l *List[T]) Take(n int) *List[T] {
-	return collection.Take(l, n).(*List[T])
+	return l.slice(0, clamp(n, l.size))
 }
 
-// TakeRight is an alias for collection.TakeRight
+// TakeRight returns a new list containing the last n elements.
+// It walks the list backward, visiting only the elements it copies.
 func (l *List[T]) TakeRight(n int) *List[T] {
-	return collection.TakeRight(l, n).(*List[T])
+	list := &List[T]{}
+	for _, v := range l.Backward() {
+		if list.size >= n {
+			break
+		}
+		list.AddFirst(v)
+	}
+	return list
+}
+
+// TakeWhile returns a new list containing the longest prefix of elements
+// satisfying the predicate.
+func (l *List[T]) TakeWhile(f func(T) bool) *List[T] {
+	return l.slice(0, l.SegmentLength(f, 0))
 }
 
-// Tail is an alias for collection.Tail
+// Tail returns a new list containing all elements excluding the first one.
 func (l *List[T]) Tail() *List[T] {
-	return collection.Tail(l).(*List[T])
+	return l.slice(min(1, l.size), l.size)
+}
+
+// Union returns a new list containing the elements of l followed by the
+// elements of s that are not present in l, using f as an equality function.
+// Duplicates within l or within s are kept. It runs in O(n*m), a
+// ComparableList unions in O(n+m).
+//
+// example usage:
+//
+//	a := NewList([]int{1,2,3})
+//	b := NewList([]int{3,4,4})
+//	a.Union(b, func(x, y int) bool { return x == y })
+//
+// output:
+//
+//	List(int) [1 2 3 4 4]
+func (l *List[T]) Union(s *List[T], f func(T, T) bool) *List[T] {
+	return collection.UnionFuncInto(l, s, NewList[T](), f)
+}
+
+// clamp limits n to the range [0, size].
+func clamp(n, size int) int {
+	return min(max(n, 0), size)
 }
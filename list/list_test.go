@@ -1,9 +1,13 @@
 package list
 
 import (
+	"context"
+	"math/rand/v2"
 	"reflect"
 	"slices"
 	"testing"
+
+	"github.com/charbz/gophers/collection"
 )
 
 func TestList_Head(t *testing.T) {
@@ -122,6 +126,12 @@ func TestList_DropRight(t *testing.T) {
 			n:     5,
 			want:  []int{},
 		},
+		{
+			name:  "drop right negative number",
+			slice: []int{1, 2, 3},
+			n:     -1,
+			want:  []int{1, 2, 3},
+		},
 	}
 
 	for _, tt := range tests {
@@ -351,6 +361,27 @@ func TestList_DropWhile(t *testing.T) {
 	}
 }
 
+func TestList_Span(t *testing.T) {
+	l := NewList([]int{1, 2, 3, 4, 1, 2})
+	isLessThan3 := func(i int) bool { return i < 3 }
+	prefix, rest := l.Span(isLessThan3)
+	if !slices.Equal(prefix.ToSlice(), []int{1, 2}) || !slices.Equal(rest.ToSlice(), []int{3, 4, 1, 2}) {
+		t.Errorf("Span() = %v, %v", prefix, rest)
+	}
+	if got := l.TakeWhile(isLessThan3).ToSlice(); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("TakeWhile() = %v", got)
+	}
+	if got := l.IndexWhere(isLessThan3, 2); got != 4 {
+		t.Errorf("IndexWhere() = %v, want 4", got)
+	}
+	if got := l.LastIndexWhere(isLessThan3, 3); got != 1 {
+		t.Errorf("LastIndexWhere() = %v, want 1", got)
+	}
+	if got := l.SegmentLength(isLessThan3, 4); got != 2 {
+		t.Errorf("SegmentLength() = %v, want 2", got)
+	}
+}
+
 func TestList_Equals(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -919,6 +950,70 @@ func TestList_ShuffleRandomization(t *testing.T) {
 	}
 }
 
+func TestList_Sample(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		n     int
+		want  int
+	}{
+		{name: "some", input: []int{1, 2, 3, 4, 5}, n: 2, want: 2},
+		{name: "more than length", input: []int{1, 2, 3}, n: 5, want: 3},
+		{name: "empty", input: []int{}, n: 2, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewList(tt.input)
+			sample := l.SampleWith(tt.n, rand.New(rand.NewPCG(7, 7)))
+			if sample.Length() != tt.want {
+				t.Fatalf("SampleWith(%d) = %v, want %d elements", tt.n, sample, tt.want)
+			}
+			if got := l.SampleWith(tt.n, rand.New(rand.NewPCG(7, 7))); !slices.Equal(got.ToSlice(), sample.ToSlice()) {
+				t.Errorf("SampleWith(%d) with the same seed = %v and %v", tt.n, sample, got)
+			}
+			if got := l.Sample(tt.n); got.Length() != tt.want {
+				t.Errorf("Sample(%d) = %v, want %d elements", tt.n, got, tt.want)
+			}
+			if got := len(slices.Compact(slices.Sorted(sample.Values()))); got != tt.want {
+				t.Errorf("SampleWith(%d) = %v, holds duplicates", tt.n, sample)
+			}
+		})
+	}
+}
+
+func TestList_ShuffleWith(t *testing.T) {
+	l := NewComparableList([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	a := l.ShuffleWith(rand.New(rand.NewPCG(1, 2)))
+	b := l.ShuffleWith(rand.New(rand.NewPCG(1, 2)))
+	if !slices.Equal(a.ToSlice(), b.ToSlice()) {
+		t.Errorf("ShuffleWith() with the same seed = %v and %v", a, b)
+	}
+	if sum := a.Sum(); sum != 36 {
+		t.Errorf("ShuffleWith() = %v, not a permutation of %v", a, l)
+	}
+	if sample := l.SampleWith(3, rand.New(rand.NewPCG(1, 2))); sample.Length() != 3 {
+		t.Errorf("SampleWith(3) = %v", sample)
+	}
+}
+
+func TestList_RandState(t *testing.T) {
+	run := func() ([]int, []int) {
+		l := NewList([]int{1, 2, 3, 4, 5, 6, 7, 8})
+		l.SetRandState(collection.NewRandState(42))
+		var picks []int
+		for range 5 {
+			picks = append(picks, l.Random())
+		}
+		return l.Shuffle().ToSlice(), picks
+	}
+	shuffled, picks := run()
+	for range 3 {
+		if s, p := run(); !slices.Equal(s, shuffled) || !slices.Equal(p, picks) {
+			t.Errorf("replay = %v %v, want %v %v", s, p, shuffled, picks)
+		}
+	}
+}
+
 func TestList_ShuffleDistribution(t *testing.T) {
 	input := []int{1, 2, 3, 4}
 	list := NewList(input)
@@ -949,3 +1044,592 @@ func TestList_ShuffleDistribution(t *testing.T) {
 		}
 	}
 }
+
+func TestList_IteratorAllocs(t *testing.T) {
+	small := NewList([]int{1})
+	large := NewList(make([]int, 1000))
+	count := func(l *List[int]) func() {
+		return func() {
+			n := 0
+			for range l.Values() {
+				n++
+			}
+			for range l.All() {
+				n++
+			}
+			for range l.Backward() {
+				n++
+			}
+		}
+	}
+	if s, l := testing.AllocsPerRun(100, count(small)), testing.AllocsPerRun(100, count(large)); l > s {
+		t.Errorf("iterating 1000 elements allocated %v times, 1 element allocated %v times", l, s)
+	}
+}
+
+func BenchmarkList_Values(b *testing.B) {
+	l := NewList(make([]int, 1<<12))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		sum := 0
+		for v := range l.Values() {
+			sum += v
+		}
+	}
+}
+
+func TestList_AppendToSlice(t *testing.T) {
+	tests := []struct {
+		name  string
+		dst   []int
+		slice []int
+		want  []int
+	}{
+		{name: "append to nil", dst: nil, slice: []int{1, 2, 3}, want: []int{1, 2, 3}},
+		{name: "append to existing", dst: []int{9}, slice: []int{1, 2}, want: []int{9, 1, 2}},
+		{name: "append empty list", dst: []int{9}, slice: []int{}, want: []int{9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewList(tt.slice).AppendToSlice(tt.dst); !slices.Equal(got, tt.want) {
+				t.Errorf("AppendToSlice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestList_ToSliceAllocs(t *testing.T) {
+	l := NewList(make([]int, 100))
+	buf := make([]int, 0, 100)
+	if n := testing.AllocsPerRun(100, func() { l.ToSlice() }); n != 1 {
+		t.Errorf("ToSlice() allocated %v times, want 1", n)
+	}
+	if n := testing.AllocsPerRun(100, func() { buf = l.AppendToSlice(buf[:0]) }); n != 0 {
+		t.Errorf("AppendToSlice() into a large enough buffer allocated %v times, want 0", n)
+	}
+}
+
+func TestList_SliceBidirectional(t *testing.T) {
+	values := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	tests := []struct {
+		name  string
+		start int
+		end   int
+	}{
+		{name: "near head", start: 1, end: 3},
+		{name: "near tail", start: 7, end: 9},
+		{name: "up to tail", start: 6, end: 10},
+		{name: "whole list", start: 0, end: 10},
+		{name: "empty range", start: 5, end: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewList(values).slice(tt.start, tt.end)
+			want := values[tt.start:tt.end]
+			if !slices.Equal(got.ToSlice(), want) {
+				t.Errorf("slice() = %v, want %v", got.ToSlice(), want)
+			}
+			if got.Length() != len(want) {
+				t.Errorf("slice() length = %v, want %v", got.Length(), len(want))
+			}
+			var backward []int
+			for _, v := range got.Backward() {
+				backward = append(backward, v)
+			}
+			slices.Reverse(backward)
+			if !slices.Equal(backward, want) {
+				t.Errorf("slice() backward links = %v, want %v", backward, want)
+			}
+		})
+	}
+}
+
+// assertLinks checks that the list holds want when walked in both directions.
+func assertLinks[T comparable](t *testing.T, l *List[T], want []T) {
+	t.Helper()
+	if got := l.ToSlice(); !slices.Equal(got, want) {
+		t.Errorf("forward = %v, want %v", got, want)
+	}
+	var backward []T
+	for _, v := range l.Backward() {
+		backward = append(backward, v)
+	}
+	slices.Reverse(backward)
+	if !slices.Equal(backward, want) {
+		t.Errorf("backward = %v, want %v", backward, want)
+	}
+	if l.Length() != len(want) {
+		t.Errorf("Length() = %v, want %v", l.Length(), len(want))
+	}
+}
+
+func TestList_InsertAt(t *testing.T) {
+	tests := []struct {
+		name  string
+		slice []int
+		index int
+		want  []int
+	}{
+		{name: "head", slice: []int{1, 2, 3}, index: 0, want: []int{9, 1, 2, 3}},
+		{name: "middle", slice: []int{1, 2, 3}, index: 2, want: []int{1, 2, 9, 3}},
+		{name: "tail", slice: []int{1, 2, 3}, index: 3, want: []int{1, 2, 3, 9}},
+		{name: "empty list", slice: []int{}, index: 0, want: []int{9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewList(tt.slice)
+			l.InsertAt(tt.index, 9)
+			assertLinks(t, l, tt.want)
+		})
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("InsertAt() out of bounds did not panic")
+		}
+	}()
+	NewList([]int{1}).InsertAt(2, 9)
+}
+
+func TestList_RemoveAt(t *testing.T) {
+	tests := []struct {
+		name    string
+		slice   []int
+		index   int
+		removed int
+		want    []int
+		err     error
+	}{
+		{name: "head", slice: []int{1, 2, 3}, index: 0, removed: 1, want: []int{2, 3}},
+		{name: "middle", slice: []int{1, 2, 3}, index: 1, removed: 2, want: []int{1, 3}},
+		{name: "tail", slice: []int{1, 2, 3}, index: 2, removed: 3, want: []int{1, 2}},
+		{name: "only element", slice: []int{1}, index: 0, removed: 1, want: []int{}},
+		{name: "out of bounds", slice: []int{1, 2}, index: 2, want: []int{1, 2}, err: collection.IndexOutOfBoundsError},
+		{name: "negative", slice: []int{1, 2}, index: -1, want: []int{1, 2}, err: collection.IndexOutOfBoundsError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewList(tt.slice)
+			got, err := l.RemoveAt(tt.index)
+			if err != tt.err || got != tt.removed {
+				t.Errorf("RemoveAt() = %v, %v, want %v, %v", got, err, tt.removed, tt.err)
+			}
+			assertLinks(t, l, tt.want)
+		})
+	}
+}
+
+func TestList_RemoveFirst(t *testing.T) {
+	even := func(v int) bool { return v%2 == 0 }
+	tests := []struct {
+		name    string
+		slice   []int
+		removed int
+		ok      bool
+		want    []int
+	}{
+		{name: "head", slice: []int{2, 1, 4}, removed: 2, ok: true, want: []int{1, 4}},
+		{name: "middle", slice: []int{1, 4, 2}, removed: 4, ok: true, want: []int{1, 2}},
+		{name: "tail", slice: []int{1, 3, 6}, removed: 6, ok: true, want: []int{1, 3}},
+		{name: "no match", slice: []int{1, 3}, want: []int{1, 3}},
+		{name: "empty", slice: []int{}, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewList(tt.slice)
+			got, ok := l.RemoveFirst(even)
+			if got != tt.removed || ok != tt.ok {
+				t.Errorf("RemoveFirst() = %v, %v, want %v, %v", got, ok, tt.removed, tt.ok)
+			}
+			assertLinks(t, l, tt.want)
+		})
+	}
+}
+
+func TestList_RemoveAll(t *testing.T) {
+	even := func(v int) bool { return v%2 == 0 }
+	tests := []struct {
+		name  string
+		slice []int
+		n     int
+		want  []int
+	}{
+		{name: "mixed", slice: []int{2, 1, 4, 3, 6}, n: 3, want: []int{1, 3}},
+		{name: "adjacent", slice: []int{1, 2, 4, 3}, n: 2, want: []int{1, 3}},
+		{name: "all", slice: []int{2, 4}, n: 2, want: []int{}},
+		{name: "none", slice: []int{1, 3}, n: 0, want: []int{1, 3}},
+		{name: "empty", slice: []int{}, n: 0, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewList(tt.slice)
+			var removed []int
+			l.OnRemove(func(v int) { removed = append(removed, v) })
+			if n := l.RemoveAll(even); n != tt.n {
+				t.Errorf("RemoveAll() = %v, want %v", n, tt.n)
+			}
+			if len(removed) != tt.n {
+				t.Errorf("RemoveAll() called OnRemove with %v", removed)
+			}
+			assertLinks(t, l, tt.want)
+		})
+	}
+}
+
+func TestList_Set(t *testing.T) {
+	l := NewList([]string{"a", "b", "c"})
+	for i, v := range []string{"x", "y", "z"} {
+		if err := l.Set(i, v); err != nil {
+			t.Errorf("Set(%d) error = %v", i, err)
+		}
+	}
+	assertLinks(t, l, []string{"x", "y", "z"})
+	if err := l.Set(3, "w"); err != collection.IndexOutOfBoundsError {
+		t.Errorf("Set() out of bounds error = %v, want %v", err, collection.IndexOutOfBoundsError)
+	}
+}
+
+func TestList_AddFirst(t *testing.T) {
+	l := NewList[int]()
+	l.AddFirst(2)
+	l.AddFirst(1)
+	l.Add(3)
+	assertLinks(t, l, []int{1, 2, 3})
+}
+
+func TestList_DrainAndReuse(t *testing.T) {
+	tests := []struct {
+		name  string
+		drain func(*List[int]) (int, error)
+	}{
+		{name: "dequeue", drain: (*List[int]).Dequeue},
+		{name: "pop", drain: (*List[int]).Pop},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewList([]int{1, 2})
+			tt.drain(l)
+			tt.drain(l)
+			if l.head != nil || l.tail != nil {
+				t.Errorf("head and tail not reset after draining the list")
+			}
+			l.Add(3)
+			l.AddFirst(4)
+			assertLinks(t, l, []int{4, 3})
+		})
+	}
+}
+
+func TestList_Drain(t *testing.T) {
+	tests := []struct {
+		name     string
+		stopAt   int
+		cancel   bool
+		want     []int
+		wantLeft []int
+	}{
+		{name: "full drain", stopAt: -1, want: []int{1, 2, 3, 4}, wantLeft: []int{}},
+		{name: "early break", stopAt: 2, want: []int{1, 2}, wantLeft: []int{3, 4}},
+		{name: "canceled context", stopAt: 1, cancel: true, want: []int{1}, wantLeft: []int{2, 3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewList([]int{1, 2, 3, 4})
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			got := []int{}
+			for v := range l.Drain(ctx) {
+				got = append(got, v)
+				if v == tt.stopAt {
+					if !tt.cancel {
+						break
+					}
+					cancel()
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Drain() = %v, want %v", got, tt.want)
+			}
+			assertLinks(t, l, tt.wantLeft)
+		})
+	}
+}
+
+func TestList_DrainAddDuringIteration(t *testing.T) {
+	l := NewList([]int{1, 2, 3})
+	got := []int{}
+	for v := range l.Drain(context.Background()) {
+		got = append(got, v)
+		if v == 1 {
+			l.Add(10)
+		}
+	}
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Drain() = %v, want [1 2 3]", got)
+	}
+	assertLinks(t, l, []int{10})
+}
+
+func TestList_DrainInto(t *testing.T) {
+	l := NewList([]int{1, 2, 3})
+	dst := NewList([]int{0})
+	if n := l.DrainInto(dst); n != 3 {
+		t.Errorf("DrainInto() = %v, want 3", n)
+	}
+	assertLinks(t, l, []int{})
+	assertLinks(t, dst, []int{0, 1, 2, 3})
+}
+
+func TestList_SlidingAndGrouped(t *testing.T) {
+	l := NewList([]int{1, 2, 3, 4, 5})
+	var windows []string
+	for w := range l.Sliding(3, 2) {
+		windows = append(windows, w.String())
+	}
+	if want := []string{"List(int) [1 2 3]", "List(int) [3 4 5]"}; !slices.Equal(windows, want) {
+		t.Errorf("Sliding() = %v, want %v", windows, want)
+	}
+	var chunks []string
+	for chunk := range l.Grouped(2) {
+		chunks = append(chunks, chunk.String())
+		assertLinks(t, chunk, chunk.ToSlice())
+	}
+	if want := []string{"List(int) [1 2]", "List(int) [3 4]", "List(int) [5]"}; !slices.Equal(chunks, want) {
+		t.Errorf("Grouped() = %v, want %v", chunks, want)
+	}
+}
+
+func TestList_OrErrAccessors(t *testing.T) {
+	l := NewComparableList([]int{1, 2, 3})
+	if v, err := l.AtOrErr(2); err != nil || v != 3 {
+		t.Errorf("AtOrErr(2) = %v, %v, want 3, nil", v, err)
+	}
+	if _, err := l.AtOrErr(3); err != collection.IndexOutOfBoundsError {
+		t.Errorf("AtOrErr(3) error = %v, want %v", err, collection.IndexOutOfBoundsError)
+	}
+	if v := l.AtOrElse(-1, 0); v != 0 {
+		t.Errorf("AtOrElse(-1) = %v, want 0", v)
+	}
+	if s, err := l.SliceOrErr(1, 3); err != nil || !slices.Equal(s.ToSlice(), []int{2, 3}) {
+		t.Errorf("SliceOrErr(1, 3) = %v, %v, want [2 3], nil", s, err)
+	}
+	if _, err := l.SliceOrErr(1, 4); err != collection.IndexOutOfBoundsError {
+		t.Errorf("SliceOrErr(1, 4) error = %v, want %v", err, collection.IndexOutOfBoundsError)
+	}
+}
+
+func TestNewListFromSeq(t *testing.T) {
+	l := NewListFromSeq(slices.Values([]int{1, 2, 3}))
+	if got := l.ToSlice(); !slices.Equal(got, []int{1, 2, 3}) || l.Length() != 3 {
+		t.Errorf("NewListFromSeq() = %v, want [1 2 3]", l)
+	}
+}
+
+func TestList_Constructors(t *testing.T) {
+	tests := []struct {
+		name string
+		list *List[int]
+		want []int
+	}{
+		{name: "Tabulate", list: Tabulate(4, func(i int) int { return i * i }), want: []int{0, 1, 4, 9}},
+		{name: "Tabulate none", list: Tabulate(0, func(i int) int { return i }), want: []int{}},
+		{name: "Fill", list: Fill(3, 7), want: []int{7, 7, 7}},
+		{name: "Fill negative", list: Fill(-1, 7), want: []int{}},
+		{name: "RangeList", list: RangeList(0, 10, 3), want: []int{0, 3, 6, 9}},
+		{name: "RangeList down", list: RangeList(10, 0, -3), want: []int{10, 7, 4, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertLinks(t, tt.list, tt.want)
+		})
+	}
+}
+
+func TestList_Rotate(t *testing.T) {
+	tests := []struct {
+		name  string
+		slice []int
+		n     int
+		want  []int
+	}{
+		{name: "left", slice: []int{1, 2, 3, 4, 5}, n: 2, want: []int{3, 4, 5, 1, 2}},
+		{name: "right", slice: []int{1, 2, 3, 4, 5}, n: -2, want: []int{4, 5, 1, 2, 3}},
+		{name: "by length", slice: []int{1, 2, 3}, n: 3, want: []int{1, 2, 3}},
+		{name: "beyond length", slice: []int{1, 2, 3}, n: 7, want: []int{2, 3, 1}},
+		{name: "by last", slice: []int{1, 2, 3}, n: 2, want: []int{3, 1, 2}},
+		{name: "zero", slice: []int{1, 2, 3}, n: 0, want: []int{1, 2, 3}},
+		{name: "single", slice: []int{1}, n: 5, want: []int{1}},
+		{name: "empty", slice: []int{}, n: 1, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewList(tt.slice)
+			l.Rotate(tt.n)
+			assertLinks(t, l, tt.want)
+			r := NewList(tt.slice)
+			r.RotateRight(-tt.n)
+			assertLinks(t, r, tt.want)
+		})
+	}
+	l := NewList([]int{1, 2, 3, 4}).CachePositions(true)
+	l.At(1)
+	l.Rotate(1)
+	if got := l.At(1); got != 3 {
+		t.Errorf("At(1) after Rotate(1) = %v, want 3", got)
+	}
+}
+
+func TestList_Cycle(t *testing.T) {
+	l := NewList([]int{1, 2, 3})
+	var got []int
+	for v := range l.Cycle() {
+		got = append(got, v)
+		if len(got) == 7 {
+			break
+		}
+	}
+	if want := []int{1, 2, 3, 1, 2, 3, 1}; !slices.Equal(got, want) {
+		t.Errorf("Cycle() = %v, want %v", got, want)
+	}
+
+	got = nil
+	for v := range l.Cycle() {
+		got = append(got, v)
+		if v == 2 || len(got) > 2 {
+			l.RemoveFirst(func(x int) bool { return x == v })
+		}
+	}
+	if want := []int{1, 2, 3, 1}; !slices.Equal(got, want) || l.NonEmpty() {
+		t.Errorf("Cycle() removing values = %v, left %v, want %v", got, l, want)
+	}
+
+	for range NewList[int]().Cycle() {
+		t.Fatal("Cycle() of an empty list yielded a value")
+	}
+}
+
+func TestNewListFromChannel(t *testing.T) {
+	t.Run("closed", func(t *testing.T) {
+		ch := make(chan int, 3)
+		ch <- 1
+		ch <- 2
+		close(ch)
+		l, err := NewListFromChannel(context.Background(), ch)
+		if err != nil || !slices.Equal(l.ToSlice(), []int{1, 2}) {
+			t.Errorf("NewListFromChannel() = %v, %v, want [1 2], nil", l, err)
+		}
+	})
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan int)
+		go func() {
+			ch <- 1
+			cancel()
+		}()
+		l, err := NewListFromChannel(ctx, ch)
+		if err != context.Canceled || !slices.Equal(l.ToSlice(), []int{1}) {
+			t.Errorf("NewListFromChannel() = %v, %v, want [1], %v", l, err, context.Canceled)
+		}
+	})
+}
+
+func TestList_ZeroValue(t *testing.T) {
+	var l List[int]
+	if l.Length() != 0 || !l.IsEmpty() || l.String() != "List(int) []" {
+		t.Errorf("zero value = %v, want an empty list", &l)
+	}
+	l.Add(2)
+	l.AddFirst(1)
+	l.InsertAt(2, 3)
+	if got := l.ToSlice(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("ToSlice() = %v, want [1 2 3]", got)
+	}
+}
+
+func TestList_NilReceiver(t *testing.T) {
+	var l *List[int]
+	pairs := 0
+	for range l.Backward() {
+		pairs++
+	}
+	for range l.All() {
+		pairs++
+	}
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "length", got: l.Length(), want: 0},
+		{name: "is empty", got: l.IsEmpty(), want: true},
+		{name: "non empty", got: l.NonEmpty(), want: false},
+		{name: "values", got: len(slices.Collect(l.Values())), want: 0},
+		{name: "all and backward", got: pairs, want: 0},
+		{name: "to slice", got: len(l.ToSlice()), want: 0},
+		{name: "first node", got: l.FirstNode() == nil, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestList_OnRemove(t *testing.T) {
+	tests := []struct {
+		name   string
+		remove func(l *List[int])
+		want   []int
+		rest   []int
+	}{
+		{name: "Pop", remove: func(l *List[int]) { l.Pop() }, want: []int{4}, rest: []int{1, 2, 3}},
+		{name: "Dequeue", remove: func(l *List[int]) { l.Dequeue() }, want: []int{1}, rest: []int{2, 3, 4}},
+		{name: "RemoveAt", remove: func(l *List[int]) { l.RemoveAt(2) }, want: []int{3}, rest: []int{1, 2, 4}},
+		{name: "RemoveAt out of bounds", remove: func(l *List[int]) { l.RemoveAt(4) }, want: nil, rest: []int{1, 2, 3, 4}},
+		{name: "RemoveNode", remove: func(l *List[int]) { l.RemoveNode(l.FirstNode().next) }, want: []int{2}, rest: []int{1, 3, 4}},
+		{name: "Iterator", remove: func(l *List[int]) {
+			it := l.Iterator()
+			for v, ok := it.Next(); ok; v, ok = it.Next() {
+				if v%2 == 0 {
+					it.Remove()
+				}
+			}
+		}, want: []int{2, 4}, rest: []int{1, 3}},
+		{name: "Drain", remove: func(l *List[int]) {
+			for v := range l.Drain(context.Background()) {
+				if v == 2 {
+					break
+				}
+			}
+		}, want: []int{1, 2}, rest: []int{3, 4}},
+		{name: "DrainInto", remove: func(l *List[int]) { l.DrainInto(NewList[int]()) }, want: []int{1, 2, 3, 4}, rest: []int{}},
+		{name: "Free", remove: func(l *List[int]) { l.Free() }, want: []int{1, 2, 3, 4}, rest: []int{}},
+		{name: "UnmarshalJSON", remove: func(l *List[int]) { l.UnmarshalJSON([]byte("[5]")) }, want: []int{1, 2, 3, 4}, rest: []int{5}},
+		{name: "Filter", remove: func(l *List[int]) { l.Filter(func(v int) bool { return v > 2 }) }, want: nil, rest: []int{1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var removed []int
+			l := NewList([]int{1, 2, 3, 4}).OnRemove(func(v int) { removed = append(removed, v) })
+			tt.remove(l)
+			if !slices.Equal(removed, tt.want) {
+				t.Errorf("removed %v, want %v", removed, tt.want)
+			}
+			if got := l.ToSlice(); !slices.Equal(got, tt.rest) {
+				t.Errorf("ToSlice() = %v, want %v", got, tt.rest)
+			}
+		})
+	}
+}
+
+func TestList_MkString(t *testing.T) {
+	l := NewList([]string{"a", "b", "c"})
+	if got := l.MkString(", "); got != "a, b, c" {
+		t.Errorf("MkString() = %q, want %q", got, "a, b, c")
+	}
+	if got := l.MkStringf("<", "|", ">", "%q"); got != `<"a"|"b"|"c">` {
+		t.Errorf("MkStringf() = %q", got)
+	}
+}
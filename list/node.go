@@ -0,0 +1,84 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// node.go defines the methods working on node handles, to remove or move
+// a known element in O(1), i.e. to build an LRU cache out of a List and
+// a map of handles.
+
+package list
+
+// Value returns the value of the node.
+func (n *Node[T]) Value() T {
+	return n.value
+}
+
+// SetValue replaces the value of the node.
+func (n *Node[T]) SetValue(v T) {
+	n.value = v
+}
+
+// AddNode adds a value to the end of the list and returns its node.
+func (l *List[T]) AddNode(v T) *Node[T] {
+	return l.insertBetween(l.tail, nil, v)
+}
+
+// AddFirstNode adds a value to the beginning of the list and returns its node.
+func (l *List[T]) AddFirstNode(v T) *Node[T] {
+	return l.insertBetween(nil, l.head, v)
+}
+
+// FirstNode returns the node of the first element, or nil if the list is empty.
+func (l *List[T]) FirstNode() *Node[T] {
+	if l == nil {
+		return nil
+	}
+	return l.head
+}
+
+// LastNode returns the node of the last element, or nil if the list is empty.
+func (l *List[T]) LastNode() *Node[T] {
+	if l == nil {
+		return nil
+	}
+	return l.tail
+}
+
+// RemoveNode removes the node from the list in O(1) and returns its value.
+// The node must belong to the list, and must not be used after it is removed.
+//
+// example usage:
+//
+//	l := NewList([]int{1,2})
+//	n := l.AddNode(3)
+//	l.AddFirst(0)
+//	l.RemoveNode(n)
+//
+// output:
+//
+//	List(int) [0 1 2]
+func (l *List[T]) RemoveNode(n *Node[T]) T {
+	l.unlink(n)
+	l.removed(n.value)
+	return n.value
+}
+
+// MoveToBack moves the node to the end of the list in O(1).
+// The node must belong to the list.
+func (l *List[T]) MoveToBack(n *Node[T]) {
+	if l.tail == n {
+		return
+	}
+	l.unlink(n)
+	l.link(l.tail, nil, n)
+}
+
+// MoveToFront moves the node to the beginning of the list in O(1).
+// The node must belong to the list.
+func (l *List[T]) MoveToFront(n *Node[T]) {
+	if l.head == n {
+		return
+	}
+	l.unlink(n)
+	l.link(nil, l.head, n)
+}
@@ -0,0 +1,36 @@
+package list
+
+import "testing"
+
+func TestList_NodeHandles(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  func(l *List[int], first, last *Node[int])
+		want []int
+	}{
+		{name: "remove first", ops: func(l *List[int], first, _ *Node[int]) { l.RemoveNode(first) }, want: []int{1, 2, 3}},
+		{name: "remove last", ops: func(l *List[int], _, last *Node[int]) { l.RemoveNode(last) }, want: []int{0, 1, 2}},
+		{name: "move last to front", ops: func(l *List[int], _, last *Node[int]) { l.MoveToFront(last) }, want: []int{3, 0, 1, 2}},
+		{name: "move first to back", ops: func(l *List[int], first, _ *Node[int]) { l.MoveToBack(first) }, want: []int{1, 2, 3, 0}},
+		{name: "move front to front", ops: func(l *List[int], first, _ *Node[int]) { l.MoveToFront(first) }, want: []int{0, 1, 2, 3}},
+		{name: "set value", ops: func(_ *List[int], first, _ *Node[int]) { first.SetValue(first.Value() - 1) }, want: []int{-1, 1, 2, 3}},
+		{name: "remove all", ops: func(l *List[int], first, last *Node[int]) {
+			l.RemoveNode(first)
+			l.RemoveNode(last)
+			l.RemoveNode(l.FirstNode())
+			l.RemoveNode(l.LastNode())
+			if l.FirstNode() != nil || l.LastNode() != nil {
+				t.Errorf("FirstNode() or LastNode() of an empty list is not nil")
+			}
+		}, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewList([]int{1, 2})
+			last := l.AddNode(3)
+			first := l.AddFirstNode(0)
+			tt.ops(l, first, last)
+			assertLinks(t, l, tt.want)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// position.go defines the position cache of a List, remembering the last
+// node reached by index so that the next walk can start from it.
+
+package list
+
+// position is the last node reached by index, and its index.
+type position[T any] struct {
+	node  *Node[T] // nil when unknown
+	index int
+}
+
+// CachePositions enables or disables the position cache of the list and
+// returns the list. With the cache, the list remembers the last node reached
+// by index, i.e. by At, Set, InsertAt or RemoveAt, and starts the next walk
+// from the closest of that node and the two ends of the list. Accessing
+// neighbouring indices in a row, as in a loop over At(i), then runs in O(1)
+// instead of O(n) per access.
+//
+// Reading a list with a position cache updates the cache, so a list with
+// the cache enabled must not be read concurrently. Iterating with Values,
+// All or Iterator remains the cheapest way to visit every element.
+//
+// example usage:
+//
+//	l := NewList(rows).CachePositions(true)
+//	for i := range l.Length() {
+//	  process(l.At(i))
+//	}
+func (l *List[T]) CachePositions(enabled bool) *List[T] {
+	switch {
+	case !enabled:
+		l.position = nil
+	case l.position == nil:
+		l.position = new(position[T])
+	}
+	return l
+}
+
+// cachedNodeAt is nodeAt for lists with a position cache, walking from the
+// cached position when it is closer than both ends. The index must be in
+// bounds.
+func (l *List[T]) cachedNodeAt(index int) *Node[T] {
+	node, i := l.head, 0
+	if index >= l.size/2 {
+		node, i = l.tail, l.size-1
+	}
+	if p := l.position; p.node != nil && abs(p.index-index) < abs(i-index) {
+		node, i = p.node, p.index
+	}
+	for ; i < index; i++ {
+		node = node.next
+	}
+	for ; i > index; i-- {
+		node = node.prev
+	}
+	l.position.node, l.position.index = node, index
+	return node
+}
+
+// cachePosition records the node at the given index, if the list has a
+// position cache.
+func (l *List[T]) cachePosition(node *Node[T], index int) {
+	if l.position != nil {
+		l.position.node, l.position.index = node, index
+	}
+}
+
+// forgetPosition clears the position cache, if the list has one. It is
+// called whenever nodes are linked or unlinked before the cached node,
+// which shifts its index.
+func (l *List[T]) forgetPosition() {
+	if l.position != nil {
+		l.position.node = nil
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,100 @@
+package list
+
+import (
+	"cmp"
+	"math/rand"
+	"testing"
+
+	"github.com/charbz/gophers/collectiontest"
+)
+
+func TestList_CachePositions(t *testing.T) {
+	value := func(r *rand.Rand) int { return r.Intn(50) }
+	index := func(l *List[int], r *rand.Rand, extra int) int { return r.Intn(l.Length() + extra + 1) }
+	ops := append(collectiontest.OrderedOps[int, *List[int]](value),
+		collectiontest.Op[*List[int]]{Name: "AddFirst", Apply: func(l *List[int], r *rand.Rand) any {
+			l.AddFirst(value(r))
+			return nil
+		}},
+		collectiontest.Op[*List[int]]{Name: "InsertAt", Apply: func(l *List[int], r *rand.Rand) any {
+			l.InsertAt(index(l, r, 0), value(r))
+			return nil
+		}},
+		collectiontest.Op[*List[int]]{Name: "RemoveAt", Apply: func(l *List[int], r *rand.Rand) any {
+			v, err := l.RemoveAt(index(l, r, 0))
+			return []any{v, err}
+		}},
+		collectiontest.Op[*List[int]]{Name: "Set", Apply: func(l *List[int], r *rand.Rand) any {
+			return l.Set(index(l, r, 0), value(r))
+		}},
+		collectiontest.Op[*List[int]]{Name: "Dequeue", Apply: func(l *List[int], r *rand.Rand) any {
+			v, err := l.Dequeue()
+			return []any{v, err}
+		}},
+		collectiontest.Op[*List[int]]{Name: "Pop", Apply: func(l *List[int], r *rand.Rand) any {
+			v, err := l.Pop()
+			return []any{v, err}
+		}},
+		collectiontest.Op[*List[int]]{Name: "MoveToFront", Apply: func(l *List[int], r *rand.Rand) any {
+			if l.IsEmpty() {
+				return nil
+			}
+			node := l.LastNode()
+			for range r.Intn(l.Length()) {
+				node = node.prev
+			}
+			l.MoveToFront(node)
+			return nil
+		}},
+		collectiontest.Op[*List[int]]{Name: "SortFunc", Apply: func(l *List[int], r *rand.Rand) any {
+			l.SortFunc(cmp.Compare[int])
+			return nil
+		}},
+		collectiontest.Op[*List[int]]{Name: "Sequential At", Apply: func(l *List[int], r *rand.Rand) any {
+			var values []int
+			for i := range l.Length() {
+				values = append(values, l.At(i))
+			}
+			return values
+		}},
+	)
+	for seed := range int64(5) {
+		collectiontest.Check(t,
+			func() *List[int] { return NewList[int]() },
+			func() *List[int] { return NewList[int]().CachePositions(true) },
+			ops, collectiontest.Options[int]{Seed: seed, Steps: 1000},
+		)
+	}
+}
+
+func TestList_CachePositionsDisable(t *testing.T) {
+	l := NewList([]int{1, 2, 3}).CachePositions(true)
+	l.At(1)
+	if l.position.node == nil || l.position.index != 1 {
+		t.Errorf("At(1) cached %v", l.position)
+	}
+	l.CachePositions(false)
+	if l.position != nil {
+		t.Errorf("CachePositions(false) kept the cache")
+	}
+	if got := l.At(2); got != 3 {
+		t.Errorf("At(2) = %v, want 3", got)
+	}
+}
+
+func BenchmarkList_SequentialAt(b *testing.B) {
+	for _, cached := range []bool{false, true} {
+		name := "uncached"
+		if cached {
+			name = "cached"
+		}
+		b.Run(name, func(b *testing.B) {
+			l := NewList(make([]int, 10_000)).CachePositions(cached)
+			for range b.N {
+				for i := range l.Length() {
+					l.At(i)
+				}
+			}
+		})
+	}
+}
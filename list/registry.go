@@ -0,0 +1,32 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package list
+
+import (
+	"cmp"
+
+	"github.com/charbz/gophers/collection"
+)
+
+func init() {
+	Register[any]()
+	RegisterComparable[string]()
+	RegisterComparable[int]()
+	RegisterComparable[int64]()
+	RegisterComparable[float64]()
+}
+
+// Register registers the list constructor under the name "list" for element type T,
+// making it available to collection.Make. Builtin element types are registered on init.
+func Register[T any]() {
+	collection.Register("list", NewList[T])
+}
+
+// RegisterComparable registers both the list and comparable list constructors
+// under the names "list" and "comparable_list" for element type T.
+func RegisterComparable[T cmp.Ordered]() {
+	Register[T]()
+	collection.Register("comparable_list", NewComparableList[T])
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package list
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// SortFunc sorts the list in place, in ascending order as determined by the
+// cmp function, which must return a negative number when a < b, a positive
+// number when a > b, and zero when a == b. The sort is stable: equal elements
+// keep their original order.
+//
+// The nodes are relinked with a merge sort, in O(n log n) time,
+// without copying the elements into a slice.
+//
+// example usage:
+//
+//	l := NewList([]string{"ccc","a","bb"})
+//	l.SortFunc(func(a, b string) int { return len(a) - len(b) })
+//
+// output:
+//
+//	[a,bb,ccc]
+func (l *List[T]) SortFunc(cmp func(a, b T) int) *List[T] {
+	l.head = mergeSort(l.head, l.size, cmp)
+	var prev *Node[T]
+	for node := l.head; node != nil; node = node.next {
+		node.prev = prev
+		prev = node
+	}
+	l.tail = prev
+	l.forgetPosition()
+	return l
+}
+
+// SortFuncContext is similar to SortFunc but reports a "list.SortFunc" span
+// to the tracer of the context, see collection.WithTracer, with the number
+// of elements as attribute.
+func (l *List[T]) SortFuncContext(ctx context.Context, cmp func(a, b T) int) *List[T] {
+	_, span := collection.StartSpan(ctx, "list.SortFunc")
+	span.SetAttributes(collection.Attribute{Key: "length", Value: l.size})
+	l.SortFunc(cmp)
+	span.End(nil)
+	return l
+}
+
+// SortBy returns a new list with the elements sorted by the less function,
+// keeping the original order of equal elements.
+//
+// example usage:
+//
+//	l := NewList([]string{"ccc","a","bb","b"})
+//	l.SortBy(func(a, b string) bool { return len(a) < len(b) })
+//
+// output:
+//
+//	[a,b,bb,ccc]
+func (l *List[T]) SortBy(less func(a, b T) bool) *List[T] {
+	return l.Clone().SortFunc(func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	})
+}
+
+// Sort sorts the list in place in ascending order.
+func (l *ComparableList[T]) Sort() *ComparableList[T] {
+	l.SortFunc(cmp.Compare[T])
+	return l
+}
+
+// SortContext is similar to Sort but reports a span to the tracer
+// of the context, see List.SortFuncContext.
+func (l *ComparableList[T]) SortContext(ctx context.Context) *ComparableList[T] {
+	l.SortFuncContext(ctx, cmp.Compare[T])
+	return l
+}
+
+// Sorted returns a new list with the elements sorted in ascending order.
+func (l *ComparableList[T]) Sorted() *ComparableList[T] {
+	return l.Clone().Sort()
+}
+
+// mergeSort sorts the n nodes starting at head, following the next links
+// only, and returns the new head. The prev links are left unset.
+func mergeSort[T any](head *Node[T], n int, cmp func(a, b T) int) *Node[T] {
+	if n <= 1 {
+		if head != nil {
+			head.next = nil
+		}
+		return head
+	}
+	mid := head
+	for i := 1; i < n/2; i++ {
+		mid = mid.next
+	}
+	right := mid.next
+	mid.next = nil
+	return mergeNodes(mergeSort(head, n/2, cmp), mergeSort(right, n-n/2, cmp), cmp)
+}
+
+// mergeNodes merges two sorted chains of nodes, taking from a first on ties.
+func mergeNodes[T any](a, b *Node[T], cmp func(a, b T) int) *Node[T] {
+	var head Node[T]
+	tail := &head
+	for a != nil && b != nil {
+		if cmp(a.value, b.value) <= 0 {
+			tail.next, a = a, a.next
+		} else {
+			tail.next, b = b, b.next
+		}
+		tail = tail.next
+	}
+	if a != nil {
+		tail.next = a
+	} else {
+		tail.next = b
+	}
+	return head.next
+}
@@ -0,0 +1,96 @@
+package list
+
+import (
+	"cmp"
+	"context"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+)
+
+func TestList_SortFunc(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{name: "sort by length", input: []string{"ccc", "a", "bb"}, want: []string{"a", "bb", "ccc"}},
+		{name: "stable", input: []string{"bb", "b", "aa", "a"}, want: []string{"b", "a", "bb", "aa"}},
+		{name: "single element", input: []string{"a"}, want: []string{"a"}},
+		{name: "empty", input: []string{}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewList(tt.input)
+			l.SortFunc(func(a, b string) int { return len(a) - len(b) })
+			assertLinks(t, l, tt.want)
+		})
+	}
+}
+
+func TestList_SortFuncRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	for _, n := range []int{2, 3, 17, 1000} {
+		input := make([]int, n)
+		for i := range input {
+			input[i] = r.Intn(n)
+		}
+		l := NewList(input)
+		l.SortFunc(cmp.Compare[int])
+		assertLinks(t, l, slices.Sorted(slices.Values(input)))
+		l.Add(-1)
+		if last, _ := l.Last(); last != -1 {
+			t.Errorf("Add() after SortFunc() appended %v", last)
+		}
+	}
+}
+
+func TestList_SortBy(t *testing.T) {
+	l := NewList([]string{"ccc", "a", "bb", "b"})
+	got := l.SortBy(func(a, b string) bool { return len(a) < len(b) })
+	assertLinks(t, got, []string{"a", "b", "bb", "ccc"})
+	assertLinks(t, l, []string{"ccc", "a", "bb", "b"})
+}
+
+func TestComparableList_Sort(t *testing.T) {
+	l := NewComparableList([]int{5, 3, 1, 4, 2})
+	sorted := l.Sorted()
+	if !slices.Equal(l.ToSlice(), []int{5, 3, 1, 4, 2}) {
+		t.Errorf("Sorted() modified the list: %v", l)
+	}
+	if got := l.Sort().ToSlice(); !slices.Equal(got, []int{1, 2, 3, 4, 5}) || !slices.Equal(sorted.ToSlice(), got) {
+		t.Errorf("Sort() = %v, Sorted() = %v, want [1 2 3 4 5]", got, sorted)
+	}
+}
+
+// spanRecorder is a collection.Tracer recording the name and attributes of its last span.
+type spanRecorder struct {
+	name  string
+	attrs map[string]any
+}
+
+func (r *spanRecorder) Start(ctx context.Context, name string) (context.Context, collection.Span) {
+	r.name, r.attrs = name, map[string]any{}
+	return ctx, r
+}
+
+func (r *spanRecorder) SetAttributes(attrs ...collection.Attribute) {
+	for _, a := range attrs {
+		r.attrs[a.Key] = a.Value
+	}
+}
+
+func (r *spanRecorder) End(error) {}
+
+func TestComparableList_SortContext(t *testing.T) {
+	r := &spanRecorder{}
+	c := NewComparableList([]int{3, 1, 2})
+	if got := c.SortContext(collection.WithTracer(context.Background(), r)).ToSlice(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("SortContext() = %v, want [1 2 3]", got)
+	}
+	if r.name != "list.SortFunc" || r.attrs["length"] != 3 {
+		t.Errorf("span = %v %v, want list.SortFunc of length 3", r.name, r.attrs)
+	}
+}
@@ -0,0 +1,464 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package list
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// SyncList is a List that is safe for concurrent use by multiple goroutines.
+// Every method locks an internal mutex, and the compound operations
+// DequeueBatch, DequeueOrWait, PopOrWait, Do, Txn, Drain and DrainInto run
+// atomically, and a Batch of mutations commits atomically. CompareAndRemove
+// and ReplaceIf check an element and update it under the same lock, avoiding
+// the race between reading the list and writing to it.
+//
+// Iterators returned by Values, All and Backward range over a snapshot
+// taken when the iteration starts, so the loop body may safely call
+// methods of the list.
+//
+// An OnRemove function or a position cache set on the underlying list
+// through Do applies to every method, including Drain, DrainInto, Txn and
+// decoding, which replace the contents of the list but keep both.
+//
+// A SyncList is a good fit for a work queue shared by goroutines:
+//
+//	jobs := NewSyncList[Job]()
+//	go func() {
+//	  for {
+//	    job, err := jobs.DequeueOrWait(ctx)
+//	    if err != nil {
+//	      return
+//	    }
+//	    job.Run()
+//	  }
+//	}()
+//	jobs.Enqueue(job)
+//
+// The zero value is an empty list ready to use. A SyncList must not be copied after first use.
+type SyncList[T any] struct {
+	mu   sync.Mutex
+	list List[T]
+	// added is closed, then reset, whenever elements are added,
+	// waking up the goroutines blocked in DequeueOrWait, PopOrWait or Wait.
+	added chan struct{}
+	collection.Randomized
+}
+
+// closed is a closed channel, returned by NotEmpty when the list is not empty.
+var closed = func() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}()
+
+func NewSyncList[T any](s ...[]T) *SyncList[T] {
+	return &SyncList[T]{list: *NewList(s...)}
+}
+
+// notify wakes up the waiting goroutines if the list is not empty.
+// The lock must be held.
+func (l *SyncList[T]) notify() {
+	if l.added != nil && l.list.size > 0 {
+		close(l.added)
+		l.added = nil
+	}
+}
+
+// The following methods implement
+// the Collection interface.
+
+// Add adds a value to the end of the list.
+func (l *SyncList[T]) Add(v T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.list.Add(v)
+	l.notify()
+}
+
+// Length returns the number of elements in the list.
+func (l *SyncList[T]) Length() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.size
+}
+
+// New returns a new synchronized list.
+func (l *SyncList[T]) New(s ...[]T) collection.Collection[T] {
+	return NewSyncList(s...)
+}
+
+// Random returns a random element from the list.
+func (l *SyncList[T]) Random() T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.list.size == 0 {
+		return *new(T)
+	}
+	return l.list.At(l.RandState().IntN(l.list.size))
+}
+
+// Values returns an iterator over a snapshot of all values in the list.
+func (l *SyncList[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range l.ToSlice() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// The following methods implement
+// the OrderedCollection interface.
+
+// At returns the element at the given index.
+func (l *SyncList[T]) At(index int) T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.At(index)
+}
+
+// All returns an iterator over a snapshot of all elements and their indices.
+func (l *SyncList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range l.ToSlice() {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over a snapshot of all elements
+// and their indices in reverse order.
+func (l *SyncList[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range slices.Backward(l.ToSlice()) {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns a new synchronized list containing the elements
+// between the start and end indices.
+func (l *SyncList[T]) Slice(start, end int) collection.OrderedCollection[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return &SyncList[T]{list: *l.list.slice(start, end)}
+}
+
+// NewOrdered returns a new synchronized list.
+func (l *SyncList[T]) NewOrdered(s ...[]T) collection.OrderedCollection[T] {
+	return NewSyncList(s...)
+}
+
+// AddFirst adds a value to the beginning of the list.
+func (l *SyncList[T]) AddFirst(v T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.list.AddFirst(v)
+	l.notify()
+}
+
+// CompareAndRemove atomically removes the first element equal to expected,
+// according to eq, and returns true, or returns false if there is none.
+// Unlike finding the index of the element and then calling RemoveAt, it
+// cannot remove another element moved to that index concurrently.
+//
+// example usage:
+//
+//	l := NewSyncList([]string{"a", "b", "c"})
+//	l.CompareAndRemove(func(x, y string) bool { return x == y }, "b")
+//
+// output:
+//
+//	true
+//	SyncList(string) [a c]
+func (l *SyncList[T]) CompareAndRemove(eq func(a, b T) bool, expected T) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for node := l.list.head; node != nil; node = node.next {
+		if eq(node.value, expected) {
+			l.list.unlink(node)
+			l.list.removed(node.value)
+			return true
+		}
+	}
+	return false
+}
+
+// Dequeue removes and returns the first element of the list.
+func (l *SyncList[T]) Dequeue() (T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Dequeue()
+}
+
+// DequeueBatch atomically removes and returns up to n elements
+// from the front of the list. It returns an empty slice if the list is empty.
+//
+// example usage:
+//
+//	l := NewSyncList([]int{1,2,3,4,5})
+//	l.DequeueBatch(2)
+//
+// output:
+//
+//	[1,2]
+func (l *SyncList[T]) DequeueBatch(n int) []T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	batch := make([]T, 0, clamp(n, l.list.size))
+	for len(batch) < cap(batch) {
+		v, _ := l.list.Dequeue()
+		batch = append(batch, v)
+	}
+	return batch
+}
+
+// DequeueOrWait removes and returns the first element of the list,
+// blocking until an element is available or the context is done,
+// in which case it returns the context's error.
+func (l *SyncList[T]) DequeueOrWait(ctx context.Context) (T, error) {
+	return l.removeOrWait(ctx, (*List[T]).Dequeue)
+}
+
+// Do calls f with the underlying list while holding the lock, so that
+// several operations run atomically. The list must not be retained
+// or used after f returns.
+//
+// example usage:
+//
+//	l.Do(func(list *List[int]) {
+//	  if list.IsEmpty() {
+//	    list.Add(0)
+//	  }
+//	})
+func (l *SyncList[T]) Do(f func(*List[T])) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f(&l.list)
+	l.notify()
+}
+
+// Drain returns an iterator that removes and yields the elements of the list,
+// from first to last. The contents of the list are swapped out atomically
+// when the iteration starts, so the lock is not held while the loop body
+// runs and elements added concurrently are not drained. Elements not
+// yielded, because the loop stopped early or the context was done, are
+// put back at the beginning of the list.
+//
+// Drain is designed for shutdown paths, i.e. flushing pending jobs
+// within a deadline:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	for job := range jobs.Drain(ctx) {
+//	  job.Run()
+//	}
+func (l *SyncList[T]) Drain(ctx context.Context) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		l.mu.Lock()
+		detached := l.list.detach()
+		detached.onRemove = l.list.onRemove
+		l.mu.Unlock()
+		defer func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.list.prependAll(detached)
+			l.notify()
+		}()
+		for detached.size > 0 && ctx.Err() == nil {
+			if v, _ := detached.Dequeue(); !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// DrainInto atomically removes all the elements of the list, adds them
+// to dst from first to last and returns the number of elements moved.
+// The lock is not held while adding to dst.
+func (l *SyncList[T]) DrainInto(dst collection.Collection[T]) int {
+	l.mu.Lock()
+	detached := l.list.detach()
+	detached.onRemove = l.list.onRemove
+	l.mu.Unlock()
+	return detached.DrainInto(dst)
+}
+
+// Enqueue appends an element to the list.
+func (l *SyncList[T]) Enqueue(v T) {
+	l.Add(v)
+}
+
+// InsertAt inserts a value at the given index.
+// It panics if the index is out of bounds.
+func (l *SyncList[T]) InsertAt(index int, v T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.list.InsertAt(index, v)
+	l.notify()
+}
+
+// NotEmpty returns a channel that is closed once the list is not empty,
+// so that consumers can select on the availability of elements along with
+// other channels. The channel is already closed if the list is not empty.
+// Another consumer may remove the elements before the receiver does,
+// so the channel must be asked for again after each removal attempt.
+//
+// example usage:
+//
+//	for {
+//	  select {
+//	  case <-jobs.NotEmpty():
+//	    if job, err := jobs.Dequeue(); err == nil {
+//	      job.Run()
+//	    }
+//	  case <-shutdown:
+//	    return
+//	  }
+//	}
+func (l *SyncList[T]) NotEmpty() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.notEmpty()
+}
+
+// Pop removes and returns the last element of the list.
+func (l *SyncList[T]) Pop() (T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Pop()
+}
+
+// PopOrWait removes and returns the last element of the list,
+// blocking until an element is available or the context is done,
+// in which case it returns the context's error.
+func (l *SyncList[T]) PopOrWait(ctx context.Context) (T, error) {
+	return l.removeOrWait(ctx, (*List[T]).Pop)
+}
+
+// Push appends an element to the list.
+func (l *SyncList[T]) Push(v T) {
+	l.Add(v)
+}
+
+// RemoveAt removes and returns the element at the given index.
+func (l *SyncList[T]) RemoveAt(index int) (T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.RemoveAt(index)
+}
+
+// ReplaceIf atomically replaces the element at the given index with new
+// if it is equal to old according to eq, and returns true. It returns false,
+// leaving the list untouched, if the element changed since it was read as old,
+// or if the index is out of bounds.
+//
+// example usage:
+//
+//	counters := NewSyncList([]int{0, 0})
+//	for {
+//	  v := counters.At(1)
+//	  if counters.ReplaceIf(1, v, v+1, func(a, b int) bool { return a == b }) {
+//	    break
+//	  }
+//	}
+func (l *SyncList[T]) ReplaceIf(index int, old, new T, eq func(a, b T) bool) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if index < 0 || index >= l.list.size {
+		return false
+	}
+	node := l.list.nodeAt(index)
+	if !eq(node.value, old) {
+		return false
+	}
+	node.value = new
+	return true
+}
+
+// Set replaces the element at the given index.
+func (l *SyncList[T]) Set(index int, v T) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Set(index, v)
+}
+
+// ToList returns a snapshot of the list as a List.
+func (l *SyncList[T]) ToList() *List[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Clone()
+}
+
+// ToChannel sends a snapshot of the list, taken when ToChannel is called,
+// to the returned channel from a new goroutine, see collection.ToChannel.
+// The list may be modified meanwhile.
+func (l *SyncList[T]) ToChannel(ctx context.Context, opts ...collection.ChanOption) <-chan T {
+	return collection.ToChannel(ctx, l.ToList(), opts...)
+}
+
+// ToSlice returns a snapshot of the list as a slice.
+func (l *SyncList[T]) ToSlice() []T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.ToSlice()
+}
+
+// Wait blocks until the list is not empty or the context is done,
+// in which case it returns the context's error.
+func (l *SyncList[T]) Wait(ctx context.Context) error {
+	select {
+	case <-l.NotEmpty():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// implement the Stringer interface
+func (l *SyncList[T]) String() string {
+	return fmt.Sprintf("SyncList(%T) %v", *new(T), l.ToSlice())
+}
+
+// notEmpty returns the channel returned by NotEmpty. The lock must be held.
+func (l *SyncList[T]) notEmpty() chan struct{} {
+	if l.list.size > 0 {
+		return closed
+	}
+	if l.added == nil {
+		l.added = make(chan struct{})
+	}
+	return l.added
+}
+
+// removeOrWait calls remove on the underlying list as soon as it is not empty.
+func (l *SyncList[T]) removeOrWait(ctx context.Context, remove func(*List[T]) (T, error)) (T, error) {
+	for {
+		l.mu.Lock()
+		if l.list.size > 0 {
+			defer l.mu.Unlock()
+			return remove(&l.list)
+		}
+		added := l.notEmpty()
+		l.mu.Unlock()
+		select {
+		case <-added:
+		case <-ctx.Done():
+			return *new(T), ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,360 @@
+package list
+
+import (
+	"context"
+	"iter"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/collectiontest"
+)
+
+func TestSyncList_MatchesList(t *testing.T) {
+	ops := collectiontest.OrderedOps[int, collection.OrderedCollection[int]](func(r *rand.Rand) int { return r.Intn(50) })
+	collectiontest.Check(t,
+		func() collection.OrderedCollection[int] { return NewList[int]() },
+		func() collection.OrderedCollection[int] { return NewSyncList[int]() },
+		ops, collectiontest.Options[int]{Steps: 500},
+	)
+}
+
+func TestSyncList_DequeueBatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		slice []int
+		n     int
+		want  []int
+		rest  []int
+	}{
+		{name: "partial", slice: []int{1, 2, 3, 4, 5}, n: 2, want: []int{1, 2}, rest: []int{3, 4, 5}},
+		{name: "more than length", slice: []int{1, 2}, n: 5, want: []int{1, 2}, rest: []int{}},
+		{name: "zero", slice: []int{1, 2}, n: 0, want: []int{}, rest: []int{1, 2}},
+		{name: "empty", slice: []int{}, n: 3, want: []int{}, rest: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewSyncList(tt.slice)
+			if got := l.DequeueBatch(tt.n); !slices.Equal(got, tt.want) {
+				t.Errorf("DequeueBatch() = %v, want %v", got, tt.want)
+			}
+			if got := l.ToSlice(); !slices.Equal(got, tt.rest) {
+				t.Errorf("DequeueBatch() left %v, want %v", got, tt.rest)
+			}
+		})
+	}
+}
+
+func TestSyncList_OrWait(t *testing.T) {
+	tests := []struct {
+		name string
+		wait func(*SyncList[int], context.Context) (int, error)
+		want int
+	}{
+		{name: "dequeue", wait: (*SyncList[int]).DequeueOrWait, want: 1},
+		{name: "pop", wait: (*SyncList[int]).PopOrWait, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewSyncList[int]()
+			done := make(chan int)
+			go func() {
+				v, err := tt.wait(l, context.Background())
+				if err != nil {
+					t.Errorf("wait returned error %v", err)
+				}
+				done <- v
+			}()
+			time.Sleep(10 * time.Millisecond)
+			l.Do(func(list *List[int]) {
+				list.Add(1)
+				list.Add(2)
+			})
+			select {
+			case v := <-done:
+				if v != tt.want {
+					t.Errorf("got %v, want %v", v, tt.want)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("waiting goroutine was not woken up")
+			}
+		})
+	}
+}
+
+func TestSyncList_OrWaitCanceled(t *testing.T) {
+	l := NewSyncList[int]()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.PopOrWait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("PopOrWait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestSyncList_NotEmpty(t *testing.T) {
+	l := NewSyncList[int]()
+	ready := l.NotEmpty()
+	l.Do(func(list *List[int]) {
+		list.Add(1)
+		list.Pop()
+	})
+	select {
+	case <-ready:
+		t.Fatal("NotEmpty() closed while the list is empty")
+	default:
+	}
+	l.Add(1)
+	select {
+	case <-ready:
+	case <-time.After(time.Second):
+		t.Fatal("NotEmpty() not closed after Add")
+	}
+	select {
+	case <-l.NotEmpty():
+	default:
+		t.Error("NotEmpty() not closed on a non-empty list")
+	}
+}
+
+func TestSyncList_Wait(t *testing.T) {
+	l := NewSyncList[int]()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		l.AddFirst(1)
+	}()
+	if err := l.Wait(context.Background()); err != nil || l.Length() != 1 {
+		t.Errorf("Wait() = %v with length %v, want nil with length 1", err, l.Length())
+	}
+}
+
+func TestSyncList_Concurrent(t *testing.T) {
+	const consumers, producers, perProducer = 3, 4, 250
+	l := NewSyncList[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	var (
+		wg    sync.WaitGroup
+		total int
+	)
+	results := make(chan int)
+	for range consumers {
+		go func() {
+			sum := 0
+			for {
+				v, err := l.DequeueOrWait(ctx)
+				if err != nil {
+					results <- sum
+					return
+				}
+				sum += v
+			}
+		}()
+	}
+	for p := range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perProducer {
+				l.Enqueue(p*perProducer + i)
+			}
+		}()
+	}
+	wg.Wait()
+	for l.Length() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	for range consumers {
+		total += <-results
+	}
+	n := producers * perProducer
+	if want := n * (n - 1) / 2; total != want {
+		t.Errorf("consumed sum = %v, want %v", total, want)
+	}
+}
+
+func TestSyncList_IterateWhileMutating(t *testing.T) {
+	l := NewSyncList([]int{1, 2, 3})
+	for v := range l.Values() {
+		l.Add(v * 10)
+	}
+	if got := l.ToSlice(); !slices.Equal(got, []int{1, 2, 3, 10, 20, 30}) {
+		t.Errorf("ToSlice() = %v, want [1 2 3 10 20 30]", got)
+	}
+	if got := l.String(); got != "SyncList(int) [1 2 3 10 20 30]" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestSyncList_Drain(t *testing.T) {
+	l := NewSyncList([]int{1, 2, 3, 4})
+	got := []int{}
+	for v := range l.Drain(context.Background()) {
+		got = append(got, v)
+		l.Add(v * 10)
+		if v == 2 {
+			break
+		}
+	}
+	if !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Drain() = %v, want [1 2]", got)
+	}
+	if got := l.ToSlice(); !slices.Equal(got, []int{3, 4, 10, 20}) {
+		t.Errorf("ToSlice() after Drain() = %v, want [3 4 10 20]", got)
+	}
+}
+
+func TestSyncList_DrainWakesWaiters(t *testing.T) {
+	l := NewSyncList([]int{1, 2})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	next, stop := iter.Pull(l.Drain(ctx))
+	next()
+	result := make(chan int)
+	go func() {
+		v, _ := l.DequeueOrWait(ctx)
+		result <- v
+	}()
+	stop()
+	if v := <-result; v != 2 {
+		t.Errorf("DequeueOrWait() = %v, want 2", v)
+	}
+}
+
+func TestSyncList_DrainInto(t *testing.T) {
+	l := NewSyncList([]int{1, 2, 3})
+	dst := NewList[int]()
+	if n := l.DrainInto(dst); n != 3 {
+		t.Errorf("DrainInto() = %v, want 3", n)
+	}
+	if l.Length() != 0 || !slices.Equal(dst.ToSlice(), []int{1, 2, 3}) {
+		t.Errorf("DrainInto() left %v and moved %v", l, dst)
+	}
+}
+
+func TestSyncList_OnRemove(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+	encoded, _ := NewList([]int{7, 8}).GobEncode()
+	tests := []struct {
+		name        string
+		remove      func(*SyncList[int])
+		wantRemoved []int
+		want        []int
+	}{
+		{name: "Dequeue", remove: func(l *SyncList[int]) { l.Dequeue() }, wantRemoved: []int{1}, want: []int{2, 3}},
+		{name: "DequeueBatch", remove: func(l *SyncList[int]) { l.DequeueBatch(2) }, wantRemoved: []int{1, 2}, want: []int{3}},
+		{name: "DequeueOrWait", remove: func(l *SyncList[int]) { l.DequeueOrWait(context.Background()) }, wantRemoved: []int{1}, want: []int{2, 3}},
+		{name: "Pop", remove: func(l *SyncList[int]) { l.Pop() }, wantRemoved: []int{3}, want: []int{1, 2}},
+		{name: "PopOrWait", remove: func(l *SyncList[int]) { l.PopOrWait(context.Background()) }, wantRemoved: []int{3}, want: []int{1, 2}},
+		{name: "RemoveAt", remove: func(l *SyncList[int]) { l.RemoveAt(1) }, wantRemoved: []int{2}, want: []int{1, 3}},
+		{name: "CompareAndRemove", remove: func(l *SyncList[int]) { l.CompareAndRemove(eq, 2) }, wantRemoved: []int{2}, want: []int{1, 3}},
+		{name: "Drain", remove: func(l *SyncList[int]) {
+			for v := range l.Drain(context.Background()) {
+				if v == 2 {
+					break
+				}
+			}
+		}, wantRemoved: []int{1, 2}, want: []int{3}},
+		{name: "DrainInto", remove: func(l *SyncList[int]) { l.DrainInto(NewList[int]()) }, wantRemoved: []int{1, 2, 3}, want: []int{}},
+		{name: "Txn", remove: func(l *SyncList[int]) {
+			l.Txn(func(l *List[int]) error { l.Pop(); l.Add(4); return nil })
+		}, wantRemoved: []int{3}, want: []int{1, 2, 4}},
+		{name: "Txn rolled back", remove: func(l *SyncList[int]) {
+			l.Txn(func(l *List[int]) error { l.Pop(); return collection.EmptyCollectionError })
+		}, wantRemoved: nil, want: []int{1, 2, 3}},
+		{name: "Batch", remove: func(l *SyncList[int]) { l.Batch().Dequeue().Commit() }, wantRemoved: []int{1}, want: []int{2, 3}},
+		{name: "GobDecode", remove: func(l *SyncList[int]) { l.GobDecode(encoded) }, wantRemoved: []int{1, 2, 3}, want: []int{7, 8}},
+		{name: "UnmarshalJSON", remove: func(l *SyncList[int]) { l.UnmarshalJSON([]byte("[7,8]")) }, wantRemoved: []int{1, 2, 3}, want: []int{7, 8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var removed []int
+			l := NewSyncList([]int{1, 2, 3})
+			l.Do(func(l *List[int]) {
+				l.OnRemove(func(v int) { removed = append(removed, v) }).CachePositions(true)
+			})
+			tt.remove(l)
+			if !slices.Equal(removed, tt.wantRemoved) {
+				t.Errorf("OnRemove called with %v, want %v", removed, tt.wantRemoved)
+			}
+			if got := l.ToSlice(); !slices.Equal(got, tt.want) {
+				t.Errorf("ToSlice() = %v, want %v", got, tt.want)
+			}
+			l.Do(func(list *List[int]) {
+				if list.onRemove == nil || list.position == nil {
+					t.Errorf("%v dropped the OnRemove function or the position cache", tt.name)
+				}
+			})
+		})
+	}
+}
+
+func TestSyncList_CompareAndSwap(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+	tests := []struct {
+		name string
+		op   func(l *SyncList[int]) bool
+		want bool
+		list []int
+	}{
+		{name: "remove present", op: func(l *SyncList[int]) bool { return l.CompareAndRemove(eq, 2) }, want: true, list: []int{1, 3, 2}},
+		{name: "remove missing", op: func(l *SyncList[int]) bool { return l.CompareAndRemove(eq, 9) }, want: false, list: []int{1, 2, 3, 2}},
+		{name: "replace equal", op: func(l *SyncList[int]) bool { return l.ReplaceIf(1, 2, 7, eq) }, want: true, list: []int{1, 7, 3, 2}},
+		{name: "replace changed", op: func(l *SyncList[int]) bool { return l.ReplaceIf(1, 3, 7, eq) }, want: false, list: []int{1, 2, 3, 2}},
+		{name: "replace out of bounds", op: func(l *SyncList[int]) bool { return l.ReplaceIf(4, 2, 7, eq) }, want: false, list: []int{1, 2, 3, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewSyncList([]int{1, 2, 3, 2})
+			if got := tt.op(l); got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+			if got := l.ToSlice(); !slices.Equal(got, tt.list) {
+				t.Errorf("list = %v, want %v", got, tt.list)
+			}
+		})
+	}
+}
+
+func TestSyncList_ReplaceIfConcurrent(t *testing.T) {
+	l := NewSyncList([]int{0})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				for {
+					v := l.At(0)
+					if l.ReplaceIf(0, v, v+1, func(a, b int) bool { return a == b }) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if got := l.At(0); got != 400 {
+		t.Errorf("counter = %v, want 400", got)
+	}
+}
+
+func TestSyncList_ToChannel(t *testing.T) {
+	l := NewSyncList([]int{1, 2, 3})
+	ch := l.ToChannel(context.Background())
+	l.Add(4)
+	var got []int
+	for v := range ch {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("ToChannel() = %v, want the snapshot [1 2 3]", got)
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package multimap implements MultiMap, a dictionary holding several
+// values per key, in insertion order:
+//
+//	byAuthor := multimap.NewMultiMap[string, string]()
+//	byAuthor.Put("alice", "intro.md")
+//	byAuthor.Put("alice", "setup.md")
+//	byAuthor.Get("alice") // List(string) [intro.md setup.md]
+//
+// It replaces maps of slices built by hand, and the results of GroupBy
+// can be converted to a MultiMap with FromGroups and FromMap.
+package multimap
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/dict"
+	"github.com/charbz/gophers/list"
+	"github.com/charbz/gophers/set"
+)
+
+// MultiMap is an unordered dictionary mapping keys of type K to lists of
+// values of type V. A key is present as long as it holds at least one value.
+// The zero value is an empty multimap ready to use.
+type MultiMap[K comparable, V any] struct {
+	elements map[K]*list.List[V]
+	size     int // number of values
+}
+
+// NewMultiMap returns a new MultiMap holding the values of the given Go
+// maps. The values of a key appearing in several maps are concatenated.
+func NewMultiMap[K comparable, V any](m ...map[K][]V) *MultiMap[K, V] {
+	mm := &MultiMap[K, V]{elements: make(map[K]*list.List[V])}
+	for _, entries := range m {
+		for k, values := range entries {
+			mm.PutAll(k, values...)
+		}
+	}
+	return mm
+}
+
+// FromEntries returns a new MultiMap holding the entries yielded by s.
+func FromEntries[K comparable, V any](s iter.Seq[collection.Entry[K, V]]) *MultiMap[K, V] {
+	mm := NewMultiMap[K, V]()
+	for e := range s {
+		mm.Put(e.Key, e.Value)
+	}
+	return mm
+}
+
+// FromGroups returns a new MultiMap holding the groups returned by
+// collection.GroupBy.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3,4,5,6})
+//	FromGroups(collection.GroupBy(c, func(i int) int { return i % 2 }))
+//
+// output:
+//
+//	MultiMap(int, int) map[0:[2 4 6] 1:[1 3 5]]
+func FromGroups[K comparable, V any](groups map[K]collection.Collection[V]) *MultiMap[K, V] {
+	mm := NewMultiMap[K, V]()
+	for k, c := range groups {
+		for v := range c.Values() {
+			mm.Put(k, v)
+		}
+	}
+	return mm
+}
+
+// FromMap returns a new MultiMap holding the values of a dict.Map of
+// slices, such as the result of dict.GroupBy.
+func FromMap[K comparable, V any](m *dict.Map[K, []V]) *MultiMap[K, V] {
+	mm := NewMultiMap[K, V]()
+	for k, values := range m.All() {
+		mm.PutAll(k, values...)
+	}
+	return mm
+}
+
+// GroupBy returns a new MultiMap holding the elements of the collection
+// under the key returned by f, in the iteration order of the collection.
+func GroupBy[T any, K comparable](c collection.Collection[T], f func(T) K) *MultiMap[K, T] {
+	mm := NewMultiMap[K, T]()
+	for v := range c.Values() {
+		mm.Put(f(v), v)
+	}
+	return mm
+}
+
+// All returns an iterator over all key/value pairs of the multimap. The
+// keys come in no particular order, the values of a key in insertion order.
+func (mm *MultiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, l := range mm.elements {
+			for v := range l.Values() {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Clone returns a copy of the multimap. This is a shallow clone.
+func (mm *MultiMap[K, V]) Clone() *MultiMap[K, V] {
+	c := &MultiMap[K, V]{elements: make(map[K]*list.List[V], len(mm.elements)), size: mm.size}
+	for k, l := range mm.elements {
+		c.elements[k] = l.Clone()
+	}
+	return c
+}
+
+// Contains returns true if the multimap holds values for the key.
+func (mm *MultiMap[K, V]) Contains(k K) bool {
+	_, ok := mm.elements[k]
+	return ok
+}
+
+// Count returns the number of values of the key.
+func (mm *MultiMap[K, V]) Count(k K) int {
+	if l, ok := mm.elements[k]; ok {
+		return l.Length()
+	}
+	return 0
+}
+
+// Delete removes the key and all its values, and returns the number of
+// values removed.
+func (mm *MultiMap[K, V]) Delete(k K) int {
+	l, ok := mm.elements[k]
+	if !ok {
+		return 0
+	}
+	delete(mm.elements, k)
+	mm.size -= l.Length()
+	return l.Length()
+}
+
+// Entries returns an iterator over all entries of the multimap, see All.
+func (mm *MultiMap[K, V]) Entries() iter.Seq[collection.Entry[K, V]] {
+	return func(yield func(collection.Entry[K, V]) bool) {
+		for k, v := range mm.All() {
+			if !yield(collection.Entry[K, V]{Key: k, Value: v}) {
+				return
+			}
+		}
+	}
+}
+
+// Get returns a new list of the values of the key, in insertion order.
+// The list is empty if the multimap does not contain the key.
+func (mm *MultiMap[K, V]) Get(k K) *list.List[V] {
+	if l, ok := mm.elements[k]; ok {
+		return l.Clone()
+	}
+	return list.NewList[V]()
+}
+
+// IsEmpty returns true if the multimap is empty.
+func (mm *MultiMap[K, V]) IsEmpty() bool {
+	return mm.size == 0
+}
+
+// KeyCount returns the number of distinct keys.
+func (mm *MultiMap[K, V]) KeyCount() int {
+	return len(mm.elements)
+}
+
+// Keys returns an iterator over the distinct keys, in no particular order.
+func (mm *MultiMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range mm.elements {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// KeySet returns a new set of the distinct keys.
+func (mm *MultiMap[K, V]) KeySet() *set.Set[K] {
+	s := set.NewSet[K]()
+	for k := range mm.elements {
+		s.Add(k)
+	}
+	return s
+}
+
+// Length returns the number of values in the multimap, across all keys.
+func (mm *MultiMap[K, V]) Length() int {
+	return mm.size
+}
+
+// NonEmpty returns true if the multimap is not empty.
+func (mm *MultiMap[K, V]) NonEmpty() bool {
+	return mm.size > 0
+}
+
+// Put adds a value to the values of the key.
+func (mm *MultiMap[K, V]) Put(k K, v V) {
+	l, ok := mm.elements[k]
+	if !ok {
+		if mm.elements == nil {
+			mm.elements = make(map[K]*list.List[V])
+		}
+		l = list.NewList[V]()
+		mm.elements[k] = l
+	}
+	l.Add(v)
+	mm.size++
+}
+
+// PutAll adds values to the values of the key.
+func (mm *MultiMap[K, V]) PutAll(k K, values ...V) {
+	for _, v := range values {
+		mm.Put(k, v)
+	}
+}
+
+// RemoveValue removes the values of the key satisfying the predicate, and
+// returns the number of values removed. The key is removed with its last value.
+//
+// example usage:
+//
+//	mm := NewMultiMap(map[string][]int{"a": {1, 2, 3}})
+//	mm.RemoveValue("a", func(v int) bool { return v != 2 })
+//
+// output:
+//
+//	2
+//	MultiMap(string, int) map[a:[2]]
+func (mm *MultiMap[K, V]) RemoveValue(k K, f func(V) bool) int {
+	l, ok := mm.elements[k]
+	if !ok {
+		return 0
+	}
+	kept := l.FilterNot(f)
+	removed := l.Length() - kept.Length()
+	mm.size -= removed
+	if kept.Length() == 0 {
+		delete(mm.elements, k)
+	} else {
+		mm.elements[k] = kept
+	}
+	return removed
+}
+
+// ToMap returns the multimap as a Go map of slices.
+func (mm *MultiMap[K, V]) ToMap() map[K][]V {
+	m := make(map[K][]V, len(mm.elements))
+	for k, l := range mm.elements {
+		m[k] = l.ToSlice()
+	}
+	return m
+}
+
+// Values returns an iterator over all values, see All.
+func (mm *MultiMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range mm.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// implement the Stringer interface
+func (mm *MultiMap[K, V]) String() string {
+	return fmt.Sprintf("MultiMap(%T, %T) %v", *new(K), *new(V), mm.ToMap())
+}
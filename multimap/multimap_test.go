@@ -0,0 +1,132 @@
+package multimap
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/dict"
+	"github.com/charbz/gophers/sequence"
+)
+
+func TestMultiMap_PutGet(t *testing.T) {
+	mm := NewMultiMap[string, int]()
+	mm.Put("a", 1)
+	mm.PutAll("b", 2, 3)
+	mm.Put("a", 4)
+	if got := mm.Get("a").ToSlice(); !slices.Equal(got, []int{1, 4}) {
+		t.Errorf("Get(a) = %v, want [1 4]", got)
+	}
+	if got := mm.Get("z"); got.Length() != 0 {
+		t.Errorf("Get(z) = %v, want an empty list", got)
+	}
+	mm.Get("a").Add(9)
+	if mm.Count("a") != 2 || mm.Length() != 4 || mm.KeyCount() != 2 {
+		t.Errorf("Count(a) = %v, Length() = %v, KeyCount() = %v", mm.Count("a"), mm.Length(), mm.KeyCount())
+	}
+	if got := mm.String(); got != "MultiMap(string, int) map[a:[1 4] b:[2 3]]" {
+		t.Errorf("String() = %v", got)
+	}
+}
+
+func TestMultiMap_Remove(t *testing.T) {
+	tests := []struct {
+		name        string
+		remove      func(*MultiMap[string, int]) int
+		wantRemoved int
+		want        map[string][]int
+	}{
+		{
+			name:        "some values",
+			remove:      func(mm *MultiMap[string, int]) int { return mm.RemoveValue("a", func(v int) bool { return v%2 == 1 }) },
+			wantRemoved: 2,
+			want:        map[string][]int{"a": {2}, "b": {4}},
+		},
+		{
+			name:        "last value",
+			remove:      func(mm *MultiMap[string, int]) int { return mm.RemoveValue("b", func(int) bool { return true }) },
+			wantRemoved: 1,
+			want:        map[string][]int{"a": {1, 2, 3}},
+		},
+		{
+			name:        "missing key",
+			remove:      func(mm *MultiMap[string, int]) int { return mm.RemoveValue("z", func(int) bool { return true }) },
+			wantRemoved: 0,
+			want:        map[string][]int{"a": {1, 2, 3}, "b": {4}},
+		},
+		{
+			name:        "delete",
+			remove:      func(mm *MultiMap[string, int]) int { return mm.Delete("a") },
+			wantRemoved: 3,
+			want:        map[string][]int{"b": {4}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mm := NewMultiMap(map[string][]int{"a": {1, 2, 3}, "b": {4}})
+			if got := tt.remove(mm); got != tt.wantRemoved {
+				t.Errorf("removed %v values, want %v", got, tt.wantRemoved)
+			}
+			if got := mm.ToMap(); !maps.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("ToMap() = %v, want %v", got, tt.want)
+			}
+			total := 0
+			for _, v := range tt.want {
+				total += len(v)
+			}
+			if mm.Length() != total || mm.KeySet().Length() != len(tt.want) {
+				t.Errorf("Length() = %v, KeySet() = %v", mm.Length(), mm.KeySet())
+			}
+		})
+	}
+}
+
+func TestMultiMap_FromGroupBy(t *testing.T) {
+	c := sequence.NewSequence([]int{1, 2, 3, 4, 5, 6})
+	parity := func(i int) int { return i % 2 }
+	want := map[int][]int{0: {2, 4, 6}, 1: {1, 3, 5}}
+	tests := []struct {
+		name string
+		mm   *MultiMap[int, int]
+	}{
+		{name: "collection.GroupBy", mm: FromGroups(collection.GroupBy[int, int](c, parity))},
+		{name: "dict.GroupBy", mm: FromMap(dict.GroupBy[int, int](c, parity))},
+		{name: "GroupBy", mm: GroupBy[int, int](c, parity)},
+		{name: "FromEntries", mm: FromEntries(NewMultiMap(want).Entries())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mm.ToMap(); !maps.EqualFunc(got, want, slices.Equal) {
+				t.Errorf("ToMap() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestMultiMap_Iterators(t *testing.T) {
+	mm := NewMultiMap(map[string][]int{"a": {1, 2}, "b": {3}})
+	if got := slices.Sorted(mm.Values()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Values() = %v", got)
+	}
+	if got := slices.Sorted(mm.Keys()); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Keys() = %v", got)
+	}
+	for range mm.All() {
+		break
+	}
+	c := mm.Clone()
+	c.Put("a", 9)
+	if mm.Count("a") != 2 || c.Count("a") != 3 {
+		t.Errorf("Clone() shares its lists with the original")
+	}
+}
+
+func TestMultiMap_ZeroValue(t *testing.T) {
+	var mm MultiMap[string, int]
+	mm.Put("a", 1)
+	mm.Put("a", 2)
+	if mm.Length() != 2 || mm.KeyCount() != 1 {
+		t.Errorf("zero value after Put = %v, want a: [1 2]", &mm)
+	}
+}
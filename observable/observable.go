@@ -0,0 +1,327 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package observable implements a list that notifies its listeners of every
+// mutation, so that UIs and caches can react to changes without polling:
+//
+//	todos := observable.NewList[string]()
+//	stop := todos.Listen(func(e observable.Event[string]) {
+//	  fmt.Println(e)
+//	})
+//	defer stop()
+//	todos.Add("write docs") // Add(0, write docs)
+//
+// Mutations made within Batch emit a single Batch event, i.e. to load many
+// values at once. A List is not safe for concurrent use: listeners are
+// called by the goroutine mutating the list, once the mutation is done.
+package observable
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/list"
+)
+
+// Kind is the kind of mutation reported by an Event.
+type Kind int
+
+const (
+	// Add reports a value inserted at Index.
+	Add Kind = iota
+	// Remove reports a value removed from Index.
+	Remove
+	// Update reports the value at Index replaced, Old holding the previous value.
+	Update
+	// Clear reports all the values removed at once.
+	Clear
+	// Batch reports the mutations made within Batch, the list must be read again.
+	Batch
+)
+
+var kindNames = [...]string{Add: "Add", Remove: "Remove", Update: "Update", Clear: "Clear", Batch: "Batch"}
+
+// implement the Stringer interface
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+	return kindNames[k]
+}
+
+// Event is a mutation of a List. Index is -1 for Clear and Batch events,
+// Value and Old are the zero value when they do not apply.
+type Event[T any] struct {
+	Kind  Kind
+	Index int
+	Value T
+	Old   T
+}
+
+// implement the Stringer interface
+func (e Event[T]) String() string {
+	switch e.Kind {
+	case Update:
+		return fmt.Sprintf("Update(%d, %v -> %v)", e.Index, e.Old, e.Value)
+	case Clear, Batch:
+		return e.Kind.String()
+	}
+	return fmt.Sprintf("%v(%d, %v)", e.Kind, e.Index, e.Value)
+}
+
+// listener is a registered listener, compared by address on removal.
+type listener[T any] struct {
+	f func(Event[T])
+}
+
+// List is a doubly linked list, see list.List, emitting an Event to its
+// listeners for every mutation. It implements the Collection interface.
+type List[T any] struct {
+	l         *list.List[T]
+	listeners []*listener[T]
+	batching  int  // depth of the nested Batch calls
+	batched   bool // a mutation was made within the current Batch
+}
+
+// NewList returns an observable list holding the elements of the given slices.
+func NewList[T any](s ...[]T) *List[T] {
+	return &List[T]{l: list.NewList(s...)}
+}
+
+// The following methods implement
+// the Collection interface.
+
+// Add appends a value to the list and emits an Add event.
+func (l *List[T]) Add(v T) {
+	l.l.Add(v)
+	l.emit(Event[T]{Kind: Add, Index: l.l.Length() - 1, Value: v})
+}
+
+// Length returns the number of values in the list.
+func (l *List[T]) Length() int {
+	return l.l.Length()
+}
+
+// New returns a new observable list, without the listeners of l.
+func (l *List[T]) New(s ...[]T) collection.Collection[T] {
+	return NewList(s...)
+}
+
+// Random returns a random value from the list.
+func (l *List[T]) Random() T {
+	return l.l.Random()
+}
+
+// Values returns an iterator over the values of the list.
+func (l *List[T]) Values() iter.Seq[T] {
+	return l.l.Values()
+}
+
+// AddFirst inserts a value at the beginning of the list and emits an Add event.
+func (l *List[T]) AddFirst(v T) {
+	l.l.AddFirst(v)
+	l.emit(Event[T]{Kind: Add, Index: 0, Value: v})
+}
+
+// All returns an iterator over the indices and values of the list.
+func (l *List[T]) All() iter.Seq2[int, T] {
+	return l.l.All()
+}
+
+// At returns the value at the given index.
+// It panics if the index is out of bounds.
+func (l *List[T]) At(index int) T {
+	return l.l.At(index)
+}
+
+// Backward returns an iterator over the indices and values of the list,
+// last value first.
+func (l *List[T]) Backward() iter.Seq2[int, T] {
+	return l.l.Backward()
+}
+
+// Batch calls f with the list, emitting a single Batch event once f returns
+// instead of an event per mutation, if f mutated the list. Batch calls can
+// be nested, the Batch event being emitted when the outermost one returns.
+//
+// example usage:
+//
+//	l.Batch(func(l *List[Row]) {
+//	  for _, r := range rows {
+//	    l.Add(r)
+//	  }
+//	})
+func (l *List[T]) Batch(f func(l *List[T])) {
+	l.batching++
+	defer func() {
+		l.batching--
+		if l.batching == 0 && l.batched {
+			l.batched = false
+			l.emit(Event[T]{Kind: Batch, Index: -1})
+		}
+	}()
+	f(l)
+}
+
+// Clear removes all the values from the list and emits a Clear event,
+// if the list was not empty.
+func (l *List[T]) Clear() {
+	if l.l.IsEmpty() {
+		return
+	}
+	l.l.Free()
+	l.emit(Event[T]{Kind: Clear, Index: -1})
+}
+
+// Dequeue removes and returns the first value of the list,
+// and emits a Remove event.
+func (l *List[T]) Dequeue() (T, error) {
+	v, err := l.l.Dequeue()
+	if err == nil {
+		l.emit(Event[T]{Kind: Remove, Index: 0, Value: v})
+	}
+	return v, err
+}
+
+// Events returns a channel receiving the events of the list, buffered with
+// buffer events, and a function stopping the subscription and closing the
+// channel. Events are sent by the goroutine mutating the list, which blocks
+// while the buffer is full: the channel must be drained concurrently, or
+// stopped.
+func (l *List[T]) Events(buffer int) (<-chan Event[T], func()) {
+	ch := make(chan Event[T], buffer)
+	unlisten := l.Listen(func(e Event[T]) { ch <- e })
+	stopped := false
+	return ch, func() {
+		if !stopped {
+			stopped = true
+			unlisten()
+			close(ch)
+		}
+	}
+}
+
+// InsertAt inserts a value at the given index and emits an Add event.
+// It panics if the index is out of bounds.
+func (l *List[T]) InsertAt(index int, v T) {
+	l.l.InsertAt(index, v)
+	l.emit(Event[T]{Kind: Add, Index: index, Value: v})
+}
+
+// IsEmpty returns true if the list is empty.
+func (l *List[T]) IsEmpty() bool {
+	return l.l.IsEmpty()
+}
+
+// Listen registers a function called with every event of the list, after
+// the listeners registered before it, and returns a function removing it.
+// Listeners may read the list, but must not mutate it.
+func (l *List[T]) Listen(f func(Event[T])) (stop func()) {
+	r := &listener[T]{f: f}
+	l.listeners = append(l.listeners, r)
+	return func() {
+		l.listeners = slices.DeleteFunc(l.listeners, func(o *listener[T]) bool { return o == r })
+	}
+}
+
+// NonEmpty returns true if the list is not empty.
+func (l *List[T]) NonEmpty() bool {
+	return l.l.NonEmpty()
+}
+
+// Pop removes and returns the last value of the list,
+// and emits a Remove event.
+func (l *List[T]) Pop() (T, error) {
+	v, err := l.l.Pop()
+	if err == nil {
+		l.emit(Event[T]{Kind: Remove, Index: l.l.Length(), Value: v})
+	}
+	return v, err
+}
+
+// RemoveAll removes every value satisfying the predicate f, and emits a
+// Remove event for each of them, once they are all removed. The index of
+// each event accounts for the values removed before it, so that applying
+// the events in order to a copy of the list reproduces the change.
+// It returns the number of values removed.
+func (l *List[T]) RemoveAll(f func(T) bool) int {
+	var events []Event[T]
+	var matches []bool
+	for i, v := range l.l.All() {
+		match := f(v)
+		matches = append(matches, match)
+		if match {
+			events = append(events, Event[T]{Kind: Remove, Index: i - len(events), Value: v})
+		}
+	}
+	i := 0
+	l.l.RemoveAll(func(T) bool {
+		i++
+		return matches[i-1]
+	})
+	for _, e := range events {
+		l.emit(e)
+	}
+	return len(events)
+}
+
+// RemoveAt removes and returns the value at the given index,
+// and emits a Remove event. If the index is out of bounds,
+// it returns the zero value and an error.
+func (l *List[T]) RemoveAt(index int) (T, error) {
+	v, err := l.l.RemoveAt(index)
+	if err == nil {
+		l.emit(Event[T]{Kind: Remove, Index: index, Value: v})
+	}
+	return v, err
+}
+
+// RemoveFirst removes the first value satisfying the predicate f, emits a
+// Remove event and returns the value along with true, or the zero value and
+// false if no value satisfies f.
+func (l *List[T]) RemoveFirst(f func(T) bool) (T, bool) {
+	for i, v := range l.l.All() {
+		if f(v) {
+			_, _ = l.RemoveAt(i)
+			return v, true
+		}
+	}
+	return *new(T), false
+}
+
+// Set replaces the value at the given index and emits an Update event.
+// If the index is out of bounds, it returns an error.
+func (l *List[T]) Set(index int, v T) error {
+	if index < 0 || index >= l.l.Length() {
+		return collection.IndexOutOfBoundsError
+	}
+	old := l.l.At(index)
+	_ = l.l.Set(index, v)
+	l.emit(Event[T]{Kind: Update, Index: index, Value: v, Old: old})
+	return nil
+}
+
+// ToSlice returns a slice of the values of the list.
+func (l *List[T]) ToSlice() []T {
+	return l.l.ToSlice()
+}
+
+// implement the Stringer interface
+func (l *List[T]) String() string {
+	return fmt.Sprintf("ObservableList(%T) %v", *new(T), l.l.ToSlice())
+}
+
+// emit calls the listeners with the event, or records it as part of the
+// current batch.
+func (l *List[T]) emit(e Event[T]) {
+	if l.batching > 0 {
+		l.batched = true
+		return
+	}
+	for _, r := range slices.Clone(l.listeners) {
+		r.f(e)
+	}
+}
@@ -0,0 +1,156 @@
+package observable
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// mirror applies the events of l to a slice, which must then equal l.
+func mirror[T any](l *List[T]) (*[]T, func()) {
+	values := l.ToSlice()
+	stop := l.Listen(func(e Event[T]) {
+		switch e.Kind {
+		case Add:
+			values = slices.Insert(values, e.Index, e.Value)
+		case Remove:
+			values = slices.Delete(values, e.Index, e.Index+1)
+		case Update:
+			values[e.Index] = e.Value
+		case Clear:
+			values = nil
+		case Batch:
+			values = l.ToSlice()
+		}
+	})
+	return &values, stop
+}
+
+func TestList_Events(t *testing.T) {
+	l := NewList([]int{1, 2, 3})
+	var got []string
+	stop := l.Listen(func(e Event[int]) { got = append(got, e.String()) })
+	l.Add(4)
+	l.AddFirst(0)
+	l.InsertAt(2, 9)
+	l.Set(2, 8)
+	l.RemoveAt(2)
+	l.Pop()
+	l.Dequeue()
+	l.RemoveFirst(func(v int) bool { return v == 2 })
+	l.Clear()
+	l.Clear()
+	l.RemoveAt(0)
+	want := []string{
+		"Add(3, 4)", "Add(0, 0)", "Add(2, 9)", "Update(2, 9 -> 8)", "Remove(2, 8)",
+		"Remove(4, 4)", "Remove(0, 0)", "Remove(1, 2)", "Clear",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+	stop()
+	l.Add(1)
+	if len(got) != len(want) {
+		t.Errorf("stopped listener received %v", got[len(want):])
+	}
+}
+
+func TestList_RemoveAll(t *testing.T) {
+	l := NewList([]int{2, 1, 4, 6, 3, 8})
+	values, stop := mirror(l)
+	defer stop()
+	if n := l.RemoveAll(func(v int) bool { return v%2 == 0 }); n != 4 {
+		t.Errorf("RemoveAll() = %d, want 4", n)
+	}
+	if !slices.Equal(*values, []int{1, 3}) || !slices.Equal(l.ToSlice(), []int{1, 3}) {
+		t.Errorf("RemoveAll() left %v, mirrored as %v, want [1 3]", l.ToSlice(), *values)
+	}
+}
+
+func TestList_Mirror(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	l := NewList[int]()
+	values, stop := mirror(l)
+	defer stop()
+	for step := range 2000 {
+		n := l.Length()
+		switch r.Intn(9) {
+		case 0:
+			l.Add(step)
+		case 1:
+			l.AddFirst(step)
+		case 2:
+			l.InsertAt(r.Intn(n+1), step)
+		case 3:
+			l.Set(r.Intn(n+1), step)
+		case 4:
+			l.RemoveAt(r.Intn(n + 1))
+		case 5:
+			l.Pop()
+		case 6:
+			l.Dequeue()
+		case 7:
+			l.RemoveAll(func(v int) bool { return v%7 == step%7 })
+		case 8:
+			if r.Intn(20) == 0 {
+				l.Clear()
+			}
+		}
+		if !slices.Equal(*values, l.ToSlice()) {
+			t.Fatalf("step %d: mirror = %v, want %v", step, *values, l.ToSlice())
+		}
+	}
+}
+
+func TestList_Batch(t *testing.T) {
+	l := NewList[int]()
+	var got []Kind
+	l.Listen(func(e Event[int]) { got = append(got, e.Kind) })
+	l.Batch(func(l *List[int]) {
+		for i := range 100 {
+			l.Add(i)
+		}
+		l.Batch(func(l *List[int]) { l.RemoveAt(0) })
+	})
+	l.Batch(func(l *List[int]) {})
+	if !slices.Equal(got, []Kind{Batch}) {
+		t.Errorf("events = %v, want [Batch]", got)
+	}
+	if l.Length() != 99 {
+		t.Errorf("Length() = %d, want 99", l.Length())
+	}
+	l.Add(100)
+	if !slices.Equal(got, []Kind{Batch, Add}) {
+		t.Errorf("events after Batch = %v, want [Batch Add]", got)
+	}
+}
+
+func TestList_EventsChannel(t *testing.T) {
+	l := NewList[string]()
+	ch, stop := l.Events(2)
+	l.Add("a")
+	l.Set(0, "b")
+	stop()
+	stop()
+	l.Add("c")
+	var got []Event[string]
+	for e := range ch {
+		got = append(got, e)
+	}
+	want := []Event[string]{{Kind: Add, Index: 0, Value: "a"}, {Kind: Update, Index: 0, Value: "b", Old: "a"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("Events() = %v, want %v", got, want)
+	}
+}
+
+func TestKind_String(t *testing.T) {
+	if got := Update.String(); got != "Update" {
+		t.Errorf("Update.String() = %q", got)
+	}
+	if got := Kind(42).String(); got != "Kind(42)" {
+		t.Errorf("Kind(42).String() = %q", got)
+	}
+	if got := NewList([]int{1}).String(); got != "ObservableList(int) [1]" {
+		t.Errorf("String() = %q", got)
+	}
+}
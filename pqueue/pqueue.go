@@ -0,0 +1,388 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package pqueue implements a generic priority queue backed by a binary heap.
+// A PriorityQueue is a Collection whose elements are popped in priority
+// order, as defined by the less function it was constructed with:
+// the element for which less holds against every other is popped first.
+//
+//	type Task struct {
+//	  Name     string
+//	  Priority int
+//	}
+//
+//	tasks := pqueue.NewPriorityQueue(func(a, b Task) bool { return a.Priority > b.Priority })
+//	tasks.Push(Task{"deploy", 2})
+//	tasks.Push(Task{"hotfix", 9})
+//	tasks.Pop() // {hotfix 9}, nil
+//
+// For ordered types, NewMinQueue and NewMaxQueue provide the natural orderings.
+package pqueue
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// PriorityQueue is a binary heap of elements ordered by a less function.
+// Push and Pop run in O(log n), Peek in O(1). A PriorityQueue must be
+// created with NewPriorityQueue, NewMinQueue or NewMaxQueue, which set its order.
+type PriorityQueue[T any] struct {
+	elements   []T
+	less       func(a, b T) bool
+	onRemove   func(T)
+	watermarks collection.Watermarks
+	collection.Randomized
+}
+
+// NewPriorityQueue returns a priority queue ordered by less,
+// holding the elements of the given slices.
+//
+// example usage:
+//
+//	pq := NewPriorityQueue(func(a, b string) bool { return len(a) < len(b) }, []string{"gopher", "go", "gos"})
+//	pq.Pop()
+//
+// output:
+//
+//	go, nil
+func NewPriorityQueue[T any](less func(a, b T) bool, s ...[]T) *PriorityQueue[T] {
+	pq := &PriorityQueue[T]{less: less}
+	for _, slice := range s {
+		pq.elements = append(pq.elements, slice...)
+	}
+	pq.heapify()
+	return pq
+}
+
+// NewMinQueue returns a priority queue popping the smallest element first.
+func NewMinQueue[T cmp.Ordered](s ...[]T) *PriorityQueue[T] {
+	return NewPriorityQueue(cmp.Less[T], s...)
+}
+
+// NewMaxQueue returns a priority queue popping the greatest element first.
+func NewMaxQueue[T cmp.Ordered](s ...[]T) *PriorityQueue[T] {
+	return NewPriorityQueue(func(a, b T) bool { return cmp.Less(b, a) }, s...)
+}
+
+// The following methods implement
+// the Collection interface.
+
+// Add is an alias for Push.
+func (pq *PriorityQueue[T]) Add(v T) {
+	pq.Push(v)
+}
+
+// Length returns the number of elements in the queue.
+func (pq *PriorityQueue[T]) Length() int {
+	return len(pq.elements)
+}
+
+// New returns a new priority queue with the same ordering.
+func (pq *PriorityQueue[T]) New(s ...[]T) collection.Collection[T] {
+	return NewPriorityQueue(pq.less, s...)
+}
+
+// Random returns a random element from the queue.
+func (pq *PriorityQueue[T]) Random() T {
+	if len(pq.elements) == 0 {
+		panic(collection.EmptyCollectionError)
+	}
+	return pq.elements[pq.RandState().IntN(len(pq.elements))]
+}
+
+// Values returns an iterator over all elements of the queue in heap order,
+// which is not the priority order. Use ToSlice or Drain to iterate in priority order.
+func (pq *PriorityQueue[T]) Values() iter.Seq[T] {
+	return slices.Values(pq.elements)
+}
+
+// Age applies f to every element of the queue and restores the heap order
+// in O(n). It is meant to be called periodically by schedulers, with f
+// raising the priority of the waiting elements, so that elements pushed
+// with a low priority are not starved by a steady flow of higher priority ones.
+//
+// example usage:
+//
+//	for range ticker.C {
+//	  tasks.Age(func(t Task) Task {
+//	    t.Priority++
+//	    return t
+//	  })
+//	}
+func (pq *PriorityQueue[T]) Age(f func(T) T) {
+	for i, v := range pq.elements {
+		pq.elements[i] = f(v)
+	}
+	pq.heapify()
+}
+
+// Clone returns a copy of the queue. This is a shallow clone.
+func (pq *PriorityQueue[T]) Clone() *PriorityQueue[T] {
+	return &PriorityQueue[T]{elements: slices.Clone(pq.elements), less: pq.less}
+}
+
+// Drain returns an iterator that pops the elements in priority order.
+// Elements not yielded, because the loop stopped early or the context
+// was done, remain in the queue.
+//
+// example usage:
+//
+//	pq := NewMinQueue([]int{3,1,2})
+//	slices.Collect(pq.Drain(ctx))
+//
+// output:
+//
+//	[1,2,3]
+func (pq *PriorityQueue[T]) Drain(ctx context.Context) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for len(pq.elements) > 0 && ctx.Err() == nil {
+			if v, _ := pq.Pop(); !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// DrainInto pops all the elements of the queue, adds them to dst
+// in priority order and returns the number of elements moved.
+func (pq *PriorityQueue[T]) DrainInto(dst collection.Collection[T]) int {
+	n := len(pq.elements)
+	for len(pq.elements) > 0 {
+		v, _ := pq.Pop()
+		dst.Add(v)
+	}
+	return n
+}
+
+// IsEmpty returns true if the queue is empty.
+func (pq *PriorityQueue[T]) IsEmpty() bool {
+	return len(pq.elements) == 0
+}
+
+// NonEmpty returns true if the queue is not empty.
+func (pq *PriorityQueue[T]) NonEmpty() bool {
+	return len(pq.elements) > 0
+}
+
+// OnHighWatermark sets a function called with the length of the queue
+// when it grows to size elements, i.e. to pause the producers, see
+// collection.Watermarks. It returns the queue, so that it can be set along
+// with its creation.
+func (pq *PriorityQueue[T]) OnHighWatermark(size int, f func(length int)) *PriorityQueue[T] {
+	pq.watermarks.SetHigh(size, f)
+	return pq
+}
+
+// OnLowWatermark sets a function called with the length of the queue when
+// it falls back to size elements after reaching the high watermark, i.e. to
+// resume the producers. It returns the queue.
+func (pq *PriorityQueue[T]) OnLowWatermark(size int, f func(length int)) *PriorityQueue[T] {
+	pq.watermarks.SetLow(size, f)
+	return pq
+}
+
+// OnRemove sets a function called with every element popped from the
+// queue, by Pop, Drain or DrainInto, before it is handed over. Clones of
+// the queue do not inherit it. It returns the queue, so that it can be set
+// along with its creation.
+func (pq *PriorityQueue[T]) OnRemove(f func(T)) *PriorityQueue[T] {
+	pq.onRemove = f
+	return pq
+}
+
+// Peek returns the element with the highest priority without removing it.
+func (pq *PriorityQueue[T]) Peek() (T, error) {
+	if len(pq.elements) == 0 {
+		return *new(T), collection.EmptyCollectionError
+	}
+	return pq.elements[0], nil
+}
+
+// PeekLast returns the element with the lowest priority without removing
+// it. It runs in O(n), the lowest priority being any of the leaves of the heap.
+func (pq *PriorityQueue[T]) PeekLast() (T, error) {
+	if len(pq.elements) == 0 {
+		return *new(T), collection.EmptyCollectionError
+	}
+	return pq.elements[pq.last()], nil
+}
+
+// Pop removes and returns the element with the highest priority.
+func (pq *PriorityQueue[T]) Pop() (T, error) {
+	if len(pq.elements) == 0 {
+		return *new(T), collection.EmptyCollectionError
+	}
+	return pq.removeAt(0), nil
+}
+
+// PopLast removes and returns the element with the lowest priority, i.e.
+// to shed the least urgent work from a full queue. It runs in O(n).
+//
+// example usage:
+//
+//	pq := NewMinQueue([]int{5, 1, 4, 2})
+//	pq.PopLast()
+//	pq.ToSlice()
+//
+// output:
+//
+//	5, nil
+//	[1 2 4]
+func (pq *PriorityQueue[T]) PopLast() (T, error) {
+	if len(pq.elements) == 0 {
+		return *new(T), collection.EmptyCollectionError
+	}
+	return pq.removeAt(pq.last()), nil
+}
+
+// Push adds an element to the queue.
+func (pq *PriorityQueue[T]) Push(v T) {
+	pq.elements = append(pq.elements, v)
+	pq.up(len(pq.elements) - 1)
+	pq.resized()
+}
+
+// ReprioritizeWhere replaces every element satisfying the predicate with
+// the result of update, restores the heap order in O(n) and returns the
+// number of elements updated.
+//
+// example usage:
+//
+//	tasks.ReprioritizeWhere(
+//	  func(t Task) bool { return t.Owner == "oncall" },
+//	  func(t Task) Task { t.Priority += 10; return t },
+//	)
+func (pq *PriorityQueue[T]) ReprioritizeWhere(f func(T) bool, update func(T) T) int {
+	n := 0
+	for i, v := range pq.elements {
+		if f(v) {
+			pq.elements[i] = update(v)
+			n++
+		}
+	}
+	if n > 0 {
+		pq.heapify()
+	}
+	return n
+}
+
+// ToSlice returns the elements of the queue in priority order,
+// without modifying the queue.
+func (pq *PriorityQueue[T]) ToSlice() []T {
+	return slices.Collect(pq.Clone().Drain(context.Background()))
+}
+
+// UpdatePriority replaces the first element satisfying the predicate with v
+// and restores the heap order. It returns false if no element matched.
+// Finding the element takes O(n), restoring the order O(log n).
+//
+// example usage:
+//
+//	tasks.UpdatePriority(func(t Task) bool { return t.Name == "deploy" }, Task{"deploy", 10})
+//	tasks.Peek()
+//
+// output:
+//
+//	{deploy 10}, nil
+func (pq *PriorityQueue[T]) UpdatePriority(f func(T) bool, v T) bool {
+	i := slices.IndexFunc(pq.elements, f)
+	if i < 0 {
+		return false
+	}
+	pq.elements[i] = v
+	if !pq.down(i) {
+		pq.up(i)
+	}
+	return true
+}
+
+// implement the Stringer interface
+func (pq *PriorityQueue[T]) String() string {
+	return fmt.Sprintf("PriorityQueue(%T) %v", *new(T), pq.ToSlice())
+}
+
+// resized calls the watermark callback crossed by
+// the length of the queue, if any.
+func (pq *PriorityQueue[T]) resized() {
+	n := len(pq.elements)
+	if f := pq.watermarks.Update(n); f != nil {
+		f(n)
+	}
+}
+
+// last returns the index of the element with the lowest priority,
+// searching the leaves of the heap. The queue must not be empty.
+func (pq *PriorityQueue[T]) last() int {
+	n := len(pq.elements)
+	last := n / 2
+	for i := last + 1; i < n; i++ {
+		if pq.less(pq.elements[last], pq.elements[i]) {
+			last = i
+		}
+	}
+	return last
+}
+
+// removeAt removes and returns the element at index i, restoring the heap
+// order, then calls the OnRemove and watermark callbacks.
+func (pq *PriorityQueue[T]) removeAt(i int) T {
+	v := pq.elements[i]
+	last := len(pq.elements) - 1
+	pq.elements[i] = pq.elements[last]
+	pq.elements[last] = *new(T)
+	pq.elements = pq.elements[:last]
+	if i < last && !pq.down(i) {
+		pq.up(i)
+	}
+	if pq.onRemove != nil {
+		pq.onRemove(v)
+	}
+	pq.resized()
+	return v
+}
+
+// heapify restores the heap order of all the elements in O(n).
+func (pq *PriorityQueue[T]) heapify() {
+	for i := len(pq.elements)/2 - 1; i >= 0; i-- {
+		pq.down(i)
+	}
+}
+
+// up moves the element at index i towards the root until its parent precedes it.
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(pq.elements[i], pq.elements[parent]) {
+			return
+		}
+		pq.elements[i], pq.elements[parent] = pq.elements[parent], pq.elements[i]
+		i = parent
+	}
+}
+
+// down moves the element at index i towards the leaves until it precedes
+// its children. It returns true if the element moved.
+func (pq *PriorityQueue[T]) down(i int) bool {
+	start, n := i, len(pq.elements)
+	for {
+		first, left, right := i, 2*i+1, 2*i+2
+		if left < n && pq.less(pq.elements[left], pq.elements[first]) {
+			first = left
+		}
+		if right < n && pq.less(pq.elements[right], pq.elements[first]) {
+			first = right
+		}
+		if first == i {
+			return i > start
+		}
+		pq.elements[i], pq.elements[first] = pq.elements[first], pq.elements[i]
+		i = first
+	}
+}
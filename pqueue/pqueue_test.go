@@ -0,0 +1,270 @@
+package pqueue
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+)
+
+type task struct {
+	name     string
+	priority int
+}
+
+func byPriority(a, b task) bool { return a.priority > b.priority }
+
+func TestNewPriorityQueue(t *testing.T) {
+	tests := []struct {
+		name string
+		pq   *PriorityQueue[int]
+		want []int
+	}{
+		{name: "min queue", pq: NewMinQueue([]int{5, 1, 4}, []int{2, 3}), want: []int{1, 2, 3, 4, 5}},
+		{name: "max queue", pq: NewMaxQueue([]int{5, 1, 4, 2, 3}), want: []int{5, 4, 3, 2, 1}},
+		{name: "duplicates", pq: NewMinQueue([]int{2, 1, 2, 1}), want: []int{1, 1, 2, 2}},
+		{name: "empty", pq: NewMinQueue[int](), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pq.ToSlice(); !slices.Equal(got, tt.want) {
+				t.Errorf("ToSlice() = %v, want %v", got, tt.want)
+			}
+			if got := slices.Collect(tt.pq.Drain(context.Background())); !slices.Equal(got, tt.want) {
+				t.Errorf("Drain(ctx) = %v, want %v", got, tt.want)
+			}
+			if tt.pq.NonEmpty() {
+				t.Errorf("Drain(ctx) left %d elements", tt.pq.Length())
+			}
+		})
+	}
+}
+
+func TestPriorityQueue_PushPop(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	pq := NewMinQueue[int]()
+	var want []int
+	for range 500 {
+		v := r.Intn(100)
+		pq.Push(v)
+		want = append(want, v)
+	}
+	slices.Sort(want)
+	for i, w := range want {
+		if v, _ := pq.Peek(); v != w {
+			t.Fatalf("Peek() at step %d = %v, want %v", i, v, w)
+		}
+		if v, err := pq.Pop(); v != w || err != nil {
+			t.Fatalf("Pop() at step %d = %v, %v, want %v, nil", i, v, err, w)
+		}
+	}
+	if _, err := pq.Pop(); err != collection.EmptyCollectionError {
+		t.Errorf("Pop() on empty queue error = %v, want %v", err, collection.EmptyCollectionError)
+	}
+	if _, err := pq.Peek(); err != collection.EmptyCollectionError {
+		t.Errorf("Peek() on empty queue error = %v, want %v", err, collection.EmptyCollectionError)
+	}
+}
+
+func TestPriorityQueue_PopBothEnds(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	pq := NewMinQueue[int]()
+	var want []int
+	for range 500 {
+		v := r.Intn(100)
+		pq.Push(v)
+		want = append(want, v)
+	}
+	slices.Sort(want)
+	for i := 0; len(want) > 0; i++ {
+		if i%2 == 0 {
+			if v, _ := pq.PeekLast(); v != want[len(want)-1] {
+				t.Fatalf("PeekLast() at step %d = %v, want %v", i, v, want[len(want)-1])
+			}
+			if v, err := pq.PopLast(); v != want[len(want)-1] || err != nil {
+				t.Fatalf("PopLast() at step %d = %v, %v, want %v, nil", i, v, err, want[len(want)-1])
+			}
+			want = want[:len(want)-1]
+		} else {
+			if v, err := pq.Pop(); v != want[0] || err != nil {
+				t.Fatalf("Pop() at step %d = %v, %v, want %v, nil", i, v, err, want[0])
+			}
+			want = want[1:]
+		}
+	}
+	if _, err := pq.PopLast(); err != collection.EmptyCollectionError {
+		t.Errorf("PopLast() on empty queue error = %v, want %v", err, collection.EmptyCollectionError)
+	}
+	if _, err := pq.PeekLast(); err != collection.EmptyCollectionError {
+		t.Errorf("PeekLast() on empty queue error = %v, want %v", err, collection.EmptyCollectionError)
+	}
+}
+
+func TestPriorityQueue_UpdatePriority(t *testing.T) {
+	tasks := func() *PriorityQueue[task] {
+		return NewPriorityQueue(byPriority, []task{{"a", 5}, {"b", 3}, {"c", 8}, {"d", 1}, {"e", 4}})
+	}
+	tests := []struct {
+		name   string
+		target string
+		to     int
+		ok     bool
+		want   []string
+	}{
+		{name: "raise", target: "d", to: 10, ok: true, want: []string{"d", "c", "a", "e", "b"}},
+		{name: "lower", target: "c", to: 0, ok: true, want: []string{"a", "e", "b", "d", "c"}},
+		{name: "missing", target: "z", to: 7, ok: false, want: []string{"c", "a", "e", "b", "d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pq := tasks()
+			ok := pq.UpdatePriority(func(v task) bool { return v.name == tt.target }, task{tt.target, tt.to})
+			if ok != tt.ok {
+				t.Errorf("UpdatePriority() = %v, want %v", ok, tt.ok)
+			}
+			var got []string
+			for v := range pq.Drain(context.Background()) {
+				got = append(got, v.name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Drain(ctx) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriorityQueue_ReprioritizeWhere(t *testing.T) {
+	tests := []struct {
+		name string
+		pred func(task) bool
+		n    int
+		want []string
+	}{
+		{name: "boost vowels", pred: func(v task) bool { return v.name == "a" || v.name == "e" }, n: 2, want: []string{"a", "e", "c", "b", "d"}},
+		{name: "no match", pred: func(v task) bool { return false }, n: 0, want: []string{"c", "a", "e", "b", "d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pq := NewPriorityQueue(byPriority, []task{{"a", 5}, {"b", 3}, {"c", 8}, {"d", 1}, {"e", 4}})
+			n := pq.ReprioritizeWhere(tt.pred, func(v task) task { v.priority += 10; return v })
+			if n != tt.n {
+				t.Errorf("ReprioritizeWhere() = %v, want %v", n, tt.n)
+			}
+			var got []string
+			for v := range pq.Drain(context.Background()) {
+				got = append(got, v.name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Drain(ctx) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriorityQueue_Age(t *testing.T) {
+	pq := NewPriorityQueue(byPriority, []task{{"old", 3}})
+	age := func(v task) task { v.priority += 2; return v }
+	for _, name := range []string{"new1", "new2", "new3"} {
+		pq.Age(age)
+		pq.Push(task{name, 4})
+	}
+	var got []string
+	for v := range pq.Drain(context.Background()) {
+		got = append(got, v.name)
+	}
+	if want := []string{"old", "new1", "new2", "new3"}; !slices.Equal(got, want) {
+		t.Errorf("Drain(ctx) = %v, want %v", got, want)
+	}
+}
+
+func TestPriorityQueue_Collection(t *testing.T) {
+	var c collection.Collection[int] = NewMaxQueue([]int{1, 3, 2})
+	c.Add(7)
+	if c.Length() != 4 {
+		t.Errorf("Length() = %v, want 4", c.Length())
+	}
+	if got := slices.Sorted(c.Values()); !slices.Equal(got, []int{1, 2, 3, 7}) {
+		t.Errorf("Values() = %v, want the elements [1 2 3 7]", got)
+	}
+	if v := c.Random(); !slices.Contains([]int{1, 2, 3, 7}, v) {
+		t.Errorf("Random() = %v, not an element of the queue", v)
+	}
+	n := c.New([]int{4, 9}).(*PriorityQueue[int])
+	if v, _ := n.Peek(); v != 9 {
+		t.Errorf("New() does not keep the ordering, Peek() = %v, want 9", v)
+	}
+	if got := collection.Count(c, func(i int) bool { return i > 2 }); got != 2 {
+		t.Errorf("Count() = %v, want 2", got)
+	}
+}
+
+func TestPriorityQueue_DrainPartial(t *testing.T) {
+	pq := NewMinQueue([]int{4, 2, 3, 1})
+	for v := range pq.Drain(context.Background()) {
+		if v == 2 {
+			break
+		}
+	}
+	if got := pq.ToSlice(); !slices.Equal(got, []int{3, 4}) {
+		t.Errorf("queue after partial Drain() = %v, want [3 4]", got)
+	}
+	if got := pq.String(); got != "PriorityQueue(int) [3 4]" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestPriorityQueue_DrainCanceled(t *testing.T) {
+	pq := NewMinQueue([]int{4, 2, 3, 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var got []int
+	for v := range pq.Drain(ctx) {
+		got = append(got, v)
+		if v == 2 {
+			cancel()
+		}
+	}
+	if !slices.Equal(got, []int{1, 2}) || !slices.Equal(pq.ToSlice(), []int{3, 4}) {
+		t.Errorf("Drain() with canceled context = %v, left %v, want [1 2], [3 4]", got, pq.ToSlice())
+	}
+}
+
+func TestPriorityQueue_DrainInto(t *testing.T) {
+	pq := NewMaxQueue([]int{1, 3, 2})
+	dst := NewMinQueue[int]()
+	if n := pq.DrainInto(dst); n != 3 {
+		t.Errorf("DrainInto() = %v, want 3", n)
+	}
+	if pq.NonEmpty() || !slices.Equal(dst.ToSlice(), []int{1, 2, 3}) {
+		t.Errorf("DrainInto() left %v and moved %v", pq, dst)
+	}
+}
+
+func TestPriorityQueue_OnRemove(t *testing.T) {
+	var removed []int
+	pq := NewMinQueue([]int{3, 1, 2}).OnRemove(func(v int) { removed = append(removed, v) })
+	pq.Pop()
+	pq.DrainInto(NewMaxQueue[int]())
+	if !slices.Equal(removed, []int{1, 2, 3}) {
+		t.Errorf("removed %v, want [1 2 3]", removed)
+	}
+	if _, err := pq.Pop(); err != collection.EmptyCollectionError || len(removed) != 3 {
+		t.Errorf("Pop() on empty queue returned %v and removed %v", err, removed)
+	}
+}
+
+func TestPriorityQueue_Watermarks(t *testing.T) {
+	var events []int
+	pq := NewMinQueue[int]().
+		OnHighWatermark(2, func(n int) { events = append(events, n) }).
+		OnLowWatermark(1, func(n int) { events = append(events, -n) })
+	pq.Push(3)
+	pq.Push(1)
+	pq.Push(2)
+	pq.DrainInto(NewMinQueue[int]())
+	if !slices.Equal(events, []int{2, -1}) {
+		t.Errorf("watermark events = %v, want [2 -1]", events)
+	}
+}
@@ -0,0 +1,358 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package queue implements FIFO queues.
+//
+// A Queue is backed by a List but only exposes the queue operations:
+//
+//	q := queue.NewQueue[string]()
+//	q.Enqueue("a")
+//	q.Dequeue() // a, nil
+//
+// A DedupQueue coalesces pending items sharing the same key, so that an
+// item enqueued several times before a worker picks it up is processed once:
+//
+//	resync := queue.NewDedupQueue(func(e Event) string { return e.Object }, queue.KeepLatest)
+//	resync.Enqueue(Event{Object: "pod/a", Version: 1})
+//	resync.Enqueue(Event{Object: "pod/a", Version: 2}) // coalesced
+//	resync.Dequeue() // {pod/a 2}, nil
+package queue
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/set"
+)
+
+// Policy decides which value is kept when an item is enqueued
+// while an item with the same key is pending.
+type Policy int
+
+const (
+	// KeepFirst keeps the pending value and drops the new one.
+	KeepFirst Policy = iota
+	// KeepLatest replaces the pending value with the new one.
+	KeepLatest
+)
+
+// DedupQueue is a FIFO queue holding at most one pending item per key.
+// Coalesced items keep the position of the first pending item with
+// their key, whichever the policy. It is safe for concurrent use.
+// A DedupQueue must be created with NewDedupQueue, which sets its key function.
+type DedupQueue[T any, K comparable] struct {
+	mu      sync.Mutex
+	key     func(T) K
+	policy  Policy
+	order   *set.OrderedSet[K]
+	pending map[K]T
+	// added is closed, then reset, whenever items are added,
+	// waking up the goroutines blocked in DequeueOrWait.
+	added      chan struct{}
+	watermarks collection.Watermarks
+	collection.Randomized
+}
+
+// NewDedupQueue returns a queue coalescing the items sharing the same key
+// according to the policy, holding the elements of the given slices.
+//
+// example usage:
+//
+//	q := NewDedupQueue(func(s string) byte { return s[0] }, KeepFirst, []string{"go", "zig", "gopher"})
+//	q.ToSlice()
+//
+// output:
+//
+//	[go zig]
+func NewDedupQueue[T any, K comparable](key func(T) K, policy Policy, s ...[]T) *DedupQueue[T, K] {
+	q := &DedupQueue[T, K]{
+		key:     key,
+		policy:  policy,
+		order:   set.NewOrderedSet[K](),
+		pending: make(map[K]T),
+	}
+	for _, slice := range s {
+		for _, v := range slice {
+			q.enqueue(v)
+		}
+	}
+	return q
+}
+
+// The following methods implement
+// the Collection interface.
+
+// Add is an alias for Enqueue.
+func (q *DedupQueue[T, K]) Add(v T) {
+	q.Enqueue(v)
+}
+
+// Length returns the number of pending items.
+func (q *DedupQueue[T, K]) Length() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// New returns a new queue with the same key function and policy.
+func (q *DedupQueue[T, K]) New(s ...[]T) collection.Collection[T] {
+	return NewDedupQueue(q.key, q.policy, s...)
+}
+
+// Random returns a random pending item.
+func (q *DedupQueue[T, K]) Random() T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.pending) == 0 {
+		panic(collection.EmptyCollectionError)
+	}
+	return q.pending[q.order.At(q.RandState().IntN(len(q.pending)))]
+}
+
+// Values returns an iterator over a snapshot of the pending items, in queue order.
+func (q *DedupQueue[T, K]) Values() iter.Seq[T] {
+	return slices.Values(q.ToSlice())
+}
+
+// Contains returns true if an item with the key is pending.
+func (q *DedupQueue[T, K]) Contains(k K) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_, ok := q.pending[k]
+	return ok
+}
+
+// Dequeue removes and returns the first pending item.
+func (q *DedupQueue[T, K]) Dequeue() (T, error) {
+	q.mu.Lock()
+	defer q.unlock()
+	return q.dequeue()
+}
+
+// DequeueOrWait removes and returns the first pending item,
+// blocking until an item is available or the context is done,
+// in which case it returns the context's error.
+func (q *DedupQueue[T, K]) DequeueOrWait(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		if len(q.pending) > 0 {
+			defer q.unlock()
+			return q.dequeue()
+		}
+		if q.added == nil {
+			q.added = make(chan struct{})
+		}
+		added := q.added
+		q.mu.Unlock()
+		select {
+		case <-added:
+		case <-ctx.Done():
+			return *new(T), ctx.Err()
+		}
+	}
+}
+
+// Drain returns an iterator that removes and yields the pending items in
+// queue order. The contents of the queue are swapped out atomically when
+// the iteration starts, so the lock is not held while the loop body runs.
+// Items not yielded, because the loop stopped early or the context was
+// done, are put back at the front of the queue and coalesced with the
+// items enqueued in the meantime according to the policy.
+func (q *DedupQueue[T, K]) Drain(ctx context.Context) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		q.mu.Lock()
+		order, pending := q.order, q.pending
+		q.order, q.pending = set.NewOrderedSet[K](), make(map[K]T)
+		q.unlock()
+		defer func() {
+			q.mu.Lock()
+			defer q.unlock()
+			q.restore(order, pending)
+		}()
+		for order.NonEmpty() && ctx.Err() == nil {
+			k, _ := order.Head()
+			v := pending[k]
+			order.Remove(k)
+			delete(pending, k)
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// DrainInto atomically removes all the pending items, adds them to dst
+// in queue order and returns the number of items moved.
+// The lock is not held while adding to dst.
+func (q *DedupQueue[T, K]) DrainInto(dst collection.Collection[T]) int {
+	n := 0
+	for v := range q.Drain(context.Background()) {
+		dst.Add(v)
+		n++
+	}
+	return n
+}
+
+// Enqueue adds an item to the back of the queue, or coalesces it with the
+// pending item sharing its key according to the policy. It returns true
+// if the item was added, false if it was coalesced.
+//
+// example usage:
+//
+//	q := NewDedupQueue(func(s string) byte { return s[0] }, KeepLatest)
+//	q.Enqueue("go")
+//	q.Enqueue("zig")
+//	q.Enqueue("gopher")
+//	q.ToSlice()
+//
+// output:
+//
+//	true
+//	true
+//	false
+//	[gopher zig]
+func (q *DedupQueue[T, K]) Enqueue(v T) bool {
+	q.mu.Lock()
+	defer q.unlock()
+	added := q.enqueue(v)
+	if added && q.added != nil {
+		close(q.added)
+		q.added = nil
+	}
+	return added
+}
+
+// IsEmpty returns true if no item is pending.
+func (q *DedupQueue[T, K]) IsEmpty() bool {
+	return q.Length() == 0
+}
+
+// NonEmpty returns true if items are pending.
+func (q *DedupQueue[T, K]) NonEmpty() bool {
+	return q.Length() > 0
+}
+
+// OnHighWatermark sets a function called with the number of pending items
+// when it grows to size, i.e. to pause the producers, see
+// collection.Watermarks. It is called without holding the lock of the queue.
+// It returns the queue, so that it can be set along with its creation.
+func (q *DedupQueue[T, K]) OnHighWatermark(size int, f func(length int)) *DedupQueue[T, K] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.watermarks.SetHigh(size, f)
+	return q
+}
+
+// OnLowWatermark sets a function called with the number of pending items
+// when it falls back to size after reaching the high watermark, i.e. to
+// resume the producers. It returns the queue.
+func (q *DedupQueue[T, K]) OnLowWatermark(size int, f func(length int)) *DedupQueue[T, K] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.watermarks.SetLow(size, f)
+	return q
+}
+
+// Peek returns the first pending item without removing it.
+func (q *DedupQueue[T, K]) Peek() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	k, err := q.order.Head()
+	if err != nil {
+		return *new(T), collection.EmptyCollectionError
+	}
+	return q.pending[k], nil
+}
+
+// Remove removes the pending item with the key and returns it,
+// or returns false if no such item is pending.
+func (q *DedupQueue[T, K]) Remove(k K) (T, bool) {
+	q.mu.Lock()
+	defer q.unlock()
+	v, ok := q.pending[k]
+	if ok {
+		q.order.Remove(k)
+		delete(q.pending, k)
+	}
+	return v, ok
+}
+
+// ToSlice returns a snapshot of the pending items, in queue order.
+func (q *DedupQueue[T, K]) ToSlice() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	s := make([]T, 0, len(q.pending))
+	for k := range q.order.Values() {
+		s = append(s, q.pending[k])
+	}
+	return s
+}
+
+// implement the Stringer interface
+func (q *DedupQueue[T, K]) String() string {
+	return fmt.Sprintf("DedupQueue(%T) %v", *new(T), q.ToSlice())
+}
+
+// unlock releases the lock, then calls the watermark callback
+// crossed by the number of pending items, if any.
+func (q *DedupQueue[T, K]) unlock() {
+	n := len(q.pending)
+	f := q.watermarks.Update(n)
+	q.mu.Unlock()
+	if f != nil {
+		f(n)
+	}
+}
+
+// enqueue adds or coalesces an item. The lock must be held.
+func (q *DedupQueue[T, K]) enqueue(v T) bool {
+	k := q.key(v)
+	if _, ok := q.pending[k]; ok {
+		if q.policy == KeepLatest {
+			q.pending[k] = v
+		}
+		return false
+	}
+	q.order.Add(k)
+	q.pending[k] = v
+	return true
+}
+
+// dequeue removes the first pending item. The lock must be held.
+func (q *DedupQueue[T, K]) dequeue() (T, error) {
+	k, err := q.order.Head()
+	if err != nil {
+		return *new(T), collection.EmptyCollectionError
+	}
+	v := q.pending[k]
+	q.order.Remove(k)
+	delete(q.pending, k)
+	return v, nil
+}
+
+// restore puts the items of a drained queue back at the front of the queue.
+// Keys enqueued again during the drain keep their front position, and the
+// policy decides between the drained value and the newer one.
+// The lock must be held.
+func (q *DedupQueue[T, K]) restore(order *set.OrderedSet[K], pending map[K]T) {
+	if order.IsEmpty() {
+		return
+	}
+	for k := range q.order.Values() {
+		v := q.pending[k]
+		if _, ok := pending[k]; !ok || q.policy == KeepLatest {
+			pending[k] = v
+		}
+		order.Add(k)
+	}
+	q.order, q.pending = order, pending
+	if q.added != nil {
+		close(q.added)
+		q.added = nil
+	}
+}
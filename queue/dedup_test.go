@@ -0,0 +1,200 @@
+package queue
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/list"
+)
+
+type event struct {
+	object  string
+	version int
+}
+
+func byObject(e event) string { return e.object }
+
+func TestDedupQueue_Enqueue(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    Policy
+		want      []event
+		wantAdded []bool
+	}{
+		{
+			name:      "keep first",
+			policy:    KeepFirst,
+			want:      []event{{"a", 1}, {"b", 1}},
+			wantAdded: []bool{true, true, false},
+		},
+		{
+			name:      "keep latest",
+			policy:    KeepLatest,
+			want:      []event{{"a", 2}, {"b", 1}},
+			wantAdded: []bool{true, true, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewDedupQueue(byObject, tt.policy)
+			var added []bool
+			for _, e := range []event{{"a", 1}, {"b", 1}, {"a", 2}} {
+				added = append(added, q.Enqueue(e))
+			}
+			if !slices.Equal(added, tt.wantAdded) {
+				t.Errorf("Enqueue() = %v, want %v", added, tt.wantAdded)
+			}
+			if got := q.ToSlice(); !slices.Equal(got, tt.want) {
+				t.Errorf("ToSlice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDedupQueue_DequeueAndRemove(t *testing.T) {
+	q := NewDedupQueue(func(i int) int { return i % 10 }, KeepFirst, []int{1, 2, 11, 3})
+	if v, err := q.Peek(); err != nil || v != 1 {
+		t.Errorf("Peek() = %v, %v, want 1, nil", v, err)
+	}
+	if v, ok := q.Remove(2); !ok || v != 2 {
+		t.Errorf("Remove(2) = %v, %v, want 2, true", v, ok)
+	}
+	if _, ok := q.Remove(2); ok {
+		t.Error("Remove(2) twice returned true")
+	}
+	var got []int
+	for q.NonEmpty() {
+		v, _ := q.Dequeue()
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("Dequeue() = %v, want [1 3]", got)
+	}
+	if _, err := q.Dequeue(); err != collection.EmptyCollectionError {
+		t.Errorf("Dequeue() on empty queue error = %v", err)
+	}
+	// a dequeued key can be enqueued again
+	if !q.Enqueue(11) || !q.Contains(1) {
+		t.Error("Enqueue() of a dequeued key was coalesced")
+	}
+}
+
+func TestDedupQueue_Collection(t *testing.T) {
+	q := NewDedupQueue(byObject, KeepFirst, []event{{"a", 1}, {"a", 2}})
+	q.Add(event{"b", 1})
+	if q.Length() != 2 || q.IsEmpty() {
+		t.Errorf("Length() = %v, want 2", q.Length())
+	}
+	if r := q.Random(); !q.Contains(r.object) {
+		t.Errorf("Random() = %v, not pending", r)
+	}
+	if c := q.New([]event{{"c", 1}, {"c", 2}}); c.Length() != 1 {
+		t.Errorf("New() did not keep the key function: %v", c)
+	}
+	if got := q.String(); got != "DedupQueue(queue.event) [{a 1} {b 1}]" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestDedupQueue_Drain(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		want   []event
+	}{
+		{name: "keep first", policy: KeepFirst, want: []event{{"b", 1}, {"c", 1}, {"d", 1}}},
+		{name: "keep latest", policy: KeepLatest, want: []event{{"b", 1}, {"c", 2}, {"d", 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewDedupQueue(byObject, tt.policy, []event{{"a", 1}, {"b", 1}, {"c", 1}})
+			for e := range q.Drain(context.Background()) {
+				q.Enqueue(event{"d", 1})
+				q.Enqueue(event{"c", 2})
+				if e.object == "a" {
+					break
+				}
+			}
+			if got := q.ToSlice(); !slices.Equal(got, tt.want) {
+				t.Errorf("ToSlice() after Drain() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDedupQueue_DrainInto(t *testing.T) {
+	q := NewDedupQueue(func(i int) int { return i }, KeepFirst, []int{3, 1, 3, 2})
+	dst := list.NewList[int]()
+	if n := q.DrainInto(dst); n != 3 || q.NonEmpty() {
+		t.Errorf("DrainInto() = %v, leaving %v", n, q)
+	}
+	if !slices.Equal(dst.ToSlice(), []int{3, 1, 2}) {
+		t.Errorf("DrainInto() moved %v, want [3 1 2]", dst)
+	}
+}
+
+func TestDedupQueue_DequeueOrWait(t *testing.T) {
+	q := NewDedupQueue(func(i int) int { return i }, KeepFirst)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.DequeueOrWait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("DequeueOrWait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.Enqueue(7)
+	}()
+	if v, err := q.DequeueOrWait(context.Background()); err != nil || v != 7 {
+		t.Errorf("DequeueOrWait() = %v, %v, want 7, nil", v, err)
+	}
+}
+
+func TestDedupQueue_Concurrent(t *testing.T) {
+	const producers, keys = 8, 20
+	q := NewDedupQueue(func(i int) int { return i % keys }, KeepFirst)
+	var wg sync.WaitGroup
+	for range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				q.Enqueue(rand.Intn(1000))
+			}
+		}()
+	}
+	wg.Wait()
+	seen := make(map[int]bool)
+	for v := range q.Values() {
+		if seen[v%keys] {
+			t.Fatalf("key %v pending twice", v%keys)
+		}
+		seen[v%keys] = true
+	}
+	if q.Length() > keys {
+		t.Errorf("Length() = %v, want at most %v", q.Length(), keys)
+	}
+}
+
+func TestDedupQueue_Watermarks(t *testing.T) {
+	var events []int
+	var q *DedupQueue[int, int]
+	q = NewDedupQueue(func(v int) int { return v }, KeepFirst).
+		OnHighWatermark(3, func(n int) { events = append(events, n, q.Length()) }).
+		OnLowWatermark(0, func(n int) { events = append(events, -n, q.Length()) })
+	q.Enqueue(1)
+	q.Enqueue(2)
+	q.Enqueue(2) // coalesced
+	q.Enqueue(3)
+	q.Remove(3)
+	q.Enqueue(4) // still above the low watermark
+	q.DrainInto(list.NewList[int]())
+	want := []int{3, 3, 0, 0}
+	if !slices.Equal(events, want) {
+		t.Errorf("watermark events = %v, want %v", events, want)
+	}
+}
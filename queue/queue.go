@@ -0,0 +1,124 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package queue
+
+import (
+	"fmt"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/list"
+)
+
+// Queue is a first-in first-out queue backed by a List. Unlike the List,
+// it only exposes the queue operations, so that elements cannot be inserted
+// or removed out of order. It is not safe for concurrent use, see
+// list.SyncList or DedupQueue for queues shared by goroutines.
+// The zero value is an empty queue ready to use.
+type Queue[T any] struct {
+	elements   list.List[T]
+	watermarks collection.Watermarks
+}
+
+// NewQueue returns a queue holding the elements of the given slices,
+// enqueued in order so that the first element is at the front.
+//
+// example usage:
+//
+//	q := NewQueue([]string{"a", "b"})
+//	q.Enqueue("c")
+//	q.Dequeue()
+//
+// output:
+//
+//	a, nil
+func NewQueue[T any](s ...[]T) *Queue[T] {
+	q := new(Queue[T])
+	for _, slice := range s {
+		for _, v := range slice {
+			q.Enqueue(v)
+		}
+	}
+	return q
+}
+
+// Dequeue removes and returns the element at the front of the queue,
+// or returns an EmptyCollectionError if the queue is empty.
+func (q *Queue[T]) Dequeue() (T, error) {
+	v, err := q.elements.Dequeue()
+	q.resized()
+	return v, err
+}
+
+// Enqueue adds an element at the back of the queue.
+func (q *Queue[T]) Enqueue(v T) {
+	q.elements.Enqueue(v)
+	q.resized()
+}
+
+// IsEmpty returns true if the queue holds no elements.
+func (q *Queue[T]) IsEmpty() bool {
+	return q.elements.IsEmpty()
+}
+
+// Length returns the number of elements in the queue.
+func (q *Queue[T]) Length() int {
+	return q.elements.Length()
+}
+
+// OnHighWatermark sets a function called with the length of the queue when
+// it grows to size elements, i.e. to pause the producers, see
+// collection.Watermarks. It returns the queue, so that it can be set along
+// with its creation.
+//
+// example usage:
+//
+//	jobs := NewQueue[Job]().
+//	  OnHighWatermark(1000, func(int) { producer.Pause() }).
+//	  OnLowWatermark(100, func(int) { producer.Resume() })
+func (q *Queue[T]) OnHighWatermark(size int, f func(length int)) *Queue[T] {
+	q.watermarks.SetHigh(size, f)
+	return q
+}
+
+// OnLowWatermark sets a function called with the length of the queue when
+// it falls back to size elements after reaching the high watermark, i.e. to
+// resume the producers. It returns the queue.
+func (q *Queue[T]) OnLowWatermark(size int, f func(length int)) *Queue[T] {
+	q.watermarks.SetLow(size, f)
+	return q
+}
+
+// OnRemove sets a function called with every element dequeued from the
+// queue, see List.OnRemove. It returns the queue, so that it can be set
+// along with its creation.
+func (q *Queue[T]) OnRemove(f func(T)) *Queue[T] {
+	q.elements.OnRemove(f)
+	return q
+}
+
+// Peek returns the element at the front of the queue without removing it,
+// or an EmptyCollectionError if the queue is empty.
+func (q *Queue[T]) Peek() (T, error) {
+	return q.elements.Head()
+}
+
+// ToSlice returns the elements of the queue from front to back.
+func (q *Queue[T]) ToSlice() []T {
+	return q.elements.ToSlice()
+}
+
+// implement the Stringer interface
+func (q *Queue[T]) String() string {
+	return fmt.Sprintf("Queue(%T) %v", *new(T), q.ToSlice())
+}
+
+// resized calls the watermark callback crossed by
+// the length of the queue, if any.
+func (q *Queue[T]) resized() {
+	n := q.elements.Length()
+	if f := q.watermarks.Update(n); f != nil {
+		f(n)
+	}
+}
@@ -0,0 +1,79 @@
+package queue
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+)
+
+func TestQueue(t *testing.T) {
+	q := NewQueue([]int{1, 2})
+	q.Enqueue(3)
+	if v, err := q.Peek(); err != nil || v != 1 {
+		t.Errorf("Peek() = %v, %v, want 1, nil", v, err)
+	}
+	var got []int
+	for !q.IsEmpty() {
+		v, err := q.Dequeue()
+		if err != nil {
+			t.Fatalf("Dequeue() error = %v", err)
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("dequeued %v, want [1 2 3]", got)
+	}
+	if _, err := q.Dequeue(); err != collection.EmptyCollectionError {
+		t.Errorf("Dequeue() error = %v, want %v", err, collection.EmptyCollectionError)
+	}
+	if _, err := q.Peek(); err != collection.EmptyCollectionError {
+		t.Errorf("Peek() error = %v, want %v", err, collection.EmptyCollectionError)
+	}
+}
+
+func TestQueue_String(t *testing.T) {
+	q := NewQueue([]string{"a", "b"})
+	if got := q.String(); got != "Queue(string) [a b]" {
+		t.Errorf("String() = %v", got)
+	}
+	if q.Length() != 2 {
+		t.Errorf("Length() = %v, want 2", q.Length())
+	}
+}
+
+func TestQueue_ZeroValue(t *testing.T) {
+	var q Queue[int]
+	q.Enqueue(1)
+	if v, err := q.Dequeue(); err != nil || v != 1 || !q.IsEmpty() {
+		t.Errorf("Dequeue() = %v, %v, want 1, nil", v, err)
+	}
+}
+
+func TestQueue_OnRemove(t *testing.T) {
+	var removed []int
+	q := NewQueue([]int{1, 2}).OnRemove(func(v int) { removed = append(removed, v) })
+	q.Dequeue()
+	q.Dequeue()
+	q.Dequeue()
+	if !slices.Equal(removed, []int{1, 2}) {
+		t.Errorf("removed %v, want [1 2]", removed)
+	}
+}
+
+func TestQueue_Watermarks(t *testing.T) {
+	var events []int
+	q := NewQueue([]int{1}).
+		OnHighWatermark(3, func(n int) { events = append(events, n) }).
+		OnLowWatermark(1, func(n int) { events = append(events, -n) })
+	q.Enqueue(2)
+	q.Enqueue(3)
+	q.Enqueue(4)
+	q.Dequeue()
+	q.Dequeue()
+	q.Dequeue()
+	q.Dequeue()
+	if !slices.Equal(events, []int{3, -1}) {
+		t.Errorf("watermark events = %v, want [3 -1]", events)
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package recorder implements an opt-in wrapper that records every mutation
+// of a collection into a bounded log, optionally along with the call stack
+// of the code performing it. The log can be dumped when an invariant check
+// fails, or replayed against another collection to reproduce a bug:
+//
+//	jobs := recorder.New[Job](list.NewList[Job](), recorder.Options[*list.List[Job]]{
+//	  Stack: true,
+//	  Invariant: func(l *list.List[Job]) error {
+//	    if l.IsEmpty() {
+//	      return errors.New("queue emptied")
+//	    }
+//	    return nil
+//	  },
+//	})
+//	jobs.Add(job)
+//	jobs.Do("Dequeue", func(l *list.List[Job]) { l.Dequeue() })
+//
+// Add is recorded automatically, type specific mutations go through Do.
+// A Recorder is safe for concurrent use, every mutation runs under a lock.
+package recorder
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// DefaultCapacity is the number of entries kept in the log when Options.Capacity is not set.
+const DefaultCapacity = 256
+
+// maxStackDepth is the maximum number of frames captured for an entry.
+const maxStackDepth = 32
+
+// Options configures a Recorder.
+type Options[C any] struct {
+	// Capacity is the maximum number of entries kept in the log,
+	// the oldest entries are dropped first. It defaults to DefaultCapacity.
+	Capacity int
+	// Stack enables capturing the call stack of every mutation.
+	Stack bool
+	// Invariant is checked after every mutation when set.
+	Invariant func(C) error
+	// OnFailure is called with the log when the invariant fails.
+	// It defaults to panicking with the *InvariantError.
+	OnFailure func(*InvariantError)
+}
+
+// Entry is a recorded mutation.
+type Entry[C any] struct {
+	// Seq numbers the mutations from 1, it keeps increasing when old entries are dropped.
+	Seq int
+	// Op is the name of the mutation, i.e. "Add".
+	Op string
+	// Args are the arguments of the mutation, as given to Do.
+	Args []any
+	// Length is the length of the collection after the mutation.
+	Length int
+	// Time is the time at which the mutation was recorded.
+	Time time.Time
+
+	apply func(C)
+	stack []uintptr
+}
+
+// String implements the Stringer interface.
+func (e Entry[C]) String() string {
+	args := make([]string, len(e.Args))
+	for i, a := range e.Args {
+		args[i] = fmt.Sprintf("%v", a)
+	}
+	return fmt.Sprintf("#%d %s(%s) len=%d", e.Seq, e.Op, strings.Join(args, ", "), e.Length)
+}
+
+// Stack returns the call stack captured for the entry, one frame per line,
+// or an empty string if stacks were not captured.
+func (e Entry[C]) Stack() string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			return b.String()
+		}
+	}
+}
+
+// InvariantError is reported when the invariant fails after a mutation.
+type InvariantError struct {
+	// Err is the error returned by the invariant.
+	Err error
+	// Dump is the formatted log, ending with the offending mutation.
+	Dump string
+}
+
+// Error implements the error interface.
+func (e *InvariantError) Error() string {
+	return fmt.Sprintf("invariant violated: %v\n%s", e.Err, e.Dump)
+}
+
+// Unwrap returns the error returned by the invariant.
+func (e *InvariantError) Unwrap() error {
+	return e.Err
+}
+
+// Recorder wraps a collection and records its mutations.
+// It implements the Collection interface.
+type Recorder[T any, C collection.Collection[T]] struct {
+	mu      sync.Mutex
+	c       C
+	opts    Options[C]
+	entries []Entry[C] // ring buffer, the oldest entry is at start
+	start   int
+	seq     int
+}
+
+// New returns a recorder wrapping the collection c. The element type
+// cannot be inferred and must be given, i.e. New[int](list.NewList[int](), opts).
+func New[T any, C collection.Collection[T]](c C, opts Options[C]) *Recorder[T, C] {
+	if opts.Capacity <= 0 {
+		opts.Capacity = DefaultCapacity
+	}
+	if opts.OnFailure == nil {
+		opts.OnFailure = func(err *InvariantError) { panic(err) }
+	}
+	return &Recorder[T, C]{c: c, opts: opts, entries: make([]Entry[C], 0, opts.Capacity)}
+}
+
+// The following methods implement
+// the Collection interface.
+
+// Add adds a value to the underlying collection and records it.
+func (r *Recorder[T, C]) Add(v T) {
+	r.record("Add", func(c C) { c.Add(v) }, []any{v})
+}
+
+// Length returns the number of elements in the underlying collection.
+func (r *Recorder[T, C]) Length() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.c.Length()
+}
+
+// New returns a new collection of the underlying type, which is not recorded.
+func (r *Recorder[T, C]) New(s ...[]T) collection.Collection[T] {
+	return r.c.New(s...)
+}
+
+// Random returns a random element of the underlying collection.
+func (r *Recorder[T, C]) Random() T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.c.Random()
+}
+
+// Values returns an iterator over a snapshot of the values of the underlying collection.
+func (r *Recorder[T, C]) Values() iter.Seq[T] {
+	r.mu.Lock()
+	values := slices.Collect(r.c.Values())
+	r.mu.Unlock()
+	return slices.Values(values)
+}
+
+// Do runs f on the underlying collection while holding the lock and
+// records it as the mutation op with the given arguments.
+//
+// example usage:
+//
+//	r.Do("InsertAt", func(l *list.List[int]) { l.InsertAt(0, 42) }, 0, 42)
+//	r.Log()
+//
+// output:
+//
+//	[#1 InsertAt(0, 42) len=1]
+func (r *Recorder[T, C]) Do(op string, f func(C), args ...any) {
+	r.record(op, f, args)
+}
+
+// Dump writes the log to w, oldest entry first,
+// including the call stacks when they were captured.
+func (r *Recorder[T, C]) Dump(w io.Writer) error {
+	_, err := io.WriteString(w, formatLog(r.Log()))
+	return err
+}
+
+// Log returns a copy of the entries currently held in the log, oldest first.
+func (r *Recorder[T, C]) Log() []Entry[C] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.log()
+}
+
+// Replay applies the logged mutations, oldest first, to dst.
+// The state of the recorded collection is reproduced if it started empty
+// and the log never dropped entries, or if dst starts in the state the
+// recorded collection had before the oldest logged entry.
+func (r *Recorder[T, C]) Replay(dst C) {
+	for _, e := range r.Log() {
+		e.apply(dst)
+	}
+}
+
+// Unwrap returns the underlying collection. Mutations made on it
+// directly are not recorded.
+func (r *Recorder[T, C]) Unwrap() C {
+	return r.c
+}
+
+// implement the Stringer interface
+func (r *Recorder[T, C]) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return fmt.Sprintf("Recorder(%v) %d mutations", r.c, r.seq)
+}
+
+// record applies the mutation to the collection, logs it and checks the invariant.
+func (r *Recorder[T, C]) record(op string, f func(C), args []any) {
+	var stack []uintptr
+	if r.opts.Stack {
+		var pcs [maxStackDepth]uintptr
+		// skip runtime.Callers, record and the exported method calling it.
+		n := runtime.Callers(3, pcs[:])
+		stack = slices.Clone(pcs[:n])
+	}
+	if failure := r.apply(op, f, args, stack); failure != nil {
+		r.opts.OnFailure(failure)
+	}
+}
+
+// apply runs the mutation under the lock and returns the invariant failure, if any.
+func (r *Recorder[T, C]) apply(op string, f func(C), args []any, stack []uintptr) *InvariantError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f(r.c)
+	r.seq++
+	entry := Entry[C]{
+		Seq:    r.seq,
+		Op:     op,
+		Args:   args,
+		Length: r.c.Length(),
+		Time:   time.Now(),
+		apply:  f,
+		stack:  stack,
+	}
+	if len(r.entries) < cap(r.entries) {
+		r.entries = append(r.entries, entry)
+	} else {
+		r.entries[r.start] = entry
+		r.start = (r.start + 1) % len(r.entries)
+	}
+	if r.opts.Invariant != nil {
+		if err := r.opts.Invariant(r.c); err != nil {
+			return &InvariantError{Err: err, Dump: formatLog(r.log())}
+		}
+	}
+	return nil
+}
+
+// log returns a copy of the entries, oldest first. The lock must be held.
+func (r *Recorder[T, C]) log() []Entry[C] {
+	return slices.Concat(r.entries[r.start:], r.entries[:r.start])
+}
+
+func formatLog[C any](entries []Entry[C]) string {
+	var b strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(&b, "%s at %s\n", e, e.Time.Format("15:04:05.000000"))
+		for _, line := range strings.SplitAfter(e.Stack(), "\n") {
+			if line != "" {
+				b.WriteString("    " + line)
+			}
+		}
+	}
+	return b.String()
+}
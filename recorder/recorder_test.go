@@ -0,0 +1,156 @@
+package recorder
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/charbz/gophers/list"
+)
+
+var errEmptied = errors.New("queue emptied")
+
+func notEmpty(l *list.List[int]) error {
+	if l.IsEmpty() {
+		return errEmptied
+	}
+	return nil
+}
+
+func dequeue(l *list.List[int]) { l.Dequeue() }
+
+func TestRecorder_Log(t *testing.T) {
+	r := New[int](list.NewList[int](), Options[*list.List[int]]{})
+	r.Add(1)
+	r.Add(2)
+	r.Do("InsertAt", func(l *list.List[int]) { l.InsertAt(0, 42) }, 0, 42)
+	r.Do("Dequeue", dequeue)
+	var got []string
+	for _, e := range r.Log() {
+		got = append(got, e.String())
+	}
+	want := []string{"#1 Add(1) len=1", "#2 Add(2) len=2", "#3 InsertAt(0, 42) len=3", "#4 Dequeue() len=2"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Log() = %v, want %v", got, want)
+	}
+	if got := slices.Collect(r.Values()); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Values() = %v, want [1 2]", got)
+	}
+	if r.Log()[0].Stack() != "" {
+		t.Errorf("Stack() = %q, want empty when stacks are disabled", r.Log()[0].Stack())
+	}
+}
+
+func TestRecorder_Capacity(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		adds     int
+		want     []int
+	}{
+		{name: "not full", capacity: 5, adds: 3, want: []int{1, 2, 3}},
+		{name: "exactly full", capacity: 3, adds: 3, want: []int{1, 2, 3}},
+		{name: "wrapped", capacity: 3, adds: 10, want: []int{8, 9, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New[int](list.NewList[int](), Options[*list.List[int]]{Capacity: tt.capacity})
+			for i := range tt.adds {
+				r.Add(i)
+			}
+			var got []int
+			for _, e := range r.Log() {
+				got = append(got, e.Seq)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Log() sequence numbers = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecorder_Invariant(t *testing.T) {
+	var failure *InvariantError
+	r := New[int](list.NewList([]int{1}), Options[*list.List[int]]{
+		Stack:     true,
+		Invariant: notEmpty,
+		OnFailure: func(err *InvariantError) { failure = err },
+	})
+	r.Add(2)
+	r.Do("Dequeue", dequeue)
+	if failure != nil {
+		t.Fatalf("invariant failed early: %v", failure)
+	}
+	r.Do("Dequeue", dequeue)
+	if failure == nil {
+		t.Fatal("invariant failure was not reported")
+	}
+	if !errors.Is(failure, errEmptied) {
+		t.Errorf("errors.Is(%v, errEmptied) = false", failure.Err)
+	}
+	if !strings.Contains(failure.Dump, "#3 Dequeue() len=0") {
+		t.Errorf("Dump does not contain the offending mutation:\n%s", failure.Dump)
+	}
+	if !strings.Contains(failure.Dump, "TestRecorder_Invariant") {
+		t.Errorf("Dump does not contain the caller stack:\n%s", failure.Dump)
+	}
+	if strings.Contains(failure.Dump, "recorder.(*Recorder") {
+		t.Errorf("Dump contains recorder frames:\n%s", failure.Dump)
+	}
+	var b strings.Builder
+	if err := r.Dump(&b); err != nil || b.String() != failure.Dump {
+		t.Errorf("Dump() = %q, %v, want the failure dump", b.String(), err)
+	}
+}
+
+func TestRecorder_InvariantPanics(t *testing.T) {
+	r := New[int](list.NewList([]int{1}), Options[*list.List[int]]{Invariant: notEmpty})
+	defer func() {
+		err, ok := recover().(*InvariantError)
+		if !ok || !errors.Is(err, errEmptied) {
+			t.Errorf("recover() = %v, want an *InvariantError", err)
+		}
+		if r.Length() != 0 {
+			t.Errorf("Length() = %v, want 0", r.Length())
+		}
+	}()
+	r.Do("Dequeue", dequeue)
+}
+
+func TestRecorder_Replay(t *testing.T) {
+	r := New[int](list.NewList[int](), Options[*list.List[int]]{})
+	for i := range 5 {
+		r.Add(i)
+	}
+	r.Do("Pop", func(l *list.List[int]) { l.Pop() })
+	r.Do("Set", func(l *list.List[int]) { l.Set(1, 10) }, 1, 10)
+	dst := list.NewList[int]()
+	r.Replay(dst)
+	if got, want := dst.ToSlice(), r.Unwrap().ToSlice(); !slices.Equal(got, want) {
+		t.Errorf("Replay() = %v, want %v", got, want)
+	}
+}
+
+func TestRecorder_Concurrent(t *testing.T) {
+	r := New[int](list.NewList[int](), Options[*list.List[int]]{Capacity: 50})
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				r.Add(g*100 + i)
+			}
+		}()
+	}
+	wg.Wait()
+	if r.Length() != 800 {
+		t.Errorf("Length() = %v, want 800", r.Length())
+	}
+	log := r.Log()
+	if len(log) != 50 || log[49].Seq != 800 {
+		t.Errorf("Log() has %d entries ending at #%d, want 50 ending at #800", len(log), log[len(log)-1].Seq)
+	}
+}
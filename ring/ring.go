@@ -0,0 +1,354 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package ring implements a fixed-capacity circular buffer.
+// A Buffer never grows: once full, pushing either evicts the oldest value
+// or fails, depending on its mode, which makes it suitable for bounded
+// telemetry and history buffers:
+//
+//	latencies := ring.NewBuffer[time.Duration](1024, ring.Overwrite)
+//	latencies.Push(12 * time.Millisecond)
+//	report(latencies.Snapshot()) // the last 1024 values, oldest first
+package ring
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// Mode decides what happens when a value is pushed to a full buffer.
+type Mode int
+
+const (
+	// Overwrite evicts the oldest value to make room for the new one.
+	Overwrite Mode = iota
+	// Reject keeps the buffer unchanged and fails the push.
+	Reject
+)
+
+// Buffer is a circular FIFO buffer of fixed capacity. Push and Pop run
+// in O(1) and never allocate. It is safe for concurrent use.
+// A Buffer must be created with NewBuffer, which sets its capacity.
+type Buffer[T any] struct {
+	mu         sync.RWMutex
+	elements   []T // len(elements) is the capacity
+	start      int // index in elements of the oldest value
+	size       int
+	mode       Mode
+	onRemove   func(T)
+	watermarks collection.Watermarks
+	collection.Randomized
+}
+
+// NewBuffer returns a buffer of the given capacity and mode, holding the
+// elements of the given slices pushed in order. In Overwrite mode only the
+// last capacity elements are kept, in Reject mode the first ones.
+// It panics if capacity is less than 1.
+//
+// example usage:
+//
+//	b := NewBuffer(3, Overwrite, []int{1,2,3,4,5})
+//	b.Snapshot()
+//
+// output:
+//
+//	[3 4 5]
+func NewBuffer[T any](capacity int, mode Mode, s ...[]T) *Buffer[T] {
+	if capacity < 1 {
+		panic("ring: buffer capacity must be at least 1")
+	}
+	b := &Buffer[T]{elements: make([]T, capacity), mode: mode}
+	for _, slice := range s {
+		for _, v := range slice {
+			b.push(v)
+		}
+	}
+	return b
+}
+
+// The following methods implement
+// the Collection interface.
+
+// Add is an alias for Push ignoring its error:
+// in Reject mode, values added to a full buffer are dropped.
+func (b *Buffer[T]) Add(v T) {
+	_ = b.Push(v)
+}
+
+// Length returns the number of values in the buffer.
+func (b *Buffer[T]) Length() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.size
+}
+
+// New returns a new buffer with the same capacity and mode.
+func (b *Buffer[T]) New(s ...[]T) collection.Collection[T] {
+	return NewBuffer(len(b.elements), b.mode, s...)
+}
+
+// Random returns a random value from the buffer.
+func (b *Buffer[T]) Random() T {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.size == 0 {
+		panic(collection.EmptyCollectionError)
+	}
+	return b.at(b.RandState().IntN(b.size))
+}
+
+// Values returns an iterator over a snapshot of the buffer, oldest first.
+func (b *Buffer[T]) Values() iter.Seq[T] {
+	return slices.Values(b.Snapshot())
+}
+
+// The following methods implement
+// the OrderedCollection interface.
+
+// At returns the value at the given index, the oldest value being at index 0.
+func (b *Buffer[T]) At(index int) T {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if index < 0 || index >= b.size {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	return b.at(index)
+}
+
+// All returns an iterator over a snapshot of the buffer and the indices of
+// the values, oldest first.
+func (b *Buffer[T]) All() iter.Seq2[int, T] {
+	return slices.All(b.Snapshot())
+}
+
+// Backward returns an iterator over a snapshot of the buffer and the
+// indices of the values, newest first.
+func (b *Buffer[T]) Backward() iter.Seq2[int, T] {
+	return slices.Backward(b.Snapshot())
+}
+
+// Slice returns a new buffer with the same capacity and mode holding the
+// values between the start and end indices.
+func (b *Buffer[T]) Slice(start, end int) collection.OrderedCollection[T] {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if start < 0 || end > b.size || start > end {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	s := &Buffer[T]{elements: make([]T, len(b.elements)), mode: b.mode}
+	for i := start; i < end; i++ {
+		s.push(b.at(i))
+	}
+	return s
+}
+
+// NewOrdered returns a new buffer with the same capacity and mode.
+func (b *Buffer[T]) NewOrdered(s ...[]T) collection.OrderedCollection[T] {
+	return NewBuffer(len(b.elements), b.mode, s...)
+}
+
+// Capacity returns the maximum number of values the buffer can hold.
+func (b *Buffer[T]) Capacity() int {
+	return len(b.elements)
+}
+
+// Clear removes all the values from the buffer.
+func (b *Buffer[T]) Clear() {
+	b.mu.Lock()
+	var removed []T
+	if b.onRemove != nil {
+		removed = b.snapshot()
+	}
+	clear(b.elements)
+	b.start, b.size = 0, 0
+	f := b.onRemove
+	b.unlock()
+	for _, v := range removed {
+		f(v)
+	}
+}
+
+// IsEmpty returns true if the buffer is empty.
+func (b *Buffer[T]) IsEmpty() bool {
+	return b.Length() == 0
+}
+
+// IsFull returns true if the buffer holds as many values as its capacity.
+func (b *Buffer[T]) IsFull() bool {
+	return b.Length() == len(b.elements)
+}
+
+// NonEmpty returns true if the buffer is not empty.
+func (b *Buffer[T]) NonEmpty() bool {
+	return b.Length() > 0
+}
+
+// OnHighWatermark sets a function called with the length of the buffer when
+// it grows to size values, see collection.Watermarks. In Reject mode, a high
+// watermark equal to the capacity notifies the producers that their next
+// pushes will fail. It is called without holding the lock of the buffer.
+// It returns the buffer, so that it can be set along with its creation.
+func (b *Buffer[T]) OnHighWatermark(size int, f func(length int)) *Buffer[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.watermarks.SetHigh(size, f)
+	return b
+}
+
+// OnLowWatermark sets a function called with the length of the buffer when
+// it falls back to size values after reaching the high watermark.
+// It returns the buffer.
+func (b *Buffer[T]) OnLowWatermark(size int, f func(length int)) *Buffer[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.watermarks.SetLow(size, f)
+	return b
+}
+
+// OnRemove sets a function called with every value leaving the buffer,
+// i.e. to release the resources held by the values: it is called by Pop
+// and Clear, and by Push for the values evicted in Overwrite mode. It is
+// called without holding the lock of the buffer, so it may use the buffer.
+// It returns the buffer, so that it can be set along with its creation.
+//
+// example usage:
+//
+//	frames := NewBuffer[*Frame](64, Overwrite).OnRemove(func(f *Frame) { pool.Put(f) })
+func (b *Buffer[T]) OnRemove(f func(T)) *Buffer[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onRemove = f
+	return b
+}
+
+// Peek returns the oldest value without removing it.
+func (b *Buffer[T]) Peek() (T, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.size == 0 {
+		return *new(T), collection.EmptyCollectionError
+	}
+	return b.elements[b.start], nil
+}
+
+// PeekLast returns the newest value without removing it.
+func (b *Buffer[T]) PeekLast() (T, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.size == 0 {
+		return *new(T), collection.EmptyCollectionError
+	}
+	return b.at(b.size - 1), nil
+}
+
+// Pop removes and returns the oldest value.
+func (b *Buffer[T]) Pop() (T, error) {
+	b.mu.Lock()
+	if b.size == 0 {
+		b.mu.Unlock()
+		return *new(T), collection.EmptyCollectionError
+	}
+	v := b.elements[b.start]
+	b.elements[b.start] = *new(T)
+	b.start = (b.start + 1) % len(b.elements)
+	b.size--
+	f := b.onRemove
+	b.unlock()
+	if f != nil {
+		f(v)
+	}
+	return v, nil
+}
+
+// Push adds a value after the newest one. When the buffer is full, it
+// evicts the oldest value in Overwrite mode, and returns
+// collection.FullCollectionError without adding the value in Reject mode.
+//
+// example usage:
+//
+//	b := NewBuffer[int](2, Reject)
+//	b.Push(1)
+//	b.Push(2)
+//	b.Push(3)
+//
+// output:
+//
+//	nil
+//	nil
+//	error 104: invalid operation on a full collection
+func (b *Buffer[T]) Push(v T) error {
+	b.mu.Lock()
+	evicted, evicts := b.elements[b.start], b.size == len(b.elements)
+	if !b.push(v) {
+		b.mu.Unlock()
+		return collection.FullCollectionError
+	}
+	f := b.onRemove
+	b.unlock()
+	if evicts && f != nil {
+		f(evicted)
+	}
+	return nil
+}
+
+// Snapshot returns a copy of the values in the buffer, oldest first.
+func (b *Buffer[T]) Snapshot() []T {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.snapshot()
+}
+
+// snapshot returns a copy of the values in the buffer. The lock must be held.
+func (b *Buffer[T]) snapshot() []T {
+	s := make([]T, b.size)
+	n := copy(s, b.elements[b.start:min(b.start+b.size, len(b.elements))])
+	copy(s[n:], b.elements)
+	return s
+}
+
+// ToSlice is an alias for Snapshot.
+func (b *Buffer[T]) ToSlice() []T {
+	return b.Snapshot()
+}
+
+// implement the Stringer interface
+func (b *Buffer[T]) String() string {
+	return fmt.Sprintf("RingBuffer(%T) %v", *new(T), b.Snapshot())
+}
+
+// unlock releases the write lock, then calls the watermark
+// callback crossed by the length of the buffer, if any.
+func (b *Buffer[T]) unlock() {
+	n := b.size
+	f := b.watermarks.Update(n)
+	b.mu.Unlock()
+	if f != nil {
+		f(n)
+	}
+}
+
+// at returns the value at the given index. The lock must be held.
+func (b *Buffer[T]) at(index int) T {
+	return b.elements[(b.start+index)%len(b.elements)]
+}
+
+// push adds a value according to the mode and returns false if it was
+// rejected. The lock must be held.
+func (b *Buffer[T]) push(v T) bool {
+	if b.size == len(b.elements) {
+		if b.mode == Reject {
+			return false
+		}
+		b.elements[b.start] = v
+		b.start = (b.start + 1) % len(b.elements)
+		return true
+	}
+	b.elements[(b.start+b.size)%len(b.elements)] = v
+	b.size++
+	return true
+}
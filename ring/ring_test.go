@@ -0,0 +1,161 @@
+package ring
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/collectiontest"
+	"github.com/charbz/gophers/list"
+)
+
+func TestBuffer_MatchesList(t *testing.T) {
+	ops := collectiontest.OrderedOps[int, collection.OrderedCollection[int]](func(r *rand.Rand) int { return r.Intn(50) })
+	collectiontest.Check(t,
+		func() collection.OrderedCollection[int] { return list.NewList[int]() },
+		func() collection.OrderedCollection[int] { return NewBuffer[int](1000, Reject) },
+		ops, collectiontest.Options[int]{Steps: 300},
+	)
+}
+
+func TestBuffer_Push(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     Mode
+		want     []int
+		wantErrs int
+	}{
+		{name: "overwrite", mode: Overwrite, want: []int{3, 4, 5}, wantErrs: 0},
+		{name: "reject", mode: Reject, want: []int{1, 2, 3}, wantErrs: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBuffer[int](3, tt.mode)
+			errs := 0
+			for i := 1; i <= 5; i++ {
+				if err := b.Push(i); err != nil {
+					if err != collection.FullCollectionError {
+						t.Fatalf("Push() error = %v", err)
+					}
+					errs++
+				}
+			}
+			if errs != tt.wantErrs {
+				t.Errorf("Push() returned %v errors, want %v", errs, tt.wantErrs)
+			}
+			if got := b.Snapshot(); !slices.Equal(got, tt.want) {
+				t.Errorf("Snapshot() = %v, want %v", got, tt.want)
+			}
+			if !b.IsFull() || b.Length() != 3 {
+				t.Errorf("Length() = %v, want 3", b.Length())
+			}
+		})
+	}
+}
+
+func TestBuffer_PopWrapsAround(t *testing.T) {
+	b := NewBuffer(3, Overwrite, []int{1, 2, 3, 4})
+	if v, err := b.Pop(); err != nil || v != 2 {
+		t.Errorf("Pop() = %v, %v, want 2, nil", v, err)
+	}
+	b.Push(5)
+	b.Push(6)
+	if got := b.Snapshot(); !slices.Equal(got, []int{4, 5, 6}) {
+		t.Errorf("Snapshot() = %v, want [4 5 6]", got)
+	}
+	if v, _ := b.Peek(); v != 4 {
+		t.Errorf("Peek() = %v, want 4", v)
+	}
+	if v, _ := b.PeekLast(); v != 6 {
+		t.Errorf("PeekLast() = %v, want 6", v)
+	}
+	if b.At(2) != 6 || b.Slice(1, 3).Length() != 2 {
+		t.Errorf("At(2) = %v, Slice(1, 3) = %v", b.At(2), b.Slice(1, 3))
+	}
+	b.Clear()
+	if _, err := b.Pop(); err != collection.EmptyCollectionError {
+		t.Errorf("Pop() on empty buffer error = %v", err)
+	}
+}
+
+func TestBuffer_Collection(t *testing.T) {
+	b := NewBuffer(2, Reject, []string{"a", "b", "c"})
+	if got := b.String(); got != "RingBuffer(string) [a b]" {
+		t.Errorf("String() = %q", got)
+	}
+	n := b.New([]string{"x", "y", "z"}).(*Buffer[string])
+	if n.Capacity() != 2 || !slices.Equal(n.Snapshot(), []string{"x", "y"}) {
+		t.Errorf("New() = %v, want the capacity and mode of the original", n)
+	}
+	filtered := collection.Filter(b, func(s string) bool { return s != "a" })
+	if got := slices.Collect(filtered.Values()); !slices.Equal(got, []string{"b"}) {
+		t.Errorf("Filter() = %v, want [b]", got)
+	}
+}
+
+func TestBuffer_Concurrent(t *testing.T) {
+	b := NewBuffer[int](64, Overwrite)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 500 {
+				b.Push(i)
+				if s := b.Snapshot(); len(s) > 64 {
+					t.Errorf("Snapshot() holds %v values", len(s))
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if b.Length() != 64 {
+		t.Errorf("Length() = %v, want 64", b.Length())
+	}
+}
+
+func TestBuffer_OnRemove(t *testing.T) {
+	var removed []int
+	var b *Buffer[int]
+	b = NewBuffer(3, Overwrite, []int{1, 2, 3}).OnRemove(func(v int) {
+		removed = append(removed, v)
+		b.Length() // the callback may use the buffer
+	})
+	b.Push(4)
+	b.Pop()
+	if !slices.Equal(removed, []int{1, 2}) {
+		t.Errorf("removed %v after Push and Pop, want [1 2]", removed)
+	}
+	b.Clear()
+	if !slices.Equal(removed, []int{1, 2, 3, 4}) {
+		t.Errorf("removed %v after Clear, want [1 2 3 4]", removed)
+	}
+
+	removed = nil
+	r := NewBuffer(1, Reject, []int{1}).OnRemove(func(v int) { removed = append(removed, v) })
+	r.Push(2)
+	if removed != nil {
+		t.Errorf("rejected Push removed %v", removed)
+	}
+}
+
+func TestBuffer_Watermarks(t *testing.T) {
+	var events []int
+	b := NewBuffer[int](4, Reject).
+		OnHighWatermark(4, func(n int) { events = append(events, n) }).
+		OnLowWatermark(2, func(n int) { events = append(events, -n) })
+	for i := range 5 {
+		b.Push(i)
+	}
+	b.Pop()
+	b.Pop()
+	b.Push(5)
+	b.Push(6)
+	b.Clear()
+	if !slices.Equal(events, []int{4, -2, 4, 0}) {
+		t.Errorf("watermark events = %v, want [4 -2 4 0]", events)
+	}
+}
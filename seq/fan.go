@@ -0,0 +1,93 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package seq
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// FanOut sends the values of s to n returned channels in turn, round-robin,
+// from a new goroutine, each channel buffered with buffer values, and closes
+// them once s is exhausted or the context is done. Values are distributed,
+// not copied, see Broadcast to send every value to every channel. A receiver
+// falling behind blocks the others, every returned channel must therefore be
+// drained, or the context cancelled. It panics if n is less than 1.
+//
+// example usage:
+//
+//	for _, jobs := range FanOut(ctx, pending, 4, 0) {
+//	  go worker(jobs)
+//	}
+func FanOut[T any](ctx context.Context, s iter.Seq[T], n, buffer int) []<-chan T {
+	if n < 1 {
+		panic("seq: fan out needs at least 1 channel")
+	}
+	outs := make([]chan T, n)
+	result := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T, buffer)
+		result[i] = outs[i]
+	}
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		i := 0
+		for v := range s {
+			select {
+			case outs[i] <- v:
+			case <-ctx.Done():
+				return
+			}
+			i = (i + 1) % n
+		}
+	}()
+	return result
+}
+
+// FanIn sends the values received from all the given channels to the
+// returned channel, in the order they are received, and closes it once
+// every channel is closed or the context is done. The returned channel must
+// be drained, or the context cancelled, for its goroutines to exit.
+//
+// example usage:
+//
+//	for r := range FanIn(ctx, results...) {
+//	  fmt.Println(r)
+//	}
+func FanIn[T any](ctx context.Context, chs ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(chs))
+	for _, ch := range chs {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case v, ok := <-ch:
+					if !ok {
+						return
+					}
+					select {
+					case out <- v:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
@@ -0,0 +1,74 @@
+package seq
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestFanOut(t *testing.T) {
+	outs := FanOut(context.Background(), slices.Values([]int{0, 1, 2, 3, 4, 5, 6}), 3, 3)
+	results := make([][]int, len(outs))
+	var wg sync.WaitGroup
+	for i, out := range outs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = slices.Collect(FromChan(out))
+		}()
+	}
+	wg.Wait()
+	want := [][]int{{0, 3, 6}, {1, 4}, {2, 5}}
+	for i := range want {
+		if !slices.Equal(results[i], want[i]) {
+			t.Errorf("receiver %d = %v, want %v", i, results[i], want[i])
+		}
+	}
+}
+
+func TestFanOutCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	outs := FanOut(ctx, func(yield func(int) bool) {
+		for i := 0; yield(i); i++ {
+		}
+	}, 2, 0)
+	<-outs[0]
+	cancel()
+	for _, out := range outs {
+		for range out {
+		}
+	}
+}
+
+func TestFanOutPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("FanOut() with 0 channels did not panic")
+		}
+	}()
+	FanOut(context.Background(), slices.Values([]int{1}), 0, 0)
+}
+
+func TestFanIn(t *testing.T) {
+	chs := make([]<-chan int, 3)
+	for i := range chs {
+		chs[i] = ToChan(context.Background(), slices.Values([]int{i * 10, i*10 + 1}), 0)
+	}
+	got := slices.Sorted(FromChan(FanIn(context.Background(), chs...)))
+	if want := []int{0, 1, 10, 11, 20, 21}; !slices.Equal(got, want) {
+		t.Errorf("FanIn() = %v, want %v", got, want)
+	}
+	if got := slices.Collect(FromChan(FanIn[int](context.Background()))); len(got) != 0 {
+		t.Errorf("FanIn() of no channels = %v, want []", got)
+	}
+}
+
+func TestFanInCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	open := make(chan int)
+	out := FanIn(ctx, open, open)
+	cancel()
+	for range out {
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package seq
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+)
+
+// MergeAll returns an iterator merging sorted sequences into a single sorted
+// sequence, in O(log k) per value for k sequences. Values comparing equal are
+// yielded in the order of the sequences passed in. Every sequence must be
+// sorted in ascending order.
+//
+// example usage:
+//
+//	slices.Collect(MergeAll(slices.Values([]int{1,4}), slices.Values([]int{2,3}), slices.Values([]int{0,5})))
+//
+// output:
+//
+//	[0,1,2,3,4,5]
+func MergeAll[T cmp.Ordered](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return MergeAllFunc(cmp.Compare[T], seqs...)
+}
+
+// MergeAllFunc is similar to MergeAll but takes a comparison function
+// returning a negative number when a < b, zero when a == b and a
+// positive number when a > b. Every sequence must be sorted by f.
+func MergeAllFunc[T any](f func(T, T) int, seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		h := &mergeHeap[T]{cmp: f}
+		for i, s := range seqs {
+			next, stop := iter.Pull(s)
+			defer stop()
+			if v, ok := next(); ok {
+				h.heads = append(h.heads, mergeHead[T]{value: v, index: i, next: next})
+			}
+		}
+		heap.Init(h)
+		for h.Len() > 0 {
+			head := &h.heads[0]
+			if !yield(head.value) {
+				return
+			}
+			if v, ok := head.next(); ok {
+				head.value = v
+				heap.Fix(h, 0)
+			} else {
+				heap.Pop(h)
+			}
+		}
+	}
+}
+
+// mergeHead is the next value of one of the merged sequences.
+type mergeHead[T any] struct {
+	value T
+	index int
+	next  func() (T, bool)
+}
+
+// mergeHeap orders the heads of the merged sequences by value,
+// then by position of the sequence to keep the merge stable.
+type mergeHeap[T any] struct {
+	heads []mergeHead[T]
+	cmp   func(T, T) int
+}
+
+func (h *mergeHeap[T]) Len() int { return len(h.heads) }
+
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	if c := h.cmp(h.heads[i].value, h.heads[j].value); c != 0 {
+		return c < 0
+	}
+	return h.heads[i].index < h.heads[j].index
+}
+
+func (h *mergeHeap[T]) Swap(i, j int) { h.heads[i], h.heads[j] = h.heads[j], h.heads[i] }
+
+func (h *mergeHeap[T]) Push(x any) { h.heads = append(h.heads, x.(mergeHead[T])) }
+
+func (h *mergeHeap[T]) Pop() any {
+	last := h.heads[len(h.heads)-1]
+	h.heads = h.heads[:len(h.heads)-1]
+	return last
+}
@@ -0,0 +1,65 @@
+package seq
+
+import (
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMergeAll(t *testing.T) {
+	tests := []struct {
+		name string
+		in   [][]int
+		want []int
+	}{
+		{name: "three sequences", in: [][]int{{1, 4, 7}, {2, 5}, {0, 3, 6, 8}}, want: []int{0, 1, 2, 3, 4, 5, 6, 7, 8}},
+		{name: "duplicates", in: [][]int{{1, 1}, {1, 2}}, want: []int{1, 1, 1, 2}},
+		{name: "empty sequences", in: [][]int{{}, {2}, {}}, want: []int{2}},
+		{name: "no sequence", in: nil, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seqs := make([]iter.Seq[int], len(tt.in))
+			for i, s := range tt.in {
+				seqs[i] = slices.Values(s)
+			}
+			if got := slices.Collect(MergeAll(seqs...)); !slices.Equal(got, tt.want) {
+				t.Errorf("MergeAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeAllFuncStable(t *testing.T) {
+	byLength := func(a, b string) int { return len(a) - len(b) }
+	got := slices.Collect(Take(MergeAllFunc(byLength, slices.Values([]string{"a", "bb"}), slices.Values([]string{"c", "dd", "eee"})), 4))
+	if want := []string{"a", "c", "bb", "dd"}; !slices.Equal(got, want) {
+		t.Errorf("MergeAllFunc() = %v, want %v", got, want)
+	}
+}
+
+func TestZipLongest(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []int
+		want string
+	}{
+		{name: "longer first", a: []string{"a", "b", "c"}, b: []int{1}, want: "a1 b0 c0"},
+		{name: "longer second", a: []string{"a"}, b: []int{1, 2}, want: "a1 -2"},
+		{name: "same length", a: []string{"a"}, b: []int{1}, want: "a1"},
+		{name: "empty", a: nil, b: nil, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pairs []string
+			for s, i := range ZipLongest(slices.Values(tt.a), slices.Values(tt.b), "-", 0) {
+				pairs = append(pairs, s+string(rune('0'+i)))
+			}
+			if got := strings.Join(pairs, " "); got != tt.want {
+				t.Errorf("ZipLongest() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package seq
+
+import "iter"
+
+// Peekable is a pull-style iterator over an iter.Seq that can look at the
+// next value without consuming it. It is meant for parser-style consumers
+// and merge algorithms that decide what to do based on the upcoming value:
+//
+//	tokens := seq.NewPeekable(lexer.Tokens())
+//	defer tokens.Stop()
+//	for tok, ok := tokens.Peek(); ok && tok.Kind == Digit; tok, ok = tokens.Peek() {
+//	  number = append(number, tok)
+//	  tokens.Next()
+//	}
+//
+// Stop must be called when the caller is done with the iterator,
+// unless it was consumed to the end. A Peekable is not safe for concurrent use.
+type Peekable[T any] struct {
+	next   func() (T, bool)
+	stop   func()
+	peeked bool
+	value  T
+	ok     bool
+}
+
+// NewPeekable returns a Peekable iterator over the values of s.
+func NewPeekable[T any](s iter.Seq[T]) *Peekable[T] {
+	next, stop := iter.Pull(s)
+	return &Peekable[T]{next: next, stop: stop}
+}
+
+// Next consumes and returns the next value and true,
+// or the zero value and false once the iterator is exhausted.
+func (p *Peekable[T]) Next() (T, bool) {
+	if p.peeked {
+		p.peeked = false
+		v := p.value
+		p.value = *new(T)
+		return v, p.ok
+	}
+	return p.next()
+}
+
+// NextIf consumes and returns the next value and true if it satisfies the
+// predicate. Otherwise the value is not consumed and NextIf returns false.
+//
+// example usage:
+//
+//	p := NewPeekable(slices.Values([]int{1,2,3}))
+//	p.NextIf(func(i int) bool { return i < 2 })
+//	p.NextIf(func(i int) bool { return i < 2 })
+//
+// output:
+//
+//	1, true
+//	0, false
+func (p *Peekable[T]) NextIf(f func(T) bool) (T, bool) {
+	if v, ok := p.Peek(); ok && f(v) {
+		return p.Next()
+	}
+	return *new(T), false
+}
+
+// NextN consumes and returns up to n values, fewer if the iterator is exhausted.
+//
+// example usage:
+//
+//	p := NewPeekable(slices.Values([]int{1,2,3,4,5}))
+//	p.NextN(2)
+//	p.NextN(4)
+//
+// output:
+//
+//	[1,2]
+//	[3,4,5]
+func (p *Peekable[T]) NextN(n int) []T {
+	batch := make([]T, 0, max(n, 0))
+	for len(batch) < n {
+		v, ok := p.Next()
+		if !ok {
+			break
+		}
+		batch = append(batch, v)
+	}
+	return batch
+}
+
+// Peek returns the next value and true without consuming it,
+// or the zero value and false once the iterator is exhausted.
+func (p *Peekable[T]) Peek() (T, bool) {
+	if !p.peeked {
+		p.value, p.ok = p.next()
+		p.peeked = true
+	}
+	return p.value, p.ok
+}
+
+// Remaining returns an iterator consuming the values not consumed yet,
+// including a peeked value.
+func (p *Peekable[T]) Remaining() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, ok := p.Next()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Stop releases the resources of the underlying iterator.
+// Next and Peek return false after Stop, except for a value already peeked.
+func (p *Peekable[T]) Stop() {
+	p.stop()
+}
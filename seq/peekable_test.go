@@ -0,0 +1,80 @@
+package seq
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPeekable(t *testing.T) {
+	p := NewPeekable(slices.Values([]int{1, 2, 3, 4, 5}))
+	defer p.Stop()
+	steps := []struct {
+		name   string
+		call   func() (int, bool)
+		want   int
+		wantOK bool
+	}{
+		{name: "peek", call: p.Peek, want: 1, wantOK: true},
+		{name: "peek again", call: p.Peek, want: 1, wantOK: true},
+		{name: "next after peek", call: p.Next, want: 1, wantOK: true},
+		{name: "next", call: p.Next, want: 2, wantOK: true},
+		{name: "next if rejected", call: func() (int, bool) { return p.NextIf(func(i int) bool { return i > 3 }) }, want: 0, wantOK: false},
+		{name: "next if accepted", call: func() (int, bool) { return p.NextIf(func(i int) bool { return i == 3 }) }, want: 3, wantOK: true},
+	}
+	for _, step := range steps {
+		if got, ok := step.call(); got != step.want || ok != step.wantOK {
+			t.Fatalf("%s = %v, %v, want %v, %v", step.name, got, ok, step.want, step.wantOK)
+		}
+	}
+	p.Peek()
+	if got := slices.Collect(p.Remaining()); !slices.Equal(got, []int{4, 5}) {
+		t.Errorf("Remaining() = %v, want [4 5]", got)
+	}
+	if _, ok := p.Peek(); ok {
+		t.Error("Peek() on an exhausted iterator returned true")
+	}
+}
+
+func TestPeekable_NextN(t *testing.T) {
+	p := NewPeekable(slices.Values([]int{1, 2, 3, 4, 5}))
+	defer p.Stop()
+	tests := []struct {
+		n    int
+		want []int
+	}{
+		{n: 2, want: []int{1, 2}},
+		{n: 0, want: []int{}},
+		{n: 4, want: []int{3, 4, 5}},
+		{n: 1, want: []int{}},
+	}
+	for _, tt := range tests {
+		if got := p.NextN(tt.n); !slices.Equal(got, tt.want) {
+			t.Errorf("NextN(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestPeekable_Merge(t *testing.T) {
+	a := NewPeekable(slices.Values([]int{1, 4, 6}))
+	b := NewPeekable(slices.Values([]int{2, 3, 7}))
+	defer a.Stop()
+	defer b.Stop()
+	var merged []int
+	for {
+		va, okA := a.Peek()
+		vb, okB := b.Peek()
+		if !okA && !okB {
+			break
+		}
+		if okA && (!okB || va <= vb) {
+			a.Next()
+			merged = append(merged, va)
+		} else {
+			b.Next()
+			merged = append(merged, vb)
+		}
+	}
+	if !slices.Equal(merged, []int{1, 2, 3, 4, 6, 7}) {
+		t.Errorf("merged = %v, want [1 2 3 4 6 7]", merged)
+	}
+}
@@ -0,0 +1,862 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package seq implements the algorithmic core of the library on top of
+// plain iter.Seq values. Every function accepts any iterator, whether it
+// comes from a collection's Values method, a channel, a generator or a
+// third-party library, i.e. seq.Filter(list.Values(), f).
+//
+// Functions returning an iter.Seq are lazy and honor early exit,
+// they do no work until the result is ranged over.
+package seq
+
+import (
+	"cmp"
+	"context"
+	"iter"
+	"slices"
+	"time"
+)
+
+// FromChan returns an iterator that yields the values received from ch
+// until it is closed.
+//
+// example usage:
+//
+//	ch := make(chan int, 3)
+//	ch <- 1; ch <- 2; ch <- 3
+//	close(ch)
+//	slices.Collect(FromChan(ch))
+//
+// output:
+//
+//	[1,2,3]
+func FromChan[T any](ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ToChan sends the values of s to the returned channel, buffered with buffer
+// values, from a new goroutine, and closes the channel once s is exhausted or
+// the context is done. The channel must be drained, or the context cancelled,
+// for the goroutine to exit.
+//
+// example usage:
+//
+//	for v := range ToChan(ctx, slices.Values([]int{1,2,3}), 0) {
+//	  fmt.Println(v)
+//	}
+//
+// output:
+//
+//	1
+//	2
+//	3
+func ToChan[T any](ctx context.Context, s iter.Seq[T], buffer int) <-chan T {
+	ch := make(chan T, buffer)
+	go func() {
+		defer close(ch)
+		for v := range s {
+			select {
+			case ch <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// Concat returns an iterator that yields the values of each sequence in turn.
+//
+// example usage:
+//
+//	slices.Collect(Concat(slices.Values([]int{1,2}), slices.Values([]int{3,4})))
+//
+// output:
+//
+//	[1,2,3,4]
+func Concat[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, s := range seqs {
+			for v := range s {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Contains returns true if the sequence yields a value equal to v.
+//
+// example usage:
+//
+//	Contains(slices.Values([]int{1,2,3}), 2)
+//
+// output:
+//
+//	true
+func Contains[T comparable](s iter.Seq[T], v T) bool {
+	return Exists(s, func(t T) bool { return t == v })
+}
+
+// Count returns the number of values in the sequence that satisfy the predicate.
+//
+// example usage:
+//
+//	Count(slices.Values([]int{1,2,3,4,5,6}), func(i int) bool { return i % 2 == 0 })
+//
+// output:
+//
+//	3
+func Count[T any](s iter.Seq[T], f func(T) bool) int {
+	count := 0
+	for v := range s {
+		if f(v) {
+			count++
+		}
+	}
+	return count
+}
+
+// Distinct returns an iterator that yields the unique values of the sequence
+// in the order they are first seen.
+//
+// example usage:
+//
+//	slices.Collect(Distinct(slices.Values([]int{1,1,2,1,3})))
+//
+// output:
+//
+//	[1,2,3]
+func Distinct[T comparable](s iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := make(map[T]struct{})
+		for v := range s {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// DistinctFunc is similar to Distinct but applies to non-comparable types.
+// It takes an "equality" function as an argument such as
+// func(a T, b T) bool {return a == b}
+//
+// example usage:
+//
+//	slices.Collect(DistinctFunc(slices.Values([]int{1,1,2,1,3}), func(a, b int) bool { return a == b }))
+//
+// output:
+//
+//	[1,2,3]
+func DistinctFunc[T any](s iter.Seq[T], f func(T, T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var seen []T
+		for v := range s {
+			if slices.ContainsFunc(seen, func(t T) bool { return f(v, t) }) {
+				continue
+			}
+			seen = append(seen, v)
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drop returns an iterator that skips the first n values of the sequence.
+//
+// example usage:
+//
+//	slices.Collect(Drop(slices.Values([]int{1,2,3,4}), 2))
+//
+// output:
+//
+//	[3,4]
+func Drop[T any](s iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+		for v := range s {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// DropLast returns an iterator that yields all the values of s except the
+// last n. It holds back n values in a ring buffer, yielding each value once
+// n more have been read, so it streams in O(n) memory.
+//
+// example usage:
+//
+//	slices.Collect(DropLast(slices.Values([]int{1,2,3,4}), 1))
+//
+// output:
+//
+//	[1,2,3]
+func DropLast[T any](s iter.Seq[T], n int) iter.Seq[T] {
+	if n <= 0 {
+		return s
+	}
+	return func(yield func(T) bool) {
+		var buf []T
+		next := 0 // index in buf of the oldest value once buf is full
+		for v := range s {
+			if len(buf) < n {
+				buf = append(buf, v)
+				continue
+			}
+			oldest := buf[next]
+			buf[next] = v
+			next = (next + 1) % n
+			if !yield(oldest) {
+				return
+			}
+		}
+	}
+}
+
+// DropWhile returns an iterator that skips the longest prefix of values
+// satisfying the predicate and yields the rest.
+//
+// example usage:
+//
+//	slices.Collect(DropWhile(slices.Values([]int{1,2,3,1}), func(i int) bool { return i < 3 }))
+//
+// output:
+//
+//	[3,1]
+func DropWhile[T any](s iter.Seq[T], f func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		dropping := true
+		for v := range s {
+			if dropping && f(v) {
+				continue
+			}
+			dropping = false
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Exists returns true if at least one value in the sequence satisfies the predicate.
+//
+// example usage:
+//
+//	Exists(slices.Values([]int{1,2,3}), func(i int) bool { return i > 2 })
+//
+// output:
+//
+//	true
+func Exists[T any](s iter.Seq[T], f func(T) bool) bool {
+	for v := range s {
+		if f(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns an iterator that yields the values satisfying the predicate.
+//
+// example usage:
+//
+//	slices.Collect(Filter(slices.Values([]int{1,2,3,4,5,6}), func(i int) bool { return i % 2 == 0 }))
+//
+// output:
+//
+//	[2,4,6]
+func Filter[T any](s iter.Seq[T], f func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s {
+			if f(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Find returns the position and value of the first value that satisfies
+// the predicate, otherwise returns -1 and the zero value.
+//
+// example usage:
+//
+//	Find(slices.Values([]int{1,2,3,4}), func(i int) bool { return i > 2 })
+//
+// output:
+//
+//	2, 3
+func Find[T any](s iter.Seq[T], f func(T) bool) (int, T) {
+	i := 0
+	for v := range s {
+		if f(v) {
+			return i, v
+		}
+		i++
+	}
+	return -1, *new(T)
+}
+
+// FoldMap maps every value with f and combines the results pairwise with combine.
+// It returns the zero value of M if the sequence is empty.
+//
+// example usage:
+//
+//	FoldMap(slices.Values([]string{"go","is"}), func(s string) int { return len(s) }, func(a, b int) int { return a + b })
+//
+// output:
+//
+//	4
+func FoldMap[T, M any](s iter.Seq[T], f func(T) M, combine func(M, M) M) M {
+	var (
+		accumulator M
+		started     bool
+	)
+	for v := range s {
+		if !started {
+			accumulator, started = f(v), true
+			continue
+		}
+		accumulator = combine(accumulator, f(v))
+	}
+	return accumulator
+}
+
+// FoldErr applies f to each value of the sequence, starting from init, and
+// stops at the first error. It returns the accumulator of the last
+// successful call along with that error, or the final accumulator and nil.
+//
+// example usage:
+//
+//	FoldErr(slices.Values([]string{"1","2","x","4"}), 0, func(acc int, s string) (int, error) {
+//	  n, err := strconv.Atoi(s)
+//	  return acc + n, err
+//	})
+//
+// output:
+//
+//	3, strconv.Atoi: parsing "x": invalid syntax
+func FoldErr[T, K any](s iter.Seq[T], init K, f func(K, T) (K, error)) (K, error) {
+	accumulator := init
+	for v := range s {
+		next, err := f(accumulator, v)
+		if err != nil {
+			return accumulator, err
+		}
+		accumulator = next
+	}
+	return accumulator, nil
+}
+
+// FoldWhile applies f to each value of the sequence, starting from init, and
+// stops as soon as f returns false. The accumulator returned by the last call is the result.
+//
+// example usage:
+//
+//	FoldWhile(slices.Values([]int{1,2,3,4}), 0, func(acc, i int) (int, bool) { return acc + i, acc + i < 3 })
+//
+// output:
+//
+//	3
+func FoldWhile[T, K any](s iter.Seq[T], init K, f func(K, T) (K, bool)) K {
+	accumulator := init
+	for v := range s {
+		var ok bool
+		if accumulator, ok = f(accumulator, v); !ok {
+			break
+		}
+	}
+	return accumulator
+}
+
+// ForAll returns true if every value in the sequence satisfies the predicate.
+// An empty sequence always returns true.
+//
+// example usage:
+//
+//	ForAll(slices.Values([]int{1,2,3}), func(i int) bool { return i < 10 })
+//
+// output:
+//
+//	true
+func ForAll[T any](s iter.Seq[T], f func(T) bool) bool {
+	for v := range s {
+		if !f(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// LastN returns an iterator over the last n values of s. It reads s to the
+// end keeping the last n values in a ring buffer, so it runs in O(n) memory
+// however long s is. s must be finite.
+//
+// example usage:
+//
+//	slices.Collect(LastN(slices.Values([]int{1,2,3,4}), 2))
+//
+// output:
+//
+//	[3,4]
+func LastN[T any](s iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		var buf []T
+		next := 0 // index in buf of the oldest value once buf is full
+		for v := range s {
+			if len(buf) < n {
+				buf = append(buf, v)
+				continue
+			}
+			buf[next] = v
+			next = (next + 1) % n
+		}
+		for _, v := range buf[next:] {
+			if !yield(v) {
+				return
+			}
+		}
+		for _, v := range buf[:next] {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Map returns an iterator that yields the values of the sequence
+// transformed by the mapping function.
+//
+// example usage:
+//
+//	slices.Collect(Map(slices.Values([]string{"a","bb"}), func(s string) int { return len(s) }))
+//
+// output:
+//
+//	[1,2]
+func Map[T, K any](s iter.Seq[T], f func(T) K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for v := range s {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+
+// MaxBy returns the value for which f returns the greatest key.
+// The boolean is false if the sequence is empty.
+//
+// example usage:
+//
+//	MaxBy(slices.Values([]string{"a","ccc","bb"}), func(s string) int { return len(s) })
+//
+// output:
+//
+//	"ccc", true
+func MaxBy[T any, K cmp.Ordered](s iter.Seq[T], f func(T) K) (T, bool) {
+	return extremeBy(s, f, func(a, b K) bool { return a > b })
+}
+
+// MinBy returns the value for which f returns the smallest key.
+// The boolean is false if the sequence is empty.
+//
+// example usage:
+//
+//	MinBy(slices.Values([]string{"a","ccc","bb"}), func(s string) int { return len(s) })
+//
+// output:
+//
+//	"a", true
+func MinBy[T any, K cmp.Ordered](s iter.Seq[T], f func(T) K) (T, bool) {
+	return extremeBy(s, f, func(a, b K) bool { return a < b })
+}
+
+// Pairwise returns an iterator over pairs of consecutive values (prev, curr).
+// Sequences with fewer than two values yield nothing.
+//
+// example usage:
+//
+//	for prev, curr := range Pairwise(slices.Values([]int{1,2,3})) {
+//		fmt.Println(prev, curr)
+//	}
+//
+// output:
+//
+//	1 2
+//	2 3
+func Pairwise[T any](s iter.Seq[T]) iter.Seq2[T, T] {
+	return func(yield func(T, T) bool) {
+		var (
+			prev    T
+			started bool
+		)
+		for v := range s {
+			if started && !yield(prev, v) {
+				return
+			}
+			prev, started = v, true
+		}
+	}
+}
+
+// AdjacentDiff returns an iterator over f applied to each pair of consecutive values.
+//
+// example usage:
+//
+//	slices.Collect(AdjacentDiff(slices.Values([]int{1,4,9}), func(a, b int) int { return b - a }))
+//
+// output:
+//
+//	[3,5]
+func AdjacentDiff[T, R any](s iter.Seq[T], f func(T, T) R) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for prev, curr := range Pairwise(s) {
+			if !yield(f(prev, curr)) {
+				return
+			}
+		}
+	}
+}
+
+// Sliding returns an iterator over windows of size consecutive values,
+// the start of each window being step values after the start of the
+// previous one. The last window is shorter than size when the remaining
+// values do not fill it, it is omitted when its values were all part of
+// the previous window. Each window is a new slice.
+// It panics if size or step is less than 1.
+//
+// example usage:
+//
+//	slices.Collect(Sliding(slices.Values([]int{1,2,3,4,5}), 3, 2))
+//
+// output:
+//
+//	[[1,2,3],[3,4,5]]
+func Sliding[T any](s iter.Seq[T], size, step int) iter.Seq[[]T] {
+	if size < 1 || step < 1 {
+		panic("seq: window size and step must be at least 1")
+	}
+	return func(yield func([]T) bool) {
+		window := make([]T, 0, size)
+		skip, pending := 0, false
+		for v := range s {
+			if skip > 0 {
+				skip--
+				continue
+			}
+			window, pending = append(window, v), true
+			if len(window) < size {
+				continue
+			}
+			if !yield(slices.Clone(window)) {
+				return
+			}
+			pending = false
+			if step < size {
+				window = window[:copy(window, window[step:])]
+			} else {
+				window, skip = window[:0], step-size
+			}
+		}
+		if pending {
+			yield(slices.Clone(window))
+		}
+	}
+}
+
+// Grouped returns an iterator over consecutive chunks of n values,
+// the last chunk holding the remaining values. Each chunk is a new slice.
+// It panics if n is less than 1.
+//
+// example usage:
+//
+//	slices.Collect(Grouped(slices.Values([]int{1,2,3,4,5}), 2))
+//
+// output:
+//
+//	[[1,2],[3,4],[5]]
+func Grouped[T any](s iter.Seq[T], n int) iter.Seq[[]T] {
+	return Sliding(s, n, n)
+}
+
+// Reduce applies the reducing function to each value of the sequence,
+// starting from init, and returns the accumulated result.
+//
+// example usage:
+//
+//	Reduce(slices.Values([]int{1,2,3}), func(acc int, i int) int { return acc + i }, 0)
+//
+// output:
+//
+//	6
+func Reduce[T, K any](s iter.Seq[T], f func(K, T) K, init K) K {
+	accumulator := init
+	for v := range s {
+		accumulator = f(accumulator, v)
+	}
+	return accumulator
+}
+
+// ReduceUntil is similar to Reduce but stops as soon as the accumulated
+// value satisfies the until predicate, which is also checked against init.
+//
+// example usage:
+//
+//	ReduceUntil(slices.Values([]int{5,4,3,2}), func(acc, i int) int { return acc + i }, 0, func(acc int) bool { return acc > 6 })
+//
+// output:
+//
+//	9
+func ReduceUntil[T, K any](s iter.Seq[T], f func(K, T) K, init K, until func(K) bool) K {
+	accumulator := init
+	if until(accumulator) {
+		return accumulator
+	}
+	for v := range s {
+		if accumulator = f(accumulator, v); until(accumulator) {
+			break
+		}
+	}
+	return accumulator
+}
+
+// Scan returns an iterator that yields init, then the accumulated value after
+// applying f to each value of s, i.e. the intermediate results of Reduce.
+//
+// example usage:
+//
+//	slices.Collect(Scan(slices.Values([]int{1,2,3}), func(acc, v int) int { return acc + v }, 0))
+//
+// output:
+//
+//	[0,1,3,6]
+func Scan[T, K any](s iter.Seq[T], f func(K, T) K, init K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		accumulator := init
+		if !yield(accumulator) {
+			return
+		}
+		for v := range s {
+			if accumulator = f(accumulator, v); !yield(accumulator) {
+				return
+			}
+		}
+	}
+}
+
+// Reject returns an iterator that yields the values that do not satisfy the predicate.
+//
+// example usage:
+//
+//	slices.Collect(Reject(slices.Values([]int{1,2,3,4,5,6}), func(i int) bool { return i % 2 == 0 }))
+//
+// output:
+//
+//	[1,3,5]
+func Reject[T any](s iter.Seq[T], f func(T) bool) iter.Seq[T] {
+	return Filter(s, func(t T) bool { return !f(t) })
+}
+
+// Take returns an iterator that yields at most the first n values of the sequence.
+// The underlying sequence is not advanced past the n-th value.
+//
+// example usage:
+//
+//	slices.Collect(Take(slices.Values([]int{1,2,3,4}), 2))
+//
+// output:
+//
+//	[1,2]
+func Take[T any](s iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for v := range s {
+			if !yield(v) {
+				return
+			}
+			i++
+			if i == n {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhile returns an iterator that yields values while they satisfy the predicate.
+//
+// example usage:
+//
+//	slices.Collect(TakeWhile(slices.Values([]int{1,2,3,1}), func(i int) bool { return i < 3 }))
+//
+// output:
+//
+//	[1,2]
+func TakeWhile[T any](s iter.Seq[T], f func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s {
+			if !f(v) || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Throttle returns an iterator that yields the values of s at most once per
+// interval, the first one immediately, and stops once the context is done.
+// It waits for the interval, measured from the previous yield, before taking
+// each following value from s, so that no value is taken and lost when the
+// context is done meanwhile. The iteration thus ends one interval after the
+// last value, unless the consumer took longer than that.
+//
+// example usage:
+//
+//	for job := range Throttle(ctx, jobs, time.Second) {
+//	  api.Submit(job) // at most one call per second
+//	}
+func Throttle[T any](ctx context.Context, s iter.Seq[T], interval time.Duration) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if ctx.Err() != nil {
+			return
+		}
+		next, stop := iter.Pull(s)
+		defer stop()
+		var timer *time.Timer
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+		var last time.Time
+		for {
+			if wait := interval - time.Since(last); !last.IsZero() && wait > 0 {
+				if timer == nil {
+					timer = time.NewTimer(wait)
+				} else {
+					timer.Reset(wait)
+				}
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					return
+				}
+			}
+			// the context is checked before taking a value, never after,
+			// so that every value taken is yielded
+			if ctx.Err() != nil {
+				return
+			}
+			v, ok := next()
+			if !ok {
+				return
+			}
+			last = time.Now()
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Zip returns an iterator over pairs of corresponding values of s1 and s2.
+// The iteration stops when the shorter sequence is exhausted.
+//
+// example usage:
+//
+//	for k, v := range Zip(slices.Values([]string{"a","b","c"}), slices.Values([]int{1,2})) {
+//		fmt.Println(k, v)
+//	}
+//
+// output:
+//
+//	a 1
+//	b 2
+func Zip[T, K any](s1 iter.Seq[T], s2 iter.Seq[K]) iter.Seq2[T, K] {
+	return func(yield func(T, K) bool) {
+		next, stop := iter.Pull(s2)
+		defer stop()
+		for v := range s1 {
+			v2, ok := next()
+			if !ok || !yield(v, v2) {
+				return
+			}
+		}
+	}
+}
+
+// ZipLongest returns an iterator over pairs of corresponding values of s1
+// and s2. The iteration stops when both sequences are exhausted, the missing
+// values of the shorter sequence being replaced by fill1 or fill2.
+//
+// example usage:
+//
+//	for k, v := range ZipLongest(slices.Values([]string{"a","b","c"}), slices.Values([]int{1,2}), "", -1) {
+//		fmt.Println(k, v)
+//	}
+//
+// output:
+//
+//	a 1
+//	b 2
+//	c -1
+func ZipLongest[T, K any](s1 iter.Seq[T], s2 iter.Seq[K], fill1 T, fill2 K) iter.Seq2[T, K] {
+	return func(yield func(T, K) bool) {
+		next, stop := iter.Pull(s2)
+		defer stop()
+		for v := range s1 {
+			v2, ok := next()
+			if !ok {
+				v2 = fill2
+			}
+			if !yield(v, v2) {
+				return
+			}
+		}
+		for v2, ok := next(); ok; v2, ok = next() {
+			if !yield(fill1, v2) {
+				return
+			}
+		}
+	}
+}
+
+func extremeBy[T any, K cmp.Ordered](s iter.Seq[T], f func(T) K, better func(K, K) bool) (T, bool) {
+	var (
+		best    T
+		bestKey K
+		found   bool
+	)
+	for v := range s {
+		k := f(v)
+		if !found || better(k, bestKey) {
+			best, bestKey, found = v, k, true
+		}
+	}
+	return best, found
+}
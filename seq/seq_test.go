@@ -0,0 +1,275 @@
+package seq
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	if got := slices.Collect(FromChan(ch)); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("FromChan() = %v, want [1 2 3]", got)
+	}
+}
+
+func TestToChan(t *testing.T) {
+	ch := ToChan(context.Background(), slices.Values([]int{1, 2, 3}), 1)
+	if got := slices.Collect(FromChan(ch)); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("ToChan() = %v, want [1 2 3]", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch = ToChan(ctx, slices.Values([]int{1, 2, 3}), 0)
+	<-ch
+	cancel()
+	for range ch {
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	const interval = 50 * time.Millisecond
+	start := time.Now()
+	got := slices.Collect(Throttle(context.Background(), slices.Values([]int{1, 2, 3}), interval))
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Throttle() = %v, want [1 2 3]", got)
+	}
+	// the end of s is only found after waiting for a fourth value
+	if elapsed := time.Since(start); elapsed < 3*interval || elapsed >= 4*interval {
+		t.Errorf("Throttle() yielded 3 values in %v, want between %v and %v", elapsed, 3*interval, 4*interval)
+	}
+	start = time.Now()
+	for range Throttle(context.Background(), slices.Values([]int{1, 2}), interval) {
+		break
+	}
+	if elapsed := time.Since(start); elapsed >= interval {
+		t.Errorf("Throttle() stopped after one value in %v, want no wait", elapsed)
+	}
+}
+
+func TestThrottle_Cancel(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		consume  func(v int, cancel context.CancelFunc)
+		want     []int
+	}{
+		{
+			name:     "during the wait",
+			interval: time.Hour,
+			consume: func(v int, cancel context.CancelFunc) {
+				time.AfterFunc(10*time.Millisecond, cancel)
+			},
+			want: []int{1},
+		},
+		{
+			name:     "by a slow consumer",
+			interval: time.Millisecond,
+			consume: func(v int, cancel context.CancelFunc) {
+				time.Sleep(5 * time.Millisecond)
+				if v == 2 {
+					cancel()
+				}
+			},
+			want: []int{1, 2},
+		},
+		{
+			name:     "without interval",
+			interval: 0,
+			consume: func(v int, cancel context.CancelFunc) {
+				if v == 3 {
+					cancel()
+				}
+			},
+			want: []int{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			pulled := 0
+			counted := func(yield func(int) bool) {
+				for i := 1; ; i++ {
+					pulled++
+					if !yield(i) {
+						return
+					}
+				}
+			}
+			var got []int
+			for v := range Throttle(ctx, counted, tt.interval) {
+				got = append(got, v)
+				tt.consume(v, cancel)
+			}
+			if !slices.Equal(got, tt.want) || pulled != len(tt.want) {
+				t.Errorf("Throttle() after cancel = %v, pulled %d values, want %v, %d", got, pulled, tt.want, len(tt.want))
+			}
+			if got := slices.Collect(Throttle(ctx, counted, 0)); len(got) != 0 || pulled != len(tt.want) {
+				t.Errorf("Throttle() with a done context = %v, want []", got)
+			}
+		})
+	}
+}
+
+func TestTransforms(t *testing.T) {
+	isEven := func(i int) bool { return i%2 == 0 }
+	lessThan3 := func(i int) bool { return i < 3 }
+	tests := []struct {
+		name string
+		got  func([]int) []int
+		in   []int
+		want []int
+	}{
+		{name: "filter", got: func(s []int) []int { return slices.Collect(Filter(slices.Values(s), isEven)) }, in: []int{1, 2, 3, 4}, want: []int{2, 4}},
+		{name: "reject", got: func(s []int) []int { return slices.Collect(Reject(slices.Values(s), isEven)) }, in: []int{1, 2, 3, 4}, want: []int{1, 3}},
+		{name: "map", got: func(s []int) []int { return slices.Collect(Map(slices.Values(s), func(i int) int { return i * 10 })) }, in: []int{1, 2}, want: []int{10, 20}},
+		{name: "distinct", got: func(s []int) []int { return slices.Collect(Distinct(slices.Values(s))) }, in: []int{1, 1, 2, 1, 3}, want: []int{1, 2, 3}},
+		{name: "distinct func", got: func(s []int) []int {
+			return slices.Collect(DistinctFunc(slices.Values(s), func(a, b int) bool { return a == b }))
+		}, in: []int{3, 3, 1, 3}, want: []int{3, 1}},
+		{name: "concat", got: func(s []int) []int { return slices.Collect(Concat(slices.Values(s), slices.Values(s))) }, in: []int{1, 2}, want: []int{1, 2, 1, 2}},
+		{name: "take", got: func(s []int) []int { return slices.Collect(Take(slices.Values(s), 2)) }, in: []int{1, 2, 3}, want: []int{1, 2}},
+		{name: "take zero", got: func(s []int) []int { return slices.Collect(Take(slices.Values(s), 0)) }, in: []int{1, 2, 3}, want: nil},
+		{name: "drop", got: func(s []int) []int { return slices.Collect(Drop(slices.Values(s), 2)) }, in: []int{1, 2, 3}, want: []int{3}},
+		{name: "take while", got: func(s []int) []int { return slices.Collect(TakeWhile(slices.Values(s), lessThan3)) }, in: []int{1, 2, 3, 1}, want: []int{1, 2}},
+		{name: "last n", got: func(s []int) []int { return slices.Collect(LastN(slices.Values(s), 2)) }, in: []int{1, 2, 3, 4, 5}, want: []int{4, 5}},
+		{name: "last n wrapped", got: func(s []int) []int { return slices.Collect(LastN(slices.Values(s), 3)) }, in: []int{1, 2, 3, 4, 5}, want: []int{3, 4, 5}},
+		{name: "last n longer", got: func(s []int) []int { return slices.Collect(LastN(slices.Values(s), 5)) }, in: []int{1, 2}, want: []int{1, 2}},
+		{name: "last zero", got: func(s []int) []int { return slices.Collect(LastN(slices.Values(s), 0)) }, in: []int{1, 2}, want: nil},
+		{name: "drop last", got: func(s []int) []int { return slices.Collect(DropLast(slices.Values(s), 2)) }, in: []int{1, 2, 3, 4, 5}, want: []int{1, 2, 3}},
+		{name: "drop last longer", got: func(s []int) []int { return slices.Collect(DropLast(slices.Values(s), 5)) }, in: []int{1, 2}, want: nil},
+		{name: "drop last zero", got: func(s []int) []int { return slices.Collect(DropLast(slices.Values(s), 0)) }, in: []int{1, 2}, want: []int{1, 2}},
+		{name: "adjacent diff", got: func(s []int) []int {
+			return slices.Collect(AdjacentDiff(slices.Values(s), func(a, b int) int { return b - a }))
+		}, in: []int{1, 4, 9}, want: []int{3, 5}},
+		{name: "drop while", got: func(s []int) []int { return slices.Collect(DropWhile(slices.Values(s), lessThan3)) }, in: []int{1, 2, 3, 1}, want: []int{3, 1}},
+		{name: "zip", got: func(s []int) []int {
+			var r []int
+			for a, b := range Zip(slices.Values(s), slices.Values(s[1:])) {
+				r = append(r, a*b)
+			}
+			return r
+		}, in: []int{1, 2, 3}, want: []int{2, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got(tt.in); !slices.Equal(got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEarlyExit(t *testing.T) {
+	pulled := 0
+	counting := func(yield func(int) bool) {
+		for i := 0; i < 100; i++ {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	for range Take(Filter(counting, func(i int) bool { return i%2 == 0 }), 3) {
+	}
+	if pulled != 5 {
+		t.Errorf("source advanced %d times, want 5", pulled)
+	}
+
+	pulled = 0
+	for range Take(DropLast(counting, 10), 3) {
+	}
+	if pulled != 13 {
+		t.Errorf("DropLast advanced the source %d times, want 13", pulled)
+	}
+}
+
+func TestReductions(t *testing.T) {
+	s := slices.Values([]int{4, 1, 3, 2})
+	if got := Count(s, func(i int) bool { return i > 1 }); got != 3 {
+		t.Errorf("Count() = %v, want 3", got)
+	}
+	if got := Reduce(s, func(acc, i int) int { return acc + i }, 0); got != 10 {
+		t.Errorf("Reduce() = %v, want 10", got)
+	}
+	if !ForAll(s, func(i int) bool { return i > 0 }) || ForAll(s, func(i int) bool { return i > 1 }) {
+		t.Errorf("ForAll() returned an unexpected result")
+	}
+	if !Contains(s, 3) || Contains(s, 5) {
+		t.Errorf("Contains() returned an unexpected result")
+	}
+	if i, v := Find(s, func(i int) bool { return i < 3 }); i != 1 || v != 1 {
+		t.Errorf("Find() = %v, %v, want 1, 1", i, v)
+	}
+	if i, _ := Find(s, func(i int) bool { return i > 4 }); i != -1 {
+		t.Errorf("Find() = %v, want -1", i)
+	}
+	if v, ok := MaxBy(s, func(i int) int { return i }); !ok || v != 4 {
+		t.Errorf("MaxBy() = %v, %v, want 4, true", v, ok)
+	}
+	if v, ok := MinBy(s, func(i int) int { return i }); !ok || v != 1 {
+		t.Errorf("MinBy() = %v, %v, want 1, true", v, ok)
+	}
+	if got := FoldWhile(s, 0, func(acc, i int) (int, bool) { return acc + i, acc+i < 5 }); got != 5 {
+		t.Errorf("FoldWhile() = %v, want 5", got)
+	}
+	if got := ReduceUntil(s, func(acc, i int) int { return acc + i }, 0, func(acc int) bool { return acc >= 5 }); got != 5 {
+		t.Errorf("ReduceUntil() = %v, want 5", got)
+	}
+	if _, ok := MaxBy(slices.Values([]int{}), func(i int) int { return i }); ok {
+		t.Errorf("MaxBy() on an empty sequence returned true")
+	}
+}
+
+func TestSliding(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         []int
+		size, step int
+		want       [][]int
+	}{
+		{name: "overlapping", in: []int{1, 2, 3, 4}, size: 2, step: 1, want: [][]int{{1, 2}, {2, 3}, {3, 4}}},
+		{name: "partial last window", in: []int{1, 2, 3, 4}, size: 3, step: 2, want: [][]int{{1, 2, 3}, {3, 4}}},
+		{name: "covered last window", in: []int{1, 2, 3, 4, 5}, size: 3, step: 2, want: [][]int{{1, 2, 3}, {3, 4, 5}}},
+		{name: "gaps", in: []int{1, 2, 3, 4, 5, 6}, size: 2, step: 3, want: [][]int{{1, 2}, {4, 5}}},
+		{name: "shorter than size", in: []int{1, 2}, size: 3, step: 1, want: [][]int{{1, 2}}},
+		{name: "empty", in: []int{}, size: 2, step: 1, want: nil},
+		{name: "grouped", in: []int{1, 2, 3, 4, 5}, size: 2, step: 2, want: [][]int{{1, 2}, {3, 4}, {5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Collect(Sliding(slices.Values(tt.in), tt.size, tt.step))
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("Sliding() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := slices.Collect(Grouped(slices.Values([]int{1, 2, 3}), 2)); len(got) != 2 || !slices.Equal(got[1], []int{3}) {
+		t.Errorf("Grouped() = %v, want [[1 2] [3]]", got)
+	}
+}
+
+func TestSlidingPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Sliding() with a step of 0 did not panic")
+		}
+	}()
+	Sliding(slices.Values([]int{1}), 1, 0)
+}
+
+func TestScan(t *testing.T) {
+	sum := func(acc, v int) int { return acc + v }
+	if got := slices.Collect(Scan(slices.Values([]int{1, 2, 3}), sum, 0)); !slices.Equal(got, []int{0, 1, 3, 6}) {
+		t.Errorf("Scan() = %v, want [0 1 3 6]", got)
+	}
+	if got := slices.Collect(Take(Scan(slices.Values([]int{1, 2, 3}), sum, 0), 2)); !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("Take(Scan()) = %v, want [0 1]", got)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package seq
+
+import (
+	"container/heap"
+	"iter"
+	"slices"
+)
+
+// SortedBy returns an iterator yielding the values of s in the order defined
+// by less. When the iteration starts, the values are copied into a binary
+// heap in O(n), then each value is popped from it in O(log n), so a caller
+// breaking after the first k values pays O(n + k log n) instead of sorting
+// all of them. The order of values comparing equal is unspecified.
+//
+// example usage:
+//
+//	scores := map[string]int{"ann": 7, "bob": 9, "cid": 3}
+//	for name := range Take(SortedBy(maps.Keys(scores), func(a, b string) bool { return scores[a] > scores[b] }), 2) {
+//		fmt.Println(name)
+//	}
+//
+// output:
+//
+//	bob
+//	ann
+func SortedBy[T any](s iter.Seq[T], less func(a, b T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		h := &lessHeap[T]{values: slices.Collect(s), less: less}
+		heap.Init(h)
+		for h.Len() > 0 {
+			if !yield(heap.Pop(h).(T)) {
+				return
+			}
+		}
+	}
+}
+
+// lessHeap is a binary heap of values ordered by a less function.
+type lessHeap[T any] struct {
+	values []T
+	less   func(a, b T) bool
+}
+
+func (h *lessHeap[T]) Len() int { return len(h.values) }
+
+func (h *lessHeap[T]) Less(i, j int) bool { return h.less(h.values[i], h.values[j]) }
+
+func (h *lessHeap[T]) Swap(i, j int) { h.values[i], h.values[j] = h.values[j], h.values[i] }
+
+func (h *lessHeap[T]) Push(x any) { h.values = append(h.values, x.(T)) }
+
+func (h *lessHeap[T]) Pop() any {
+	last := h.values[len(h.values)-1]
+	h.values[len(h.values)-1] = *new(T)
+	h.values = h.values[:len(h.values)-1]
+	return last
+}
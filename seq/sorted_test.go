@@ -0,0 +1,40 @@
+package seq
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestSortedBy(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+		less func(a, b int) bool
+		take int
+		want []int
+	}{
+		{name: "ascending", in: []int{5, 2, 8, 1, 9}, less: cmp.Less[int], take: 5, want: []int{1, 2, 5, 8, 9}},
+		{name: "descending", in: []int{5, 2, 8, 1, 9}, less: func(a, b int) bool { return a > b }, take: 5, want: []int{9, 8, 5, 2, 1}},
+		{name: "first values only", in: []int{4, 4, 3, 7, 0}, less: cmp.Less[int], take: 3, want: []int{0, 3, 4}},
+		{name: "empty", in: nil, less: cmp.Less[int], take: 3, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(Take(SortedBy(slices.Values(tt.in), tt.less), tt.take)); !slices.Equal(got, tt.want) {
+				t.Errorf("SortedBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortedBy_Reusable(t *testing.T) {
+	m := map[string]int{"b": 2, "a": 1, "c": 3}
+	sorted := SortedBy(maps.Keys(m), cmp.Less[string])
+	for range 2 {
+		if got := slices.Collect(sorted); !slices.Equal(got, []string{"a", "b", "c"}) {
+			t.Errorf("SortedBy() = %v, want [a b c]", got)
+		}
+	}
+}
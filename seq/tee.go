@@ -0,0 +1,187 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package seq
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// Tee splits s into n iterators that each yield all the values of s,
+// pulling every value from s only once. It lets several pipeline branches
+// consume one expensive source without materializing it.
+//
+// Values are buffered until every iterator has yielded them, up to buffer
+// values: an iterator running buffer values ahead of the slowest one blocks
+// until it catches up. The iterators are therefore meant to be ranged over
+// concurrently, from different goroutines, unless buffer is larger than
+// the number of values of the source. An iterator that is never ranged over holds
+// the others back, while one that stops early releases them. s is stopped
+// once all the iterators are done.
+// Each iterator can be ranged over once. It panics if buffer is less than 1.
+//
+// example usage:
+//
+//	branches := Tee(rows, 2, 64)
+//	go func() { count <- Count(branches[0], isValid) }()
+//	go func() { total <- Reduce(branches[1], sumAmount, 0) }()
+func Tee[T any](s iter.Seq[T], n, buffer int) []iter.Seq[T] {
+	if buffer < 1 {
+		panic("seq: tee buffer must be at least 1")
+	}
+	t := &tee[T]{source: s, limit: buffer, pos: make([]int, n), active: make([]bool, n), running: n}
+	t.cond = sync.NewCond(&t.mu)
+	seqs := make([]iter.Seq[T], n)
+	for i := range seqs {
+		t.active[i] = true
+		seqs[i] = func(yield func(T) bool) { t.consume(i, yield) }
+	}
+	return seqs
+}
+
+// tee holds the state shared by the iterators returned by Tee.
+type tee[T any] struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	source  iter.Seq[T]
+	next    func() (T, bool) // pulls from source, set on first use
+	stop    func()
+	limit   int
+	buf     []T    // values not yielded by every active iterator yet
+	base    int    // index in the source of buf[0]
+	pos     []int  // index in the source of the next value of each iterator
+	active  []bool // iterators that did not stop yet
+	running int    // number of active iterators
+	done    bool   // the source is exhausted or stopped
+}
+
+// consume yields the values of the source to the i-th iterator.
+func (t *tee[T]) consume(i int, yield func(T) bool) {
+	t.mu.Lock()
+	if !t.active[i] {
+		t.mu.Unlock()
+		return
+	}
+	locked := true
+	defer func() {
+		// yield may panic while the lock is released.
+		if !locked {
+			t.mu.Lock()
+		}
+		t.detach(i)
+		t.mu.Unlock()
+	}()
+	for {
+		if p := t.pos[i]; p < t.base+len(t.buf) {
+			v := t.buf[p-t.base]
+			t.pos[i]++
+			t.trim()
+			t.mu.Unlock()
+			locked = false
+			ok := yield(v)
+			t.mu.Lock()
+			locked = true
+			if !ok {
+				return
+			}
+			continue
+		}
+		if t.done {
+			return
+		}
+		if len(t.buf) >= t.limit {
+			t.cond.Wait()
+			continue
+		}
+		if t.next == nil {
+			t.next, t.stop = iter.Pull(t.source)
+		}
+		v, ok := t.next()
+		if !ok {
+			t.done = true
+		} else {
+			t.buf = append(t.buf, v)
+		}
+		t.cond.Broadcast()
+	}
+}
+
+// trim drops the buffered values yielded by every active iterator. The lock must be held.
+func (t *tee[T]) trim() {
+	slowest := t.base + len(t.buf)
+	for i, p := range t.pos {
+		if t.active[i] {
+			slowest = min(slowest, p)
+		}
+	}
+	if drop := slowest - t.base; drop > 0 {
+		clear(t.buf[:drop])
+		t.buf = t.buf[drop:]
+		t.base = slowest
+		t.cond.Broadcast()
+	}
+}
+
+// detach removes the i-th iterator, stopping the source once no iterator
+// is left. The lock must be held.
+func (t *tee[T]) detach(i int) {
+	t.active[i] = false
+	t.running--
+	t.trim()
+	if t.running == 0 {
+		t.done = true
+		if t.stop != nil {
+			t.stop()
+		}
+	}
+}
+
+// Broadcast sends every value received from ch to each of n returned
+// channels, buffered with buffer values each, and closes them once ch is
+// closed or the context is done. A receiver falling behind by more than
+// buffer values blocks the others, every returned channel must therefore
+// be drained, or the context cancelled.
+//
+// example usage:
+//
+//	outs := Broadcast(ctx, events, 2, 16)
+//	go audit(outs[0])
+//	go index(outs[1])
+func Broadcast[T any](ctx context.Context, ch <-chan T, n, buffer int) []<-chan T {
+	outs := make([]chan T, n)
+	result := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T, buffer)
+		result[i] = outs[i]
+	}
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for {
+			var v T
+			select {
+			case <-ctx.Done():
+				return
+			case received, ok := <-ch:
+				if !ok {
+					return
+				}
+				v = received
+			}
+			for _, out := range outs {
+				select {
+				case out <- v:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return result
+}
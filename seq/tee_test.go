@@ -0,0 +1,127 @@
+package seq
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestTee(t *testing.T) {
+	tests := []struct {
+		name   string
+		n      int
+		buffer int
+	}{
+		{name: "buffer holds the source", n: 3, buffer: 100},
+		{name: "small buffer", n: 3, buffer: 1},
+		{name: "single iterator", n: 1, buffer: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pulled := 0
+			source := func(yield func(int) bool) {
+				for i := range 50 {
+					pulled++
+					if !yield(i) {
+						return
+					}
+				}
+			}
+			results := make([][]int, tt.n)
+			var wg sync.WaitGroup
+			for i, branch := range Tee(source, tt.n, tt.buffer) {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					results[i] = slices.Collect(branch)
+				}()
+			}
+			wg.Wait()
+			if pulled != 50 {
+				t.Errorf("source pulled %d times, want 50", pulled)
+			}
+			want := slices.Collect(Take(source, 50))
+			for i, got := range results {
+				if !slices.Equal(got, want) {
+					t.Errorf("branch %d = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTeeEarlyStop(t *testing.T) {
+	stopped := false
+	source := func(yield func(int) bool) {
+		defer func() { stopped = true }()
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	branches := Tee(source, 2, 1)
+	var wg sync.WaitGroup
+	for _, n := range []int{3, 10} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := slices.Collect(Take(branches[n/10], n)); len(got) != n {
+				t.Errorf("Take(%d) = %v", n, got)
+			}
+		}()
+	}
+	wg.Wait()
+	if !stopped {
+		t.Error("source not stopped after every branch stopped")
+	}
+	if got := slices.Collect(branches[0]); len(got) != 0 {
+		t.Errorf("ranging a stopped branch again = %v, want nothing", got)
+	}
+}
+
+func TestTeeSequential(t *testing.T) {
+	branches := Tee(slices.Values([]int{1, 2, 3}), 2, 4)
+	first, second := slices.Collect(branches[0]), slices.Collect(branches[1])
+	if !slices.Equal(first, []int{1, 2, 3}) || !slices.Equal(second, first) {
+		t.Errorf("Tee() = %v, %v, want [1 2 3] twice", first, second)
+	}
+}
+
+func TestBroadcast(t *testing.T) {
+	in := make(chan int)
+	outs := Broadcast(context.Background(), in, 2, 1)
+	go func() {
+		for i := range 5 {
+			in <- i
+		}
+		close(in)
+	}()
+	results := make([][]int, len(outs))
+	var wg sync.WaitGroup
+	for i, out := range outs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = slices.Collect(FromChan(out))
+		}()
+	}
+	wg.Wait()
+	for i, got := range results {
+		if !slices.Equal(got, []int{0, 1, 2, 3, 4}) {
+			t.Errorf("receiver %d = %v, want [0 1 2 3 4]", i, got)
+		}
+	}
+}
+
+func TestBroadcastCanceled(t *testing.T) {
+	in := make(chan int)
+	ctx, cancel := context.WithCancel(context.Background())
+	outs := Broadcast(ctx, in, 2, 0)
+	cancel()
+	for _, out := range outs {
+		for range out {
+		}
+	}
+}
@@ -41,6 +41,25 @@ func NewComparableSequence[T cmp.Ordered](s ...[]T) *ComparableSequence[T] {
 // wrapping Collection functions to enable function chaining:
 // i.e. sequence.Filter(f).Take(n)
 
+// BinarySearch searches a sequence sorted in increasing order for v in
+// O(log n), and returns the position where v is found, or would be inserted
+// to keep the sequence sorted, and whether it was found. Unlike IndexOf,
+// the position is not necessarily the first occurrence of v.
+//
+// example usage:
+//
+//	c := NewComparableSequence([]int{1,3,5})
+//	c.BinarySearch(3)
+//	c.BinarySearch(4)
+//
+// output:
+//
+//	1, true
+//	2, false
+func (c *ComparableSequence[T]) BinarySearch(v T) (int, bool) {
+	return slices.BinarySearch(c.items(), v)
+}
+
 // Clone returns a copy of the collection. This is a shallow clone.
 func (c *ComparableSequence[T]) Clone() *ComparableSequence[T] {
 	return &ComparableSequence[T]{
@@ -49,17 +68,22 @@ func (c *ComparableSequence[T]) Clone() *ComparableSequence[T] {
 }
 
 // Contains returns true if the sequence contains the given value.
+// Sequences of int64 and float64 use a specialized unrolled loop.
 func (c *ComparableSequence[T]) Contains(v T) bool {
+	if found, ok := containsFast(c.elements, v); ok {
+		return found
+	}
 	return slices.Contains(c.elements, v)
 }
 
 // Concat returns a new sequence concatenating the passed in sequences.
 func (c *ComparableSequence[T]) Concat(sequences ...*ComparableSequence[T]) *ComparableSequence[T] {
-	e := c.elements
+	e := make([][]T, 0, len(sequences)+1)
+	e = append(e, c.elements)
 	for _, col := range sequences {
-		e = slices.Concat(e, col.elements)
+		e = append(e, col.elements)
 	}
-	return &ComparableSequence[T]{Sequence[T]{elements: e}}
+	return &ComparableSequence[T]{Sequence[T]{elements: slices.Concat(e...)}}
 }
 
 // Concatenated is an alias for collection.Concatenated
@@ -68,19 +92,18 @@ func (c *ComparableSequence[T]) Concatenated(s *ComparableSequence[T]) iter.Seq[
 }
 
 // Corresponds is an alias for collection.Corresponds
-func (c *ComparableSequence[T]) Corresponds(s *ComparableSequence[T], f func(T, T) bool) bool {
+func (c *ComparableSequence[T]) Corresponds(s collection.OrderedCollection[T], f func(T, T) bool) bool {
 	return collection.Corresponds(c, s, f)
 }
 
 // Distinct returns a new sequence containing only the unique elements from the original sequence.
 func (c *ComparableSequence[T]) Distinct() *ComparableSequence[T] {
-	m := make(map[T]interface{})
+	m := make(map[T]struct{})
 	r := &ComparableSequence[T]{}
-	for v := range c.Values() {
-		_, ok := m[v]
-		if !ok {
+	for _, v := range c.elements {
+		if _, ok := m[v]; !ok {
 			r.Add(v)
-			m[v] = true
+			m[v] = struct{}{}
 		}
 	}
 	return r
@@ -93,7 +116,7 @@ func (c *ComparableSequence[T]) Distincted() iter.Seq[T] {
 
 // Diff is an alias for collection.Diff
 func (c *ComparableSequence[T]) Diff(s *ComparableSequence[T]) *ComparableSequence[T] {
-	return collection.Diff(c, s).(*ComparableSequence[T])
+	return collection.FilterInto(c, NewComparableSequence[T](), func(v T) bool { return !s.Contains(v) })
 }
 
 // Diffed is an alias for collection.Diffed
@@ -101,9 +124,12 @@ func (c *ComparableSequence[T]) Diffed(s *ComparableSequence[T]) iter.Seq[T] {
 	return collection.Diffed(c, s)
 }
 
-// Equals returns true if the two sequences are equal.
-func (c *ComparableSequence[T]) Equals(c2 *ComparableSequence[T]) bool {
-	return slices.Equal(c.elements, c2.elements)
+// Equals returns true if the sequence holds the same elements as c2 in the same order.
+func (c *ComparableSequence[T]) Equals(c2 collection.OrderedCollection[T]) bool {
+	if s, ok := c2.(*ComparableSequence[T]); ok {
+		return slices.Equal(c.elements, s.elements)
+	}
+	return collection.Equal(c, c2)
 }
 
 // Exists returns true if the sequence contains the given value.
@@ -119,7 +145,7 @@ func (c *ComparableSequence[T]) IndexOf(v T) int {
 
 // Intersect returns a new sequence containing the elements that are present in both sequences.
 func (c *ComparableSequence[T]) Intersect(s *ComparableSequence[T]) *ComparableSequence[T] {
-	return collection.Intersect(c, s).(*ComparableSequence[T])
+	return collection.FilterInto(c, NewComparableSequence[T](), s.Contains)
 }
 
 // IntersectIterator is an alias for collection.IntersectIterator
@@ -139,17 +165,41 @@ func (c *ComparableSequence[T]) LastIndexOf(v T) int {
 }
 
 // Max returns the maximum value in the sequence.
+// Sequences of int64 and float64 use a specialized unrolled loop.
 func (c *ComparableSequence[T]) Max() T {
+	if len(c.elements) > 0 {
+		if m, ok := maxFast(c.elements); ok {
+			return m
+		}
+	}
 	return slices.Max(c.elements)
 }
 
+// MergeSorted merges the sequence with another sorted ordered collection and
+// returns a new sorted sequence. Both inputs must be sorted in ascending order.
+func (c *ComparableSequence[T]) MergeSorted(other collection.OrderedCollection[T]) *ComparableSequence[T] {
+	merged := make([]T, 0, len(c.elements)+other.Length())
+	return &ComparableSequence[T]{Sequence[T]{elements: slices.AppendSeq(merged, collection.Merged(c, other))}}
+}
+
 // Min returns the minimum value in the sequence.
+// Sequences of int64 and float64 use a specialized unrolled loop.
 func (c *ComparableSequence[T]) Min() T {
+	if len(c.elements) > 0 {
+		if m, ok := minFast(c.elements); ok {
+			return m
+		}
+	}
 	return slices.Min(c.elements)
 }
 
 // Sum returns the sum of the elements in the sequence.
+// Sequences of int64 use a specialized unrolled loop. Other sequences,
+// including float64 ones, are summed from left to right.
 func (c *ComparableSequence[T]) Sum() T {
+	if sum, ok := sumFast(c.elements); ok {
+		return sum
+	}
 	var sum T
 	for _, v := range c.elements {
 		sum += v
@@ -157,12 +207,12 @@ func (c *ComparableSequence[T]) Sum() T {
 	return sum
 }
 
-// StartsWith returns true if the sequence starts with the given sequence.
-func (c *ComparableSequence[T]) StartsWith(other *ComparableSequence[T]) bool {
+// StartsWith returns true if the sequence starts with the elements of other.
+func (c *ComparableSequence[T]) StartsWith(other collection.OrderedCollection[T]) bool {
 	return collection.StartsWith(c, other)
 }
 
-// EndsWith returns true if the sequence ends with the given sequence.
-func (c *ComparableSequence[T]) EndsWith(other *ComparableSequence[T]) bool {
+// EndsWith returns true if the sequence ends with the elements of other.
+func (c *ComparableSequence[T]) EndsWith(other collection.OrderedCollection[T]) bool {
 	return collection.EndsWith(c, other)
 }
@@ -2,6 +2,7 @@ package sequence
 
 import (
 	"slices"
+	"strings"
 	"testing"
 )
 
@@ -227,3 +228,48 @@ func TestEndsWith(t *testing.T) {
 		})
 	}
 }
+
+func TestComparableSequence_MergeSorted(t *testing.T) {
+	got := NewComparableSequence([]int{1, 4, 6}).MergeSorted(NewSequence([]int{2, 3, 7}))
+	if !slices.Equal(got.ToSlice(), []int{1, 2, 3, 4, 6, 7}) {
+		t.Errorf("MergeSorted() = %v, want [1 2 3 4 6 7]", got)
+	}
+	if !got.Equals(NewComparableSequence([]int{1, 2, 3, 4, 6, 7})) {
+		t.Errorf("Equals() = false for sequences with the same elements")
+	}
+}
+
+func TestComparableSequence_BinarySearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []int
+		v         int
+		wantIndex int
+		wantFound bool
+	}{
+		{name: "empty", input: []int{}, v: 1, wantIndex: 0, wantFound: false},
+		{name: "found", input: []int{1, 3, 5, 7}, v: 5, wantIndex: 2, wantFound: true},
+		{name: "missing", input: []int{1, 3, 5, 7}, v: 4, wantIndex: 2, wantFound: false},
+		{name: "after last", input: []int{1, 3, 5, 7}, v: 8, wantIndex: 4, wantFound: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i, found := NewComparableSequence(tt.input).BinarySearch(tt.v)
+			if i != tt.wantIndex || found != tt.wantFound {
+				t.Errorf("BinarySearch(%d) = %d, %v, want %d, %v", tt.v, i, found, tt.wantIndex, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestSequence_BinarySearchFunc(t *testing.T) {
+	c := NewSequence([]string{"a", "bb", "ccc"})
+	i, found := c.BinarySearchFunc("xx", func(a, b string) int { return len(a) - len(b) })
+	if i != 1 || !found {
+		t.Errorf("BinarySearchFunc(xx) = %d, %v, want 1, true", i, found)
+	}
+	var empty *Sequence[string]
+	if i, found := empty.BinarySearchFunc("xx", strings.Compare); i != 0 || found {
+		t.Errorf("BinarySearchFunc() on nil sequence = %d, %v, want 0, false", i, found)
+	}
+}
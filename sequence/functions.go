@@ -0,0 +1,96 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// functions.go defines package functions grouping a Sequence into a dict.Map
+// or transforming it into a Sequence of a different element type. Go does not
+// allow type parameters on methods, so these cannot be written as methods on
+// Sequence[T]. Same-type variants are available as methods, i.e. c.ScanLeft(init, f).
+
+package sequence
+
+import (
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/dict"
+)
+
+// GroupBy groups the elements of the sequence by the key function into a Map
+// of sequences, preserving the order of the elements within each group.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3,4,5,6})
+//	GroupBy(c, func(i int) int { return i % 2 })
+//
+// output:
+//
+//	Map(int, *sequence.Sequence[int]) map[0:Seq(int) [2 4 6] 1:Seq(int) [1 3 5]]
+func GroupBy[T any, K comparable](c *Sequence[T], key func(T) K) *dict.Map[K, *Sequence[T]] {
+	return GroupMap(c, key, func(v T) T { return v })
+}
+
+// GroupMap groups the elements of the sequence by the key function and maps
+// each element with f, preserving the order of the elements within each group.
+//
+// example usage:
+//
+//	words := NewSequence([]string{"go", "gopher", "rust"})
+//	GroupMap(words, func(w string) byte { return w[0] }, func(w string) int { return len(w) })
+//
+// output:
+//
+//	Map(uint8, *sequence.Sequence[int]) map[103:Seq(int) [2 6] 114:Seq(int) [4]]
+func GroupMap[T any, K comparable, V any](c *Sequence[T], key func(T) K, f func(T) V) *dict.Map[K, *Sequence[V]] {
+	m := dict.NewMap[K, *Sequence[V]]()
+	for k, values := range collection.GroupMap(c, key, f) {
+		m.Set(k, &Sequence[V]{elements: values})
+	}
+	return m
+}
+
+// GroupMapReduce groups the elements of the sequence by the key function, maps
+// each element with f and reduces the mapped values of each group with reduce,
+// in a single pass.
+//
+// example usage:
+//
+//	words := NewSequence([]string{"go", "gopher", "rust"})
+//	GroupMapReduce(words, func(w string) byte { return w[0] }, func(w string) int { return len(w) }, func(a, b int) int { return a + b })
+//
+// output:
+//
+//	Map(uint8, int) map[103:8 114:4]
+func GroupMapReduce[T any, K comparable, V any](c *Sequence[T], key func(T) K, f func(T) V, reduce func(V, V) V) *dict.Map[K, V] {
+	return dict.NewMap(collection.GroupMapReduce(c, key, f, reduce))
+}
+
+// ScanLeft applies f to each element of the sequence, starting from init, and
+// returns a new sequence of the intermediate results, init included.
+//
+// example usage:
+//
+//	prices := NewSequence([]float64{1.5, 2, 0.5})
+//	ScanLeft(prices, 0, func(total float64, p float64) float64 { return total + p })
+//
+// output:
+//
+//	Seq(float64) [0 1.5 3.5 4]
+func ScanLeft[T, U any](c *Sequence[T], init U, f func(U, T) U) *Sequence[U] {
+	return NewSequence(collection.ScanLeft(c, f, init))
+}
+
+// ScanRight applies f to each element of the sequence from right to left,
+// starting from init, and returns a new sequence of the intermediate results
+// in the order of the elements they end with, init being last.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1, 2, 3})
+//	ScanRight(c, 1, func(product int, v int) int { return product * v })
+//
+// output:
+//
+//	Seq(int) [6 6 3 1]
+func ScanRight[T, U any](c *Sequence[T], init U, f func(U, T) U) *Sequence[U] {
+	return NewSequence(collection.ScanRight(c, f, init))
+}
@@ -0,0 +1,67 @@
+package sequence
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	c := NewSequence([]int{1, 2, 3, 4, 5, 6})
+	got := GroupBy(c, func(i int) int { return i % 2 })
+	if got.Length() != 2 {
+		t.Fatalf("GroupBy() = %v, want 2 groups", got)
+	}
+	for k, want := range map[int][]int{0: {2, 4, 6}, 1: {1, 3, 5}} {
+		if group, _ := got.Get(k); !slices.Equal(group.ToSlice(), want) {
+			t.Errorf("GroupBy()[%v] = %v, want %v", k, group, want)
+		}
+	}
+}
+
+func TestGroupMapAndReduce(t *testing.T) {
+	words := NewSequence([]string{"go", "gopher", "rust"})
+	first := func(w string) byte { return w[0] }
+	length := func(w string) int { return len(w) }
+	tests := []struct {
+		name    string
+		key     byte
+		want    []int
+		wantSum int
+	}{
+		{name: "two elements", key: 'g', want: []int{2, 6}, wantSum: 8},
+		{name: "one element", key: 'r', want: []int{4}, wantSum: 4},
+	}
+	grouped := GroupMap(words, first, length)
+	reduced := GroupMapReduce(words, first, length, func(a, b int) int { return a + b })
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if group, _ := grouped.Get(tt.key); !slices.Equal(group.ToSlice(), tt.want) {
+				t.Errorf("GroupMap()[%c] = %v, want %v", tt.key, group, tt.want)
+			}
+			if sum := reduced.GetOrElse(tt.key, -1); sum != tt.wantSum {
+				t.Errorf("GroupMapReduce()[%c] = %v, want %v", tt.key, sum, tt.wantSum)
+			}
+		})
+	}
+}
+
+func TestScanLeftAndRight(t *testing.T) {
+	c := NewSequence([]int{1, 2, 3})
+	tests := []struct {
+		name string
+		got  *Sequence[int]
+		want []int
+	}{
+		{name: "running totals", got: ScanLeft(c, 0, func(acc, v int) int { return acc + v }), want: []int{0, 1, 3, 6}},
+		{name: "suffix products", got: ScanRight(c, 1, func(acc, v int) int { return acc * v }), want: []int{6, 6, 3, 1}},
+		{name: "running maximum", got: c.ScanLeft(2, func(acc, v int) int { return max(acc, v) }), want: []int{2, 2, 2, 3}},
+		{name: "suffix sums", got: c.ScanRight(0, func(acc, v int) int { return acc + v }), want: []int{6, 5, 3, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.Equal(tt.got.ToSlice(), tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sequence
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// GobEncode encodes the elements of the sequence with encoding/gob, which
+// lets a sequence, or a struct holding one, be persisted with gob when its
+// element type is gob-encodable.
+//
+// example usage:
+//
+//	var buf bytes.Buffer
+//	err := gob.NewEncoder(&buf).Encode(NewSequence([]string{"go", "zig"}))
+func (c *Sequence[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(c.elements)
+	return buf.Bytes(), err
+}
+
+// GobDecode decodes elements encoded by GobEncode into the sequence,
+// replacing its contents.
+func (c *Sequence[T]) GobDecode(data []byte) error {
+	var s []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&s); err != nil {
+		return err
+	}
+	c.elements = s
+	return nil
+}
@@ -0,0 +1,50 @@
+package sequence
+
+import (
+	"bytes"
+	"encoding/gob"
+	"slices"
+	"testing"
+)
+
+func TestSequence_Gob(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  *Sequence[string]
+	}{
+		{name: "non-empty sequence", seq: NewSequence([]string{"go", "zig"})},
+		{name: "empty sequence", seq: NewSequence[string]()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(tt.seq); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			decoded := NewSequence([]string{"stale"})
+			if err := gob.NewDecoder(&buf).Decode(decoded); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if !slices.Equal(decoded.ToSlice(), tt.seq.ToSlice()) {
+				t.Errorf("Decode() = %v, want %v", decoded, tt.seq)
+			}
+		})
+	}
+}
+
+func TestComparableSequence_Gob(t *testing.T) {
+	type report struct {
+		Scores *ComparableSequence[int]
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(report{Scores: NewComparableSequence([]int{3, 1, 2})}); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	var out report
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got := out.Scores.ToSlice(); !slices.Equal(got, []int{3, 1, 2}) {
+		t.Errorf("Decode() = %v, want [3 1 2]", got)
+	}
+}
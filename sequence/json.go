@@ -0,0 +1,33 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sequence
+
+import "encoding/json"
+
+// MarshalJSON encodes the sequence as a JSON array.
+//
+// example usage:
+//
+//	json.Marshal(NewSequence([]string{"go", "zig"}))
+//
+// output:
+//
+//	["go","zig"]
+func (c *Sequence[T]) MarshalJSON() ([]byte, error) {
+	if c.elements == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(c.elements)
+}
+
+// UnmarshalJSON decodes a JSON array into the sequence, replacing its contents.
+func (c *Sequence[T]) UnmarshalJSON(data []byte) error {
+	var s []T
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	c.elements = s
+	return nil
+}
@@ -0,0 +1,45 @@
+package sequence
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestSequence_JSON(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  *Sequence[string]
+		want string
+	}{
+		{name: "non-empty sequence", seq: NewSequence([]string{"go", "zig"}), want: `["go","zig"]`},
+		{name: "empty sequence", seq: NewSequence[string](), want: "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.seq)
+			if err != nil || string(data) != tt.want {
+				t.Fatalf("Marshal() = %s, %v, want %s", data, err, tt.want)
+			}
+			decoded := NewSequence([]string{"stale"})
+			if err := json.Unmarshal(data, decoded); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !slices.Equal(decoded.ToSlice(), tt.seq.ToSlice()) {
+				t.Errorf("Unmarshal() = %v, want %v", decoded, tt.seq)
+			}
+		})
+	}
+}
+
+func TestComparableSequence_JSON(t *testing.T) {
+	var c struct {
+		Scores *ComparableSequence[int] `json:"scores"`
+	}
+	if err := json.Unmarshal([]byte(`{"scores":[3,1,2]}`), &c); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if c.Scores.Max() != 3 || c.Scores.Sum() != 6 {
+		t.Errorf("Unmarshal() = %v, want [3 1 2]", c.Scores)
+	}
+}
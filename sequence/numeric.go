@@ -0,0 +1,161 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// numeric.go defines transforms for sequences of numeric values.
+// Transforms that change the element type are defined as functions.
+
+package sequence
+
+import (
+	"math"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/stats"
+)
+
+// Clamp returns a new sequence with every element limited to the range [lo, hi].
+//
+// example usage:
+//
+//	c := NewComparableSequence([]int{-5,0,5,10,15})
+//	c.Clamp(0, 10)
+//
+// output:
+//
+//	[0,0,5,10,10]
+func (c *ComparableSequence[T]) Clamp(lo, hi T) *ComparableSequence[T] {
+	result := make([]T, len(c.elements))
+	for i, v := range c.elements {
+		result[i] = min(max(v, lo), hi)
+	}
+	return NewComparableSequence(result)
+}
+
+// NormalizeMinMax returns a new sequence with the values linearly mapped
+// to the range [0, 1] using min-max normalization.
+// If all values are equal, every element maps to 0.
+//
+// example usage:
+//
+//	c := NewComparableSequence([]int{10,15,20})
+//	NormalizeMinMax(c)
+//
+// output:
+//
+//	[0,0.5,1]
+func NormalizeMinMax[T collection.Number](c *ComparableSequence[T]) *ComparableSequence[float64] {
+	return Rescale(c, 0, 1)
+}
+
+// NormalizeZScore returns a new sequence containing the z-score of each value,
+// i.e. the number of standard deviations it lies away from the mean.
+// If the standard deviation is 0, every element maps to 0.
+//
+// example usage:
+//
+//	c := NewComparableSequence([]int{2,4,4,4,5,5,7,9})
+//	NormalizeZScore(c)
+//
+// output:
+//
+//	[-1.5,-0.5,-0.5,-0.5,0,0,1,2]
+func NormalizeZScore[T collection.Number](c *ComparableSequence[T]) *ComparableSequence[float64] {
+	result := make([]float64, len(c.elements))
+	mean, err := stats.Mean(c.elements)
+	if err != nil {
+		return NewComparableSequence(result)
+	}
+	stddev, _ := stats.StdDev(c.elements)
+	if stddev == 0 {
+		return NewComparableSequence(result)
+	}
+	for i, v := range c.elements {
+		result[i] = (float64(v) - mean) / stddev
+	}
+	return NewComparableSequence(result)
+}
+
+// Rescale returns a new sequence with the values linearly mapped to the range [lo, hi].
+// If all values are equal, every element maps to lo.
+//
+// example usage:
+//
+//	c := NewComparableSequence([]int{0,5,10})
+//	Rescale(c, -1, 1)
+//
+// output:
+//
+//	[-1,0,1]
+func Rescale[T collection.Number](c *ComparableSequence[T], lo, hi float64) *ComparableSequence[float64] {
+	result := make([]float64, len(c.elements))
+	minValue, maxValue, err := stats.MinMax(c.elements)
+	if err != nil {
+		return NewComparableSequence(result)
+	}
+	span := float64(maxValue) - float64(minValue)
+	for i, v := range c.elements {
+		if span == 0 {
+			result[i] = lo
+			continue
+		}
+		result[i] = lo + (float64(v)-float64(minValue))/span*(hi-lo)
+	}
+	return NewComparableSequence(result)
+}
+
+// FilterOutliersIQR partitions the sequence using Tukey's fences: values lying
+// more than k interquartile ranges below the first quartile or above the third
+// quartile are considered outliers. The conventional value for k is 1.5.
+// It returns the kept values and the removed outliers, in their original order.
+//
+// example usage:
+//
+//	c := NewComparableSequence([]int{1,2,3,4,5,100})
+//	FilterOutliersIQR(c, 1.5)
+//
+// output:
+//
+//	[1,2,3,4,5], [100]
+func FilterOutliersIQR[T collection.Number](c *ComparableSequence[T], k float64) (*ComparableSequence[T], *ComparableSequence[T]) {
+	if c.IsEmpty() {
+		return NewComparableSequence[T](), NewComparableSequence[T]()
+	}
+	q1, _ := stats.Quantile(c.elements, 0.25)
+	q3, _ := stats.Quantile(c.elements, 0.75)
+	iqr := q3 - q1
+	lo, hi := q1-k*iqr, q3+k*iqr
+	return partitionNumeric(c, func(v T) bool {
+		return float64(v) >= lo && float64(v) <= hi
+	})
+}
+
+// FilterOutliersZScore partitions the sequence by z-score: values lying more than
+// threshold standard deviations away from the mean are considered outliers.
+// It returns the kept values and the removed outliers, in their original order.
+//
+// example usage:
+//
+//	c := NewComparableSequence([]int{10,11,9,10,12,10,50})
+//	FilterOutliersZScore(c, 2)
+//
+// output:
+//
+//	[10,11,9,10,12,10], [50]
+func FilterOutliersZScore[T collection.Number](c *ComparableSequence[T], threshold float64) (*ComparableSequence[T], *ComparableSequence[T]) {
+	if c.IsEmpty() {
+		return NewComparableSequence[T](), NewComparableSequence[T]()
+	}
+	mean, _ := stats.Mean(c.elements)
+	stddev, _ := stats.StdDev(c.elements)
+	if stddev == 0 {
+		return c.Clone(), NewComparableSequence[T]()
+	}
+	return partitionNumeric(c, func(v T) bool {
+		return math.Abs(float64(v)-mean)/stddev <= threshold
+	})
+}
+
+func partitionNumeric[T collection.Number](c *ComparableSequence[T], keep func(T) bool) (*ComparableSequence[T], *ComparableSequence[T]) {
+	return collection.PartitionInto(c, NewComparableSequence[T](), NewComparableSequence[T](), keep)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// numeric_fast.go implements specialized loops for sequences of int64 and
+// float64, selected automatically by the ComparableSequence methods.
+// The loops are unrolled over independent accumulators so the compiler can
+// keep them in registers and the CPU can execute them in parallel. Sums of
+// float64 are not unrolled: reassociating the additions would change their
+// rounding.
+
+package sequence
+
+import "cmp"
+
+// sumFast returns the sum of s and true if T has a specialized implementation.
+func sumFast[T cmp.Ordered](s []T) (T, bool) {
+	switch e := any(s).(type) {
+	case []int64:
+		return any(sumInt64(e)).(T), true
+	}
+	return *new(T), false
+}
+
+// minFast returns the minimum of a non-empty s and true if T has a specialized implementation.
+func minFast[T cmp.Ordered](s []T) (T, bool) {
+	switch e := any(s).(type) {
+	case []int64:
+		return any(minOf(e)).(T), true
+	case []float64:
+		return any(minOf(e)).(T), true
+	}
+	return *new(T), false
+}
+
+// maxFast returns the maximum of a non-empty s and true if T has a specialized implementation.
+func maxFast[T cmp.Ordered](s []T) (T, bool) {
+	switch e := any(s).(type) {
+	case []int64:
+		return any(maxOf(e)).(T), true
+	case []float64:
+		return any(maxOf(e)).(T), true
+	}
+	return *new(T), false
+}
+
+// containsFast reports whether s contains v, and true if T has a specialized implementation.
+func containsFast[T cmp.Ordered](s []T, v T) (bool, bool) {
+	switch e := any(s).(type) {
+	case []int64:
+		return containsOf(e, any(v).(int64)), true
+	case []float64:
+		return containsOf(e, any(v).(float64)), true
+	}
+	return false, false
+}
+
+func sumInt64(s []int64) int64 {
+	var s0, s1, s2, s3 int64
+	i := 0
+	for ; i+4 <= len(s); i += 4 {
+		s0 += s[i]
+		s1 += s[i+1]
+		s2 += s[i+2]
+		s3 += s[i+3]
+	}
+	for ; i < len(s); i++ {
+		s0 += s[i]
+	}
+	return s0 + s1 + s2 + s3
+}
+
+// minOf relies on the builtin min, which propagates NaN and orders -0 before +0
+// exactly like slices.Min.
+func minOf[T int64 | float64](s []T) T {
+	m0, m1, m2, m3 := s[0], s[0], s[0], s[0]
+	i := 1
+	for ; i+4 <= len(s); i += 4 {
+		m0 = min(m0, s[i])
+		m1 = min(m1, s[i+1])
+		m2 = min(m2, s[i+2])
+		m3 = min(m3, s[i+3])
+	}
+	for ; i < len(s); i++ {
+		m0 = min(m0, s[i])
+	}
+	return min(m0, m1, m2, m3)
+}
+
+func maxOf[T int64 | float64](s []T) T {
+	m0, m1, m2, m3 := s[0], s[0], s[0], s[0]
+	i := 1
+	for ; i+4 <= len(s); i += 4 {
+		m0 = max(m0, s[i])
+		m1 = max(m1, s[i+1])
+		m2 = max(m2, s[i+2])
+		m3 = max(m3, s[i+3])
+	}
+	for ; i < len(s); i++ {
+		m0 = max(m0, s[i])
+	}
+	return max(m0, m1, m2, m3)
+}
+
+func containsOf[T int64 | float64](s []T, v T) bool {
+	i := 0
+	for ; i+4 <= len(s); i += 4 {
+		if s[i] == v || s[i+1] == v || s[i+2] == v || s[i+3] == v {
+			return true
+		}
+	}
+	for ; i < len(s); i++ {
+		if s[i] == v {
+			return true
+		}
+	}
+	return false
+}
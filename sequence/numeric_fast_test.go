@@ -0,0 +1,120 @@
+package sequence
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestComparableSequence_FastPathsInt64(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int64
+	}{
+		{name: "single element", input: []int64{7}},
+		{name: "unrolled length", input: []int64{4, -2, 9, 1, 3, 8, -7, 5}},
+		{name: "with remainder", input: []int64{4, -2, 9, 1, 3, 8, -7, 5, 11, -12, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewComparableSequence(tt.input)
+			var sum int64
+			for _, v := range tt.input {
+				sum += v
+			}
+			if got := c.Sum(); got != sum {
+				t.Errorf("Sum() = %v, want %v", got, sum)
+			}
+			if got := c.Min(); got != slices.Min(tt.input) {
+				t.Errorf("Min() = %v, want %v", got, slices.Min(tt.input))
+			}
+			if got := c.Max(); got != slices.Max(tt.input) {
+				t.Errorf("Max() = %v, want %v", got, slices.Max(tt.input))
+			}
+			for _, v := range tt.input {
+				if !c.Contains(v) {
+					t.Errorf("Contains(%v) = false, want true", v)
+				}
+			}
+			if c.Contains(100) {
+				t.Errorf("Contains(100) = true, want false")
+			}
+		})
+	}
+}
+
+func TestComparableSequence_FastPathsFloat64(t *testing.T) {
+	c := NewComparableSequence([]float64{1.5, -2, 3.25, 0, 8, 2.5})
+	if got := c.Sum(); got != 13.25 {
+		t.Errorf("Sum() = %v, want 13.25", got)
+	}
+	if got := c.Min(); got != -2 {
+		t.Errorf("Min() = %v, want -2", got)
+	}
+	if got := c.Max(); got != 8 {
+		t.Errorf("Max() = %v, want 8", got)
+	}
+	if !c.Contains(3.25) || c.Contains(3) {
+		t.Errorf("Contains() returned unexpected results")
+	}
+	rounding := []float64{1e16, 1, 1, 1, -1e16, 1, 1, 1, 0.1}
+	var sum float64
+	for _, v := range rounding {
+		sum += v
+	}
+	if got := NewComparableSequence(rounding).Sum(); got != sum {
+		t.Errorf("Sum() = %v, want %v as summed from left to right", got, sum)
+	}
+	nan := NewComparableSequence([]float64{1, 2, math.NaN(), 4, 5})
+	if !math.IsNaN(nan.Min()) || !math.IsNaN(nan.Max()) {
+		t.Errorf("Min()/Max() = %v/%v, want NaN like slices.Min/Max", nan.Min(), nan.Max())
+	}
+}
+
+func TestComparableSequence_FastPathsEmpty(t *testing.T) {
+	c := NewComparableSequence[int64]()
+	if c.Sum() != 0 {
+		t.Errorf("Sum() = %v, want 0", c.Sum())
+	}
+	if c.Contains(0) {
+		t.Errorf("Contains(0) = true, want false")
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Max() on an empty sequence did not panic")
+		}
+	}()
+	c.Max()
+}
+
+func benchmarkInt64s() *ComparableSequence[int64] {
+	s := make([]int64, 1<<16)
+	for i := range s {
+		s[i] = int64(i*7919) % 1000
+	}
+	return NewComparableSequence(s)
+}
+
+func BenchmarkComparableSequence_SumInt64(b *testing.B) {
+	c := benchmarkInt64s()
+	b.ResetTimer()
+	for range b.N {
+		c.Sum()
+	}
+}
+
+func BenchmarkComparableSequence_MaxInt64(b *testing.B) {
+	c := benchmarkInt64s()
+	b.ResetTimer()
+	for range b.N {
+		c.Max()
+	}
+}
+
+func BenchmarkComparableSequence_ContainsInt64(b *testing.B) {
+	c := benchmarkInt64s()
+	b.ResetTimer()
+	for range b.N {
+		c.Contains(-1)
+	}
+}
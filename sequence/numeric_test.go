@@ -0,0 +1,154 @@
+package sequence
+
+import (
+	"math"
+	"testing"
+)
+
+func floatsAlmostEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestComparableSequence_Clamp(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		lo    int
+		hi    int
+		want  []int
+	}{
+		{name: "clamp both ends", input: []int{-5, 0, 5, 10, 15}, lo: 0, hi: 10, want: []int{0, 0, 5, 10, 10}},
+		{name: "already in range", input: []int{1, 2, 3}, lo: 0, hi: 10, want: []int{1, 2, 3}},
+		{name: "empty", input: []int{}, lo: 0, hi: 10, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewComparableSequence(tt.input)
+			got := c.Clamp(tt.lo, tt.hi)
+			if !got.Equals(NewComparableSequence(tt.want)) {
+				t.Errorf("Clamp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeMinMax(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		want  []float64
+	}{
+		{name: "normalize", input: []int{10, 15, 20}, want: []float64{0, 0.5, 1}},
+		{name: "constant values", input: []int{4, 4}, want: []float64{0, 0}},
+		{name: "empty", input: []int{}, want: []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeMinMax(NewComparableSequence(tt.input)).ToSlice()
+			if !floatsAlmostEqual(got, tt.want) {
+				t.Errorf("NormalizeMinMax() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeZScore(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		want  []float64
+	}{
+		{name: "z-score", input: []int{2, 4, 4, 4, 5, 5, 7, 9}, want: []float64{-1.5, -0.5, -0.5, -0.5, 0, 0, 1, 2}},
+		{name: "constant values", input: []int{3, 3, 3}, want: []float64{0, 0, 0}},
+		{name: "empty", input: []int{}, want: []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeZScore(NewComparableSequence(tt.input)).ToSlice()
+			if !floatsAlmostEqual(got, tt.want) {
+				t.Errorf("NormalizeZScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRescale(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []float64
+		lo    float64
+		hi    float64
+		want  []float64
+	}{
+		{name: "rescale", input: []float64{0, 5, 10}, lo: -1, hi: 1, want: []float64{-1, 0, 1}},
+		{name: "inverted range", input: []float64{0, 5, 10}, lo: 1, hi: 0, want: []float64{1, 0.5, 0}},
+		{name: "constant values", input: []float64{2, 2}, lo: 5, hi: 6, want: []float64{5, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Rescale(NewComparableSequence(tt.input), tt.lo, tt.hi).ToSlice()
+			if !floatsAlmostEqual(got, tt.want) {
+				t.Errorf("Rescale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterOutliersIQR(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []int
+		k       float64
+		kept    []int
+		removed []int
+	}{
+		{name: "high outlier", input: []int{1, 2, 3, 4, 5, 100}, k: 1.5, kept: []int{1, 2, 3, 4, 5}, removed: []int{100}},
+		{name: "both ends", input: []int{-50, 10, 11, 12, 13, 14, 80}, k: 1.5, kept: []int{10, 11, 12, 13, 14}, removed: []int{-50, 80}},
+		{name: "no outliers", input: []int{1, 2, 3}, k: 1.5, kept: []int{1, 2, 3}, removed: []int{}},
+		{name: "empty", input: []int{}, k: 1.5, kept: []int{}, removed: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, removed := FilterOutliersIQR(NewComparableSequence(tt.input), tt.k)
+			if !kept.Equals(NewComparableSequence(tt.kept)) {
+				t.Errorf("FilterOutliersIQR() kept = %v, want %v", kept, tt.kept)
+			}
+			if !removed.Equals(NewComparableSequence(tt.removed)) {
+				t.Errorf("FilterOutliersIQR() removed = %v, want %v", removed, tt.removed)
+			}
+		})
+	}
+}
+
+func TestFilterOutliersZScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []int
+		threshold float64
+		kept      []int
+		removed   []int
+	}{
+		{name: "single outlier", input: []int{10, 11, 9, 10, 12, 10, 50}, threshold: 2, kept: []int{10, 11, 9, 10, 12, 10}, removed: []int{50}},
+		{name: "constant values", input: []int{5, 5, 5}, threshold: 1, kept: []int{5, 5, 5}, removed: []int{}},
+		{name: "empty", input: []int{}, threshold: 1, kept: []int{}, removed: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, removed := FilterOutliersZScore(NewComparableSequence(tt.input), tt.threshold)
+			if !kept.Equals(NewComparableSequence(tt.kept)) {
+				t.Errorf("FilterOutliersZScore() kept = %v, want %v", kept, tt.kept)
+			}
+			if !removed.Equals(NewComparableSequence(tt.removed)) {
+				t.Errorf("FilterOutliersZScore() removed = %v, want %v", removed, tt.removed)
+			}
+		})
+	}
+}
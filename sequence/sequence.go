@@ -14,16 +14,22 @@
 package sequence
 
 import (
+	"context"
 	"fmt"
 	"iter"
-	"math/rand"
+	"math/rand/v2"
 	"slices"
 
 	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/seq"
 )
 
+// Sequence is an ordered collection backed by a Go slice. The zero value
+// is an empty sequence ready to use, and a nil *Sequence reads as an empty
+// sequence: Length, IsEmpty, NonEmpty, the iterators and ToSlice accept it.
 type Sequence[T any] struct {
 	elements []T
+	collection.Randomized
 }
 
 func NewSequence[T any](s ...[]T) *Sequence[T] {
@@ -34,6 +40,51 @@ func NewSequence[T any](s ...[]T) *Sequence[T] {
 	return &Sequence[T]{elements: slices.Concat(s...)}
 }
 
+// Tabulate returns a sequence of n elements, the element at index i being f(i).
+//
+// example usage:
+//
+//	Tabulate(4, func(i int) int { return i * i })
+//
+// output:
+//
+//	Seq(int) [0 1 4 9]
+func Tabulate[T any](n int, f func(i int) T) *Sequence[T] {
+	elements := make([]T, max(n, 0))
+	for i := range elements {
+		elements[i] = f(i)
+	}
+	return &Sequence[T]{elements: elements}
+}
+
+// Fill returns a sequence of n elements equal to v.
+//
+// example usage:
+//
+//	Fill(3, "go")
+//
+// output:
+//
+//	Seq(string) [go go go]
+func Fill[T any](n int, v T) *Sequence[T] {
+	return &Sequence[T]{elements: slices.Repeat([]T{v}, max(n, 0))}
+}
+
+// RangeSequence returns a sequence of the numbers from start up to end
+// excluded, separated by step, which may be negative to count down, see
+// collection.Range. It panics if step is 0.
+//
+// example usage:
+//
+//	RangeSequence(0, 10, 3)
+//
+// output:
+//
+//	Seq(int) [0 3 6 9]
+func RangeSequence[T collection.Number](start, end, step T) *Sequence[T] {
+	return &Sequence[T]{elements: slices.Collect(collection.Range(start, end, step))}
+}
+
 // The following methods implement
 // the Collection interface.
 
@@ -44,7 +95,7 @@ func (c *Sequence[T]) Add(v T) {
 
 // Length returns the number of elements in the sequence.
 func (c *Sequence[T]) Length() int {
-	return len(c.elements)
+	return len(c.items())
 }
 
 // New is a constructor for a generic sequence.
@@ -57,12 +108,12 @@ func (c *Sequence[T]) Random() T {
 	if len(c.elements) == 0 {
 		return *new(T)
 	}
-	return c.elements[rand.Intn(len(c.elements))]
+	return c.elements[c.RandState().IntN(len(c.elements))]
 }
 
 // Values returns an iterator over all values of the underlying slice.
 func (c *Sequence[T]) Values() iter.Seq[T] {
-	return slices.Values(c.elements)
+	return slices.Values(c.items())
 }
 
 // The following methods implement
@@ -78,18 +129,18 @@ func (c *Sequence[T]) At(index int) T {
 
 // All returns an iterator over all elements of the sequence.
 func (c *Sequence[T]) All() iter.Seq2[int, T] {
-	return slices.All(c.elements)
+	return slices.All(c.items())
 }
 
 // Backward returns an iterator over all elements of the sequence in reverse order.
 func (c *Sequence[T]) Backward() iter.Seq2[int, T] {
-	return slices.Backward(c.elements)
+	return slices.Backward(c.items())
 }
 
 // Slice returns a new sequence containing the elements from the start index to the end index.
 func (c *Sequence[T]) Slice(start, end int) collection.OrderedCollection[T] {
 	return &Sequence[T]{
-		c.elements[start:end],
+		elements: c.elements[start:end],
 	}
 }
 
@@ -110,10 +161,39 @@ func (c *Sequence[T]) Apply(f func(T) T) *Sequence[T] {
 // wrapping Collection functions to enable function chaining:
 // i.e. sequence.Filter(f).Take(n)
 
+// AtOrElse returns the element at the given index,
+// or fallback if the index is out of bounds.
+func (c *Sequence[T]) AtOrElse(index int, fallback T) T {
+	return collection.AtOrElse(c, index, fallback)
+}
+
+// AtOrErr returns the element at the given index.
+// If the index is out of bounds, it returns an error.
+func (c *Sequence[T]) AtOrErr(index int) (T, error) {
+	return collection.AtOrErr(c, index)
+}
+
+// BinarySearchFunc searches a sequence sorted in increasing order by cmp
+// for target in O(log n), and returns the position where target is found,
+// or would be inserted to keep the sequence sorted, and whether it was
+// found. See slices.BinarySearchFunc.
+//
+// example usage:
+//
+//	c := NewSequence([]string{"a", "bb", "ccc"})
+//	c.BinarySearchFunc("xx", func(a, b string) int { return len(a) - len(b) })
+//
+// output:
+//
+//	1, true
+func (c *Sequence[T]) BinarySearchFunc(target T, cmp func(T, T) int) (int, bool) {
+	return slices.BinarySearchFunc(c.items(), target, cmp)
+}
+
 // Clone returns a copy of the collection. This is a shallow clone.
 func (c *Sequence[T]) Clone() *Sequence[T] {
 	return &Sequence[T]{
-		slices.Clone(c.elements),
+		elements: slices.Clone(c.elements),
 	}
 }
 
@@ -124,11 +204,12 @@ func (c *Sequence[T]) Count(f func(T) bool) int {
 
 // Concat returns a new sequence concatenating the passed in sequences.
 func (c *Sequence[T]) Concat(sequences ...Sequence[T]) *Sequence[T] {
-	e := c.elements
+	e := make([][]T, 0, len(sequences)+1)
+	e = append(e, c.elements)
 	for _, col := range sequences {
-		e = slices.Concat(e, col.elements)
+		e = append(e, col.elements)
 	}
-	return &Sequence[T]{e}
+	return &Sequence[T]{elements: slices.Concat(e...)}
 }
 
 // Concatenated is an alias for collection.Concatenated
@@ -143,7 +224,7 @@ func (c *Sequence[T]) Contains(f func(T) bool) bool {
 }
 
 // Corresponds is an alias for collection.Corresponds
-func (c *Sequence[T]) Corresponds(s *Sequence[T], f func(T, T) bool) bool {
+func (c *Sequence[T]) Corresponds(s collection.OrderedCollection[T], f func(T, T) bool) bool {
 	return collection.Corresponds(c, s, f)
 }
 
@@ -157,9 +238,12 @@ func (c *Sequence[T]) Dequeue() (T, error) {
 	return element, nil
 }
 
-// Diff is an alias for collection.Diff
+// Diff returns a new sequence containing the elements of c that are not present in s,
+// using f as an equality function.
 func (c *Sequence[T]) Diff(s *Sequence[T], f func(T, T) bool) *Sequence[T] {
-	return collection.DiffFunc(c, s, f).(*Sequence[T])
+	return collection.FilterInto(c, NewSequence[T](), func(v T) bool {
+		return !s.Contains(func(t T) bool { return f(v, t) })
+	})
 }
 
 // Diffed is an alias for collection.Diffed
@@ -171,7 +255,7 @@ func (c *Sequence[T]) Diffed(s *Sequence[T], f func(T, T) bool) iter.Seq[T] {
 // and returns a new sequence containing all the unique elements
 // If you prefer not to pass an equality function use a ComparableSequence.
 func (c *Sequence[T]) Distinct(f func(T, T) bool) *Sequence[T] {
-	return collection.Distinct(c, f).(*Sequence[T])
+	return collection.DistinctInto(c, NewSequence[T](), f)
 }
 
 // DistinctIterator is an alias for collection.DistinctIterator
@@ -179,19 +263,24 @@ func (c *Sequence[T]) Distincted(f func(T, T) bool) iter.Seq[T] {
 	return collection.DistinctedFunc(c, f)
 }
 
-// Drop is an alias for collection.Drop
+// Drop returns a new sequence with the first n elements removed.
 func (c *Sequence[T]) Drop(n int) *Sequence[T] {
-	return collection.Drop(c, n).(*Sequence[T])
+	return c.slice(clamp(n, len(c.elements)), len(c.elements))
 }
 
-// DropWhile is an alias for collection.DropWhile
+// DropWhile returns a new sequence with the longest prefix of elements
+// satisfying the predicate removed.
 func (c *Sequence[T]) DropWhile(f func(T) bool) *Sequence[T] {
-	return collection.DropWhile(c, f).(*Sequence[T])
+	i := slices.IndexFunc(c.elements, func(v T) bool { return !f(v) })
+	if i == -1 {
+		i = len(c.elements)
+	}
+	return c.slice(i, len(c.elements))
 }
 
-// DropRight is an alias for collection.DropRight
+// DropRight returns a new sequence with the last n elements removed.
 func (c *Sequence[T]) DropRight(n int) *Sequence[T] {
-	return collection.DropRight(c, n).(*Sequence[T])
+	return c.slice(0, len(c.elements)-clamp(n, len(c.elements)))
 }
 
 // Enqueue appends an element to the sequence.
@@ -199,11 +288,14 @@ func (c *Sequence[T]) Enqueue(v T) {
 	c.elements = append(c.elements, v)
 }
 
-// Equals takes a sequence and an equality function as an argument
-// and returns true if the two sequences are equal.
+// Equals takes an ordered collection and an equality function as an argument
+// and returns true if the sequence holds the same elements in the same order.
 // If you prefer not to pass an equality function use a ComparableSequence.
-func (c *Sequence[T]) Equals(c2 *Sequence[T], f func(T, T) bool) bool {
-	return slices.EqualFunc(c.elements, c2.elements, f)
+func (c *Sequence[T]) Equals(c2 collection.OrderedCollection[T], f func(T, T) bool) bool {
+	if s, ok := c2.(*Sequence[T]); ok {
+		return slices.EqualFunc(c.elements, s.elements, f)
+	}
+	return collection.EqualFunc(c, c2, f)
 }
 
 // Exists is an alias for Contains
@@ -211,9 +303,9 @@ func (c *Sequence[T]) Exists(f func(T) bool) bool {
 	return c.Contains(f)
 }
 
-// Filter is an alias for collection.Filter
+// Filter returns a new sequence containing the elements that satisfy the predicate.
 func (c *Sequence[T]) Filter(f func(T) bool) *Sequence[T] {
-	return collection.Filter(c, f).(*Sequence[T])
+	return collection.FilterInto(c, NewSequence[T](), f)
 }
 
 // FilterIterator is an alias for collection.FilterIterator
@@ -221,9 +313,9 @@ func (c *Sequence[T]) Filtered(f func(T) bool) iter.Seq[T] {
 	return collection.Filtered(c, f)
 }
 
-// FilterNot is an alias for collection.FilterNot
+// FilterNot returns a new sequence containing the elements that do not satisfy the predicate.
 func (c *Sequence[T]) FilterNot(f func(T) bool) *Sequence[T] {
-	return collection.FilterNot(c, f).(*Sequence[T])
+	return collection.FilterInto(c, NewSequence[T](), func(v T) bool { return !f(v) })
 }
 
 // Find is an alias for collection.Find
@@ -241,19 +333,38 @@ func (c *Sequence[T]) ForAll(f func(T) bool) bool {
 	return collection.ForAll(c, f)
 }
 
+// Grouped returns an iterator over consecutive sequences of n elements,
+// the last sequence holding the remaining elements.
+// It panics if n is less than 1.
+func (c *Sequence[T]) Grouped(n int) iter.Seq[*Sequence[T]] {
+	return c.Sliding(n, n)
+}
+
 // Head is an alias for collection.Head
 func (c *Sequence[T]) Head() (T, error) {
 	return collection.Head(c)
 }
 
-// Init is an alias for collection.Init
+// IndexWhere is an alias for collection.IndexWhere
+func (c *Sequence[T]) IndexWhere(f func(T) bool, from int) int {
+	from = clamp(from, len(c.elements))
+	if i := slices.IndexFunc(c.elements[from:], f); i >= 0 {
+		return from + i
+	}
+	return -1
+}
+
+// Init returns a new sequence containing all elements excluding the last one.
 func (c *Sequence[T]) Init() *Sequence[T] {
-	return collection.Init(c).(*Sequence[T])
+	return c.slice(0, max(len(c.elements)-1, 0))
 }
 
-// Intersect is an alias for collection.Intersect
+// Intersect returns a new sequence containing the elements of c that are also present in s,
+// using f as an equality function.
 func (c *Sequence[T]) Intersect(s *Sequence[T], f func(T, T) bool) *Sequence[T] {
-	return collection.IntersectFunc(c, s, f).(*Sequence[T])
+	return collection.FilterInto(c, NewSequence[T](), func(v T) bool {
+		return s.Contains(func(t T) bool { return f(v, t) })
+	})
 }
 
 // IntersectIterator is an alias for collection.IntersectIterator
@@ -263,7 +374,7 @@ func (c *Sequence[T]) Intersected(s *Sequence[T], f func(T, T) bool) iter.Seq[T]
 
 // IsEmpty returns true if the sequence is empty.
 func (c *Sequence[T]) IsEmpty() bool {
-	return len(c.elements) == 0
+	return len(c.items()) == 0
 }
 
 // Last is an alias for collection.Last
@@ -271,9 +382,29 @@ func (c *Sequence[T]) Last() (T, error) {
 	return collection.Last(c)
 }
 
+// LastIndexWhere is an alias for collection.LastIndexWhere
+func (c *Sequence[T]) LastIndexWhere(f func(T) bool, end int) int {
+	for i := min(end, len(c.elements)-1); i >= 0; i-- {
+		if f(c.elements[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// MkString is an alias for collection.MkString
+func (c *Sequence[T]) MkString(sep string) string {
+	return collection.MkString(c, sep)
+}
+
+// MkStringf is an alias for collection.MkStringf
+func (c *Sequence[T]) MkStringf(prefix, sep, suffix, format string) string {
+	return collection.MkStringf(c, prefix, sep, suffix, format)
+}
+
 // returns true if the sequence is not empty.
 func (c *Sequence[T]) NonEmpty() bool {
-	return len(c.elements) > 0
+	return len(c.items()) > 0
 }
 
 // Pop removes and returns the last element of the sequence.
@@ -291,10 +422,15 @@ func (c *Sequence[T]) Push(v T) {
 	c.elements = append(c.elements, v)
 }
 
-// Partition is an alias for collection.Partition
+// Pairwise is an alias for collection.Pairwise
+func (c *Sequence[T]) Pairwise() iter.Seq2[T, T] {
+	return collection.Pairwise(c)
+}
+
+// Partition returns two new sequences, the first one containing the elements
+// that satisfy the predicate and the second one containing the rest.
 func (c *Sequence[T]) Partition(f func(T) bool) (*Sequence[T], *Sequence[T]) {
-	left, right := collection.Partition(c, f)
-	return left.(*Sequence[T]), right.(*Sequence[T])
+	return collection.PartitionInto(c, NewSequence[T](), NewSequence[T](), f)
 }
 
 // SplitAt splits the sequence at the given index.
@@ -304,14 +440,53 @@ func (c *Sequence[T]) SplitAt(n int) (*Sequence[T], *Sequence[T]) {
 	return left, right
 }
 
-// Reverse is an alias for collection.Reverse
+// Span returns two new sequences containing the longest prefix of elements
+// satisfying the predicate and the rest of the elements,
+// see collection.SpanWhile.
+func (c *Sequence[T]) Span(f func(T) bool) (*Sequence[T], *Sequence[T]) {
+	n := c.SegmentLength(f, 0)
+	return c.slice(0, n), c.slice(n, len(c.elements))
+}
+
+// SliceOrErr returns a new sequence containing the elements between the start
+// and end indices. If the range is out of bounds, it returns an error.
+func (c *Sequence[T]) SliceOrErr(start, end int) (*Sequence[T], error) {
+	if start < 0 || end > len(c.elements) || start > end {
+		return nil, collection.IndexOutOfBoundsError
+	}
+	return c.slice(start, end), nil
+}
+
+// ScanLeft is the same-type variant of the package function ScanLeft.
+func (c *Sequence[T]) ScanLeft(init T, f func(T, T) T) *Sequence[T] {
+	return ScanLeft(c, init, f)
+}
+
+// ScanRight is the same-type variant of the package function ScanRight.
+func (c *Sequence[T]) ScanRight(init T, f func(T, T) T) *Sequence[T] {
+	return ScanRight(c, init, f)
+}
+
+// SegmentLength is an alias for collection.SegmentLength
+func (c *Sequence[T]) SegmentLength(f func(T) bool, from int) int {
+	n := 0
+	for _, v := range c.elements[clamp(from, len(c.elements)):] {
+		if !f(v) {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// Reverse returns a new sequence with the elements in reverse order.
 func (c *Sequence[T]) Reverse() *Sequence[T] {
-	return collection.Reverse(c).(*Sequence[T])
+	return collection.ReverseInto(c, NewSequence[T]())
 }
 
-// Reject is an alias for collection.FilterNot
-func (l *Sequence[T]) Reject(f func(T) bool) *Sequence[T] {
-	return collection.FilterNot(l, f).(*Sequence[T])
+// Reject is an alias for FilterNot
+func (c *Sequence[T]) Reject(f func(T) bool) *Sequence[T] {
+	return c.FilterNot(f)
 }
 
 // Rejected is an alias for collection.Rejected
@@ -321,29 +496,121 @@ func (c *Sequence[T]) Rejected(f func(T) bool) iter.Seq[T] {
 
 // String implements the Stringer interface.
 func (c *Sequence[T]) String() string {
-	return fmt.Sprintf("Seq(%T) %v", *new(T), c.elements)
+	return fmt.Sprintf("Seq(%T) %v", *new(T), c.items())
 }
 
-// Take is an alias for collection.Take
+// Take returns a new sequence containing the first n elements.
 func (c *Sequence[T]) Take(n int) *Sequence[T] {
-	return collection.Take(c, n).(*Sequence[T])
+	return c.slice(0, clamp(n, len(c.elements)))
 }
 
-// TakeRight is an alias for collection.TakeRight
+// TakeRight returns a new sequence containing the last n elements.
 func (c *Sequence[T]) TakeRight(n int) *Sequence[T] {
-	return collection.TakeRight(c, n).(*Sequence[T])
+	return c.slice(len(c.elements)-clamp(n, len(c.elements)), len(c.elements))
+}
+
+// TakeWhile returns a new sequence containing the longest prefix of
+// elements satisfying the predicate.
+func (c *Sequence[T]) TakeWhile(f func(T) bool) *Sequence[T] {
+	return c.slice(0, c.SegmentLength(f, 0))
 }
 
-// Tail is an alias for collection.Tail
+// Tail returns a new sequence containing all elements excluding the first one.
 func (c *Sequence[T]) Tail() *Sequence[T] {
-	return collection.Tail(c).(*Sequence[T])
+	return c.slice(min(1, len(c.elements)), len(c.elements))
+}
+
+// ToChannel sends the elements of the sequence to the returned channel from
+// a new goroutine, see collection.ToChannel. The sequence must not be
+// modified until the channel is closed.
+func (c *Sequence[T]) ToChannel(ctx context.Context, opts ...collection.ChanOption) <-chan T {
+	return collection.ToChannel(ctx, c, opts...)
 }
 
 // ToSlice returns the underlying slice.
 func (c *Sequence[T]) ToSlice() []T {
-	return c.elements
+	return c.items()
+}
+
+// AppendToSlice appends all elements of the sequence to dst and returns the extended slice.
+// Unlike ToSlice, the result never shares memory with the sequence.
+func (c *Sequence[T]) AppendToSlice(dst []T) []T {
+	return append(slices.Grow(dst, len(c.items())), c.items()...)
 }
 
+// Shuffle returns a new sequence with the elements in a random order.
 func (c *Sequence[T]) Shuffle() *Sequence[T] {
-	return collection.Shuffle(c).(*Sequence[T])
+	return collection.ShuffleInto(c, NewSequence[T]())
+}
+
+// ShuffleWith is like Shuffle, drawing from r instead of the RandState of
+// the sequence, i.e. to shuffle deterministically in tests.
+func (c *Sequence[T]) ShuffleWith(r *rand.Rand) *Sequence[T] {
+	return collection.ShuffleIntoWith(c, NewSequence[T](), r)
+}
+
+// Sample returns a new sequence of n elements picked at random without
+// replacement, in a random order. If n is greater than the length of
+// the sequence, all the elements are returned shuffled.
+//
+// example usage:
+//
+//	c := NewSequence([]int{1,2,3,4,5})
+//	c.Sample(2)
+//
+// possible output:
+//
+//	[4,1]
+func (c *Sequence[T]) Sample(n int) *Sequence[T] {
+	return collection.SampleInto(c, NewSequence[T](), n)
+}
+
+// SampleWith is like Sample, drawing from r instead of the RandState of
+// the sequence, i.e. to sample deterministically in tests.
+//
+// example usage:
+//
+//	c.SampleWith(2, rand.New(rand.NewPCG(1, 2)))
+func (c *Sequence[T]) SampleWith(n int, r *rand.Rand) *Sequence[T] {
+	return collection.SampleIntoWith(c, NewSequence[T](), n, r)
+}
+
+// Sliding returns an iterator over windows of size consecutive elements,
+// each starting step elements after the previous one, as new sequences.
+// It panics if size or step is less than 1.
+//
+// example usage:
+//
+//	prices := NewSequence([]float64{1,2,3,4})
+//	for w := range prices.Sliding(2, 1) {
+//		fmt.Println(w)
+//	}
+//
+// output:
+//
+//	Seq(float64) [1 2]
+//	Seq(float64) [2 3]
+//	Seq(float64) [3 4]
+func (c *Sequence[T]) Sliding(size, step int) iter.Seq[*Sequence[T]] {
+	return seq.Map(seq.Sliding(slices.Values(c.elements), size, step), func(w []T) *Sequence[T] {
+		return &Sequence[T]{elements: w}
+	})
+}
+
+// items returns the elements of the sequence, or nil for a nil sequence.
+func (c *Sequence[T]) items() []T {
+	if c == nil {
+		return nil
+	}
+	return c.elements
+}
+
+// slice returns a new sequence holding a copy of the elements between start and end.
+func (c *Sequence[T]) slice(start, end int) *Sequence[T] {
+	return NewSequence(c.elements[start:end])
+}
+
+// clamp limits n to the range [0, size].
+func clamp(n, size int) int {
+	return min(max(n, 0), size)
 }
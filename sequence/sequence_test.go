@@ -1,9 +1,12 @@
 package sequence
 
 import (
+	"math/rand/v2"
 	"reflect"
 	"slices"
 	"testing"
+
+	"github.com/charbz/gophers/collection"
 )
 
 func TestConcat(t *testing.T) {
@@ -212,6 +215,44 @@ func TestSequence_Drop(t *testing.T) {
 	}
 }
 
+func TestSequence_Span(t *testing.T) {
+	isLessThan3 := func(i int) bool { return i < 3 }
+	tests := []struct {
+		name       string
+		slice      []int
+		wantPrefix []int
+		wantRest   []int
+		wantIndex  int // IndexWhere from 1
+		wantLast   int // LastIndexWhere to 2
+	}{
+		{name: "prefix and rest", slice: []int{1, 2, 3, 4, 1}, wantPrefix: []int{1, 2}, wantRest: []int{3, 4, 1}, wantIndex: 1, wantLast: 1},
+		{name: "no prefix", slice: []int{5, 6, 1}, wantPrefix: nil, wantRest: []int{5, 6, 1}, wantIndex: 2, wantLast: 2},
+		{name: "empty", slice: nil, wantPrefix: nil, wantRest: nil, wantIndex: -1, wantLast: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewSequence(tt.slice)
+			prefix, rest := c.Span(isLessThan3)
+			if !slices.Equal(prefix.ToSlice(), tt.wantPrefix) || !slices.Equal(rest.ToSlice(), tt.wantRest) {
+				t.Errorf("Span() = %v, %v, want %v, %v", prefix, rest, tt.wantPrefix, tt.wantRest)
+			}
+			if got := c.TakeWhile(isLessThan3).ToSlice(); !slices.Equal(got, tt.wantPrefix) {
+				t.Errorf("TakeWhile() = %v, want %v", got, tt.wantPrefix)
+			}
+			if got := c.SegmentLength(isLessThan3, 0); got != len(tt.wantPrefix) {
+				t.Errorf("SegmentLength() = %v, want %v", got, len(tt.wantPrefix))
+			}
+			if got := c.IndexWhere(isLessThan3, 1); got != tt.wantIndex {
+				t.Errorf("IndexWhere() = %v, want %v", got, tt.wantIndex)
+			}
+			if got := c.LastIndexWhere(isLessThan3, 2); got != tt.wantLast {
+				t.Errorf("LastIndexWhere() = %v, want %v", got, tt.wantLast)
+			}
+		})
+	}
+}
+
 func TestSequence_Filter(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -711,6 +752,23 @@ func TestSequence_Shuffle(t *testing.T) {
 	}
 }
 
+func TestSequence_Sample(t *testing.T) {
+	seq := NewSequence([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	a := seq.SampleWith(3, rand.New(rand.NewPCG(3, 4)))
+	b := seq.SampleWith(3, rand.New(rand.NewPCG(3, 4)))
+	if a.Length() != 3 || !slices.Equal(a.ToSlice(), b.ToSlice()) {
+		t.Errorf("SampleWith(3) with the same seed = %v and %v", a, b)
+	}
+	if got := seq.Sample(10); !slices.Equal(slices.Sorted(got.Values()), seq.ToSlice()) {
+		t.Errorf("Sample(10) = %v, not a permutation of %v", got, seq)
+	}
+	c := seq.ShuffleWith(rand.New(rand.NewPCG(3, 4)))
+	d := seq.ShuffleWith(rand.New(rand.NewPCG(3, 4)))
+	if !slices.Equal(c.ToSlice(), d.ToSlice()) {
+		t.Errorf("ShuffleWith() with the same seed = %v and %v", c, d)
+	}
+}
+
 func TestSequence_ShuffleRandomization(t *testing.T) {
 	input := []int{1, 2, 3, 4, 5, 6}
 	seq := NewSequence(input)
@@ -761,3 +819,162 @@ func TestSequence_ShuffleDistribution(t *testing.T) {
 		}
 	}
 }
+
+func TestSequence_IteratorAllocs(t *testing.T) {
+	small := NewSequence([]int{1})
+	large := NewSequence(make([]int, 1000))
+	count := func(c *Sequence[int]) func() {
+		return func() {
+			n := 0
+			for range c.Values() {
+				n++
+			}
+			for range c.All() {
+				n++
+			}
+			for range c.Backward() {
+				n++
+			}
+		}
+	}
+	if s, l := testing.AllocsPerRun(100, count(small)), testing.AllocsPerRun(100, count(large)); l > s {
+		t.Errorf("iterating 1000 elements allocated %v times, 1 element allocated %v times", l, s)
+	}
+}
+
+func BenchmarkSequence_Values(b *testing.B) {
+	c := NewSequence(make([]int, 1<<12))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		sum := 0
+		for v := range c.Values() {
+			sum += v
+		}
+	}
+}
+
+func TestSequence_AppendToSlice(t *testing.T) {
+	c := NewSequence([]int{1, 2, 3})
+	got := c.AppendToSlice([]int{0})
+	if !slices.Equal(got, []int{0, 1, 2, 3}) {
+		t.Errorf("AppendToSlice() = %v, want [0 1 2 3]", got)
+	}
+	got = c.AppendToSlice(nil)
+	got[0] = 42
+	if c.At(0) != 1 {
+		t.Errorf("AppendToSlice() shares memory with the sequence")
+	}
+}
+
+func TestSequence_ConcatDoesNotAlias(t *testing.T) {
+	c := NewSequence([]int{1, 2, 3})
+	r := c.Concat(*NewSequence([]int{4}))
+	r.Apply(func(i int) int { return i * 10 })
+	if !slices.Equal(c.ToSlice(), []int{1, 2, 3}) {
+		t.Errorf("Concat() result aliases the original sequence: %v", c)
+	}
+}
+
+func TestSequence_Pairwise(t *testing.T) {
+	var deltas []int
+	for prev, curr := range NewSequence([]int{10, 12, 11}).Pairwise() {
+		deltas = append(deltas, curr-prev)
+	}
+	if !slices.Equal(deltas, []int{2, -1}) {
+		t.Errorf("Pairwise() deltas = %v, want [2 -1]", deltas)
+	}
+}
+
+func TestSequence_SlidingAndGrouped(t *testing.T) {
+	c := NewSequence([]float64{1, 2, 3, 4, 5})
+	var averages []float64
+	for w := range c.Sliding(3, 1) {
+		averages = append(averages, collection.Reduce(w, func(acc, v float64) float64 { return acc + v }, 0)/3)
+	}
+	if !slices.Equal(averages, []float64{2, 3, 4}) {
+		t.Errorf("moving averages = %v, want [2 3 4]", averages)
+	}
+	var chunks []string
+	for chunk := range c.Grouped(2) {
+		chunks = append(chunks, chunk.String())
+	}
+	if want := []string{"Seq(float64) [1 2]", "Seq(float64) [3 4]", "Seq(float64) [5]"}; !slices.Equal(chunks, want) {
+		t.Errorf("Grouped() = %v, want %v", chunks, want)
+	}
+}
+
+func TestSequence_OrErrAccessors(t *testing.T) {
+	c := NewSequence([]string{"a", "b"})
+	if v, err := c.AtOrErr(1); err != nil || v != "b" {
+		t.Errorf("AtOrErr(1) = %v, %v, want b, nil", v, err)
+	}
+	if _, err := c.AtOrErr(2); err != collection.IndexOutOfBoundsError {
+		t.Errorf("AtOrErr(2) error = %v, want %v", err, collection.IndexOutOfBoundsError)
+	}
+	if v := c.AtOrElse(2, "?"); v != "?" {
+		t.Errorf("AtOrElse(2) = %v, want ?", v)
+	}
+	if s, err := c.SliceOrErr(0, 1); err != nil || !slices.Equal(s.ToSlice(), []string{"a"}) {
+		t.Errorf("SliceOrErr(0, 1) = %v, %v, want [a], nil", s, err)
+	}
+	if _, err := c.SliceOrErr(-1, 1); err != collection.IndexOutOfBoundsError {
+		t.Errorf("SliceOrErr(-1, 1) error = %v, want %v", err, collection.IndexOutOfBoundsError)
+	}
+}
+
+func TestSequence_ZeroValue(t *testing.T) {
+	var c Sequence[int]
+	if c.Length() != 0 || !c.IsEmpty() {
+		t.Errorf("zero value = %v, want an empty sequence", &c)
+	}
+	c.Add(1)
+	c.Push(2)
+	if got := c.ToSlice(); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("ToSlice() = %v, want [1 2]", got)
+	}
+}
+
+func TestSequence_NilReceiver(t *testing.T) {
+	var c *Sequence[int]
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "length", got: c.Length(), want: 0},
+		{name: "is empty", got: c.IsEmpty(), want: true},
+		{name: "non empty", got: c.NonEmpty(), want: false},
+		{name: "values", got: len(slices.Collect(c.Values())), want: 0},
+		{name: "to slice", got: len(c.ToSlice()), want: 0},
+		{name: "string", got: c.String(), want: "Seq(int) []"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSequence_Constructors(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  *Sequence[float64]
+		want []float64
+	}{
+		{name: "Tabulate", seq: Tabulate(3, func(i int) float64 { return float64(i) / 2 }), want: []float64{0, 0.5, 1}},
+		{name: "Tabulate negative", seq: Tabulate(-2, func(i int) float64 { return 1 }), want: []float64{}},
+		{name: "Fill", seq: Fill(2, 1.5), want: []float64{1.5, 1.5}},
+		{name: "RangeSequence", seq: RangeSequence(0, 1, 0.25), want: []float64{0, 0.25, 0.5, 0.75}},
+		{name: "RangeSequence empty", seq: RangeSequence(1, 0, 0.25), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.seq.ToSlice(); !slices.Equal(got, tt.want) || tt.seq.Length() != len(tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
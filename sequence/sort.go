@@ -0,0 +1,181 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package sequence
+
+import (
+	"cmp"
+	"context"
+	"runtime"
+	"slices"
+	"sync"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// parallelSortThreshold is the minimum number of elements
+// for which a sort is split across multiple goroutines.
+const parallelSortThreshold = 1 << 16
+
+// SortFunc sorts the sequence in place, in ascending order as determined by the
+// cmp function, which must return a negative number when a < b, a positive
+// number when a > b, and zero when a == b. The sort is not guaranteed to be stable.
+//
+// Sequences larger than an internal threshold are split into chunks that are
+// sorted concurrently (one goroutine per available CPU) and merged pairwise.
+//
+// example usage:
+//
+//	c := NewSequence([]string{"ccc","a","bb"})
+//	c.SortFunc(func(a, b string) int { return len(a) - len(b) })
+//
+// output:
+//
+//	[a,bb,ccc]
+func (c *Sequence[T]) SortFunc(cmp func(a, b T) int) *Sequence[T] {
+	parallelSortFunc(c.elements, cmp, runtime.GOMAXPROCS(0))
+	return c
+}
+
+// SortFuncContext is similar to SortFunc but reports a "sequence.SortFunc"
+// span to the tracer of the context, see collection.WithTracer, with the
+// number of elements and whether the sort ran in parallel as attributes.
+func (c *Sequence[T]) SortFuncContext(ctx context.Context, cmp func(a, b T) int) *Sequence[T] {
+	_, span := collection.StartSpan(ctx, "sequence.SortFunc")
+	workers := runtime.GOMAXPROCS(0)
+	span.SetAttributes(
+		collection.Attribute{Key: "length", Value: len(c.elements)},
+		collection.Attribute{Key: "parallel", Value: len(c.elements) >= parallelSortThreshold && workers > 1},
+	)
+	parallelSortFunc(c.elements, cmp, workers)
+	span.End(nil)
+	return c
+}
+
+// SortStableFunc sorts the sequence in place, in ascending order as
+// determined by the cmp function, keeping the original order of equal elements.
+// Unlike SortFunc, it always runs on the calling goroutine.
+func (c *Sequence[T]) SortStableFunc(cmp func(a, b T) int) *Sequence[T] {
+	slices.SortStableFunc(c.elements, cmp)
+	return c
+}
+
+// SortBy returns a new sequence with the elements sorted by the less function,
+// keeping the original order of equal elements.
+//
+// example usage:
+//
+//	c := NewSequence([]string{"ccc","a","bb","b"})
+//	c.SortBy(func(a, b string) bool { return len(a) < len(b) })
+//
+// output:
+//
+//	[a,b,bb,ccc]
+func (c *Sequence[T]) SortBy(less func(a, b T) bool) *Sequence[T] {
+	return c.Clone().SortStableFunc(compareFunc(less))
+}
+
+// Sort sorts the sequence in place in ascending order.
+// Large sequences are sorted in parallel, see Sequence.SortFunc.
+func (c *ComparableSequence[T]) Sort() *ComparableSequence[T] {
+	parallelSortFunc(c.elements, cmp.Compare[T], runtime.GOMAXPROCS(0))
+	return c
+}
+
+// SortContext is similar to Sort but reports a span to the tracer
+// of the context, see Sequence.SortFuncContext.
+func (c *ComparableSequence[T]) SortContext(ctx context.Context) *ComparableSequence[T] {
+	c.SortFuncContext(ctx, cmp.Compare[T])
+	return c
+}
+
+// Sorted returns a new sequence with the elements sorted in ascending order.
+func (c *ComparableSequence[T]) Sorted() *ComparableSequence[T] {
+	return c.Clone().Sort()
+}
+
+// compareFunc turns a less function into a comparison function.
+func compareFunc[T any](less func(a, b T) bool) func(a, b T) int {
+	return func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	}
+}
+
+// parallelSortFunc sorts s by splitting it into one chunk per worker,
+// sorting the chunks concurrently, then merging adjacent runs in rounds
+// until a single sorted run remains. At most workers goroutines run at once.
+func parallelSortFunc[T any](s []T, cmp func(a, b T) int, workers int) {
+	if len(s) < parallelSortThreshold || workers < 2 {
+		slices.SortFunc(s, cmp)
+		return
+	}
+
+	chunk := (len(s) + workers - 1) / workers
+	bounds := []int{0}
+	for lo := 0; lo < len(s); lo += chunk {
+		bounds = append(bounds, min(lo+chunk, len(s)))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i+1 < len(bounds); i++ {
+		wg.Add(1)
+		go func(run []T) {
+			defer wg.Done()
+			slices.SortFunc(run, cmp)
+		}(s[bounds[i]:bounds[i+1]])
+	}
+	wg.Wait()
+
+	src, dst := s, make([]T, len(s))
+	for len(bounds) > 2 {
+		next := []int{0}
+		for i := 0; i+1 < len(bounds); i += 2 {
+			lo := bounds[i]
+			if i+2 >= len(bounds) {
+				// odd run out, carry it over to the next round.
+				hi := bounds[i+1]
+				copy(dst[lo:hi], src[lo:hi])
+				next = append(next, hi)
+				continue
+			}
+			mid, hi := bounds[i+1], bounds[i+2]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				mergeFunc(dst[lo:hi], src[lo:mid], src[mid:hi], cmp)
+			}()
+			next = append(next, hi)
+		}
+		wg.Wait()
+		src, dst = dst, src
+		bounds = next
+	}
+	if &src[0] != &s[0] {
+		copy(s, src)
+	}
+}
+
+// mergeFunc merges the sorted runs a and b into dst, preferring
+// elements of a on ties. dst must have room for len(a)+len(b) elements.
+func mergeFunc[T any](dst, a, b []T, cmp func(a, b T) int) {
+	i, j, k := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		if cmp(b[j], a[i]) < 0 {
+			dst[k] = b[j]
+			j++
+		} else {
+			dst[k] = a[i]
+			i++
+		}
+		k++
+	}
+	k += copy(dst[k:], a[i:])
+	copy(dst[k:], b[j:])
+}
@@ -0,0 +1,121 @@
+package sequence
+
+import (
+	"cmp"
+	"context"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+)
+
+func TestSequence_SortFunc(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{name: "sort by length", input: []string{"ccc", "a", "bb"}, want: []string{"a", "bb", "ccc"}},
+		{name: "already sorted", input: []string{"a", "bb"}, want: []string{"a", "bb"}},
+		{name: "empty", input: []string{}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewSequence(tt.input)
+			c.SortFunc(func(a, b string) int { return len(a) - len(b) })
+			if !slices.Equal(c.ToSlice(), tt.want) {
+				t.Errorf("SortFunc() = %v, want %v", c.ToSlice(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSequence_SortStable(t *testing.T) {
+	byLength := func(a, b string) int { return len(a) - len(b) }
+	c := NewSequence([]string{"bb", "b", "aa", "a"})
+	if got := c.SortBy(func(a, b string) bool { return len(a) < len(b) }).ToSlice(); !slices.Equal(got, []string{"b", "a", "bb", "aa"}) {
+		t.Errorf("SortBy() = %v, want [b a bb aa]", got)
+	}
+	if got := c.ToSlice(); !slices.Equal(got, []string{"bb", "b", "aa", "a"}) {
+		t.Errorf("SortBy() modified the sequence: %v", got)
+	}
+	if got := c.SortStableFunc(byLength).ToSlice(); !slices.Equal(got, []string{"b", "a", "bb", "aa"}) {
+		t.Errorf("SortStableFunc() = %v, want [b a bb aa]", got)
+	}
+}
+
+func TestComparableSequence_Sort(t *testing.T) {
+	c := NewComparableSequence([]int{5, 3, 1, 4, 2})
+	if got := c.Sorted().ToSlice(); !slices.Equal(got, []int{1, 2, 3, 4, 5}) || c.At(0) != 5 {
+		t.Errorf("Sorted() = %v, leaving %v", got, c)
+	}
+	if got := c.Sort().ToSlice(); !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Sort() = %v, want [1 2 3 4 5]", got)
+	}
+}
+
+func TestParallelSortFunc(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		workers int
+	}{
+		{name: "below threshold", size: parallelSortThreshold - 1, workers: 4},
+		{name: "two workers", size: parallelSortThreshold * 2, workers: 2},
+		{name: "odd number of runs", size: parallelSortThreshold*3 + 7, workers: 3},
+		{name: "many workers", size: parallelSortThreshold * 4, workers: 8},
+	}
+	r := rand.New(rand.NewSource(42))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := make([]int, tt.size)
+			for i := range input {
+				input[i] = r.Intn(tt.size / 2)
+			}
+			want := slices.Sorted(slices.Values(input))
+			parallelSortFunc(input, cmp.Compare[int], tt.workers)
+			if !slices.Equal(input, want) {
+				t.Errorf("parallelSortFunc() did not sort %d elements with %d workers", tt.size, tt.workers)
+			}
+		})
+	}
+}
+
+func TestMergeFunc(t *testing.T) {
+	dst := make([]int, 7)
+	mergeFunc(dst, []int{1, 3, 5, 7}, []int{2, 3, 6}, cmp.Compare[int])
+	if !slices.Equal(dst, []int{1, 2, 3, 3, 5, 6, 7}) {
+		t.Errorf("mergeFunc() = %v, want [1 2 3 3 5 6 7]", dst)
+	}
+}
+
+// spanRecorder is a collection.Tracer recording the name and attributes of its last span.
+type spanRecorder struct {
+	name  string
+	attrs map[string]any
+}
+
+func (r *spanRecorder) Start(ctx context.Context, name string) (context.Context, collection.Span) {
+	r.name, r.attrs = name, map[string]any{}
+	return ctx, r
+}
+
+func (r *spanRecorder) SetAttributes(attrs ...collection.Attribute) {
+	for _, a := range attrs {
+		r.attrs[a.Key] = a.Value
+	}
+}
+
+func (r *spanRecorder) End(error) {}
+
+func TestComparableSequence_SortContext(t *testing.T) {
+	r := &spanRecorder{}
+	c := NewComparableSequence([]int{3, 1, 2})
+	if got := c.SortContext(collection.WithTracer(context.Background(), r)).ToSlice(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("SortContext() = %v, want [1 2 3]", got)
+	}
+	if r.name != "sequence.SortFunc" || r.attrs["length"] != 3 {
+		t.Errorf("span = %v %v, want sequence.SortFunc of length 3", r.name, r.attrs)
+	}
+}
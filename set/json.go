@@ -0,0 +1,47 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package set
+
+import "encoding/json"
+
+// MarshalJSON encodes the set as a JSON array, in no particular order.
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ToSlice())
+}
+
+// UnmarshalJSON decodes a JSON array into the set, replacing its contents.
+// Duplicate values in the array are ignored.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*s = *NewSet(values)
+	return nil
+}
+
+// MarshalJSON encodes the set as a JSON array, in insertion order.
+//
+// example usage:
+//
+//	json.Marshal(NewOrderedSet([]string{"go", "zig", "go"}))
+//
+// output:
+//
+//	["go","zig"]
+func (s *OrderedSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ToSlice())
+}
+
+// UnmarshalJSON decodes a JSON array into the set, replacing its contents.
+// Duplicate values in the array keep the position of their first occurrence.
+func (s *OrderedSet[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*s = *NewOrderedSet(values)
+	return nil
+}
@@ -0,0 +1,48 @@
+package set
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestSet_JSON(t *testing.T) {
+	data, err := json.Marshal(NewSet([]int{3, 1, 2}))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded []int
+	if err := json.Unmarshal(data, &decoded); err != nil || !slices.Equal(slices.Sorted(slices.Values(decoded)), []int{1, 2, 3}) {
+		t.Fatalf("Marshal() = %s, want the elements 1, 2 and 3", data)
+	}
+	var s Set[int]
+	if err := json.Unmarshal([]byte("[1,2,2,3]"), &s); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if s.Length() != 3 || !s.Contains(2) {
+		t.Errorf("Unmarshal() = %v, want {1 2 3}", &s)
+	}
+}
+
+func TestOrderedSet_JSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "keeps first occurrence", in: `["go","zig","go","rust"]`, want: `["go","zig","rust"]`},
+		{name: "empty", in: `[]`, want: `[]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewOrderedSet([]string{"stale"})
+			if err := json.Unmarshal([]byte(tt.in), s); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			data, err := json.Marshal(s)
+			if err != nil || string(data) != tt.want {
+				t.Errorf("Marshal() = %s, %v, want %s", data, err, tt.want)
+			}
+		})
+	}
+}
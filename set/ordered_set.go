@@ -0,0 +1,461 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package set
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/seq"
+)
+
+type orderedNode[T comparable] struct {
+	value T
+	next  *orderedNode[T]
+	prev  *orderedNode[T]
+}
+
+// OrderedSet is a set of unique elements that remembers the order
+// in which elements were first inserted. It wraps an underlying hash map
+// for O(1) membership checks and a linked list to track insertion order.
+// The zero value is an empty set ready to use, and a nil *OrderedSet reads
+// as an empty set.
+//
+// OrderedSet is useful when deduplicating an ordered source while
+// keeping the first-seen order, for example:
+//
+//	tags := NewOrderedSet([]string{"go", "rust", "go", "zig", "rust"})
+//	tags.ToSlice()
+//
+// output:
+//
+//	[go rust zig]
+type OrderedSet[T comparable] struct {
+	elements map[T]*orderedNode[T]
+	head     *orderedNode[T]
+	tail     *orderedNode[T]
+	collection.Randomized
+}
+
+func NewOrderedSet[T comparable](s ...[]T) *OrderedSet[T] {
+	set := new(OrderedSet[T])
+	set.elements = make(map[T]*orderedNode[T])
+	for _, slice := range s {
+		for _, v := range slice {
+			set.Add(v)
+		}
+	}
+	return set
+}
+
+// The following methods implement
+// the Collection interface.
+
+// Add appends a value to the end of the set if it is not already present.
+// Adding an existing value does not change its position.
+func (s *OrderedSet[T]) Add(v T) {
+	if _, ok := s.elements[v]; ok {
+		return
+	}
+	if s.elements == nil {
+		s.elements = make(map[T]*orderedNode[T])
+	}
+	node := &orderedNode[T]{value: v}
+	if s.head == nil {
+		s.head = node
+		s.tail = node
+	} else {
+		s.tail.next = node
+		node.prev = s.tail
+		s.tail = node
+	}
+	s.elements[v] = node
+}
+
+// Length returns the number of elements in the set.
+func (s *OrderedSet[T]) Length() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.elements)
+}
+
+// New returns a new ordered set.
+func (s *OrderedSet[T]) New(s2 ...[]T) collection.Collection[T] {
+	return NewOrderedSet(s2...)
+}
+
+// Random returns a random element from the set.
+func (s *OrderedSet[T]) Random() T {
+	if len(s.elements) == 0 {
+		panic(collection.EmptyCollectionError)
+	}
+	n := s.RandState().IntN(len(s.elements))
+	node := s.head
+	for i := 0; i < n; i++ {
+		node = node.next
+	}
+	return node.value
+}
+
+// Values returns an iterator over all values in insertion order.
+func (s *OrderedSet[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for node := s.first(); node != nil; node = node.next {
+			if !yield(node.value) {
+				break
+			}
+		}
+	}
+}
+
+// Clone returns a copy of the set. This is a shallow clone.
+func (s *OrderedSet[T]) Clone() *OrderedSet[T] {
+	clone := NewOrderedSet[T]()
+	for v := range s.Values() {
+		clone.Add(v)
+	}
+	return clone
+}
+
+// Contains returns true if the set contains the value.
+func (s *OrderedSet[T]) Contains(v T) bool {
+	if s == nil {
+		return false
+	}
+	_, ok := s.elements[v]
+	return ok
+}
+
+// Remove removes a value from the set.
+func (s *OrderedSet[T]) Remove(v T) {
+	node, ok := s.elements[v]
+	if !ok {
+		return
+	}
+	if node.prev != nil {
+		node.prev.next = node.next
+	} else {
+		s.head = node.next
+	}
+	if node.next != nil {
+		node.next.prev = node.prev
+	} else {
+		s.tail = node.prev
+	}
+	delete(s.elements, v)
+}
+
+// ToSlice returns a slice containing all values in insertion order.
+func (s *OrderedSet[T]) ToSlice() []T {
+	return s.AppendToSlice(make([]T, 0, s.Length()))
+}
+
+// AppendToSlice appends all values in insertion order to dst and returns the extended slice.
+func (s *OrderedSet[T]) AppendToSlice(dst []T) []T {
+	dst = slices.Grow(dst, s.Length())
+	for node := s.first(); node != nil; node = node.next {
+		dst = append(dst, node.value)
+	}
+	return dst
+}
+
+// ToSet returns an unordered Set containing the same elements.
+func (s *OrderedSet[T]) ToSet() *Set[T] {
+	set := &Set[T]{elements: make(map[T]struct{}, len(s.elements))}
+	for k := range s.elements {
+		set.elements[k] = struct{}{}
+	}
+	return set
+}
+
+// implement the Stringer interface
+func (s *OrderedSet[T]) String() string {
+	return fmt.Sprintf("OrderedSet(%T) %v", *new(T), s.ToSlice())
+}
+
+// The following methods implement
+// the OrderedCollection interface.
+
+// At returns the element at the given insertion index.
+func (s *OrderedSet[T]) At(index int) T {
+	if index < 0 || index >= len(s.elements) {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	node := s.head
+	for i := 0; i < index; i++ {
+		node = node.next
+	}
+	return node.value
+}
+
+// All returns an index/value iterator over all elements in insertion order.
+func (s *OrderedSet[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for node := s.first(); node != nil; node = node.next {
+			if !yield(i, node.value) {
+				break
+			}
+			i++
+		}
+	}
+}
+
+// Backward returns an index/value iterator over all elements in reverse insertion order.
+func (s *OrderedSet[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := s.Length() - 1
+		for node := s.last(); node != nil; node = node.prev {
+			if !yield(i, node.value) {
+				break
+			}
+			i--
+		}
+	}
+}
+
+// Slice returns a new ordered set containing the elements between the start and end indices.
+func (s *OrderedSet[T]) Slice(start, end int) collection.OrderedCollection[T] {
+	if start < 0 || end > len(s.elements) || start > end {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	result := NewOrderedSet[T]()
+	for i, v := range s.All() {
+		if i >= end {
+			break
+		}
+		if i >= start {
+			result.Add(v)
+		}
+	}
+	return result
+}
+
+// NewOrdered returns a new ordered set.
+func (s *OrderedSet[T]) NewOrdered(s2 ...[]T) collection.OrderedCollection[T] {
+	return NewOrderedSet(s2...)
+}
+
+// The following methods are mostly syntatic sugar
+// wrapping Collection functions to enable function chaining:
+// i.e. set.Filter(f).Union(s2)
+
+// Apply applies a function to each element in the set. The resulting
+// elements keep their relative order, and values mapping to an element
+// already present are dropped.
+func (s *OrderedSet[T]) Apply(f func(T) T) *OrderedSet[T] {
+	head := s.head
+	s.elements = make(map[T]*orderedNode[T], len(s.elements))
+	s.head, s.tail = nil, nil
+	for node := head; node != nil; node = node.next {
+		s.Add(f(node.value))
+	}
+	return s
+}
+
+// ContainsFunc returns true if the set contains a value that satisfies the predicate.
+func (s *OrderedSet[T]) ContainsFunc(f func(T) bool) bool {
+	for v := range s.Values() {
+		if f(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Count is an alias for collection.Count
+func (s *OrderedSet[T]) Count(f func(T) bool) int {
+	return collection.Count(s, f)
+}
+
+// Diff returns a new set containing the elements of the current set
+// that are not present in the passed in set.
+func (s *OrderedSet[T]) Diff(s2 *OrderedSet[T]) *OrderedSet[T] {
+	return s.Reject(s2.Contains)
+}
+
+// Diffed returns an iterator over the elements of the current set
+// that are not present in the passed in set.
+func (s *OrderedSet[T]) Diffed(s2 *OrderedSet[T]) iter.Seq[T] {
+	return s.Rejected(s2.Contains)
+}
+
+// Equals returns true if the two sets contain the same elements, regardless of order.
+func (s *OrderedSet[T]) Equals(s2 *OrderedSet[T]) bool {
+	if s.Length() != s2.Length() {
+		return false
+	}
+	return s.ForAll(s2.Contains)
+}
+
+// Filter returns a new set containing the elements that satisfy the predicate.
+func (s *OrderedSet[T]) Filter(f func(T) bool) *OrderedSet[T] {
+	return collection.FilterInto(s, NewOrderedSet[T](), f)
+}
+
+// Filtered is an alias for collection.Filtered
+func (s *OrderedSet[T]) Filtered(f func(T) bool) iter.Seq[T] {
+	return collection.Filtered(s, f)
+}
+
+// FilterNot returns a new set containing the elements that do not satisfy the predicate.
+func (s *OrderedSet[T]) FilterNot(f func(T) bool) *OrderedSet[T] {
+	return collection.FilterInto(s, NewOrderedSet[T](), func(v T) bool { return !f(v) })
+}
+
+// ForAll is an alias for collection.ForAll
+func (s *OrderedSet[T]) ForAll(f func(T) bool) bool {
+	return collection.ForAll(s, f)
+}
+
+// Head is an alias for collection.Head
+func (s *OrderedSet[T]) Head() (T, error) {
+	return collection.Head(s)
+}
+
+// IndexOf returns the insertion index of the value, or -1 if the set does not contain it.
+func (s *OrderedSet[T]) IndexOf(v T) int {
+	if !s.Contains(v) {
+		return -1
+	}
+	for i, val := range s.All() {
+		if val == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// Intersection returns a new set containing the elements of the current set
+// that are also present in the passed in set, in the order of the current set.
+func (s *OrderedSet[T]) Intersection(s2 *OrderedSet[T]) *OrderedSet[T] {
+	return s.Filter(s2.Contains)
+}
+
+// Intersected returns an iterator over the intersection of
+// the current set and the passed in set.
+func (s *OrderedSet[T]) Intersected(s2 *OrderedSet[T]) iter.Seq[T] {
+	return s.Filtered(s2.Contains)
+}
+
+// IsEmpty returns true if the set is empty.
+func (s *OrderedSet[T]) IsEmpty() bool {
+	return s.Length() == 0
+}
+
+// Last is an alias for collection.Last
+func (s *OrderedSet[T]) Last() (T, error) {
+	return collection.Last(s)
+}
+
+// IsSubsetOf returns true if every element of the current set is present in the passed in set.
+func (s *OrderedSet[T]) IsSubsetOf(s2 *OrderedSet[T]) bool {
+	return s.Length() <= s2.Length() && s.ForAll(s2.Contains)
+}
+
+// IsSupersetOf returns true if every element of the passed in set is present in the current set.
+func (s *OrderedSet[T]) IsSupersetOf(s2 *OrderedSet[T]) bool {
+	return s2.IsSubsetOf(s)
+}
+
+// MkString is an alias for collection.MkString
+func (s *OrderedSet[T]) MkString(sep string) string {
+	return collection.MkString(s, sep)
+}
+
+// MkStringf is an alias for collection.MkStringf
+func (s *OrderedSet[T]) MkStringf(prefix, sep, suffix, format string) string {
+	return collection.MkStringf(s, prefix, sep, suffix, format)
+}
+
+// NonEmpty returns true if the set is not empty.
+func (s *OrderedSet[T]) NonEmpty() bool {
+	return s.Length() > 0
+}
+
+// Partition returns two new sets, the first one containing the elements
+// that satisfy the predicate and the second one containing the rest.
+func (s *OrderedSet[T]) Partition(f func(T) bool) (*OrderedSet[T], *OrderedSet[T]) {
+	return collection.PartitionInto(s, NewOrderedSet[T](), NewOrderedSet[T](), f)
+}
+
+// Reject is an alias for FilterNot
+func (s *OrderedSet[T]) Reject(f func(T) bool) *OrderedSet[T] {
+	return s.FilterNot(f)
+}
+
+// Rejected is an alias for collection.Rejected
+func (s *OrderedSet[T]) Rejected(f func(T) bool) iter.Seq[T] {
+	return collection.Rejected(s, f)
+}
+
+// Reverse returns a new set with the insertion order reversed.
+func (s *OrderedSet[T]) Reverse() *OrderedSet[T] {
+	return collection.ReverseInto(s, NewOrderedSet[T]())
+}
+
+// SortedValues returns an iterator over the elements of the set in the order
+// defined by less instead of insertion order. It is an alias for seq.SortedBy.
+func (s *OrderedSet[T]) SortedValues(less func(a, b T) bool) iter.Seq[T] {
+	return seq.SortedBy(s.Values(), less)
+}
+
+// SymmetricDifference returns a new set containing the elements of the current set
+// that are not present in the passed in set, followed by the elements of the
+// passed in set that are not present in the current set.
+func (s *OrderedSet[T]) SymmetricDifference(s2 *OrderedSet[T]) *OrderedSet[T] {
+	result := s.Diff(s2)
+	for v := range s2.Diffed(s) {
+		result.Add(v)
+	}
+	return result
+}
+
+// Union returns a new set containing the elements of the current set
+// followed by the elements of the passed in set that are not already present.
+func (s *OrderedSet[T]) Union(s2 *OrderedSet[T]) *OrderedSet[T] {
+	result := s.Clone()
+	for v := range s2.Values() {
+		result.Add(v)
+	}
+	return result
+}
+
+// Unioned returns an iterator over the union of the current set and the passed in set.
+func (s *OrderedSet[T]) Unioned(s2 *OrderedSet[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.Values() {
+			if !yield(v) {
+				return
+			}
+		}
+		for v := range s2.Values() {
+			if !s.Contains(v) {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// first returns the node of the first element, or nil if the set is empty or nil.
+func (s *OrderedSet[T]) first() *orderedNode[T] {
+	if s == nil {
+		return nil
+	}
+	return s.head
+}
+
+// last returns the node of the last element, or nil if the set is empty or nil.
+func (s *OrderedSet[T]) last() *orderedNode[T] {
+	if s == nil {
+		return nil
+	}
+	return s.tail
+}
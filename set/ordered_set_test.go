@@ -0,0 +1,272 @@
+package set
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+)
+
+func TestNewOrderedSet(t *testing.T) {
+	tests := []struct {
+		name   string
+		slices [][]string
+		want   []string
+	}{
+		{
+			name:   "preserves first-seen order",
+			slices: [][]string{{"go", "rust", "go", "zig", "rust"}},
+			want:   []string{"go", "rust", "zig"},
+		},
+		{
+			name:   "multiple slices",
+			slices: [][]string{{"b", "a"}, {"c", "a", "d"}},
+			want:   []string{"b", "a", "c", "d"},
+		},
+		{
+			name:   "empty",
+			slices: nil,
+			want:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewOrderedSet(tt.slices...)
+			if got := s.ToSlice(); !slices.Equal(got, tt.want) {
+				t.Errorf("NewOrderedSet() = %v, want %v", got, tt.want)
+			}
+			if s.Length() != len(tt.want) {
+				t.Errorf("Length() = %v, want %v", s.Length(), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestOrderedSet_Remove(t *testing.T) {
+	tests := []struct {
+		name   string
+		slice  []int
+		remove []int
+		want   []int
+	}{
+		{name: "remove head", slice: []int{1, 2, 3}, remove: []int{1}, want: []int{2, 3}},
+		{name: "remove tail", slice: []int{1, 2, 3}, remove: []int{3}, want: []int{1, 2}},
+		{name: "remove middle", slice: []int{1, 2, 3}, remove: []int{2}, want: []int{1, 3}},
+		{name: "remove all", slice: []int{1, 2, 3}, remove: []int{2, 1, 3}, want: []int{}},
+		{name: "remove missing", slice: []int{1, 2, 3}, remove: []int{4}, want: []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewOrderedSet(tt.slice)
+			for _, v := range tt.remove {
+				s.Remove(v)
+			}
+			if got := s.ToSlice(); !slices.Equal(got, tt.want) {
+				t.Errorf("Remove() = %v, want %v", got, tt.want)
+			}
+			s.Add(10)
+			if got := s.ToSlice(); got[len(got)-1] != 10 {
+				t.Errorf("Add() after Remove() = %v, want 10 at the end", got)
+			}
+		})
+	}
+}
+
+func TestOrderedSet_AddExisting(t *testing.T) {
+	s := NewOrderedSet([]int{1, 2, 3})
+	s.Add(1)
+	if got := s.ToSlice(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Add() of an existing value = %v, want [1 2 3]", got)
+	}
+}
+
+func TestOrderedSet_Contains(t *testing.T) {
+	s := NewOrderedSet([]int{1, 2, 3})
+	if !s.Contains(2) {
+		t.Errorf("Contains(2) = false, want true")
+	}
+	if s.Contains(4) {
+		t.Errorf("Contains(4) = true, want false")
+	}
+}
+
+func TestOrderedSet_Random(t *testing.T) {
+	s := NewOrderedSet([]int{1, 2, 3})
+	for range 10 {
+		if v := s.Random(); !s.Contains(v) {
+			t.Errorf("Random() = %v, not an element of the set", v)
+		}
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Random() on an empty set did not panic")
+		}
+	}()
+	NewOrderedSet[int]().Random()
+}
+
+func TestOrderedSet_CloneAndToSet(t *testing.T) {
+	s := NewOrderedSet([]int{3, 1, 2})
+	clone := s.Clone()
+	clone.Add(4)
+	if s.Length() != 3 {
+		t.Errorf("Clone() shares state with the original set")
+	}
+	if !s.ToSet().Equals(NewSet([]int{1, 2, 3})) {
+		t.Errorf("ToSet() = %v, want {1 2 3}", s.ToSet())
+	}
+}
+
+func TestOrderedSet_ImplementsOrderedCollection(t *testing.T) {
+	var c collection.OrderedCollection[int] = NewOrderedSet([]int{1, 2, 3})
+	if c.At(1) != 2 {
+		t.Errorf("At(1) = %v, want 2", c.At(1))
+	}
+}
+
+func TestOrderedSet_At(t *testing.T) {
+	s := NewOrderedSet([]string{"c", "a", "b"})
+	for i, want := range []string{"c", "a", "b"} {
+		if got := s.At(i); got != want {
+			t.Errorf("At(%d) = %v, want %v", i, got, want)
+		}
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("At() out of bounds did not panic")
+		}
+	}()
+	s.At(3)
+}
+
+func TestOrderedSet_Backward(t *testing.T) {
+	s := NewOrderedSet([]int{1, 2, 3})
+	var indices, values []int
+	for i, v := range s.Backward() {
+		indices = append(indices, i)
+		values = append(values, v)
+	}
+	if !slices.Equal(indices, []int{2, 1, 0}) || !slices.Equal(values, []int{3, 2, 1}) {
+		t.Errorf("Backward() = %v %v, want [2 1 0] [3 2 1]", indices, values)
+	}
+}
+
+func TestOrderedSet_Slice(t *testing.T) {
+	tests := []struct {
+		name  string
+		slice []int
+		start int
+		end   int
+		want  []int
+	}{
+		{name: "middle", slice: []int{1, 2, 3, 4, 5}, start: 1, end: 3, want: []int{2, 3}},
+		{name: "full", slice: []int{1, 2, 3}, start: 0, end: 3, want: []int{1, 2, 3}},
+		{name: "empty range", slice: []int{1, 2, 3}, start: 2, end: 2, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewOrderedSet(tt.slice).Slice(tt.start, tt.end).(*OrderedSet[int]).ToSlice()
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Slice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderedSet_Algebra(t *testing.T) {
+	a := NewOrderedSet([]int{5, 1, 4, 2})
+	b := NewOrderedSet([]int{2, 3, 5})
+	tests := []struct {
+		name string
+		got  *OrderedSet[int]
+		want []int
+	}{
+		{name: "union", got: a.Union(b), want: []int{5, 1, 4, 2, 3}},
+		{name: "intersection", got: a.Intersection(b), want: []int{5, 2}},
+		{name: "diff", got: a.Diff(b), want: []int{1, 4}},
+		{name: "filter", got: a.Filter(func(i int) bool { return i > 2 }), want: []int{5, 4}},
+		{name: "reject", got: a.Reject(func(i int) bool { return i > 2 }), want: []int{1, 2}},
+		{name: "reverse", got: a.Reverse(), want: []int{2, 4, 1, 5}},
+		{name: "symmetric difference", got: a.SymmetricDifference(b), want: []int{1, 4, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.ToSlice(); !slices.Equal(got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderedSet_Iterators(t *testing.T) {
+	a := NewOrderedSet([]int{5, 1, 4, 2})
+	b := NewOrderedSet([]int{2, 3, 5})
+	tests := []struct {
+		name string
+		got  []int
+		want []int
+	}{
+		{name: "unioned", got: slices.Collect(a.Unioned(b)), want: []int{5, 1, 4, 2, 3}},
+		{name: "intersected", got: slices.Collect(a.Intersected(b)), want: []int{5, 2}},
+		{name: "diffed", got: slices.Collect(a.Diffed(b)), want: []int{1, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.Equal(tt.got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderedSet_Equals(t *testing.T) {
+	a := NewOrderedSet([]int{1, 2, 3})
+	if !a.Equals(NewOrderedSet([]int{3, 2, 1})) {
+		t.Errorf("Equals() = false for sets with the same elements")
+	}
+	if a.Equals(NewOrderedSet([]int{1, 2})) {
+		t.Errorf("Equals() = true for sets with different elements")
+	}
+}
+
+func TestOrderedSet_IsSubsetOf(t *testing.T) {
+	a := NewOrderedSet([]int{3, 1})
+	b := NewOrderedSet([]int{1, 2, 3})
+	if !a.IsSubsetOf(b) || a.IsSupersetOf(b) {
+		t.Errorf("IsSubsetOf() = %v, IsSupersetOf() = %v, want true, false", a.IsSubsetOf(b), a.IsSupersetOf(b))
+	}
+	if b.IsSubsetOf(a) || !b.IsSupersetOf(a) {
+		t.Errorf("IsSubsetOf() = %v, IsSupersetOf() = %v, want false, true", b.IsSubsetOf(a), b.IsSupersetOf(a))
+	}
+}
+
+func TestOrderedSet_Apply(t *testing.T) {
+	s := NewOrderedSet([]int{1, 2, 3, 4}).Apply(func(i int) int { return i / 2 })
+	if got := s.ToSlice(); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("Apply() = %v, want [0 1 2]", got)
+	}
+}
+
+func TestOrderedSet_IndexOf(t *testing.T) {
+	s := NewOrderedSet([]string{"x", "y", "z"})
+	if got := s.IndexOf("z"); got != 2 {
+		t.Errorf("IndexOf(z) = %v, want 2", got)
+	}
+	if got := s.IndexOf("w"); got != -1 {
+		t.Errorf("IndexOf(w) = %v, want -1", got)
+	}
+}
+
+func TestOrderedSet_Partition(t *testing.T) {
+	evens, odds := NewOrderedSet([]int{1, 2, 3, 4, 5}).Partition(func(i int) bool { return i%2 == 0 })
+	if !slices.Equal(evens.ToSlice(), []int{2, 4}) || !slices.Equal(odds.ToSlice(), []int{1, 3, 5}) {
+		t.Errorf("Partition() = %v %v, want [2 4] [1 3 5]", evens, odds)
+	}
+}
+
+func TestOrderedSet_AppendToSlice(t *testing.T) {
+	got := NewOrderedSet([]int{3, 1, 2}).AppendToSlice([]int{0})
+	if !slices.Equal(got, []int{0, 3, 1, 2}) {
+		t.Errorf("AppendToSlice() = %v, want [0 3 1 2]", got)
+	}
+}
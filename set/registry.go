@@ -0,0 +1,23 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package set
+
+import "github.com/charbz/gophers/collection"
+
+func init() {
+	Register[string]()
+	Register[int]()
+	Register[int64]()
+	Register[float64]()
+	Register[bool]()
+}
+
+// Register registers the set and ordered set constructors under the names
+// "set" and "ordered_set" for element type T, making them available to
+// collection.Make. Builtin element types are registered on init.
+func Register[T comparable]() {
+	collection.Register("set", NewSet[T])
+	collection.Register("ordered_set", NewOrderedSet[T])
+}
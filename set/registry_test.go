@@ -0,0 +1,43 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+)
+
+func TestRegistry(t *testing.T) {
+	tests := []struct {
+		name string
+		want int
+	}{
+		{name: "set", want: 2},
+		{name: "ordered_set", want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := collection.Make[string](tt.name, []string{"a", "b", "a"})
+			if err != nil {
+				t.Fatalf("Make() error = %v", err)
+			}
+			if c.Length() != tt.want {
+				t.Errorf("Length() = %v, want %v", c.Length(), tt.want)
+			}
+			if name, _ := collection.NameOf(c); name != tt.name {
+				t.Errorf("NameOf() = %v, want %v", name, tt.name)
+			}
+		})
+	}
+}
+
+func TestRegister_CustomType(t *testing.T) {
+	type point struct{ X, Y int }
+	if _, err := collection.Make[point]("set"); err != collection.UnknownCollectionError {
+		t.Errorf("Make() error = %v before Register, want %v", err, collection.UnknownCollectionError)
+	}
+	Register[point]()
+	c, err := collection.Make[point]("set", []point{{1, 2}, {1, 2}})
+	if err != nil || c.Length() != 1 {
+		t.Errorf("Make() = %v, %v, want a set of length 1", c, err)
+	}
+}
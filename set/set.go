@@ -14,17 +14,25 @@ import (
 	"fmt"
 	"iter"
 	"maps"
+	"slices"
 
 	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/seq"
 )
 
+// Set is an unordered collection of unique elements. The zero value is
+// an empty set ready to use, and a nil *Set reads as an empty set.
 type Set[T comparable] struct {
 	elements map[T]struct{}
 }
 
 func NewSet[T comparable](s ...[]T) *Set[T] {
+	size := 0
+	for _, slice := range s {
+		size += len(slice)
+	}
 	set := new(Set[T])
-	set.elements = make(map[T]struct{})
+	set.elements = make(map[T]struct{}, size)
 	for _, slice := range s {
 		for _, v := range slice {
 			set.elements[v] = struct{}{}
@@ -37,11 +45,14 @@ func NewSet[T comparable](s ...[]T) *Set[T] {
 // the Collection interface.
 
 func (s *Set[T]) Add(v T) {
+	if s.elements == nil {
+		s.elements = make(map[T]struct{})
+	}
 	s.elements[v] = struct{}{}
 }
 
 func (s *Set[T]) Length() int {
-	return len(s.elements)
+	return len(s.items())
 }
 
 func (s *Set[T]) Random() T {
@@ -56,21 +67,21 @@ func (s *Set[T]) New(s2 ...[]T) collection.Collection[T] {
 }
 
 func (s *Set[T]) Values() iter.Seq[T] {
-	return func(yield func(T) bool) {
-		for k := range s.elements {
-			if !yield(k) {
-				break
-			}
-		}
-	}
+	return maps.Keys(s.items())
 }
 
 func (s *Set[T]) ToSlice() []T {
-	slice := make([]T, 0, len(s.elements))
-	for v := range s.elements {
-		slice = append(slice, v)
+	return s.AppendToSlice(make([]T, 0, s.Length()))
+}
+
+// AppendToSlice appends all elements of the set to dst, in no particular order,
+// and returns the extended slice.
+func (s *Set[T]) AppendToSlice(dst []T) []T {
+	dst = slices.Grow(dst, s.Length())
+	for v := range s.items() {
+		dst = append(dst, v)
 	}
-	return slice
+	return dst
 }
 
 // implement the Stringer interface
@@ -84,11 +95,11 @@ func (s *Set[T]) String() string {
 
 // Apply applies a function to each element in the set.
 func (s *Set[T]) Apply(f func(T) T) *Set[T] {
+	elements := make(map[T]struct{}, len(s.elements))
 	for k := range s.elements {
-		v := f(k)
-		s.Remove(k)
-		s.Add(v)
+		elements[f(k)] = struct{}{}
 	}
+	s.elements = elements
 	return s
 }
 
@@ -105,7 +116,7 @@ func (s *Set[T]) Count(f func(T) bool) int {
 
 // Contains returns true if the set contains the value.
 func (s *Set[T]) Contains(v T) bool {
-	_, ok := s.elements[v]
+	_, ok := s.items()[v]
 	return ok
 }
 
@@ -128,12 +139,17 @@ func (s *Set[T]) Diff(set *Set[T]) *Set[T] {
 	return newSet
 }
 
+// Diffed is an alias for DiffIterator
+func (s *Set[T]) Diffed(set *Set[T]) iter.Seq[T] {
+	return s.DiffIterator(set)
+}
+
 // DiffIterator returns an iterator over the difference of the current set and the passed in set.
 func (s *Set[T]) DiffIterator(set *Set[T]) iter.Seq[T] {
 	return func(yield func(T) bool) {
 		for k := range s.elements {
-			if !set.Contains(k) {
-				yield(k)
+			if !set.Contains(k) && !yield(k) {
+				return
 			}
 		}
 	}
@@ -152,9 +168,9 @@ func (s *Set[T]) Equals(s2 *Set[T]) bool {
 	return true
 }
 
-// Filter is an alias for collection.Filter
+// Filter returns a new set containing the elements that satisfy the predicate.
 func (s *Set[T]) Filter(f func(T) bool) *Set[T] {
-	return collection.Filter(s, f).(*Set[T])
+	return collection.FilterInto(s, NewSet[T](), f)
 }
 
 // Filtered is an alias for collection.Filtered
@@ -162,9 +178,9 @@ func (s *Set[T]) Filtered(f func(T) bool) iter.Seq[T] {
 	return collection.Filtered(s, f)
 }
 
-// FilterNot is an alias for collection.FilterNot
+// FilterNot returns a new set containing the elements that do not satisfy the predicate.
 func (s *Set[T]) FilterNot(f func(T) bool) *Set[T] {
-	return collection.FilterNot(s, f).(*Set[T])
+	return collection.FilterInto(s, NewSet[T](), func(v T) bool { return !f(v) })
 }
 
 // ForAll is an alias for collection.ForAll
@@ -193,22 +209,42 @@ func (s *Set[T]) Intersection(s2 *Set[T]) *Set[T] {
 func (s *Set[T]) Intersected(s2 *Set[T]) iter.Seq[T] {
 	return func(yield func(T) bool) {
 		for k := range s.elements {
-			if s2.Contains(k) {
-				yield(k)
+			if s2.Contains(k) && !yield(k) {
+				return
 			}
 		}
 	}
 }
 
+// IsSubsetOf returns true if every element of the current set is present in the passed in set.
+func (s *Set[T]) IsSubsetOf(s2 *Set[T]) bool {
+	return s.Length() <= s2.Length() && s.ForAll(s2.Contains)
+}
+
+// IsSupersetOf returns true if every element of the passed in set is present in the current set.
+func (s *Set[T]) IsSupersetOf(s2 *Set[T]) bool {
+	return s2.IsSubsetOf(s)
+}
+
+// MkString is an alias for collection.MkString
+func (s *Set[T]) MkString(sep string) string {
+	return collection.MkString(s, sep)
+}
+
+// MkStringf is an alias for collection.MkStringf
+func (s *Set[T]) MkStringf(prefix, sep, suffix, format string) string {
+	return collection.MkStringf(s, prefix, sep, suffix, format)
+}
+
 // NonEmpty returns true if the set is not empty.
 func (s *Set[T]) NonEmpty() bool {
 	return s.Length() > 0
 }
 
-// Partition is an alias for collection.Partition
+// Partition returns two new sets, the first one containing the elements
+// that satisfy the predicate and the second one containing the rest.
 func (s *Set[T]) Partition(f func(T) bool) (*Set[T], *Set[T]) {
-	left, right := collection.Partition(s, f)
-	return left.(*Set[T]), right.(*Set[T])
+	return collection.PartitionInto(s, NewSet[T](), NewSet[T](), f)
 }
 
 // Remove removes a value from the set.
@@ -216,9 +252,9 @@ func (s *Set[T]) Remove(v T) {
 	delete(s.elements, v)
 }
 
-// Reject is an alias for collection.FilterNot
-func (l *Set[T]) Reject(f func(T) bool) *Set[T] {
-	return collection.FilterNot(l, f).(*Set[T])
+// Reject is an alias for FilterNot
+func (s *Set[T]) Reject(f func(T) bool) *Set[T] {
+	return s.FilterNot(f)
 }
 
 // Rejected is an alias for collection.Rejected
@@ -226,6 +262,43 @@ func (s *Set[T]) Rejected(f func(T) bool) iter.Seq[T] {
 	return collection.Rejected(s, f)
 }
 
+// SortedValues returns an iterator over the elements of the set in the
+// order defined by less. The elements are heapified rather than sorted when
+// the iteration starts, so breaking after the first few is cheap.
+// It is an alias for seq.SortedBy.
+//
+// example usage:
+//
+//	s := NewSet([]int{4, 1, 3, 2})
+//	slices.Collect(seq.Take(s.SortedValues(cmp.Less[int]), 2))
+//
+// output:
+//
+//	[1 2]
+func (s *Set[T]) SortedValues(less func(a, b T) bool) iter.Seq[T] {
+	return seq.SortedBy(s.Values(), less)
+}
+
+// SymmetricDifference returns a new set containing the elements present
+// in exactly one of the current set and the passed in set.
+//
+// example usage:
+//
+//	a := NewSet([]int{1, 2, 3})
+//	b := NewSet([]int{2, 3, 4})
+//	a.SymmetricDifference(b)
+//
+// output:
+//
+//	Set(int) [1 4]
+func (s *Set[T]) SymmetricDifference(s2 *Set[T]) *Set[T] {
+	result := s.Diff(s2)
+	for k := range s2.DiffIterator(s) {
+		result.Add(k)
+	}
+	return result
+}
+
 // Union returns a new set containing the union of the current set and the passed in set.
 func (s *Set[T]) Union(s2 *Set[T]) *Set[T] {
 	result := s.Clone()
@@ -239,12 +312,22 @@ func (s *Set[T]) Union(s2 *Set[T]) *Set[T] {
 func (s *Set[T]) Unioned(s2 *Set[T]) iter.Seq[T] {
 	return func(yield func(T) bool) {
 		for k := range s.elements {
-			yield(k)
+			if !yield(k) {
+				return
+			}
 		}
 		for k := range s2.elements {
-			if !s.Contains(k) {
-				yield(k)
+			if !s.Contains(k) && !yield(k) {
+				return
 			}
 		}
 	}
 }
+
+// items returns the elements of the set, or nil for a nil set.
+func (s *Set[T]) items() map[T]struct{} {
+	if s == nil {
+		return nil
+	}
+	return s.elements
+}
@@ -279,6 +279,57 @@ func TestSet_Diff(t *testing.T) {
 	}
 }
 
+func TestSet_SymmetricDifference(t *testing.T) {
+	tests := []struct {
+		name  string
+		base  []int
+		other []int
+		want  []int
+	}{
+		{name: "overlapping sets", base: []int{1, 2, 3}, other: []int{2, 3, 4}, want: []int{1, 4}},
+		{name: "disjoint sets", base: []int{1, 2}, other: []int{3}, want: []int{1, 2, 3}},
+		{name: "equal sets", base: []int{1, 2}, other: []int{2, 1}, want: []int{}},
+		{name: "empty set", base: []int{}, other: []int{5}, want: []int{5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewSet(tt.base).SymmetricDifference(NewSet(tt.other))
+			if !assertEqualValues(result.ToSlice(), tt.want) {
+				t.Errorf("SymmetricDifference() = %v, want %v", result.ToSlice(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSet_IsSubsetOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     []int
+		other    []int
+		subset   bool
+		superset bool
+	}{
+		{name: "proper subset", base: []int{1, 2}, other: []int{1, 2, 3}, subset: true, superset: false},
+		{name: "equal sets", base: []int{1, 2}, other: []int{2, 1}, subset: true, superset: true},
+		{name: "superset", base: []int{1, 2, 3}, other: []int{3}, subset: false, superset: true},
+		{name: "overlapping sets", base: []int{1, 2}, other: []int{2, 3}, subset: false, superset: false},
+		{name: "empty set", base: []int{}, other: []int{1}, subset: true, superset: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s1, s2 := NewSet(tt.base), NewSet(tt.other)
+			if got := s1.IsSubsetOf(s2); got != tt.subset {
+				t.Errorf("IsSubsetOf() = %v, want %v", got, tt.subset)
+			}
+			if got := s1.IsSupersetOf(s2); got != tt.superset {
+				t.Errorf("IsSupersetOf() = %v, want %v", got, tt.superset)
+			}
+		})
+	}
+}
+
 func TestSet_Equals(t *testing.T) {
 	tests := []struct {
 		name string
@@ -643,3 +694,128 @@ func assertEqualValues[T cmp.Ordered](a []T, b []T) bool {
 	slices.Sort(b)
 	return slices.Equal(a, b)
 }
+
+func TestSet_IteratorAllocs(t *testing.T) {
+	small := NewSet([]int{1})
+	large := NewSet[int]()
+	for i := range 1000 {
+		large.Add(i)
+	}
+	count := func(s *Set[int]) func() {
+		return func() {
+			n := 0
+			for range s.Values() {
+				n++
+			}
+		}
+	}
+	if s, l := testing.AllocsPerRun(100, count(small)), testing.AllocsPerRun(100, count(large)); l > s {
+		t.Errorf("iterating 1000 elements allocated %v times, 1 element allocated %v times", l, s)
+	}
+}
+
+func TestSet_IteratorsEarlyExit(t *testing.T) {
+	a := NewSet([]int{1, 2, 3, 4})
+	b := NewSet([]int{3, 4, 5, 6})
+	for name, seq := range map[string]func(func(int) bool){
+		"diff":        a.DiffIterator(b),
+		"diffed":      a.Diffed(b),
+		"intersected": a.Intersected(b),
+		"unioned":     a.Unioned(b),
+	} {
+		n := 0
+		for range seq {
+			n++
+			break
+		}
+		if n != 1 {
+			t.Errorf("%s yielded %d values before break, want 1", name, n)
+		}
+	}
+}
+
+func TestSet_AppendToSlice(t *testing.T) {
+	got := NewSet([]int{3, 1, 2}).AppendToSlice([]int{0})
+	slices.Sort(got)
+	if !slices.Equal(got, []int{0, 1, 2, 3}) {
+		t.Errorf("AppendToSlice() = %v, want [0 1 2 3]", got)
+	}
+}
+
+func TestSet_ApplyCollapsesDuplicates(t *testing.T) {
+	s := NewSet([]int{1, 2, 3, 4}).Apply(func(i int) int { return i % 2 })
+	if !s.Equals(NewSet([]int{0, 1})) {
+		t.Errorf("Apply() = %v, want {0 1}", s)
+	}
+}
+
+func TestSet_SortedValues(t *testing.T) {
+	tests := []struct {
+		name string
+		less func(a, b int) bool
+		want []int
+	}{
+		{name: "ascending", less: cmp.Less[int], want: []int{1, 2, 3, 4}},
+		{name: "descending", less: func(a, b int) bool { return a > b }, want: []int{4, 3, 2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSet([]int{3, 1, 4, 2})
+			if got := slices.Collect(s.SortedValues(tt.less)); !slices.Equal(got, tt.want) {
+				t.Errorf("Set.SortedValues() = %v, want %v", got, tt.want)
+			}
+			o := NewOrderedSet([]int{3, 1, 4, 2})
+			var first []int
+			for v := range o.SortedValues(tt.less) {
+				if first = append(first, v); len(first) == 2 {
+					break
+				}
+			}
+			if !slices.Equal(first, tt.want[:2]) {
+				t.Errorf("OrderedSet.SortedValues() first values = %v, want %v", first, tt.want[:2])
+			}
+		})
+	}
+}
+
+func TestSet_ZeroValue(t *testing.T) {
+	var s Set[int]
+	var o OrderedSet[int]
+	for _, v := range []int{2, 1, 2} {
+		s.Add(v)
+		o.Add(v)
+	}
+	if s.Length() != 2 || !s.Contains(1) {
+		t.Errorf("Set zero value after Add = %v, want {1 2}", &s)
+	}
+	if got := o.ToSlice(); !slices.Equal(got, []int{2, 1}) {
+		t.Errorf("OrderedSet zero value after Add = %v, want [2 1]", got)
+	}
+}
+
+func TestSet_NilReceiver(t *testing.T) {
+	var s *Set[int]
+	var o *OrderedSet[int]
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "set length", got: s.Length(), want: 0},
+		{name: "set is empty", got: s.IsEmpty(), want: true},
+		{name: "set contains", got: s.Contains(1), want: false},
+		{name: "set values", got: len(slices.Collect(s.Values())), want: 0},
+		{name: "set to slice", got: len(s.ToSlice()), want: 0},
+		{name: "ordered length", got: o.Length(), want: 0},
+		{name: "ordered contains", got: o.Contains(1), want: false},
+		{name: "ordered values", got: len(slices.Collect(o.Values())), want: 0},
+		{name: "ordered to slice", got: len(o.ToSlice()), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
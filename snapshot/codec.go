@@ -0,0 +1,84 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// Codec encodes and decodes the elements of a snapshot. Its name is
+// recorded in the header, so that a snapshot is never decoded with
+// a codec different from the one it was written with.
+type Codec[T any] interface {
+	// Name identifies the encoding, i.e. "json".
+	Name() string
+	// Append appends the encoding of v to dst and returns the extended slice.
+	Append(dst []byte, v T) ([]byte, error)
+	// Decode decodes an element encoded by Append.
+	Decode(b []byte) (T, error)
+}
+
+// JSON returns a codec encoding each element with encoding/json.
+// It supports any type json.Marshal supports, including structs.
+func JSON[T any]() Codec[T] {
+	return jsonCodec[T]{}
+}
+
+type jsonCodec[T any] struct{}
+
+func (jsonCodec[T]) Name() string { return "json" }
+
+func (jsonCodec[T]) Append(dst []byte, v T) ([]byte, error) {
+	b, err := json.Marshal(v)
+	return append(dst, b...), err
+}
+
+func (jsonCodec[T]) Decode(b []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(b, &v)
+	return v, err
+}
+
+// String returns a codec storing strings as their raw bytes.
+func String() Codec[string] {
+	return stringCodec{}
+}
+
+type stringCodec struct{}
+
+func (stringCodec) Name() string { return "string" }
+
+func (stringCodec) Append(dst []byte, v string) ([]byte, error) {
+	return append(dst, v...), nil
+}
+
+func (stringCodec) Decode(b []byte) (string, error) {
+	return string(b), nil
+}
+
+// Int returns a codec storing integers as zig-zag varints,
+// taking 1 byte for values between -64 and 63.
+func Int[T collection.Integer]() Codec[T] {
+	return intCodec[T]{}
+}
+
+type intCodec[T collection.Integer] struct{}
+
+func (intCodec[T]) Name() string { return "int" }
+
+func (intCodec[T]) Append(dst []byte, v T) ([]byte, error) {
+	return binary.AppendVarint(dst, int64(v)), nil
+}
+
+func (intCodec[T]) Decode(b []byte) (T, error) {
+	v, n := binary.Varint(b)
+	if n != len(b) {
+		return 0, InvalidFormatError
+	}
+	return T(v), nil
+}
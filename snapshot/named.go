@@ -0,0 +1,77 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// named.go records the concrete type of a collection along with its snapshot,
+// using the collection registry, so that the collection can be rebuilt
+// without knowing its type at compile time:
+//
+//	err := snapshot.SaveNamed(f, users, snapshot.JSON[User]()) // users is a *set.OrderedSet[User]
+//	...
+//	restored, err := snapshot.LoadNamed(f, snapshot.JSON[User]()) // a *set.OrderedSet[User]
+
+package snapshot
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// SaveNamed writes the name under which the concrete type of c is registered,
+// see collection.Register, followed by a snapshot of its elements as by Save.
+// The name is written uncompressed, before the snapshot. It returns
+// collection.UnknownCollectionError if the type of c is not registered.
+func SaveNamed[T any](w io.Writer, c collection.Collection[T], codec Codec[T], opts ...Option) error {
+	name, ok := collection.NameOf(c)
+	if !ok {
+		return collection.UnknownCollectionError
+	}
+	buf := binary.AppendUvarint(nil, uint64(len(name)))
+	if _, err := w.Write(append(buf, name...)); err != nil {
+		return err
+	}
+	return Save(w, c, codec, opts...)
+}
+
+// LoadNamed reads a snapshot written by SaveNamed and returns a new collection
+// of the recorded type, built with collection.Make, holding its elements. It
+// returns collection.UnknownCollectionError if the recorded name is not
+// registered for element type T.
+//
+// example usage:
+//
+//	var buf bytes.Buffer
+//	SaveNamed(&buf, set.NewOrderedSet([]string{"b","a"}), String())
+//	LoadNamed(&buf, String())
+//
+// output:
+//
+//	OrderedSet(string) [b a], nil
+func LoadNamed[T any](r io.Reader, codec Codec[T], opts ...Option) (collection.Collection[T], error) {
+	br, ok := r.(byteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	size, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, unexpected(err)
+	}
+	if size > MaxElementSize {
+		return nil, InvalidFormatError
+	}
+	name := make([]byte, size)
+	if _, err := io.ReadFull(br, name); err != nil {
+		return nil, unexpected(err)
+	}
+	c, err := collection.Make[T](string(name))
+	if err != nil {
+		return nil, err
+	}
+	if err := Load(br, c, codec, opts...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
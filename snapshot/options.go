@@ -0,0 +1,97 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"compress/gzip"
+	"io"
+)
+
+// Option configures how a snapshot is written or read.
+type Option func(*options)
+
+type options struct {
+	compression Compression
+	limit       int // maximum number of elements to read, 0 for all
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithCompression compresses the whole snapshot with c when writing, and
+// decompresses it when reading. A snapshot must be read with the
+// compression it was written with.
+//
+// example usage:
+//
+//	err := snapshot.Save(f, sessions, snapshot.JSON[Session](), snapshot.WithCompression(snapshot.Gzip(gzip.BestSpeed)))
+func WithCompression(c Compression) Option {
+	return func(o *options) {
+		o.compression = c
+	}
+}
+
+// WithLimit makes Read and Load stop after the first n elements, leaving
+// the rest of the snapshot unread. Since the checksum covers the whole
+// snapshot, it is not verified when the snapshot holds more than n elements.
+// A limit less than 1 reads all the elements.
+func WithLimit(n int) Option {
+	return func(o *options) {
+		o.limit = max(n, 0)
+	}
+}
+
+// Compression wraps the stream of a snapshot. Compression libraries fit it
+// with a small adapter, i.e. for github.com/klauspost/compress/zstd:
+//
+//	type Zstd struct{}
+//
+//	func (Zstd) NewWriter(w io.Writer) (io.WriteCloser, error) {
+//	  return zstd.NewWriter(w)
+//	}
+//
+//	func (Zstd) NewReader(r io.Reader) (io.ReadCloser, error) {
+//	  d, err := zstd.NewReader(r)
+//	  if err != nil {
+//	    return nil, err
+//	  }
+//	  return d.IOReadCloser(), nil
+//	}
+type Compression interface {
+	// NewWriter returns a writer compressing to w. Closing it must flush
+	// all the compressed data to w, but not close w.
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+	// NewReader returns a reader decompressing from r.
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+// Gzip returns a compression using compress/gzip with the given level,
+// i.e. gzip.DefaultCompression.
+func Gzip(level int) Compression {
+	return gzipCompression{level: level}
+}
+
+type gzipCompression struct {
+	level int
+}
+
+func (c gzipCompression) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriterLevel(w, c.level)
+}
+
+func (gzipCompression) NewReader(r io.Reader) (io.ReadCloser, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	// stop at the end of the snapshot, like uncompressed snapshots
+	zr.Multistream(false)
+	return zr, nil
+}
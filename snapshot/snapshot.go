@@ -0,0 +1,317 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package snapshot implements a versioned binary format to save collections
+// to a file or a socket and load them back, i.e. to warm up a cache from
+// disk or to hand a collection over to another process:
+//
+//	f, _ := os.Create("sessions.snap")
+//	err := snapshot.Save(f, sessions, snapshot.JSON[Session]())
+//	...
+//	restored := list.NewList[Session]()
+//	err = snapshot.Load(f, restored, snapshot.JSON[Session]())
+//
+// A snapshot is laid out as follows, integers being unsigned varints
+// unless stated otherwise:
+//
+//	magic    "GPHS"
+//	version  1 byte, currently 1
+//	codec    length, then the name of the codec
+//	records  for each element, its encoded length plus 1, then its encoding
+//	end      a 0 byte, then the number of elements
+//	checksum CRC-32 (IEEE) of all the preceding bytes, 4 bytes big endian
+//
+// Records are written as the elements are iterated, so saving a collection
+// needs no more memory than the encoding of one element. The whole snapshot
+// can be compressed, see WithCompression.
+package snapshot
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"io"
+	"iter"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// Version is the version of the format written by Save.
+const Version = 1
+
+// MaxElementSize is the maximum size of an encoded element. Larger
+// records are reported as corrupted rather than allocated.
+const MaxElementSize = 1 << 28
+
+var magic = []byte("GPHS")
+
+var (
+	InvalidFormatError      = errors.New("snapshot: invalid format")
+	UnsupportedVersionError = errors.New("snapshot: unsupported version")
+	CodecMismatchError      = errors.New("snapshot: written with a different codec")
+	ChecksumError           = errors.New("snapshot: checksum mismatch")
+)
+
+// Save writes a snapshot of the elements of the collection to w.
+func Save[T any](w io.Writer, c collection.Collection[T], codec Codec[T], opts ...Option) error {
+	return Write(w, c.Values(), codec, opts...)
+}
+
+// Write writes a snapshot of the values of the sequence to w. It is the
+// streaming form of Save, for values that are not held in a collection,
+// such as the entries of a dict.Map.
+func Write[T any](w io.Writer, s iter.Seq[T], codec Codec[T], opts ...Option) error {
+	o := newOptions(opts)
+	if o.compression == nil {
+		return write(w, s, codec)
+	}
+	zw, err := o.compression.NewWriter(w)
+	if err != nil {
+		return err
+	}
+	if err := write(zw, s, codec); err != nil {
+		zw.Close()
+		return err
+	}
+	return zw.Close()
+}
+
+// write writes an uncompressed snapshot to w.
+func write[T any](w io.Writer, s iter.Seq[T], codec Codec[T]) error {
+	h := crc32.NewIEEE()
+	bw := bufio.NewWriter(io.MultiWriter(w, h))
+	buf := append(magic[:len(magic):len(magic)], Version)
+	buf = binary.AppendUvarint(buf, uint64(len(codec.Name())))
+	buf = append(buf, codec.Name()...)
+	if _, err := bw.Write(buf); err != nil {
+		return err
+	}
+	n := uint64(0)
+	var record []byte
+	for v := range s {
+		var err error
+		if record, err = codec.Append(record[:0], v); err != nil {
+			return err
+		}
+		if len(record) > MaxElementSize {
+			return InvalidFormatError
+		}
+		buf = binary.AppendUvarint(buf[:0], uint64(len(record))+1)
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+		if _, err := bw.Write(record); err != nil {
+			return err
+		}
+		n++
+	}
+	buf = binary.AppendUvarint(append(buf[:0], 0), n)
+	if _, err := bw.Write(buf); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	_, err := w.Write(h.Sum(nil))
+	return err
+}
+
+// Load reads a snapshot from r and adds its elements to dst. The whole
+// snapshot is decoded and its checksum verified before dst is modified,
+// so dst is left untouched when an error is returned.
+//
+// Load reads exactly the bytes of the snapshot when r implements
+// io.ByteReader, otherwise it may buffer data past its end.
+func Load[T any](r io.Reader, dst collection.Collection[T], codec Codec[T], opts ...Option) error {
+	values, err := Read(r, codec, opts...)
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		dst.Add(v)
+	}
+	return nil
+}
+
+// Read reads a snapshot from r and returns its elements.
+func Read[T any](r io.Reader, codec Codec[T], opts ...Option) ([]T, error) {
+	return read(r, codec, newOptions(opts), nil)
+}
+
+// ReadRange reads the elements of a snapshot between from (inclusive) and
+// to (exclusive), the snapshot being sorted according to cmp, such as the
+// snapshot of a sorted sequence or of the SortedKeys of a dict.Map.
+// It stops reading at the first element past the range, the checksum is
+// therefore only verified when the range reaches the end of the snapshot.
+//
+// example usage:
+//
+//	s := sequence.NewSequence([]int{1,3,5,7,9})
+//	snapshot.Save(&buf, s, snapshot.Int[int]())
+//	snapshot.ReadRange(&buf, snapshot.Int[int](), 3, 8, cmp.Compare[int])
+//
+// output:
+//
+//	[3 5 7]
+func ReadRange[T any](r io.Reader, codec Codec[T], from, to T, cmp func(a, b T) int, opts ...Option) ([]T, error) {
+	return read(r, codec, newOptions(opts), func(v T) int {
+		if cmp(v, from) < 0 {
+			return -1
+		}
+		if cmp(v, to) >= 0 {
+			return 1
+		}
+		return 0
+	})
+}
+
+// read reads a snapshot from r according to the options. If position is
+// not nil, the elements for which it returns a negative number are skipped,
+// and reading stops at the first one for which it returns a positive number.
+// Reading also stops after o.limit elements. The checksum is only verified
+// when the snapshot is read up to its end.
+func read[T any](r io.Reader, codec Codec[T], o options, position func(T) int) ([]T, error) {
+	if o.compression == nil {
+		values, _, err := decode(r, codec, o.limit, position)
+		return values, err
+	}
+	zr, err := o.compression.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	values, complete, err := decode(zr, codec, o.limit, position)
+	if err == nil && complete {
+		// let the decompressor verify its own trailer
+		_, err = io.Copy(io.Discard, zr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// decode reads an uncompressed snapshot from r, and reports whether it
+// was read up to its end, see read.
+func decode[T any](r io.Reader, codec Codec[T], limit int, position func(T) int) (values []T, complete bool, err error) {
+	br, ok := r.(byteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	cr := &checksumReader{r: br, h: crc32.NewIEEE()}
+	header := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(cr, header); err != nil {
+		return nil, false, unexpected(err)
+	}
+	if string(header[:len(magic)]) != string(magic) {
+		return nil, false, InvalidFormatError
+	}
+	if header[len(magic)] != Version {
+		return nil, false, UnsupportedVersionError
+	}
+	size, err := binary.ReadUvarint(cr)
+	if err != nil {
+		return nil, false, unexpected(err)
+	}
+	name, err := cr.readRecord(size)
+	if err != nil {
+		return nil, false, err
+	}
+	if string(name) != codec.Name() {
+		return nil, false, CodecMismatchError
+	}
+	count := uint64(0) // records read, including the skipped ones
+	for {
+		size, err := binary.ReadUvarint(cr)
+		if err != nil {
+			return nil, false, unexpected(err)
+		}
+		if size == 0 {
+			break
+		}
+		if limit > 0 && len(values) == limit {
+			return values, false, nil
+		}
+		record, err := cr.readRecord(size - 1)
+		if err != nil {
+			return nil, false, err
+		}
+		v, err := codec.Decode(record)
+		if err != nil {
+			return nil, false, err
+		}
+		count++
+		if position != nil {
+			if p := position(v); p < 0 {
+				continue
+			} else if p > 0 {
+				return values, false, nil
+			}
+		}
+		values = append(values, v)
+	}
+	n, err := binary.ReadUvarint(cr)
+	if err != nil {
+		return nil, false, unexpected(err)
+	}
+	sum := cr.h.Sum32()
+	checksum := make([]byte, 4)
+	if _, err := io.ReadFull(br, checksum); err != nil {
+		return nil, false, unexpected(err)
+	}
+	if binary.BigEndian.Uint32(checksum) != sum {
+		return nil, false, ChecksumError
+	}
+	if n != count {
+		return nil, false, InvalidFormatError
+	}
+	return values, true, nil
+}
+
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// checksumReader hashes the bytes read through it.
+type checksumReader struct {
+	r byteReader
+	h hash.Hash32
+}
+
+func (r *checksumReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.h.Write(p[:n])
+	return n, err
+}
+
+func (r *checksumReader) ReadByte() (byte, error) {
+	b, err := r.r.ReadByte()
+	if err == nil {
+		r.h.Write([]byte{b})
+	}
+	return b, err
+}
+
+// readRecord reads a record of the given size.
+func (r *checksumReader) readRecord(size uint64) ([]byte, error) {
+	if size > MaxElementSize {
+		return nil, InvalidFormatError
+	}
+	record := make([]byte, size)
+	if _, err := io.ReadFull(r, record); err != nil {
+		return nil, unexpected(err)
+	}
+	return record, nil
+}
+
+// unexpected reports a snapshot ending early as io.ErrUnexpectedEOF.
+func unexpected(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
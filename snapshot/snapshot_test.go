@@ -0,0 +1,290 @@
+package snapshot
+
+import (
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/dict"
+	"github.com/charbz/gophers/list"
+	"github.com/charbz/gophers/ring"
+	"github.com/charbz/gophers/sequence"
+	"github.com/charbz/gophers/set"
+)
+
+type session struct {
+	ID    string
+	Hits  int
+	Admin bool
+}
+
+func TestSaveLoad(t *testing.T) {
+	tests := []struct {
+		name string
+		save func(w io.Writer) error
+		load func(r io.Reader) (any, error)
+		want any
+	}{
+		{
+			name: "list of structs",
+			save: func(w io.Writer) error {
+				return Save(w, list.NewList([]session{{"a", 3, false}, {"b", 1, true}}), JSON[session]())
+			},
+			load: func(r io.Reader) (any, error) {
+				l := list.NewList[session]()
+				err := Load(r, l, JSON[session]())
+				return l.ToSlice(), err
+			},
+			want: []session{{"a", 3, false}, {"b", 1, true}},
+		},
+		{
+			name: "sequence of strings",
+			save: func(w io.Writer) error {
+				return Save(w, sequence.NewSequence([]string{"go", "", "gopher"}), String())
+			},
+			load: func(r io.Reader) (any, error) {
+				c := sequence.NewSequence[string]()
+				err := Load(r, c, String())
+				return c.ToSlice(), err
+			},
+			want: []string{"go", "", "gopher"},
+		},
+		{
+			name: "ordered set of integers",
+			save: func(w io.Writer) error {
+				return Save(w, set.NewOrderedSet([]int64{-1, 300, 0, 1 << 40}), Int[int64]())
+			},
+			load: func(r io.Reader) (any, error) {
+				s := set.NewOrderedSet[int64]()
+				err := Load(r, s, Int[int64]())
+				return s.ToSlice(), err
+			},
+			want: []int64{-1, 300, 0, 1 << 40},
+		},
+		{
+			name: "empty collection",
+			save: func(w io.Writer) error {
+				return Save(w, list.NewList[uint8](), Int[uint8]())
+			},
+			load: func(r io.Reader) (any, error) {
+				return Read(r, Int[uint8]())
+			},
+			want: []uint8(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.save(&buf); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			got, err := tt.load(&buf)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !equal(got, tt.want) {
+				t.Errorf("Load() = %v, want %v", got, tt.want)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("Load() left %v bytes unread", buf.Len())
+			}
+		})
+	}
+}
+
+func equal(a, b any) bool {
+	switch a := a.(type) {
+	case []session:
+		return slices.Equal(a, b.([]session))
+	case []string:
+		return slices.Equal(a, b.([]string))
+	case []int64:
+		return slices.Equal(a, b.([]int64))
+	case []uint8:
+		return slices.Equal(a, b.([]uint8))
+	}
+	return false
+}
+
+func TestWriteRead_Map(t *testing.T) {
+	m := dict.NewMap(map[string]int{"go": 1, "zig": 2})
+	codec := JSON[collection.Entry[string, int]]()
+	var buf bytes.Buffer
+	if err := Write(&buf, m.Entries(), codec); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	entries, err := Read(&buf, codec)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if got := dict.FromEntries(slices.Values(entries)); !maps.Equal(got.ToMap(), m.ToMap()) {
+		t.Errorf("Read() = %v, want %v", got, m)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Save(&buf, sequence.NewSequence([]string{"a", "b", "c"}), String()); err != nil {
+		t.Fatal(err)
+	}
+	valid := buf.Bytes()
+	tests := []struct {
+		name    string
+		corrupt func([]byte) []byte
+		codec   Codec[string]
+		wantErr error
+	}{
+		{name: "bad magic", corrupt: func(b []byte) []byte { b[0] = 'X'; return b }, wantErr: InvalidFormatError},
+		{name: "future version", corrupt: func(b []byte) []byte { b[4] = Version + 1; return b }, wantErr: UnsupportedVersionError},
+		{name: "flipped byte", corrupt: func(b []byte) []byte { b[len(b)-7] ^= 1; return b }, wantErr: ChecksumError},
+		{name: "bad checksum", corrupt: func(b []byte) []byte { b[len(b)-1] ^= 1; return b }, wantErr: ChecksumError},
+		{name: "truncated", corrupt: func(b []byte) []byte { return b[:len(b)-6] }, wantErr: io.ErrUnexpectedEOF},
+		{name: "empty input", corrupt: func(b []byte) []byte { return nil }, wantErr: io.ErrUnexpectedEOF},
+		{name: "other codec", corrupt: func(b []byte) []byte { return b }, codec: JSON[string](), wantErr: CodecMismatchError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec := tt.codec
+			if codec == nil {
+				codec = String()
+			}
+			dst := sequence.NewSequence([]string{"untouched"})
+			err := Load(bytes.NewReader(tt.corrupt(slices.Clone(valid))), dst, codec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Load() error = %v, want %v", err, tt.wantErr)
+			}
+			if dst.Length() != 1 {
+				t.Errorf("Load() modified the destination: %v", dst)
+			}
+		})
+	}
+}
+
+func TestRead_Consecutive(t *testing.T) {
+	var buf bytes.Buffer
+	Save(&buf, sequence.NewSequence([]int{1, 2}), Int[int]())
+	Save(&buf, sequence.NewSequence([]int{3}), Int[int]())
+	// bytes.Buffer is an io.ByteReader, each Read stops at the end of a snapshot
+	for _, want := range [][]int{{1, 2}, {3}} {
+		got, err := Read(&buf, Int[int]())
+		if err != nil || !slices.Equal(got, want) {
+			t.Errorf("Read() = %v, %v, want %v, nil", got, err, want)
+		}
+	}
+	if _, err := Read(&buf, Int[int]()); err != io.ErrUnexpectedEOF {
+		t.Errorf("Read() past the last snapshot error = %v", err)
+	}
+}
+
+func TestSaveLoad_Gzip(t *testing.T) {
+	words := sequence.NewSequence(slices.Repeat([]string{"gopher"}, 1000))
+	var plain, compressed bytes.Buffer
+	Save(&plain, words, String())
+	if err := Save(&compressed, words, String(), WithCompression(Gzip(gzip.BestCompression))); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if compressed.Len() >= plain.Len()/10 {
+		t.Errorf("compressed snapshot is %v bytes, uncompressed %v", compressed.Len(), plain.Len())
+	}
+	valid := slices.Clone(compressed.Bytes())
+	dst := list.NewList[string]()
+	if err := Load(&compressed, dst, String(), WithCompression(Gzip(gzip.DefaultCompression))); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !slices.Equal(dst.ToSlice(), words.ToSlice()) {
+		t.Errorf("Load() = %v values", dst.Length())
+	}
+	if _, err := Read(bytes.NewReader(valid), String()); err != InvalidFormatError {
+		t.Errorf("Read() without compression error = %v, want %v", err, InvalidFormatError)
+	}
+	valid[len(valid)-5] ^= 1 // gzip trailer
+	if _, err := Read(bytes.NewReader(valid), String(), WithCompression(Gzip(0))); err == nil {
+		t.Errorf("Read() of a corrupted gzip stream succeeded")
+	}
+}
+
+func TestRead_Partial(t *testing.T) {
+	var buf bytes.Buffer
+	Save(&buf, sequence.NewSequence([]int{1, 3, 5, 7, 9}), Int[int]())
+	valid := buf.Bytes()
+	tests := []struct {
+		name string
+		opts []Option
+		read func([]byte, ...Option) ([]int, error)
+		want []int
+	}{
+		{name: "limit", opts: []Option{WithLimit(2)}, want: []int{1, 3}},
+		{name: "limit equal to length", opts: []Option{WithLimit(5)}, want: []int{1, 3, 5, 7, 9}},
+		{name: "no limit", opts: []Option{WithLimit(0)}, want: []int{1, 3, 5, 7, 9}},
+		{name: "range", read: readRange(3, 8), want: []int{3, 5, 7}},
+		{name: "range past the end", read: readRange(6, 100), want: []int{7, 9}},
+		{name: "empty range", read: readRange(4, 5), want: []int{}},
+		{name: "range and limit", opts: []Option{WithLimit(1)}, read: readRange(2, 8), want: []int{3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			read := tt.read
+			if read == nil {
+				read = func(b []byte, opts ...Option) ([]int, error) {
+					return Read(bytes.NewReader(b), Int[int](), opts...)
+				}
+			}
+			got, err := read(valid, tt.opts...)
+			if err != nil || !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, %v, want %v, nil", got, err, tt.want)
+			}
+			// a truncated snapshot is readable up to the requested elements
+			if _, err := read(valid[:len(valid)-6], append(tt.opts, WithLimit(1))...); err != nil {
+				t.Errorf("partial read of a truncated snapshot error = %v", err)
+			}
+		})
+	}
+}
+
+func readRange(from, to int) func([]byte, ...Option) ([]int, error) {
+	return func(b []byte, opts ...Option) ([]int, error) {
+		return ReadRange(bytes.NewReader(b), Int[int](), from, to, cmp.Compare[int], opts...)
+	}
+}
+
+func TestSaveNamed(t *testing.T) {
+	tests := []struct {
+		name string
+		c    collection.Collection[string]
+		want string
+	}{
+		{name: "ordered set", c: set.NewOrderedSet([]string{"b", "a", "b"}), want: "ordered_set"},
+		{name: "comparable list", c: list.NewComparableList([]string{"x", "y"}), want: "comparable_list"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := SaveNamed(&buf, tt.c, String(), WithCompression(Gzip(gzip.BestSpeed))); err != nil {
+				t.Fatalf("SaveNamed() error = %v", err)
+			}
+			got, err := LoadNamed(&buf, String(), WithCompression(Gzip(gzip.BestSpeed)))
+			if err != nil {
+				t.Fatalf("LoadNamed() error = %v", err)
+			}
+			if name, _ := collection.NameOf(got); name != tt.want || fmt.Sprint(got) != fmt.Sprint(tt.c) {
+				t.Errorf("LoadNamed() = %v named %q, want %v named %q", got, name, tt.c, tt.want)
+			}
+		})
+	}
+
+	if err := SaveNamed(io.Discard, ring.NewBuffer(2, ring.Overwrite, []string{"a"}), String()); err != collection.UnknownCollectionError {
+		t.Errorf("SaveNamed() of an unregistered type error = %v", err)
+	}
+	var buf bytes.Buffer
+	buf.WriteString("\x07unknown")
+	if _, err := LoadNamed(&buf, String()); err != collection.UnknownCollectionError {
+		t.Errorf("LoadNamed() of an unknown name error = %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// UnregisteredTypeError is returned by the Typed codec for an element whose
+// concrete type, or a recorded type name, was not registered.
+var UnregisteredTypeError = errors.New("snapshot: unregistered type")
+
+// registeredType is a concrete type registered with Register,
+// its codec being wrapped to encode and decode values of type any.
+type registeredType struct {
+	name   string
+	typ    reflect.Type
+	append func(dst []byte, v any) ([]byte, error)
+	decode func(b []byte) (any, error)
+}
+
+var types = struct {
+	sync.RWMutex
+	byName map[string]*registeredType
+	byType map[reflect.Type]*registeredType
+}{
+	byName: make(map[string]*registeredType),
+	byType: make(map[reflect.Type]*registeredType),
+}
+
+// Register makes the concrete type T available to the Typed codec under the
+// given name, encoded with the given codec. It registers element types, the
+// collection types being registered with collection.Register, see SaveNamed. Like gob.Register, it must be
+// called for every concrete type stored in an interface element, by both
+// the writer and the reader, usually on init. The name is recorded in the
+// snapshot, it must therefore not change. Registering a type again replaces
+// its codec. It panics if the name is registered for another type, or the
+// type under another name.
+//
+// example usage:
+//
+//	func init() {
+//	  snapshot.Register("circle", snapshot.JSON[Circle]())
+//	  snapshot.Register("square", snapshot.JSON[Square]())
+//	}
+func Register[T any](name string, codec Codec[T]) {
+	typ := reflect.TypeFor[T]()
+	types.Lock()
+	defer types.Unlock()
+	if r, ok := types.byName[name]; ok && r.typ != typ {
+		panic(fmt.Sprintf("snapshot: name %q registered for both %v and %v", name, r.typ, typ))
+	}
+	if r, ok := types.byType[typ]; ok && r.name != name {
+		panic(fmt.Sprintf("snapshot: type %v registered as both %q and %q", typ, r.name, name))
+	}
+	r := &registeredType{
+		name: name,
+		typ:  typ,
+		append: func(dst []byte, v any) ([]byte, error) {
+			return codec.Append(dst, v.(T))
+		},
+		decode: func(b []byte) (any, error) {
+			return codec.Decode(b)
+		},
+	}
+	types.byName[name] = r
+	types.byType[typ] = r
+}
+
+// Typed returns a codec for elements of an interface type T, i.e. any or
+// a user-defined interface, which records the registered name of the
+// concrete type of each element before its encoding, see Register. A nil
+// element is recorded with an empty name.
+//
+// example usage:
+//
+//	shapes := list.NewList([]Shape{Circle{R: 1}, Square{Side: 2}})
+//	err := snapshot.Save(f, shapes, snapshot.Typed[Shape]())
+func Typed[T any]() Codec[T] {
+	return typedCodec[T]{}
+}
+
+type typedCodec[T any] struct{}
+
+func (typedCodec[T]) Name() string { return "typed" }
+
+func (typedCodec[T]) Append(dst []byte, v T) ([]byte, error) {
+	value := any(v)
+	if value == nil {
+		return binary.AppendUvarint(dst, 0), nil
+	}
+	types.RLock()
+	r, ok := types.byType[reflect.TypeOf(value)]
+	types.RUnlock()
+	if !ok {
+		return dst, fmt.Errorf("%w %v", UnregisteredTypeError, reflect.TypeOf(value))
+	}
+	dst = binary.AppendUvarint(dst, uint64(len(r.name)))
+	dst = append(dst, r.name...)
+	return r.append(dst, value)
+}
+
+func (typedCodec[T]) Decode(b []byte) (T, error) {
+	var zero T
+	n, size := binary.Uvarint(b)
+	if size <= 0 || n > uint64(len(b)-size) {
+		return zero, InvalidFormatError
+	}
+	name, b := string(b[size:size+int(n)]), b[size+int(n):]
+	if name == "" {
+		return zero, nil
+	}
+	types.RLock()
+	r, ok := types.byName[name]
+	types.RUnlock()
+	if !ok {
+		return zero, fmt.Errorf("%w %q", UnregisteredTypeError, name)
+	}
+	value, err := r.decode(b)
+	if err != nil {
+		return zero, err
+	}
+	v, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("snapshot: registered type %v does not implement %v", r.typ, reflect.TypeFor[T]())
+	}
+	return v, nil
+}
@@ -0,0 +1,98 @@
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/list"
+)
+
+type shape interface {
+	Area() float64
+}
+
+type circle struct{ R float64 }
+
+func (c circle) Area() float64 { return 3 * c.R * c.R }
+
+type square struct{ Side float64 }
+
+func (s square) Area() float64 { return s.Side * s.Side }
+
+type unregistered struct{}
+
+func (unregistered) Area() float64 { return 0 }
+
+func init() {
+	Register("circle", JSON[circle]())
+	Register("square", JSON[square]())
+	Register("string", String())
+	Register("int", Int[int]())
+}
+
+func TestTyped(t *testing.T) {
+	var buf bytes.Buffer
+	values := []any{1, "go", circle{2}, nil, square{3}, -7}
+	if err := Save(&buf, list.NewList(values), Typed[any]()); err != nil {
+		t.Fatal(err)
+	}
+	l := list.NewList[any]()
+	if err := Load(&buf, l, Typed[any]()); err != nil {
+		t.Fatal(err)
+	}
+	if got := l.ToSlice(); !slices.Equal(got, values) {
+		t.Errorf("Load() = %v, want %v", got, values)
+	}
+
+	buf.Reset()
+	shapes := []shape{circle{1}, square{2}}
+	if err := Save(&buf, list.NewList(shapes), Typed[shape]()); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read(&buf, Typed[shape]())
+	if err != nil || !slices.Equal(got, shapes) {
+		t.Errorf("Read() = %v, %v, want %v", got, err, shapes)
+	}
+}
+
+func TestTyped_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	err := Save(&buf, list.NewList([]shape{circle{1}, unregistered{}}), Typed[shape]())
+	if !errors.Is(err, UnregisteredTypeError) {
+		t.Errorf("Save() error = %v, want %v", err, UnregisteredTypeError)
+	}
+
+	buf.Reset()
+	if err := Save(&buf, list.NewList([]any{"not a shape"}), Typed[any]()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(&buf, Typed[shape]()); err == nil {
+		t.Errorf("Read() of a string as a shape succeeded")
+	}
+
+	if _, err := Typed[any]().Decode([]byte("\x07unknown{}")); !errors.Is(err, UnregisteredTypeError) {
+		t.Errorf("Decode() error = %v, want %v", err, UnregisteredTypeError)
+	}
+	if _, err := Typed[any]().Decode([]byte("\x09abc")); !errors.Is(err, InvalidFormatError) {
+		t.Errorf("Decode() error = %v, want %v", err, InvalidFormatError)
+	}
+}
+
+func TestRegister_Conflicts(t *testing.T) {
+	for name, register := range map[string]func(){
+		"name reused":  func() { Register("circle", JSON[square]()) },
+		"type renamed": func() { Register("disc", JSON[circle]()) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: Register() did not panic", name)
+				}
+			}()
+			register()
+		}()
+	}
+	Register("circle", JSON[circle]())
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package stack implements a LIFO stack.
+//
+// A Stack is backed by a List but only exposes the stack operations,
+// so that elements cannot be inserted or removed out of order:
+//
+//	s := stack.NewStack[int]()
+//	s.Push(1)
+//	s.Push(2)
+//	s.Pop() // 2, nil
+package stack
+
+import (
+	"fmt"
+
+	"github.com/charbz/gophers/list"
+)
+
+// Stack is a last-in first-out stack backed by a List. The zero value
+// is an empty stack ready to use. It is not safe for concurrent use.
+type Stack[T any] struct {
+	elements list.List[T]
+}
+
+// NewStack returns a stack holding the elements of the given slices,
+// pushed in order so that the last element is on top.
+//
+// example usage:
+//
+//	s := NewStack([]int{1, 2, 3})
+//	s.Peek()
+//
+// output:
+//
+//	3, nil
+func NewStack[T any](s ...[]T) *Stack[T] {
+	st := new(Stack[T])
+	for _, slice := range s {
+		for _, v := range slice {
+			st.Push(v)
+		}
+	}
+	return st
+}
+
+// IsEmpty returns true if the stack holds no elements.
+func (s *Stack[T]) IsEmpty() bool {
+	return s.elements.IsEmpty()
+}
+
+// Length returns the number of elements in the stack.
+func (s *Stack[T]) Length() int {
+	return s.elements.Length()
+}
+
+// OnRemove sets a function called with every element popped from the
+// stack, see List.OnRemove. It returns the stack, so that it can be set
+// along with its creation.
+func (s *Stack[T]) OnRemove(f func(T)) *Stack[T] {
+	s.elements.OnRemove(f)
+	return s
+}
+
+// Peek returns the element on top of the stack without removing it,
+// or an EmptyCollectionError if the stack is empty.
+func (s *Stack[T]) Peek() (T, error) {
+	return s.elements.Last()
+}
+
+// Pop removes and returns the element on top of the stack,
+// or returns an EmptyCollectionError if the stack is empty.
+//
+// example usage:
+//
+//	s := NewStack([]int{1, 2, 3})
+//	s.Pop()
+//
+// output:
+//
+//	3, nil
+func (s *Stack[T]) Pop() (T, error) {
+	return s.elements.Pop()
+}
+
+// Push adds an element on top of the stack.
+func (s *Stack[T]) Push(v T) {
+	s.elements.Push(v)
+}
+
+// ToSlice returns the elements of the stack from bottom to top.
+func (s *Stack[T]) ToSlice() []T {
+	return s.elements.ToSlice()
+}
+
+// implement the Stringer interface
+func (s *Stack[T]) String() string {
+	return fmt.Sprintf("Stack(%T) %v", *new(T), s.ToSlice())
+}
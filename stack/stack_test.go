@@ -0,0 +1,62 @@
+package stack
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+)
+
+func TestStack(t *testing.T) {
+	s := NewStack([]int{1, 2})
+	s.Push(3)
+	if v, err := s.Peek(); err != nil || v != 3 {
+		t.Errorf("Peek() = %v, %v, want 3, nil", v, err)
+	}
+	var got []int
+	for !s.IsEmpty() {
+		v, err := s.Pop()
+		if err != nil {
+			t.Fatalf("Pop() error = %v", err)
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("popped %v, want [3 2 1]", got)
+	}
+	if _, err := s.Pop(); err != collection.EmptyCollectionError {
+		t.Errorf("Pop() error = %v, want %v", err, collection.EmptyCollectionError)
+	}
+	if _, err := s.Peek(); err != collection.EmptyCollectionError {
+		t.Errorf("Peek() error = %v, want %v", err, collection.EmptyCollectionError)
+	}
+}
+
+func TestStack_String(t *testing.T) {
+	s := NewStack([]string{"a", "b"})
+	if got := s.String(); got != "Stack(string) [a b]" {
+		t.Errorf("String() = %v", got)
+	}
+	if s.Length() != 2 {
+		t.Errorf("Length() = %v, want 2", s.Length())
+	}
+}
+
+func TestStack_ZeroValue(t *testing.T) {
+	var s Stack[int]
+	s.Push(1)
+	if v, err := s.Pop(); err != nil || v != 1 || !s.IsEmpty() {
+		t.Errorf("Pop() = %v, %v, want 1, nil", v, err)
+	}
+}
+
+func TestStack_OnRemove(t *testing.T) {
+	var removed []int
+	s := NewStack([]int{1, 2}).OnRemove(func(v int) { removed = append(removed, v) })
+	s.Pop()
+	s.Pop()
+	s.Pop()
+	if !slices.Equal(removed, []int{2, 1}) {
+		t.Errorf("removed %v, want [2 1]", removed)
+	}
+}
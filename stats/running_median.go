@@ -0,0 +1,77 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"iter"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// RunningMedian maintains the median of a stream of values.
+// It keeps the lower half of the values in a max-heap and the upper half
+// in a min-heap, so Add runs in O(log n) and Median in O(1).
+//
+// example usage:
+//
+//	m := NewRunningMedian[int]()
+//	m.AddAll(slices.Values([]int{5, 1, 3, 8}))
+//	m.Median()
+//
+// output:
+//
+//	4, nil
+type RunningMedian[T collection.Number] struct {
+	lower heap[T] // max-heap
+	upper heap[T] // min-heap
+}
+
+// NewRunningMedian returns a new RunningMedian holding no values.
+func NewRunningMedian[T collection.Number]() *RunningMedian[T] {
+	return &RunningMedian[T]{
+		lower: heap[T]{less: func(a, b T) bool { return a > b }},
+		upper: heap[T]{less: func(a, b T) bool { return a < b }},
+	}
+}
+
+// Add adds a value to the stream.
+func (m *RunningMedian[T]) Add(v T) {
+	if m.lower.len() == 0 || v <= m.lower.peek() {
+		m.lower.push(v)
+	} else {
+		m.upper.push(v)
+	}
+	// rebalance so that lower holds the same number of values as upper, or one more.
+	if m.lower.len() > m.upper.len()+1 {
+		m.upper.push(m.lower.pop())
+	} else if m.upper.len() > m.lower.len() {
+		m.lower.push(m.upper.pop())
+	}
+}
+
+// AddAll adds every value yielded by the iterator to the stream.
+func (m *RunningMedian[T]) AddAll(s iter.Seq[T]) {
+	for v := range s {
+		m.Add(v)
+	}
+}
+
+// Length returns the number of values added so far.
+func (m *RunningMedian[T]) Length() int {
+	return m.lower.len() + m.upper.len()
+}
+
+// Median returns the median of the values added so far. For an even number of
+// values it returns the mean of the two middle values. If no values were added,
+// it returns 0 and an error.
+func (m *RunningMedian[T]) Median() (float64, error) {
+	if m.lower.len() == 0 {
+		return 0, collection.EmptyCollectionError
+	}
+	if m.lower.len() > m.upper.len() {
+		return float64(m.lower.peek()), nil
+	}
+	return (float64(m.lower.peek()) + float64(m.upper.peek())) / 2, nil
+}
@@ -0,0 +1,56 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestRunningMedian(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		want   float64
+	}{
+		{name: "single value", values: []int{7}, want: 7},
+		{name: "odd count", values: []int{5, 1, 3}, want: 3},
+		{name: "even count", values: []int{5, 1, 3, 8}, want: 4},
+		{name: "duplicates", values: []int{2, 2, 2, 1}, want: 2},
+		{name: "descending", values: []int{9, 8, 7, 6, 5}, want: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewRunningMedian[int]()
+			m.AddAll(slices.Values(tt.values))
+			got, err := m.Median()
+			if err != nil || got != tt.want {
+				t.Errorf("Median() = %v, %v, want %v", got, err, tt.want)
+			}
+			if m.Length() != len(tt.values) {
+				t.Errorf("Length() = %v, want %v", m.Length(), len(tt.values))
+			}
+		})
+	}
+}
+
+func TestRunningMedian_Empty(t *testing.T) {
+	if _, err := NewRunningMedian[float64]().Median(); err == nil {
+		t.Errorf("Median() on an empty stream did not return an error")
+	}
+}
+
+func TestRunningMedian_MatchesQuantile(t *testing.T) {
+	m := NewRunningMedian[float64]()
+	var values []float64
+	for range 1000 {
+		v := rand.Float64() * 100
+		values = append(values, v)
+		m.Add(v)
+		got, _ := m.Median()
+		want, _ := Quantile(values, 0.5)
+		if math.Abs(got-want) > 1e-9 {
+			t.Fatalf("Median() = %v after %d values, want %v", got, len(values), want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package stats implements descriptive statistics over numeric data.
+// Functions operate directly on Go slices so they can be applied to the
+// backing storage of a collection without copying, i.e. stats.Mean(seq.ToSlice()).
+package stats
+
+import (
+	"math"
+	"slices"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// Mean returns the arithmetic mean of the values.
+// If the slice is empty, it returns 0 and an error.
+//
+// example usage:
+//
+//	Mean([]int{1,2,3,4})
+//
+// output:
+//
+//	2.5, nil
+func Mean[T collection.Number](s []T) (float64, error) {
+	if len(s) == 0 {
+		return 0, collection.EmptyCollectionError
+	}
+	var sum float64
+	for _, v := range s {
+		sum += float64(v)
+	}
+	return sum / float64(len(s)), nil
+}
+
+// Variance returns the population variance of the values.
+// If the slice is empty, it returns 0 and an error.
+//
+// example usage:
+//
+//	Variance([]int{2,4,4,4,5,5,7,9})
+//
+// output:
+//
+//	4, nil
+func Variance[T collection.Number](s []T) (float64, error) {
+	mean, err := Mean(s)
+	if err != nil {
+		return 0, err
+	}
+	var sum float64
+	for _, v := range s {
+		d := float64(v) - mean
+		sum += d * d
+	}
+	return sum / float64(len(s)), nil
+}
+
+// StdDev returns the population standard deviation of the values.
+// If the slice is empty, it returns 0 and an error.
+//
+// example usage:
+//
+//	StdDev([]int{2,4,4,4,5,5,7,9})
+//
+// output:
+//
+//	2, nil
+func StdDev[T collection.Number](s []T) (float64, error) {
+	v, err := Variance(s)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(v), nil
+}
+
+// MinMax returns the smallest and largest values in a single pass.
+// If the slice is empty, it returns zero values and an error.
+//
+// example usage:
+//
+//	MinMax([]int{3,1,4,1,5})
+//
+// output:
+//
+//	1, 5, nil
+func MinMax[T collection.Number](s []T) (T, T, error) {
+	if len(s) == 0 {
+		return *new(T), *new(T), collection.EmptyCollectionError
+	}
+	lo, hi := s[0], s[0]
+	for _, v := range s[1:] {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	return lo, hi, nil
+}
+
+// Quantile returns the q-th quantile of the values, for q in the range [0, 1],
+// linearly interpolating between the closest ranks.
+// If the slice is empty or q is out of range, it returns 0 and an error.
+//
+// example usage:
+//
+//	Quantile([]int{1,2,3,4,5}, 0.25)
+//
+// output:
+//
+//	2, nil
+func Quantile[T collection.Number](s []T, q float64) (float64, error) {
+	if len(s) == 0 {
+		return 0, collection.EmptyCollectionError
+	}
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return 0, collection.IndexOutOfBoundsError
+	}
+	sorted := slices.Clone(s)
+	slices.Sort(sorted)
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	frac := pos - float64(lower)
+	return float64(sorted[lower]) + frac*(float64(sorted[upper])-float64(sorted[lower])), nil
+}
@@ -0,0 +1,97 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMean(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []int
+		want    float64
+		wantErr bool
+	}{
+		{name: "mean", input: []int{1, 2, 3, 4}, want: 2.5},
+		{name: "single element", input: []int{7}, want: 7},
+		{name: "empty", input: []int{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Mean(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Mean() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Mean() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVarianceAndStdDev(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []float64
+		variance float64
+		stddev   float64
+		wantErr  bool
+	}{
+		{name: "textbook example", input: []float64{2, 4, 4, 4, 5, 5, 7, 9}, variance: 4, stddev: 2},
+		{name: "constant values", input: []float64{3, 3, 3}, variance: 0, stddev: 0},
+		{name: "empty", input: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := Variance(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Variance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if math.Abs(v-tt.variance) > 1e-9 {
+				t.Errorf("Variance() = %v, want %v", v, tt.variance)
+			}
+			s, _ := StdDev(tt.input)
+			if math.Abs(s-tt.stddev) > 1e-9 {
+				t.Errorf("StdDev() = %v, want %v", s, tt.stddev)
+			}
+		})
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	lo, hi, err := MinMax([]int{3, 1, 4, 1, 5})
+	if err != nil || lo != 1 || hi != 5 {
+		t.Errorf("MinMax() = %v, %v, %v, want 1, 5, nil", lo, hi, err)
+	}
+	if _, _, err := MinMax([]int{}); err == nil {
+		t.Errorf("MinMax() on empty slice did not return an error")
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []int
+		q       float64
+		want    float64
+		wantErr bool
+	}{
+		{name: "first quartile", input: []int{5, 1, 4, 2, 3}, q: 0.25, want: 2},
+		{name: "median even length", input: []int{1, 2, 3, 4}, q: 0.5, want: 2.5},
+		{name: "minimum", input: []int{3, 1, 2}, q: 0, want: 1},
+		{name: "maximum", input: []int{3, 1, 2}, q: 1, want: 3},
+		{name: "out of range", input: []int{1, 2}, q: 1.5, wantErr: true},
+		{name: "empty", input: []int{}, q: 0.5, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Quantile(tt.input, tt.q)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Quantile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Quantile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"slices"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// DefaultCompression is the compression used by NewTDigest when
+// a non-positive compression is given. It bounds the digest to
+// a few hundred centroids with sub-percent error on tail quantiles.
+const DefaultCompression = 100
+
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// TDigest is a streaming sketch reporting approximate quantiles of a stream
+// of values in bounded memory, based on the merging t-digest by Ted Dunning.
+// Accuracy is highest near the tails (i.e. p99 latencies), and digests built
+// on separate streams can be merged.
+//
+// example usage:
+//
+//	d := NewTDigest[float64](100)
+//	d.AddAll(latencies)
+//	d.Quantile(0.99)
+//
+// output:
+//
+//	the approximate 99th percentile of latencies, nil
+type TDigest[T collection.Number] struct {
+	compression float64
+	centroids   []centroid
+	buffer      []centroid
+	count       float64
+	min         float64
+	max         float64
+}
+
+// NewTDigest returns a new empty digest of the given compression. A higher
+// compression keeps more centroids, trading memory for accuracy; a
+// non-positive compression uses DefaultCompression.
+func NewTDigest[T collection.Number](compression float64) *TDigest[T] {
+	if compression <= 0 {
+		compression = DefaultCompression
+	}
+	return &TDigest[T]{
+		compression: compression,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+// Add adds a value to the digest.
+func (d *TDigest[T]) Add(v T) {
+	f := float64(v)
+	d.buffer = append(d.buffer, centroid{mean: f, weight: 1})
+	d.count++
+	d.min = min(d.min, f)
+	d.max = max(d.max, f)
+	if len(d.buffer) >= int(5*d.compression) {
+		d.compress()
+	}
+}
+
+// AddAll adds every value yielded by the iterator to the digest.
+func (d *TDigest[T]) AddAll(s iter.Seq[T]) {
+	for v := range s {
+		d.Add(v)
+	}
+}
+
+// Merge adds the values summarized by another digest to this digest.
+func (d *TDigest[T]) Merge(other *TDigest[T]) {
+	other.compress()
+	d.buffer = append(d.buffer, other.centroids...)
+	d.count += other.count
+	d.min = min(d.min, other.min)
+	d.max = max(d.max, other.max)
+	d.compress()
+}
+
+// Length returns the number of values added to the digest.
+func (d *TDigest[T]) Length() int {
+	return int(d.count)
+}
+
+// Quantile returns the approximate q-th quantile of the values, for q in the range [0, 1].
+// If the digest is empty or q is out of range, it returns 0 and an error.
+func (d *TDigest[T]) Quantile(q float64) (float64, error) {
+	if d.count == 0 {
+		return 0, collection.EmptyCollectionError
+	}
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return 0, collection.IndexOutOfBoundsError
+	}
+	switch q {
+	case 0:
+		return d.min, nil
+	case 1:
+		return d.max, nil
+	}
+	d.compress()
+	c := d.centroids
+	index := q * d.count
+	if index <= c[0].weight/2 {
+		if c[0].weight == 1 {
+			return c[0].mean, nil
+		}
+		return d.min + (c[0].mean-d.min)*index/(c[0].weight/2), nil
+	}
+	cumulative := 0.0
+	for i := 0; i < len(c)-1; i++ {
+		left := cumulative + c[i].weight/2
+		right := cumulative + c[i].weight + c[i+1].weight/2
+		if index <= right {
+			t := (index - left) / (right - left)
+			return c[i].mean + t*(c[i+1].mean-c[i].mean), nil
+		}
+		cumulative += c[i].weight
+	}
+	last := c[len(c)-1]
+	if last.weight == 1 {
+		return last.mean, nil
+	}
+	t := (index - (d.count - last.weight/2)) / (last.weight / 2)
+	return last.mean + t*(d.max-last.mean), nil
+}
+
+// compress merges the buffered values into the centroids, merging adjacent
+// centroids as long as they stay within the size bound of the k1 scale function.
+func (d *TDigest[T]) compress() {
+	if len(d.buffer) == 0 {
+		return
+	}
+	all := append(d.buffer, d.centroids...)
+	slices.SortFunc(all, func(a, b centroid) int { return cmp.Compare(a.mean, b.mean) })
+
+	merged := make([]centroid, 0, len(d.centroids)+1)
+	current := all[0]
+	q0 := 0.0
+	limit := d.qLimit(q0)
+	for _, c := range all[1:] {
+		if q0+(current.weight+c.weight)/d.count <= limit {
+			current.mean += (c.mean - current.mean) * c.weight / (current.weight + c.weight)
+			current.weight += c.weight
+			continue
+		}
+		merged = append(merged, current)
+		q0 += current.weight / d.count
+		limit = d.qLimit(q0)
+		current = c
+	}
+	d.centroids = append(merged, current)
+	d.buffer = d.buffer[:0]
+}
+
+// qLimit returns the largest quantile a centroid starting at q0 may reach,
+// using the scale function k(q) = compression / 2π * asin(2q - 1).
+func (d *TDigest[T]) qLimit(q0 float64) float64 {
+	k := d.compression/(2*math.Pi)*math.Asin(2*q0-1) + 1
+	if k >= d.compression/4 {
+		return 1
+	}
+	return (math.Sin(k*2*math.Pi/d.compression) + 1) / 2
+}
@@ -0,0 +1,84 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestTDigest_Quantile(t *testing.T) {
+	values := make([]float64, 100_000)
+	for i := range values {
+		values[i] = rand.Float64() * 1000
+	}
+	d := NewTDigest[float64](100)
+	d.AddAll(slices.Values(values))
+
+	tests := []struct {
+		name string
+		q    float64
+		tol  float64
+	}{
+		{name: "min", q: 0, tol: 0},
+		{name: "p1", q: 0.01, tol: 1},
+		{name: "median", q: 0.5, tol: 10},
+		{name: "p99", q: 0.99, tol: 1},
+		{name: "p999", q: 0.999, tol: 0.5},
+		{name: "max", q: 1, tol: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.Quantile(tt.q)
+			want, _ := Quantile(values, tt.q)
+			if err != nil || math.Abs(got-want) > tt.tol {
+				t.Errorf("Quantile(%v) = %v, %v, want %v ± %v", tt.q, got, err, want, tt.tol)
+			}
+		})
+	}
+	if d.Length() != len(values) {
+		t.Errorf("Length() = %v, want %v", d.Length(), len(values))
+	}
+	if len(d.centroids) > 200 {
+		t.Errorf("digest holds %d centroids, want at most 200", len(d.centroids))
+	}
+}
+
+func TestTDigest_SmallInput(t *testing.T) {
+	d := NewTDigest[int](0)
+	d.AddAll(slices.Values([]int{5, 1, 4, 2, 3}))
+	for q, want := range map[float64]float64{0: 1, 0.5: 3, 1: 5} {
+		if got, _ := d.Quantile(q); got != want {
+			t.Errorf("Quantile(%v) = %v, want %v", q, got, want)
+		}
+	}
+}
+
+func TestTDigest_Merge(t *testing.T) {
+	a, b := NewTDigest[float64](100), NewTDigest[float64](100)
+	for i := range 10_000 {
+		a.Add(float64(i))
+		b.Add(float64(i + 10_000))
+	}
+	a.Merge(b)
+	if a.Length() != 20_000 {
+		t.Errorf("Length() = %v, want 20000", a.Length())
+	}
+	if got, _ := a.Quantile(0.5); math.Abs(got-10_000) > 100 {
+		t.Errorf("Quantile(0.5) = %v, want 10000 ± 100", got)
+	}
+	if got, _ := a.Quantile(1); got != 19_999 {
+		t.Errorf("Quantile(1) = %v, want 19999", got)
+	}
+}
+
+func TestTDigest_Errors(t *testing.T) {
+	d := NewTDigest[float64](100)
+	if _, err := d.Quantile(0.5); err == nil {
+		t.Errorf("Quantile() on an empty digest did not return an error")
+	}
+	d.Add(1)
+	if _, err := d.Quantile(1.5); err == nil {
+		t.Errorf("Quantile(1.5) did not return an error")
+	}
+}
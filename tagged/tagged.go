@@ -0,0 +1,175 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package tagged implements phantom typing for values and collections.
+// A Tagged[T, Tag] wraps a value of type T with a compile-time only tag,
+// so values sharing the same underlying type but carrying different tags
+// cannot be mixed accidentally:
+//
+//	type Meters struct{}
+//	type Seconds struct{}
+//
+//	distances := list.NewList(tagged.Slice[Meters]([]float64{1.5, 2.5}))
+//	durations := list.NewList(tagged.Slice[Seconds]([]float64{3, 4}))
+//	distances.Concat(durations) // does not compile
+//
+// Tags are usually empty struct types and carry no runtime cost.
+// Conversions between tags go through the explicit Retag and Convert functions.
+package tagged
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// Tagged wraps a value of type T with the phantom type Tag.
+// Tagged values are comparable whenever T is comparable,
+// so they can be stored in sets and compared with ==.
+type Tagged[T any, Tag any] struct {
+	value T
+}
+
+// New returns v tagged with Tag.
+//
+// example usage:
+//
+//	d := New[Meters](42.0)
+//	d.Value()
+//
+// output:
+//
+//	42
+func New[Tag, T any](v T) Tagged[T, Tag] {
+	return Tagged[T, Tag]{value: v}
+}
+
+// Value returns the underlying value.
+func (t Tagged[T, Tag]) Value() T {
+	return t.value
+}
+
+// String implements the Stringer interface.
+func (t Tagged[T, Tag]) String() string {
+	return fmt.Sprintf("%v", t.value)
+}
+
+// Retag returns the same value carrying the tag To instead of From.
+//
+// example usage:
+//
+//	raw := New[Unvalidated]("alice@example.com")
+//	email := Retag[Validated](raw)
+//
+// output:
+//
+//	alice@example.com
+func Retag[To, From, T any](t Tagged[T, From]) Tagged[T, To] {
+	return Tagged[T, To]{value: t.value}
+}
+
+// Map applies f to the underlying value and keeps the tag.
+//
+// example usage:
+//
+//	d := New[Meters](2.0)
+//	Map(d, func(v float64) float64 { return v * 2 })
+//
+// output:
+//
+//	4
+func Map[Tag, T, K any](t Tagged[T, Tag], f func(T) K) Tagged[K, Tag] {
+	return Tagged[K, Tag]{value: f(t.value)}
+}
+
+// Convert applies the conversion function f to the underlying value
+// and returns the result tagged with To.
+//
+// example usage:
+//
+//	m := New[Meters](1000.0)
+//	Convert[Kilometers](m, func(v float64) float64 { return v / 1000 })
+//
+// output:
+//
+//	1
+func Convert[To, From, T, K any](t Tagged[T, From], f func(T) K) Tagged[K, To] {
+	return Tagged[K, To]{value: f(t.value)}
+}
+
+// Compare compares the underlying values of two tagged values with the same tag.
+// It can be passed to sorting functions such as slices.SortFunc.
+func Compare[T cmp.Ordered, Tag any](a, b Tagged[T, Tag]) int {
+	return cmp.Compare(a.value, b.value)
+}
+
+// Slice tags every element of s with Tag.
+//
+// example usage:
+//
+//	list.NewList(Slice[Meters]([]float64{1, 2, 3}))
+//
+// output:
+//
+//	List(tagged.Tagged[float64,Meters]) [1 2 3]
+func Slice[Tag, T any](s []T) []Tagged[T, Tag] {
+	r := make([]Tagged[T, Tag], len(s))
+	for i, v := range s {
+		r[i] = Tagged[T, Tag]{value: v}
+	}
+	return r
+}
+
+// Untag returns the underlying values of the elements of a collection of tagged values.
+//
+// example usage:
+//
+//	Untag(list.NewList(Slice[Meters]([]float64{1, 2, 3})))
+//
+// output:
+//
+//	[1,2,3]
+func Untag[T, Tag any](c collection.Collection[Tagged[T, Tag]]) []T {
+	r := make([]T, 0, c.Length())
+	for v := range c.Values() {
+		r = append(r, v.value)
+	}
+	return r
+}
+
+// ConvertInto applies the conversion function f to every element of c, tags
+// the results with To, adds them to dst and returns dst.
+//
+// example usage:
+//
+//	meters := list.NewList(Slice[Meters]([]float64{1000, 2000}))
+//	ConvertInto(meters, list.NewList[Tagged[float64, Kilometers]](), func(v float64) float64 { return v / 1000 })
+//
+// output:
+//
+//	[1,2]
+func ConvertInto[C collection.Collection[Tagged[K, To]], To, From, T, K any](c collection.Collection[Tagged[T, From]], dst C, f func(T) K) C {
+	for v := range c.Values() {
+		dst.Add(Tagged[K, To]{value: f(v.value)})
+	}
+	return dst
+}
+
+// Sum returns the sum of the underlying values of a collection, keeping the tag.
+//
+// example usage:
+//
+//	Sum(list.NewList(Slice[Meters]([]float64{1.5, 2.5})))
+//
+// output:
+//
+//	4
+func Sum[T collection.Number, Tag any](c collection.Collection[Tagged[T, Tag]]) Tagged[T, Tag] {
+	var sum T
+	for v := range c.Values() {
+		sum += v.value
+	}
+	return Tagged[T, Tag]{value: sum}
+}
@@ -0,0 +1,71 @@
+package tagged
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/list"
+	"github.com/charbz/gophers/set"
+)
+
+type meters struct{}
+type kilometers struct{}
+
+func TestNew(t *testing.T) {
+	d := New[meters](42.0)
+	if d.Value() != 42 {
+		t.Errorf("Value() = %v, want 42", d.Value())
+	}
+	if d.String() != "42" {
+		t.Errorf("String() = %v, want 42", d.String())
+	}
+	if d != New[meters](42.0) {
+		t.Errorf("equal tagged values do not compare equal")
+	}
+}
+
+func TestConversions(t *testing.T) {
+	m := New[meters](1500.0)
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{name: "retag", got: Retag[kilometers](m).Value(), want: 1500},
+		{name: "map", got: Map(m, func(v float64) float64 { return v * 2 }).Value(), want: 3000},
+		{name: "convert", got: Convert[kilometers](m, func(v float64) float64 { return v / 1000 }).Value(), want: 1.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollections(t *testing.T) {
+	distances := list.NewList(Slice[meters]([]float64{1000, 3000, 2000}))
+	if got := Sum(distances); got.Value() != 6000 {
+		t.Errorf("Sum() = %v, want 6000", got)
+	}
+	km := ConvertInto(distances, list.NewList[Tagged[float64, kilometers]](), func(v float64) float64 { return v / 1000 })
+	if got := Untag(km); !slices.Equal(got, []float64{1, 3, 2}) {
+		t.Errorf("ConvertInto() = %v, want [1 3 2]", got)
+	}
+	sorted := Slice[kilometers]([]float64{3, 1, 2})
+	slices.SortFunc(sorted, Compare)
+	if got := Untag(list.NewList(sorted)); !slices.Equal(got, []float64{1, 2, 3}) {
+		t.Errorf("SortFunc(Compare) = %v, want [1 2 3]", got)
+	}
+}
+
+func TestSet(t *testing.T) {
+	s := set.NewSet(Slice[meters]([]int{1, 2, 1}))
+	if s.Length() != 2 {
+		t.Errorf("Length() = %v, want 2", s.Length())
+	}
+	if !s.Contains(New[meters](2)) {
+		t.Errorf("Contains() = false, want true")
+	}
+}
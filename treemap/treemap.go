@@ -0,0 +1,570 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package treemap implements a sorted dictionary, Map, backed by an AVL
+// tree. Unlike dict.Map, it iterates over its entries in key order and
+// answers ordered queries such as the closest key below a given one:
+//
+//	schedule := treemap.NewMap(map[int]string{9: "standup", 14: "review", 16: "deploy"})
+//	next, _ := schedule.Ceiling(now) // the first event at or after now
+//	for hour, event := range schedule.Range(12, 18) {
+//	  fmt.Println(hour, event) // 14 review, 16 deploy
+//	}
+//
+// Like dict.Map, a Map is not itself a Collection. Use Entries to view it
+// as a sequence of key/value entries, sorted by key.
+package treemap
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// node is a node of the tree. height is 1 for a leaf.
+type node[K cmp.Ordered, V any] struct {
+	key         K
+	value       V
+	left, right *node[K, V]
+	height      int
+}
+
+// Map is a dictionary of keys of type K and values of type V sorted by key.
+// Get, Set and Delete run in O(log n). The zero value is an empty map ready to use.
+type Map[K cmp.Ordered, V any] struct {
+	root *node[K, V]
+	size int
+	collection.Randomized
+}
+
+// NewMap returns a new Map holding the entries of the given Go maps.
+// When a key appears in several maps, the last value wins.
+func NewMap[K cmp.Ordered, V any](m ...map[K]V) *Map[K, V] {
+	t := new(Map[K, V])
+	for _, entries := range m {
+		for k, v := range entries {
+			t.Set(k, v)
+		}
+	}
+	return t
+}
+
+// FromEntries returns a new Map holding the entries yielded by s.
+// When a key is yielded several times, the last value wins.
+//
+// example usage:
+//
+//	c := NewSequence([]collection.Entry[string, int]{{"b", 2}, {"a", 1}})
+//	FromEntries(c.Values())
+//
+// output:
+//
+//	TreeMap(string, int) map[a:1 b:2]
+func FromEntries[K cmp.Ordered, V any](s iter.Seq[collection.Entry[K, V]]) *Map[K, V] {
+	t := NewMap[K, V]()
+	for e := range s {
+		t.Set(e.Key, e.Value)
+	}
+	return t
+}
+
+// All returns an iterator over all key/value pairs of the map, in ascending key order.
+func (t *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.root.ascend(yield)
+	}
+}
+
+// Backward returns an iterator over all key/value pairs of the map, in descending key order.
+func (t *Map[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.root.descend(yield)
+	}
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal
+// to k, or false if there is none.
+//
+// example usage:
+//
+//	m := NewMap(map[int]string{10: "a", 20: "b"})
+//	m.Ceiling(15)
+//
+// output:
+//
+//	20:b true
+func (t *Map[K, V]) Ceiling(k K) (collection.Entry[K, V], bool) {
+	return t.ceiling(k).entryOrZero()
+}
+
+// Clone returns a copy of the map. This is a shallow clone.
+func (t *Map[K, V]) Clone() *Map[K, V] {
+	return &Map[K, V]{root: t.root.clone(), size: t.size}
+}
+
+// Contains returns true if the map contains the key.
+func (t *Map[K, V]) Contains(k K) bool {
+	return t.find(k) != nil
+}
+
+// Delete removes the key from the map.
+func (t *Map[K, V]) Delete(k K) {
+	var removed bool
+	t.root, removed = t.root.delete(k)
+	if removed {
+		t.size--
+	}
+}
+
+// Entries returns an iterator over all entries of the map, in ascending key order.
+func (t *Map[K, V]) Entries() iter.Seq[collection.Entry[K, V]] {
+	return func(yield func(collection.Entry[K, V]) bool) {
+		t.root.ascend(func(k K, v V) bool {
+			return yield(collection.Entry[K, V]{Key: k, Value: v})
+		})
+	}
+}
+
+// FirstEntry is an alias for Min.
+func (t *Map[K, V]) FirstEntry() (collection.Entry[K, V], bool) {
+	return t.Min()
+}
+
+// Floor returns the entry with the largest key less than or equal to k,
+// or false if there is none.
+//
+// example usage:
+//
+//	m := NewMap(map[int]string{10: "a", 20: "b"})
+//	m.Floor(15)
+//
+// output:
+//
+//	10:a true
+func (t *Map[K, V]) Floor(k K) (collection.Entry[K, V], bool) {
+	return t.floor(k).entryOrZero()
+}
+
+// Get returns the value of the key, or false if the map does not contain it.
+func (t *Map[K, V]) Get(k K) (V, bool) {
+	if n := t.find(k); n != nil {
+		return n.value, true
+	}
+	return *new(V), false
+}
+
+// GetOrElse returns the value of the key, or def if the map does not contain it.
+func (t *Map[K, V]) GetOrElse(k K, def V) V {
+	if n := t.find(k); n != nil {
+		return n.value
+	}
+	return def
+}
+
+// IsEmpty returns true if the map is empty.
+func (t *Map[K, V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Keys returns an iterator over all keys of the map, in ascending order.
+func (t *Map[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		t.root.ascend(func(k K, _ V) bool { return yield(k) })
+	}
+}
+
+// LastEntry is an alias for Max.
+func (t *Map[K, V]) LastEntry() (collection.Entry[K, V], bool) {
+	return t.Max()
+}
+
+// Length returns the number of entries in the map.
+func (t *Map[K, V]) Length() int {
+	return t.size
+}
+
+// Max returns the entry with the largest key, or false if the map is empty.
+func (t *Map[K, V]) Max() (collection.Entry[K, V], bool) {
+	n := t.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return n.entryOrZero()
+}
+
+// Min returns the entry with the smallest key, or false if the map is empty.
+func (t *Map[K, V]) Min() (collection.Entry[K, V], bool) {
+	n := t.root
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return n.entryOrZero()
+}
+
+// NonEmpty returns true if the map is not empty.
+func (t *Map[K, V]) NonEmpty() bool {
+	return t.size > 0
+}
+
+// PollFirst removes and returns the entry with the smallest key, or false
+// if the map is empty. It descends the tree once, where Min then Delete
+// would descend it twice.
+//
+// example usage:
+//
+//	m := NewMap(map[int]string{1: "a", 2: "b"})
+//	m.PollFirst()
+//	m
+//
+// output:
+//
+//	1:a true
+//	TreeMap(int, string) map[2:b]
+func (t *Map[K, V]) PollFirst() (collection.Entry[K, V], bool) {
+	if t.root == nil {
+		return collection.Entry[K, V]{}, false
+	}
+	var smallest *node[K, V]
+	t.root, smallest = t.root.deleteMin()
+	t.size--
+	return smallest.entry(), true
+}
+
+// PollLast removes and returns the entry with the largest key, or false
+// if the map is empty.
+func (t *Map[K, V]) PollLast() (collection.Entry[K, V], bool) {
+	if t.root == nil {
+		return collection.Entry[K, V]{}, false
+	}
+	var largest *node[K, V]
+	t.root, largest = t.root.deleteMax()
+	t.size--
+	return largest.entry(), true
+}
+
+// Random returns a random entry from the map.
+func (t *Map[K, V]) Random() collection.Entry[K, V] {
+	if t.size == 0 {
+		panic(collection.EmptyCollectionError)
+	}
+	n := t.RandState().IntN(t.size)
+	for e := range t.Entries() {
+		if n == 0 {
+			return e
+		}
+		n--
+	}
+	panic("unreachable")
+}
+
+// Range returns an iterator over the key/value pairs with keys between lo
+// (inclusive) and hi (exclusive), in ascending key order. Only the
+// branches of the tree overlapping the range are visited.
+//
+// example usage:
+//
+//	m := NewMap(map[int]string{1: "a", 2: "b", 3: "c", 4: "d"})
+//	for k, v := range m.Range(2, 4) {
+//		fmt.Println(k, v)
+//	}
+//
+// output:
+//
+//	2 b
+//	3 c
+func (t *Map[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.root.ascendRange(lo, hi, yield)
+	}
+}
+
+// Set sets the value of the key.
+func (t *Map[K, V]) Set(k K, v V) {
+	var added bool
+	t.root, added = t.root.insert(k, v)
+	if added {
+		t.size++
+	}
+}
+
+// ToMap returns a copy of the map as a Go map.
+func (t *Map[K, V]) ToMap() map[K]V {
+	m := make(map[K]V, t.size)
+	for k, v := range t.All() {
+		m[k] = v
+	}
+	return m
+}
+
+// Values returns an iterator over all values of the map, in ascending key order.
+func (t *Map[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		t.root.ascend(func(_ K, v V) bool { return yield(v) })
+	}
+}
+
+// implement the Stringer interface
+func (t *Map[K, V]) String() string {
+	var sb strings.Builder
+	for k, v := range t.All() {
+		if sb.Len() > 0 {
+			sb.WriteByte(' ')
+		}
+		fmt.Fprintf(&sb, "%v:%v", k, v)
+	}
+	return fmt.Sprintf("TreeMap(%T, %T) map[%v]", *new(K), *new(V), sb.String())
+}
+
+// find returns the node of the key, or nil.
+func (t *Map[K, V]) find(k K) *node[K, V] {
+	n := t.root
+	for n != nil {
+		switch c := cmp.Compare(k, n.key); {
+		case c == 0:
+			return n
+		case c < 0:
+			n = n.left
+		default:
+			n = n.right
+		}
+	}
+	return nil
+}
+
+// ceiling returns the node with the smallest key greater than or equal to
+// k, or nil.
+func (t *Map[K, V]) ceiling(k K) *node[K, V] {
+	var found *node[K, V]
+	for n := t.root; n != nil; {
+		switch c := cmp.Compare(k, n.key); {
+		case c == 0:
+			return n
+		case c < 0:
+			found, n = n, n.left
+		default:
+			n = n.right
+		}
+	}
+	return found
+}
+
+// floor returns the node with the largest key less than or equal to k, or nil.
+func (t *Map[K, V]) floor(k K) *node[K, V] {
+	var found *node[K, V]
+	for n := t.root; n != nil; {
+		switch c := cmp.Compare(k, n.key); {
+		case c == 0:
+			return n
+		case c < 0:
+			n = n.left
+		default:
+			found, n = n, n.right
+		}
+	}
+	return found
+}
+
+// lower returns the node with the largest key strictly less than k, or nil.
+func (t *Map[K, V]) lower(k K) *node[K, V] {
+	var found *node[K, V]
+	for n := t.root; n != nil; {
+		if cmp.Less(n.key, k) {
+			found, n = n, n.right
+		} else {
+			n = n.left
+		}
+	}
+	return found
+}
+
+func (n *node[K, V]) entry() collection.Entry[K, V] {
+	return collection.Entry[K, V]{Key: n.key, Value: n.value}
+}
+
+// entryOrZero returns the entry of n, or false if n is nil.
+func (n *node[K, V]) entryOrZero() (collection.Entry[K, V], bool) {
+	if n == nil {
+		return collection.Entry[K, V]{}, false
+	}
+	return n.entry(), true
+}
+
+// ascend yields the entries of the subtree in ascending order and returns
+// false if yield stopped the iteration.
+func (n *node[K, V]) ascend(yield func(K, V) bool) bool {
+	return n == nil || n.left.ascend(yield) && yield(n.key, n.value) && n.right.ascend(yield)
+}
+
+// descend is the descending counterpart of ascend.
+func (n *node[K, V]) descend(yield func(K, V) bool) bool {
+	return n == nil || n.right.descend(yield) && yield(n.key, n.value) && n.left.descend(yield)
+}
+
+// ascendRange is ascend restricted to the keys in [lo, hi).
+func (n *node[K, V]) ascendRange(lo, hi K, yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	if cmp.Less(lo, n.key) && !n.left.ascendRange(lo, hi, yield) {
+		return false
+	}
+	if !cmp.Less(n.key, lo) && cmp.Less(n.key, hi) && !yield(n.key, n.value) {
+		return false
+	}
+	return !cmp.Less(n.key, hi) || n.right.ascendRange(lo, hi, yield)
+}
+
+// ascendWithin is ascend restricted to the keys within the bounds.
+func (n *node[K, V]) ascendWithin(b bounds[K], yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	if b.aboveLo(n.key) && !n.left.ascendWithin(b, yield) {
+		return false
+	}
+	if b.contains(n.key) && !yield(n.key, n.value) {
+		return false
+	}
+	return !b.belowHi(n.key) || n.right.ascendWithin(b, yield)
+}
+
+// descendWithin is the descending counterpart of ascendWithin.
+func (n *node[K, V]) descendWithin(b bounds[K], yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	if b.belowHi(n.key) && !n.right.descendWithin(b, yield) {
+		return false
+	}
+	if b.contains(n.key) && !yield(n.key, n.value) {
+		return false
+	}
+	return !b.aboveLo(n.key) || n.left.descendWithin(b, yield)
+}
+
+func (n *node[K, V]) clone() *node[K, V] {
+	if n == nil {
+		return nil
+	}
+	c := *n
+	c.left, c.right = n.left.clone(), n.right.clone()
+	return &c
+}
+
+// insert sets the value of the key in the subtree, and returns the new
+// root of the subtree and whether the key was added.
+func (n *node[K, V]) insert(k K, v V) (*node[K, V], bool) {
+	if n == nil {
+		return &node[K, V]{key: k, value: v, height: 1}, true
+	}
+	var added bool
+	switch c := cmp.Compare(k, n.key); {
+	case c == 0:
+		n.value = v
+		return n, false
+	case c < 0:
+		n.left, added = n.left.insert(k, v)
+	default:
+		n.right, added = n.right.insert(k, v)
+	}
+	return n.rebalance(), added
+}
+
+// delete removes the key from the subtree, and returns the new root of
+// the subtree and whether the key was removed.
+func (n *node[K, V]) delete(k K) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var removed bool
+	switch c := cmp.Compare(k, n.key); {
+	case c < 0:
+		n.left, removed = n.left.delete(k)
+	case c > 0:
+		n.right, removed = n.right.delete(k)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		// replace the node by its successor, the minimum of the right subtree
+		var successor *node[K, V]
+		n.right, successor = n.right.deleteMin()
+		successor.left, successor.right = n.left, n.right
+		return successor.rebalance(), true
+	}
+	return n.rebalance(), removed
+}
+
+// deleteMin removes the node with the smallest key from the subtree, and
+// returns the new root of the subtree and the removed node.
+func (n *node[K, V]) deleteMin() (*node[K, V], *node[K, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var smallest *node[K, V]
+	n.left, smallest = n.left.deleteMin()
+	return n.rebalance(), smallest
+}
+
+// deleteMax is the counterpart of deleteMin for the largest key.
+func (n *node[K, V]) deleteMax() (*node[K, V], *node[K, V]) {
+	if n.right == nil {
+		return n.left, n
+	}
+	var largest *node[K, V]
+	n.right, largest = n.right.deleteMax()
+	return n.rebalance(), largest
+}
+
+func (n *node[K, V]) heightOrZero() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// rebalance updates the height of the node and rotates the subtree if
+// the heights of its children differ by more than one.
+func (n *node[K, V]) rebalance() *node[K, V] {
+	n.update()
+	switch balance := n.left.heightOrZero() - n.right.heightOrZero(); {
+	case balance > 1:
+		if n.left.left.heightOrZero() < n.left.right.heightOrZero() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case balance < -1:
+		if n.right.right.heightOrZero() < n.right.left.heightOrZero() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+func (n *node[K, V]) rotateLeft() *node[K, V] {
+	r := n.right
+	n.right, r.left = r.left, n
+	n.update()
+	r.update()
+	return r
+}
+
+func (n *node[K, V]) rotateRight() *node[K, V] {
+	l := n.left
+	n.left, l.right = l.right, n
+	n.update()
+	l.update()
+	return l
+}
+
+func (n *node[K, V]) update() {
+	n.height = 1 + max(n.left.heightOrZero(), n.right.heightOrZero())
+}
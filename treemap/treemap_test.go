@@ -0,0 +1,195 @@
+package treemap
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// checkTree verifies the ordering, heights and balance of every node.
+func checkTree(t *testing.T, m *Map[int, int]) {
+	t.Helper()
+	var check func(n *node[int, int], lo, hi *int) int
+	check = func(n *node[int, int], lo, hi *int) int {
+		if n == nil {
+			return 0
+		}
+		if (lo != nil && n.key <= *lo) || (hi != nil && n.key >= *hi) {
+			t.Fatalf("key %v out of order", n.key)
+		}
+		l, r := check(n.left, lo, &n.key), check(n.right, &n.key, hi)
+		if n.height != 1+max(l, r) || l-r > 1 || r-l > 1 {
+			t.Fatalf("node %v: height %v, children heights %v and %v", n.key, n.height, l, r)
+		}
+		return n.height
+	}
+	check(m.root, nil, nil)
+	if got := len(slices.Collect(m.Keys())); got != m.Length() {
+		t.Fatalf("Length() = %v, but %v keys", m.Length(), got)
+	}
+}
+
+func TestMap_MatchesGoMap(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := NewMap[int, int]()
+	want := make(map[int]int)
+	for i := range 5000 {
+		k := r.Intn(300)
+		if r.Intn(3) == 0 {
+			m.Delete(k)
+			delete(want, k)
+		} else {
+			m.Set(k, i)
+			want[k] = i
+		}
+		if i%500 == 0 {
+			checkTree(t, m)
+		}
+	}
+	checkTree(t, m)
+	if !maps.Equal(m.ToMap(), want) {
+		t.Errorf("ToMap() = %v, want %v", m.ToMap(), want)
+	}
+	if got := slices.Collect(m.Keys()); !slices.Equal(got, slices.Sorted(maps.Keys(want))) {
+		t.Errorf("Keys() = %v, not sorted", got)
+	}
+}
+
+func TestMap_FloorCeiling(t *testing.T) {
+	m := NewMap(map[int]string{10: "a", 20: "b", 30: "c"})
+	tests := []struct {
+		name        string
+		key         int
+		wantFloor   int
+		wantCeiling int
+	}{
+		{name: "below min", key: 5, wantFloor: -1, wantCeiling: 10},
+		{name: "exact", key: 20, wantFloor: 20, wantCeiling: 20},
+		{name: "between", key: 25, wantFloor: 20, wantCeiling: 30},
+		{name: "above max", key: 35, wantFloor: 30, wantCeiling: -1},
+	}
+	key := func(e collection.Entry[int, string], ok bool) int {
+		if !ok {
+			return -1
+		}
+		return e.Key
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := key(m.Floor(tt.key)); got != tt.wantFloor {
+				t.Errorf("Floor(%v) = %v, want %v", tt.key, got, tt.wantFloor)
+			}
+			if got := key(m.Ceiling(tt.key)); got != tt.wantCeiling {
+				t.Errorf("Ceiling(%v) = %v, want %v", tt.key, got, tt.wantCeiling)
+			}
+		})
+	}
+	if e, ok := m.Min(); !ok || e.Value != "a" {
+		t.Errorf("Min() = %v, %v", e, ok)
+	}
+	if e, ok := m.Max(); !ok || e.Value != "c" {
+		t.Errorf("Max() = %v, %v", e, ok)
+	}
+	if first, _ := m.FirstEntry(); first.Key != 10 {
+		t.Errorf("FirstEntry() = %v", first)
+	}
+	if last, _ := m.LastEntry(); last.Key != 30 {
+		t.Errorf("LastEntry() = %v", last)
+	}
+	if _, ok := NewMap[int, string]().Min(); ok {
+		t.Errorf("Min() of an empty map returned true")
+	}
+}
+
+func TestMap_Poll(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := NewMap[int, int]()
+	for i := range 300 {
+		m.Set(r.Intn(1000), i)
+	}
+	want := slices.Collect(m.Keys())
+	for i := 0; len(want) > 0; i++ {
+		if i%2 == 0 {
+			if e, ok := m.PollFirst(); !ok || e.Key != want[0] {
+				t.Fatalf("PollFirst() at step %d = %v, %v, want key %v", i, e, ok, want[0])
+			}
+			want = want[1:]
+		} else {
+			if e, ok := m.PollLast(); !ok || e.Key != want[len(want)-1] {
+				t.Fatalf("PollLast() at step %d = %v, %v, want key %v", i, e, ok, want[len(want)-1])
+			}
+			want = want[:len(want)-1]
+		}
+		if i%50 == 0 {
+			checkTree(t, m)
+		}
+	}
+	if _, ok := m.PollFirst(); ok || m.Length() != 0 {
+		t.Errorf("PollFirst() of an empty map returned true")
+	}
+	if _, ok := m.PollLast(); ok {
+		t.Errorf("PollLast() of an empty map returned true")
+	}
+}
+
+func TestMap_Range(t *testing.T) {
+	m := NewMap[int, int]()
+	for i := range 20 {
+		m.Set(i*2, i)
+	}
+	tests := []struct {
+		name   string
+		lo, hi int
+		want   []int
+	}{
+		{name: "inside", lo: 3, hi: 9, want: []int{4, 6, 8}},
+		{name: "inclusive lo exclusive hi", lo: 4, hi: 8, want: []int{4, 6}},
+		{name: "before", lo: -10, hi: 0, want: nil},
+		{name: "covering", lo: -1, hi: 6, want: []int{0, 2, 4}},
+		{name: "empty", lo: 8, hi: 4, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for k := range m.Range(tt.lo, tt.hi) {
+				got = append(got, k)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Range(%v, %v) = %v, want %v", tt.lo, tt.hi, got, tt.want)
+			}
+		})
+	}
+	for k := range m.Range(0, 100) {
+		if k == 6 {
+			break
+		}
+	}
+}
+
+func TestMap_Iterators(t *testing.T) {
+	m := FromEntries(slices.Values([]collection.Entry[string, int]{{Key: "b", Value: 2}, {Key: "c", Value: 3}, {Key: "a", Value: 1}, {Key: "b", Value: 4}}))
+	if got := m.String(); got != "TreeMap(string, int) map[a:1 b:4 c:3]" {
+		t.Errorf("String() = %v", got)
+	}
+	var backward []string
+	for k := range m.Backward() {
+		backward = append(backward, k)
+	}
+	if !slices.Equal(backward, []string{"c", "b", "a"}) {
+		t.Errorf("Backward() = %v", backward)
+	}
+	if got := slices.Collect(m.Values()); !slices.Equal(got, []int{1, 4, 3}) {
+		t.Errorf("Values() = %v", got)
+	}
+	c := m.Clone()
+	c.Delete("a")
+	if !m.Contains("a") || c.Contains("a") || c.GetOrElse("a", -1) != -1 {
+		t.Errorf("Clone() shares its nodes with the original")
+	}
+	if e := m.Random(); m.GetOrElse(e.Key, 0) != e.Value {
+		t.Errorf("Random() = %v", e)
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// view.go defines the key-range views of a Map, restricting it to the keys
+// below, from, or between given keys:
+//
+//	todays := events.SubMap(midnight, midnight+24*time.Hour)
+//	for at, e := range todays.All() {
+//	  schedule(at, e)
+//	}
+//	todays.Clear() // removes today's events from events
+
+package treemap
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// bounds is a key range, inclusive of lo and exclusive of hi.
+// A missing bound leaves the range open on that side.
+type bounds[K cmp.Ordered] struct {
+	lo, hi       K
+	hasLo, hasHi bool
+}
+
+// aboveLo returns true if k is strictly greater than the lower bound,
+// i.e. if keys less than k may be in the range.
+func (b bounds[K]) aboveLo(k K) bool {
+	return !b.hasLo || cmp.Less(b.lo, k)
+}
+
+// belowHi returns true if k is less than the upper bound.
+func (b bounds[K]) belowHi(k K) bool {
+	return !b.hasHi || cmp.Less(k, b.hi)
+}
+
+func (b bounds[K]) contains(k K) bool {
+	return (!b.hasLo || !cmp.Less(k, b.lo)) && b.belowHi(k)
+}
+
+// intersect returns the range of the keys within both b and o.
+func (b bounds[K]) intersect(o bounds[K]) bounds[K] {
+	if o.hasLo && (!b.hasLo || cmp.Less(b.lo, o.lo)) {
+		b.lo, b.hasLo = o.lo, true
+	}
+	if o.hasHi && (!b.hasHi || cmp.Less(o.hi, b.hi)) {
+		b.hi, b.hasHi = o.hi, true
+	}
+	return b
+}
+
+// View is a live view of the entries of a Map whose keys are within a
+// range. It holds no entries of its own: reads go through to the map, so
+// they reflect its later changes, and writes within the range modify it.
+// Get, Set and Delete run in O(log n), Length in O(log n + m) where m is
+// the number of entries in the range. Use Clone for a snapshot.
+type View[K cmp.Ordered, V any] struct {
+	m *Map[K, V]
+	b bounds[K]
+}
+
+// HeadMap returns a view of the entries of the map with keys less than hi.
+//
+// example usage:
+//
+//	m := NewMap(map[int]string{1: "a", 2: "b", 3: "c"})
+//	m.HeadMap(3)
+//
+// output:
+//
+//	TreeMapView(int, string) map[1:a 2:b]
+func (t *Map[K, V]) HeadMap(hi K) *View[K, V] {
+	return &View[K, V]{m: t, b: bounds[K]{hi: hi, hasHi: true}}
+}
+
+// TailMap returns a view of the entries of the map with keys greater than
+// or equal to lo.
+//
+// example usage:
+//
+//	m := NewMap(map[int]string{1: "a", 2: "b", 3: "c"})
+//	m.TailMap(2)
+//
+// output:
+//
+//	TreeMapView(int, string) map[2:b 3:c]
+func (t *Map[K, V]) TailMap(lo K) *View[K, V] {
+	return &View[K, V]{m: t, b: bounds[K]{lo: lo, hasLo: true}}
+}
+
+// SubMap returns a view of the entries of the map with keys between lo
+// (inclusive) and hi (exclusive), the keys iterated by Range.
+//
+// example usage:
+//
+//	m := NewMap(map[int]string{1: "a", 2: "b", 3: "c", 4: "d"})
+//	v := m.SubMap(2, 4)
+//	v.Delete(3)
+//	m
+//
+// output:
+//
+//	TreeMap(int, string) map[1:a 2:b 4:d]
+func (t *Map[K, V]) SubMap(lo, hi K) *View[K, V] {
+	return &View[K, V]{m: t, b: bounds[K]{lo: lo, hi: hi, hasLo: true, hasHi: true}}
+}
+
+// All returns an iterator over the key/value pairs of the view, in ascending key order.
+func (v *View[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		v.m.root.ascendWithin(v.b, yield)
+	}
+}
+
+// Backward returns an iterator over the key/value pairs of the view, in descending key order.
+func (v *View[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		v.m.root.descendWithin(v.b, yield)
+	}
+}
+
+// Ceiling returns the entry of the view with the smallest key greater than
+// or equal to k, or false if there is none.
+func (v *View[K, V]) Ceiling(k K) (collection.Entry[K, V], bool) {
+	if v.b.hasLo && cmp.Less(k, v.b.lo) {
+		k = v.b.lo
+	}
+	return v.within(v.m.ceiling(k))
+}
+
+// Clear removes the entries of the view from the map.
+func (v *View[K, V]) Clear() {
+	// collect the keys first, the tree must not change during the iteration
+	for _, k := range slices.Collect(v.Keys()) {
+		v.m.Delete(k)
+	}
+}
+
+// Clone returns a new Map holding a snapshot of the entries of the view.
+func (v *View[K, V]) Clone() *Map[K, V] {
+	t := NewMap[K, V]()
+	for k, val := range v.All() {
+		t.Set(k, val)
+	}
+	return t
+}
+
+// Contains returns true if the key is within the view and in the map.
+func (v *View[K, V]) Contains(k K) bool {
+	return v.b.contains(k) && v.m.Contains(k)
+}
+
+// Delete removes the key from the map if it is within the view.
+func (v *View[K, V]) Delete(k K) {
+	if v.b.contains(k) {
+		v.m.Delete(k)
+	}
+}
+
+// Floor returns the entry of the view with the largest key less than or
+// equal to k, or false if there is none.
+func (v *View[K, V]) Floor(k K) (collection.Entry[K, V], bool) {
+	if v.b.belowHi(k) {
+		return v.within(v.m.floor(k))
+	}
+	return v.within(v.m.lower(v.b.hi))
+}
+
+// Get returns the value of the key, or false if the key is not within the
+// view or not in the map.
+func (v *View[K, V]) Get(k K) (V, bool) {
+	if !v.b.contains(k) {
+		return *new(V), false
+	}
+	return v.m.Get(k)
+}
+
+// GetOrElse returns the value of the key, or def if the key is not within
+// the view or not in the map.
+func (v *View[K, V]) GetOrElse(k K, def V) V {
+	if val, ok := v.Get(k); ok {
+		return val
+	}
+	return def
+}
+
+// HeadMap returns a view of the entries of the view with keys less than hi.
+func (v *View[K, V]) HeadMap(hi K) *View[K, V] {
+	return &View[K, V]{m: v.m, b: v.b.intersect(bounds[K]{hi: hi, hasHi: true})}
+}
+
+// IsEmpty returns true if the view is empty.
+func (v *View[K, V]) IsEmpty() bool {
+	_, ok := v.Min()
+	return !ok
+}
+
+// Keys returns an iterator over the keys of the view, in ascending order.
+func (v *View[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		v.m.root.ascendWithin(v.b, func(k K, _ V) bool { return yield(k) })
+	}
+}
+
+// Length returns the number of entries in the view.
+func (v *View[K, V]) Length() int {
+	n := 0
+	for range v.All() {
+		n++
+	}
+	return n
+}
+
+// Max returns the entry of the view with the largest key, or false if the
+// view is empty.
+func (v *View[K, V]) Max() (collection.Entry[K, V], bool) {
+	if !v.b.hasHi {
+		e, ok := v.m.Max()
+		if !ok || !v.b.contains(e.Key) {
+			return collection.Entry[K, V]{}, false
+		}
+		return e, true
+	}
+	return v.within(v.m.lower(v.b.hi))
+}
+
+// Min returns the entry of the view with the smallest key, or false if the
+// view is empty.
+func (v *View[K, V]) Min() (collection.Entry[K, V], bool) {
+	if !v.b.hasLo {
+		e, ok := v.m.Min()
+		if !ok || !v.b.contains(e.Key) {
+			return collection.Entry[K, V]{}, false
+		}
+		return e, true
+	}
+	return v.within(v.m.ceiling(v.b.lo))
+}
+
+// NonEmpty returns true if the view is not empty.
+func (v *View[K, V]) NonEmpty() bool {
+	return !v.IsEmpty()
+}
+
+// Set sets the value of the key in the map.
+// It panics with collection.KeyOutOfRangeError if the key is not within the view.
+func (v *View[K, V]) Set(k K, val V) {
+	if !v.b.contains(k) {
+		panic(collection.KeyOutOfRangeError)
+	}
+	v.m.Set(k, val)
+}
+
+// SubMap returns a view of the entries of the view with keys between lo
+// (inclusive) and hi (exclusive).
+func (v *View[K, V]) SubMap(lo, hi K) *View[K, V] {
+	return &View[K, V]{m: v.m, b: v.b.intersect(bounds[K]{lo: lo, hi: hi, hasLo: true, hasHi: true})}
+}
+
+// TailMap returns a view of the entries of the view with keys greater than
+// or equal to lo.
+func (v *View[K, V]) TailMap(lo K) *View[K, V] {
+	return &View[K, V]{m: v.m, b: v.b.intersect(bounds[K]{lo: lo, hasLo: true})}
+}
+
+// ToMap returns a copy of the view as a Go map.
+func (v *View[K, V]) ToMap() map[K]V {
+	m := make(map[K]V)
+	for k, val := range v.All() {
+		m[k] = val
+	}
+	return m
+}
+
+// Values returns an iterator over the values of the view, in ascending key order.
+func (v *View[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		v.m.root.ascendWithin(v.b, func(_ K, val V) bool { return yield(val) })
+	}
+}
+
+// implement the Stringer interface
+func (v *View[K, V]) String() string {
+	var sb strings.Builder
+	for k, val := range v.All() {
+		if sb.Len() > 0 {
+			sb.WriteByte(' ')
+		}
+		fmt.Fprintf(&sb, "%v:%v", k, val)
+	}
+	return fmt.Sprintf("TreeMapView(%T, %T) map[%v]", *new(K), *new(V), sb.String())
+}
+
+// within returns the entry of n, or false if n is nil or outside the view.
+func (v *View[K, V]) within(n *node[K, V]) (collection.Entry[K, V], bool) {
+	if n == nil || !v.b.contains(n.key) {
+		return collection.Entry[K, V]{}, false
+	}
+	return n.entry(), true
+}
@@ -0,0 +1,148 @@
+package treemap
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+)
+
+func TestView_Bounds(t *testing.T) {
+	m := NewMap[int, int]()
+	for i := range 10 {
+		m.Set(i*2, i)
+	}
+	tests := []struct {
+		name string
+		view *View[int, int]
+		want []int
+	}{
+		{name: "head", view: m.HeadMap(5), want: []int{0, 2, 4}},
+		{name: "head exclusive", view: m.HeadMap(4), want: []int{0, 2}},
+		{name: "tail", view: m.TailMap(13), want: []int{14, 16, 18}},
+		{name: "tail inclusive", view: m.TailMap(14), want: []int{14, 16, 18}},
+		{name: "sub", view: m.SubMap(3, 9), want: []int{4, 6, 8}},
+		{name: "sub empty", view: m.SubMap(9, 3), want: nil},
+		{name: "head of tail", view: m.TailMap(4).HeadMap(10), want: []int{4, 6, 8}},
+		{name: "narrowing only", view: m.SubMap(4, 10).SubMap(0, 100), want: []int{4, 6, 8}},
+		{name: "disjoint", view: m.HeadMap(6).TailMap(10), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(tt.view.Keys()); !slices.Equal(got, tt.want) {
+				t.Errorf("Keys() = %v, want %v", got, tt.want)
+			}
+			var backward []int
+			for k := range tt.view.Backward() {
+				backward = append(backward, k)
+			}
+			if want := slices.Clone(tt.want); !slices.Equal(backward, reversed(want)) {
+				t.Errorf("Backward() = %v, want %v reversed", backward, tt.want)
+			}
+			if got := tt.view.Length(); got != len(tt.want) {
+				t.Errorf("Length() = %v, want %v", got, len(tt.want))
+			}
+			minE, minOk := tt.view.Min()
+			maxE, maxOk := tt.view.Max()
+			if len(tt.want) == 0 {
+				if minOk || maxOk || tt.view.NonEmpty() {
+					t.Errorf("Min() = %v, Max() = %v of an empty view", minE, maxE)
+				}
+				return
+			}
+			if minE.Key != tt.want[0] || maxE.Key != tt.want[len(tt.want)-1] || tt.view.IsEmpty() {
+				t.Errorf("Min() = %v, Max() = %v, want keys %v and %v", minE, maxE, tt.want[0], tt.want[len(tt.want)-1])
+			}
+		})
+	}
+}
+
+func reversed(s []int) []int {
+	slices.Reverse(s)
+	return s
+}
+
+func TestView_FloorCeiling(t *testing.T) {
+	v := NewMap(map[int]string{10: "a", 20: "b", 30: "c", 40: "d"}).SubMap(15, 40)
+	tests := []struct {
+		name   string
+		f      func(int) (collection.Entry[int, string], bool)
+		k      int
+		want   int
+		wantOk bool
+	}{
+		{name: "floor inside", f: v.Floor, k: 25, want: 20, wantOk: true},
+		{name: "floor below lo", f: v.Floor, k: 17, wantOk: false},
+		{name: "floor above hi", f: v.Floor, k: 100, want: 30, wantOk: true},
+		{name: "floor at hi", f: v.Floor, k: 40, want: 30, wantOk: true},
+		{name: "ceiling inside", f: v.Ceiling, k: 25, want: 30, wantOk: true},
+		{name: "ceiling below lo", f: v.Ceiling, k: 0, want: 20, wantOk: true},
+		{name: "ceiling above last", f: v.Ceiling, k: 31, wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.f(tt.k)
+			if ok != tt.wantOk || (ok && got.Key != tt.want) {
+				t.Errorf("got %v %v, want %v %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestView_Live(t *testing.T) {
+	m := NewMap(map[string]int{"apple": 1, "banana": 2, "cherry": 3})
+	v := m.SubMap("b", "d")
+	m.Set("blueberry", 4)
+	m.Delete("cherry")
+	if got := v.String(); got != "TreeMapView(string, int) map[banana:2 blueberry:4]" {
+		t.Errorf("String() = %v", got)
+	}
+	v.Set("coconut", 5)
+	v.Delete("apple")
+	if got := m.String(); got != "TreeMap(string, int) map[apple:1 banana:2 blueberry:4 coconut:5]" {
+		t.Errorf("map after writes through the view = %v", got)
+	}
+	if _, ok := v.Get("apple"); ok || v.Contains("apple") || v.GetOrElse("apple", -1) != -1 {
+		t.Errorf("view returned a key outside its range")
+	}
+	snapshot := v.Clone()
+	v.Clear()
+	if got := m.String(); got != "TreeMap(string, int) map[apple:1]" {
+		t.Errorf("map after Clear() = %v", got)
+	}
+	if want := map[string]int{"banana": 2, "blueberry": 4, "coconut": 5}; !maps.Equal(snapshot.ToMap(), want) {
+		t.Errorf("Clone() = %v, want %v", snapshot, want)
+	}
+	defer func() {
+		if r := recover(); r != collection.KeyOutOfRangeError {
+			t.Errorf("Set() out of range panicked with %v", r)
+		}
+	}()
+	v.Set("date", 6)
+}
+
+func TestView_MatchesFilteredMap(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := NewMap[int, int]()
+	for i := range 500 {
+		m.Set(r.Intn(1000), i)
+	}
+	for range 100 {
+		lo, hi := r.Intn(1000), r.Intn(1000)
+		want := make(map[int]int)
+		for k, val := range m.All() {
+			if k >= lo && k < hi {
+				want[k] = val
+			}
+		}
+		v := m.TailMap(lo).HeadMap(hi)
+		if got := v.ToMap(); !maps.Equal(got, want) {
+			t.Fatalf("SubMap(%v, %v) = %v, want %v", lo, hi, got, want)
+		}
+		if v.Length() != len(want) {
+			t.Fatalf("SubMap(%v, %v).Length() = %v, want %v", lo, hi, v.Length(), len(want))
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// sanitize.go defines the Sanitize pipeline, which applies ordered fix-up
+// rules to each element of a collection and records what every rule did
+// in a Report, so data-cleaning steps can be audited.
+
+package validate
+
+import (
+	"fmt"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/sequence"
+)
+
+// Outcome is the result of applying a Fix to a single value.
+type Outcome int
+
+const (
+	// Unchanged means the fix left the value as it was.
+	Unchanged Outcome = iota
+	// Fixed means the fix modified the value.
+	Fixed
+	// Rejected means the value must be dropped, later fixes are not applied.
+	Rejected
+)
+
+// Fix is a named fix-up rule. Apply returns the possibly modified value
+// along with the outcome of the rule.
+type Fix[T any] struct {
+	Name  string
+	Apply func(T) (T, Outcome)
+}
+
+// Replace returns a fix that replaces each value by f(value).
+// The value is counted as fixed when f returns a different value.
+//
+// example usage:
+//
+//	trim := Replace("trim", strings.TrimSpace)
+//	trim.Apply(" go ")
+//
+// output:
+//
+//	go 1
+func Replace[T comparable](name string, f func(T) T) Fix[T] {
+	return Fix[T]{Name: name, Apply: func(v T) (T, Outcome) {
+		if r := f(v); r != v {
+			return r, Fixed
+		}
+		return v, Unchanged
+	}}
+}
+
+// RejectIf returns a fix that rejects the values satisfying the predicate.
+func RejectIf[T any](name string, f func(T) bool) Fix[T] {
+	return Fix[T]{Name: name, Apply: func(v T) (T, Outcome) {
+		if f(v) {
+			return v, Rejected
+		}
+		return v, Unchanged
+	}}
+}
+
+// RejectInvalid returns a fix that rejects the values violating any of the rules.
+//
+// example usage:
+//
+//	RejectInvalid("positive", InRange(1, math.MaxInt))
+func RejectInvalid[T any](name string, rules ...Rule[T]) Fix[T] {
+	return RejectIf(name, func(v T) bool {
+		for _, rule := range rules {
+			if len(rule(v)) > 0 {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// FixStats counts how many values a fix modified and rejected.
+type FixStats struct {
+	Name     string
+	Applied  int
+	Rejected int
+}
+
+// RejectedValue records a value dropped by a fix. Index is the position
+// of the value in the source collection, Value is the value as it was
+// when the fix rejected it.
+type RejectedValue[T any] struct {
+	Index int
+	Fix   string
+	Value T
+}
+
+// Report describes a Sanitize run. Stats holds one entry per fix,
+// in the order the fixes were given.
+type Report[T any] struct {
+	Stats   []FixStats
+	Rejects *sequence.Sequence[RejectedValue[T]]
+}
+
+// Stat returns the statistics of the named fix.
+func (r *Report[T]) Stat(name string) (FixStats, bool) {
+	for _, s := range r.Stats {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return FixStats{}, false
+}
+
+// implement the Stringer interface
+func (r *Report[T]) String() string {
+	return fmt.Sprintf("Report %v rejects=%d", r.Stats, r.Rejects.Length())
+}
+
+// Sanitize applies the fixes in order to each element of the collection
+// and returns a new collection of the same type holding the values that
+// were not rejected, along with a report of the run.
+//
+// example usage:
+//
+//	c := sequence.NewSequence([]string{" go", "", "rust "})
+//	Sanitize(c, Replace("trim", strings.TrimSpace), RejectIf("empty", func(s string) bool { return s == "" }))
+//
+// output:
+//
+//	Seq(string) [go rust], Report [{trim 2 0} {empty 0 1}] rejects=1
+func Sanitize[T any](c collection.Collection[T], fixes ...Fix[T]) (collection.Collection[T], *Report[T]) {
+	return SanitizeInto(c, c.New(), fixes...)
+}
+
+// SanitizeInto is similar to Sanitize but adds the sanitized values to dst.
+func SanitizeInto[C collection.Collection[T], T any](c collection.Collection[T], dst C, fixes ...Fix[T]) (C, *Report[T]) {
+	report := &Report[T]{
+		Stats:   make([]FixStats, len(fixes)),
+		Rejects: sequence.NewSequence[RejectedValue[T]](),
+	}
+	for i, fix := range fixes {
+		report.Stats[i].Name = fix.Name
+	}
+	i := 0
+	for v := range c.Values() {
+		rejected := false
+		for j := 0; j < len(fixes) && !rejected; j++ {
+			var outcome Outcome
+			v, outcome = fixes[j].Apply(v)
+			switch outcome {
+			case Fixed:
+				report.Stats[j].Applied++
+			case Rejected:
+				report.Stats[j].Rejected++
+				report.Rejects.Add(RejectedValue[T]{Index: i, Fix: fixes[j].Name, Value: v})
+				rejected = true
+			}
+		}
+		if !rejected {
+			dst.Add(v)
+		}
+		i++
+	}
+	return dst, report
+}
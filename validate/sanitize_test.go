@@ -0,0 +1,58 @@
+package validate
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charbz/gophers/list"
+	"github.com/charbz/gophers/sequence"
+)
+
+func TestSanitize(t *testing.T) {
+	c := sequence.NewSequence([]string{" Go", "", "rust ", "  ", "zig", "COBOL"})
+	got, report := Sanitize(c,
+		Replace("trim", strings.TrimSpace),
+		RejectIf("empty", func(s string) bool { return s == "" }),
+		Replace("lower", strings.ToLower),
+		RejectInvalid("no cobol", Check("must not be cobol", func(s string) bool { return s != "cobol" })),
+	)
+	if got := slices.Collect(got.Values()); !slices.Equal(got, []string{"go", "rust", "zig"}) {
+		t.Errorf("Sanitize() = %v, want [go rust zig]", got)
+	}
+	wantStats := []FixStats{
+		{Name: "trim", Applied: 3},
+		{Name: "empty", Rejected: 2},
+		{Name: "lower", Applied: 2},
+		{Name: "no cobol", Rejected: 1},
+	}
+	if !slices.Equal(report.Stats, wantStats) {
+		t.Errorf("Stats = %v, want %v", report.Stats, wantStats)
+	}
+	wantRejects := []RejectedValue[string]{
+		{Index: 1, Fix: "empty", Value: ""},
+		{Index: 3, Fix: "empty", Value: ""},
+		{Index: 5, Fix: "no cobol", Value: "cobol"},
+	}
+	if !slices.Equal(report.Rejects.ToSlice(), wantRejects) {
+		t.Errorf("Rejects = %v, want %v", report.Rejects.ToSlice(), wantRejects)
+	}
+	if s, ok := report.Stat("lower"); !ok || s.Applied != 2 {
+		t.Errorf("Stat(lower) = %v, %v, want {lower 2 0}, true", s, ok)
+	}
+	if _, ok := report.Stat("missing"); ok {
+		t.Errorf("Stat(missing) = _, true, want false")
+	}
+}
+
+func TestSanitizeInto(t *testing.T) {
+	c := sequence.NewSequence([]int{-3, 0, 7, 12})
+	clamp := Replace("clamp", func(i int) int { return min(max(i, 0), 10) })
+	got, report := SanitizeInto(c, list.NewList[int](), clamp)
+	if !slices.Equal(got.ToSlice(), []int{0, 0, 7, 10}) {
+		t.Errorf("SanitizeInto() = %v, want [0 0 7 10]", got)
+	}
+	if report.Stats[0].Applied != 2 || report.Rejects.Length() != 0 {
+		t.Errorf("report = %v, want 2 applied and no rejects", report)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package validate runs a set of rules over every element of a collection
+// and reports each failure as a structured Violation, rather than stopping
+// at the first invalid element:
+//
+//	type User struct {
+//	  Name  string
+//	  Email string
+//	  Age   int
+//	}
+//
+//	violations := validate.Validate(users,
+//	  validate.Field("name", func(u User) string { return u.Name }, validate.NonZero[string]()),
+//	  validate.Field("email", func(u User) string { return u.Email }, validate.MatchesRegexp[string](`^\S+@\S+$`)),
+//	  validate.Field("age", func(u User) int { return u.Age }, validate.InRange(0, 150)),
+//	)
+//
+// Rules are plain functions, so user defined rules compose with the built-in ones.
+package validate
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+
+	"github.com/charbz/gophers/collection"
+)
+
+// Violation describes a rule that an element of a collection failed.
+// Index is the position of the element in iteration order, Field is the
+// dotted path of the offending field, empty when the rule applies to the
+// element itself, and Message explains why the rule failed.
+type Violation struct {
+	Index   int
+	Field   string
+	Message string
+}
+
+// Error implements the error interface.
+func (v Violation) Error() string {
+	if v.Field == "" {
+		return fmt.Sprintf("[%d] %s", v.Index, v.Message)
+	}
+	return fmt.Sprintf("[%d] %s: %s", v.Index, v.Field, v.Message)
+}
+
+// Rule checks a single value and returns the violations it finds.
+// The Index of returned violations is set by Validate.
+type Rule[T any] func(T) []Violation
+
+// Validate applies every rule to every element of the collection and returns
+// all violations found, ordered by element then by rule.
+// It returns nil when all elements are valid.
+//
+// example usage:
+//
+//	c := sequence.NewSequence([]int{5, 0, 12})
+//	Validate(c, NonZero[int](), InRange(1, 10))
+//
+// output:
+//
+//	[{1  must not be zero} {1  must be between 1 and 10} {2  must be between 1 and 10}]
+func Validate[T any](c collection.Collection[T], rules ...Rule[T]) []Violation {
+	var violations []Violation
+	i := 0
+	for v := range c.Values() {
+		for _, rule := range rules {
+			for _, violation := range rule(v) {
+				violation.Index = i
+				violations = append(violations, violation)
+			}
+		}
+		i++
+	}
+	return violations
+}
+
+// Valid returns true if every element of the collection satisfies every rule.
+func Valid[T any](c collection.Collection[T], rules ...Rule[T]) bool {
+	for v := range c.Values() {
+		for _, rule := range rules {
+			if len(rule(v)) > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Check returns a rule that reports message whenever the predicate is not satisfied.
+//
+// example usage:
+//
+//	even := Check("must be even", func(i int) bool { return i%2 == 0 })
+//	even(3)
+//
+// output:
+//
+//	[{0  must be even}]
+func Check[T any](message string, f func(T) bool) Rule[T] {
+	return func(v T) []Violation {
+		if f(v) {
+			return nil
+		}
+		return []Violation{{Message: message}}
+	}
+}
+
+// Field returns a rule that applies the given rules to a field of T extracted by get.
+// The name is prepended to the Field of the resulting violations, so nested
+// Field rules produce dotted paths such as "address.zip".
+//
+// example usage:
+//
+//	rule := Field("age", func(u User) int { return u.Age }, InRange(0, 150))
+//	rule(User{Age: 200})
+//
+// output:
+//
+//	[{0 age must be between 0 and 150}]
+func Field[T, F any](name string, get func(T) F, rules ...Rule[F]) Rule[T] {
+	return func(v T) []Violation {
+		var violations []Violation
+		f := get(v)
+		for _, rule := range rules {
+			for _, violation := range rule(f) {
+				if violation.Field == "" {
+					violation.Field = name
+				} else {
+					violation.Field = name + "." + violation.Field
+				}
+				violations = append(violations, violation)
+			}
+		}
+		return violations
+	}
+}
+
+// NonZero returns a rule that reports the zero value of T.
+func NonZero[T comparable]() Rule[T] {
+	var zero T
+	return Check("must not be zero", func(v T) bool { return v != zero })
+}
+
+// MatchesRegexp returns a rule that reports strings not matching the regular expression.
+// It panics if the expression cannot be parsed.
+func MatchesRegexp[T ~string](expr string) Rule[T] {
+	re := regexp.MustCompile(expr)
+	return Check(fmt.Sprintf("must match %q", expr), func(v T) bool { return re.MatchString(string(v)) })
+}
+
+// InRange returns a rule that reports values outside the inclusive range [min, max].
+func InRange[T cmp.Ordered](min, max T) Rule[T] {
+	return Check(fmt.Sprintf("must be between %v and %v", min, max), func(v T) bool {
+		return v >= min && v <= max
+	})
+}
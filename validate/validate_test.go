@@ -0,0 +1,116 @@
+package validate
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/sequence"
+)
+
+type address struct {
+	City string
+	Zip  string
+}
+
+type user struct {
+	Name    string
+	Email   string
+	Age     int
+	Address address
+}
+
+func TestValidate(t *testing.T) {
+	users := sequence.NewSequence([]user{
+		{Name: "ana", Email: "ana@example.com", Age: 31, Address: address{City: "Lyon", Zip: "69001"}},
+		{Name: "", Email: "bob", Age: 200, Address: address{City: "Paris", Zip: "75001"}},
+		{Name: "cy", Email: "cy@example.com", Age: 22, Address: address{Zip: "nope"}},
+	})
+	got := Validate(users,
+		Field("name", func(u user) string { return u.Name }, NonZero[string]()),
+		Field("email", func(u user) string { return u.Email }, MatchesRegexp[string](`^\S+@\S+$`)),
+		Field("age", func(u user) int { return u.Age }, InRange(0, 150)),
+		Field("address", func(u user) address { return u.Address },
+			Field("city", func(a address) string { return a.City }, NonZero[string]()),
+			Field("zip", func(a address) string { return a.Zip }, MatchesRegexp[string](`^\d{5}$`)),
+		),
+	)
+	want := []Violation{
+		{Index: 1, Field: "name", Message: "must not be zero"},
+		{Index: 1, Field: "email", Message: `must match "^\\S+@\\S+$"`},
+		{Index: 1, Field: "age", Message: "must be between 0 and 150"},
+		{Index: 2, Field: "address.city", Message: "must not be zero"},
+		{Index: 2, Field: "address.zip", Message: `must match "^\\d{5}$"`},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+}
+
+func TestValidate_Rules(t *testing.T) {
+	even := Check("must be even", func(i int) bool { return i%2 == 0 })
+	tests := []struct {
+		name  string
+		slice []int
+		rules []Rule[int]
+		want  []Violation
+	}{
+		{
+			name:  "valid",
+			slice: []int{2, 4, 6},
+			rules: []Rule[int]{NonZero[int](), even},
+			want:  nil,
+		},
+		{
+			name:  "user rule",
+			slice: []int{2, 3, 4, 5},
+			rules: []Rule[int]{even},
+			want:  []Violation{{Index: 1, Message: "must be even"}, {Index: 3, Message: "must be even"}},
+		},
+		{
+			name:  "several rules per element",
+			slice: []int{5, 0, 12},
+			rules: []Rule[int]{NonZero[int](), InRange(1, 10)},
+			want: []Violation{
+				{Index: 1, Message: "must not be zero"},
+				{Index: 1, Message: "must be between 1 and 10"},
+				{Index: 2, Message: "must be between 1 and 10"},
+			},
+		},
+		{
+			name:  "empty",
+			slice: []int{},
+			rules: []Rule[int]{even},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := sequence.NewSequence(tt.slice)
+			got := Validate(c, tt.rules...)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+			if Valid(c, tt.rules...) != (len(tt.want) == 0) {
+				t.Errorf("Valid() = %v, want %v", !(len(tt.want) == 0), len(tt.want) == 0)
+			}
+		})
+	}
+}
+
+func TestViolation_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Violation
+		want string
+	}{
+		{name: "element", v: Violation{Index: 3, Message: "must be even"}, want: "[3] must be even"},
+		{name: "field", v: Violation{Index: 0, Field: "address.zip", Message: "must not be zero"}, want: "[0] address.zip: must not be zero"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
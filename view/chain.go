@@ -0,0 +1,165 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+package view
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/sequence"
+)
+
+// ChainView is a read-only OrderedCollection presenting several ordered
+// collections as a single one, the elements of each collection following
+// those of the previous one. Unlike Concat, it copies no element: indices
+// are translated to the collection holding the element, in O(k) for k
+// collections, and the view reflects later changes to the collections.
+type ChainView[T any] struct {
+	parts []collection.OrderedCollection[T]
+}
+
+// NewChainView returns a view chaining the given collections.
+//
+// example usage:
+//
+//	hot := sequence.NewSequence([]string{"a", "b"})
+//	cold := list.NewList([]string{"c", "d", "e"})
+//	v := NewChainView[string](hot, cold)
+//	v.Length()
+//	v.At(3)
+//
+// output:
+//
+//	5
+//	"d"
+func NewChainView[T any](cs ...collection.OrderedCollection[T]) *ChainView[T] {
+	return &ChainView[T]{parts: slices.Clone(cs)}
+}
+
+// The following methods implement
+// the Collection interface.
+
+// Add panics with collection.ReadOnlyCollectionError, a view cannot be modified.
+func (v *ChainView[T]) Add(T) {
+	panic(collection.ReadOnlyCollectionError)
+}
+
+// Length returns the sum of the lengths of the chained collections.
+func (v *ChainView[T]) Length() int {
+	n := 0
+	for _, c := range v.parts {
+		n += c.Length()
+	}
+	return n
+}
+
+// New returns a new sequence, since a view cannot hold elements of its own.
+func (v *ChainView[T]) New(s ...[]T) collection.Collection[T] {
+	return sequence.NewSequence(s...)
+}
+
+// Random returns a random element of the chained collections.
+func (v *ChainView[T]) Random() T {
+	n := v.Length()
+	if n == 0 {
+		panic(collection.EmptyCollectionError)
+	}
+	return v.At(collection.DefaultRandState().IntN(n))
+}
+
+// Values returns an iterator over the elements of each collection in turn.
+func (v *ChainView[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, c := range v.parts {
+			for e := range c.Values() {
+				if !yield(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// The following methods implement
+// the OrderedCollection interface.
+
+// At returns the element at the given index of the chain.
+func (v *ChainView[T]) At(index int) T {
+	if index >= 0 {
+		for _, c := range v.parts {
+			n := c.Length()
+			if index < n {
+				return c.At(index)
+			}
+			index -= n
+		}
+	}
+	panic(collection.IndexOutOfBoundsError)
+}
+
+// All returns an iterator over the elements of the chain and their indices.
+func (v *ChainView[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		offset := 0
+		for _, c := range v.parts {
+			for i, e := range c.All() {
+				if !yield(offset+i, e) {
+					return
+				}
+			}
+			offset += c.Length()
+		}
+	}
+}
+
+// Backward returns an iterator over the elements of the chain and their
+// indices in reverse order.
+func (v *ChainView[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		offset := v.Length()
+		for _, c := range slices.Backward(v.parts) {
+			offset -= c.Length()
+			for i, e := range c.Backward() {
+				if !yield(offset+i, e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Slice returns a view chaining the slices of the collections covered by
+// the start and end indices.
+func (v *ChainView[T]) Slice(start, end int) collection.OrderedCollection[T] {
+	if start < 0 || end > v.Length() || start > end {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	s := &ChainView[T]{}
+	for _, c := range v.parts {
+		n := c.Length()
+		if start < n && end > 0 {
+			s.parts = append(s.parts, c.Slice(max(start, 0), min(end, n)))
+		}
+		start, end = start-n, end-n
+	}
+	return s
+}
+
+// NewOrdered returns a new sequence, since a view cannot hold elements of its own.
+func (v *ChainView[T]) NewOrdered(s ...[]T) collection.OrderedCollection[T] {
+	return sequence.NewSequence(s...)
+}
+
+// ToSlice returns the elements of the chain as a new slice.
+func (v *ChainView[T]) ToSlice() []T {
+	return slices.AppendSeq(make([]T, 0, v.Length()), v.Values())
+}
+
+// implement the Stringer interface
+func (v *ChainView[T]) String() string {
+	return fmt.Sprintf("ChainView(%T) %v", *new(T), v.ToSlice())
+}
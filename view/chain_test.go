@@ -0,0 +1,74 @@
+package view
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/collectiontest"
+	"github.com/charbz/gophers/list"
+	"github.com/charbz/gophers/sequence"
+)
+
+func TestChainView_MatchesSequence(t *testing.T) {
+	var readOnly []collectiontest.Op[collection.OrderedCollection[int]]
+	for _, op := range collectiontest.OrderedOps[int, collection.OrderedCollection[int]](func(r *rand.Rand) int { return r.Intn(50) }) {
+		if op.Name != "Add" {
+			readOnly = append(readOnly, op)
+		}
+	}
+	collectiontest.Check(t,
+		func() collection.OrderedCollection[int] { return sequence.NewSequence([]int{1, 2, 3, 4, 5, 6}) },
+		func() collection.OrderedCollection[int] {
+			return NewChainView(sequence.NewSequence([]int{1, 2}), list.NewList[int](), list.NewList([]int{3, 4, 5}), sequence.NewSequence([]int{6}))
+		},
+		readOnly, collectiontest.Options[int]{Steps: 200},
+	)
+}
+
+func TestChainView_Slice(t *testing.T) {
+	v := NewChainView[int](sequence.NewSequence([]int{1, 2, 3}), list.NewList([]int{4, 5}), sequence.NewSequence([]int{6, 7}))
+	tests := []struct {
+		name       string
+		start, end int
+		want       []int
+		wantParts  int
+	}{
+		{name: "within a collection", start: 3, end: 5, want: []int{4, 5}, wantParts: 1},
+		{name: "across collections", start: 2, end: 6, want: []int{3, 4, 5, 6}, wantParts: 3},
+		{name: "whole chain", start: 0, end: 7, want: []int{1, 2, 3, 4, 5, 6, 7}, wantParts: 3},
+		{name: "empty", start: 3, end: 3, want: nil, wantParts: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := v.Slice(tt.start, tt.end).(*ChainView[int])
+			if got := slices.Collect(s.Values()); !slices.Equal(got, tt.want) {
+				t.Errorf("Slice(%v, %v) = %v, want %v", tt.start, tt.end, got, tt.want)
+			}
+			if len(s.parts) != tt.wantParts {
+				t.Errorf("Slice(%v, %v) chains %v collections, want %v", tt.start, tt.end, len(s.parts), tt.wantParts)
+			}
+		})
+	}
+}
+
+func TestChainView_ReflectsCollections(t *testing.T) {
+	hot := sequence.NewSequence([]string{"a", "b"})
+	cold := list.NewList([]string{"c"})
+	v := NewChainView[string](hot, cold)
+	hot.Add("b2")
+	cold.Add("d")
+	if got := v.String(); got != "ChainView(string) [a b b2 c d]" {
+		t.Errorf("String() = %q", got)
+	}
+	if v.Length() != 5 || v.At(3) != "c" {
+		t.Errorf("Length() = %v, At(3) = %v, want 5, c", v.Length(), v.At(3))
+	}
+	defer func() {
+		if r := recover(); r != collection.ReadOnlyCollectionError {
+			t.Errorf("Add() recovered %v, want %v", r, collection.ReadOnlyCollectionError)
+		}
+	}()
+	v.Add("e")
+}
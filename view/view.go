@@ -0,0 +1,158 @@
+// Copyright (c) 2024 Gophers. All rights reserved.
+// Use of this source code is governed by the MIT
+// license that can be found in the LICENSE file.
+
+// Package view implements lazy read-only views over collections.
+// A view holds no elements of its own: it reads the underlying collection
+// on every access, so it reflects later changes to that collection and
+// costs no memory beyond the reference to it.
+//
+//	users := sequence.NewSequence(loadUsers())
+//	emails := view.NewMappedView(users, func(u User) string { return u.Email })
+//	render(emails) // an OrderedCollection[string], no []string allocated
+//
+// A MappedView projects the elements of a collection, a ChainView presents
+// several ordered collections as a single one.
+package view
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/seq"
+	"github.com/charbz/gophers/sequence"
+)
+
+// MappedView is a read-only OrderedCollection of the results of a function
+// applied to the elements of an underlying collection. The function is
+// called again on every access, it should therefore be cheap and pure.
+//
+// When the underlying collection is an OrderedCollection, At, Slice and
+// Backward use its own methods. Otherwise the view follows the iteration
+// order of Values, and At walks the collection in O(index).
+type MappedView[A, B any] struct {
+	source collection.Collection[A]
+	f      func(A) B
+}
+
+// NewMappedView returns a view presenting the elements of c transformed by f.
+//
+// example usage:
+//
+//	c := sequence.NewSequence([]string{"go", "gopher"})
+//	v := NewMappedView(c, func(s string) int { return len(s) })
+//	c.Add("zig")
+//	v.At(2)
+//
+// output:
+//
+//	3
+func NewMappedView[A, B any](c collection.Collection[A], f func(A) B) *MappedView[A, B] {
+	return &MappedView[A, B]{source: c, f: f}
+}
+
+// The following methods implement
+// the Collection interface.
+
+// Add panics with collection.ReadOnlyCollectionError, a view cannot be modified.
+func (v *MappedView[A, B]) Add(B) {
+	panic(collection.ReadOnlyCollectionError)
+}
+
+// Length returns the number of elements of the underlying collection.
+func (v *MappedView[A, B]) Length() int {
+	return v.source.Length()
+}
+
+// New returns a new sequence, since a view cannot hold elements of its own.
+func (v *MappedView[A, B]) New(s ...[]B) collection.Collection[B] {
+	return sequence.NewSequence(s...)
+}
+
+// Random returns the transformation of a random element of the underlying collection.
+func (v *MappedView[A, B]) Random() B {
+	return v.f(v.source.Random())
+}
+
+// Values returns an iterator over the transformed elements.
+func (v *MappedView[A, B]) Values() iter.Seq[B] {
+	return seq.Map(v.source.Values(), v.f)
+}
+
+// The following methods implement
+// the OrderedCollection interface.
+
+// At returns the transformation of the element at the given index.
+func (v *MappedView[A, B]) At(index int) B {
+	if o, ok := v.source.(collection.OrderedCollection[A]); ok {
+		return v.f(o.At(index))
+	}
+	for i, b := range v.All() {
+		if i == index {
+			return b
+		}
+	}
+	panic(collection.IndexOutOfBoundsError)
+}
+
+// All returns an iterator over the transformed elements and their indices.
+func (v *MappedView[A, B]) All() iter.Seq2[int, B] {
+	return func(yield func(int, B) bool) {
+		i := 0
+		for a := range v.source.Values() {
+			if !yield(i, v.f(a)) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Backward returns an iterator over the transformed elements and their
+// indices in reverse order.
+func (v *MappedView[A, B]) Backward() iter.Seq2[int, B] {
+	return func(yield func(int, B) bool) {
+		var backward iter.Seq2[int, A]
+		if o, ok := v.source.(collection.OrderedCollection[A]); ok {
+			backward = o.Backward()
+		} else {
+			backward = slices.Backward(slices.Collect(v.source.Values()))
+		}
+		for i, a := range backward {
+			if !yield(i, v.f(a)) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns a view of the elements between the start and end indices.
+// It is a view over the slice of the underlying collection when it is an
+// OrderedCollection, and a new sequence otherwise.
+func (v *MappedView[A, B]) Slice(start, end int) collection.OrderedCollection[B] {
+	if o, ok := v.source.(collection.OrderedCollection[A]); ok {
+		return NewMappedView(o.Slice(start, end), v.f)
+	}
+	s := v.ToSlice()
+	if start < 0 || end > len(s) || start > end {
+		panic(collection.IndexOutOfBoundsError)
+	}
+	return sequence.NewSequence(s[start:end])
+}
+
+// NewOrdered returns a new sequence, since a view cannot hold elements of its own.
+func (v *MappedView[A, B]) NewOrdered(s ...[]B) collection.OrderedCollection[B] {
+	return sequence.NewSequence(s...)
+}
+
+// ToSlice returns the transformed elements as a new slice.
+func (v *MappedView[A, B]) ToSlice() []B {
+	return slices.AppendSeq(make([]B, 0, v.source.Length()), v.Values())
+}
+
+// implement the Stringer interface
+func (v *MappedView[A, B]) String() string {
+	return fmt.Sprintf("MappedView(%T) %v", *new(B), v.ToSlice())
+}
@@ -0,0 +1,124 @@
+package view
+
+import (
+	"iter"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charbz/gophers/collection"
+	"github.com/charbz/gophers/collectiontest"
+	"github.com/charbz/gophers/list"
+	"github.com/charbz/gophers/sequence"
+	"github.com/charbz/gophers/set"
+)
+
+type user struct {
+	name  string
+	email string
+}
+
+func email(u user) string { return u.email }
+
+// stableSet is an unordered collection iterating in sorted order, since
+// the order of a Set changes between iterations.
+type stableSet struct {
+	*set.Set[user]
+}
+
+func (s *stableSet) Values() iter.Seq[user] {
+	return slices.Values(slices.SortedFunc(s.Set.Values(), func(a, b user) int { return strings.Compare(a.name, b.name) }))
+}
+
+func TestMappedView(t *testing.T) {
+	users := []user{{"ann", "ann@go.dev"}, {"bob", "bob@go.dev"}, {"cid", "cid@go.dev"}}
+	tests := []struct {
+		name   string
+		source collection.Collection[user]
+	}{
+		{name: "sequence", source: sequence.NewSequence(users)},
+		{name: "list", source: list.NewList(users)},
+		{name: "unordered", source: &stableSet{set.NewOrderedSet(users).ToSet()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewMappedView(tt.source, email)
+			want := slices.Collect(v.Values())
+			if v.Length() != 3 || len(want) != 3 {
+				t.Fatalf("Length() = %v, want 3", v.Length())
+			}
+			for i, e := range v.All() {
+				if v.At(i) != e || e != want[i] {
+					t.Errorf("At(%v) = %v, All() = %v, want %v", i, v.At(i), e, want[i])
+				}
+			}
+			var backward []string
+			for _, e := range v.Backward() {
+				backward = append(backward, e)
+			}
+			slices.Reverse(backward)
+			if !slices.Equal(backward, want) {
+				t.Errorf("Backward() = %v, want the reverse of %v", backward, want)
+			}
+			if got := slices.Collect(v.Slice(1, 3).Values()); !slices.Equal(got, want[1:]) {
+				t.Errorf("Slice(1, 3) = %v, want %v", got, want[1:])
+			}
+			if r := v.Random(); !slices.Contains(want, r) {
+				t.Errorf("Random() = %v, not in %v", r, want)
+			}
+		})
+	}
+}
+
+func TestMappedView_ReflectsSource(t *testing.T) {
+	calls := 0
+	c := sequence.NewSequence([]string{"go", "gopher"})
+	v := NewMappedView(c, func(s string) int {
+		calls++
+		return len(s)
+	})
+	if calls != 0 {
+		t.Errorf("NewMappedView() called the function %v times", calls)
+	}
+	c.Add("zig")
+	if v.Length() != 3 || v.At(2) != 3 {
+		t.Errorf("view = %v, want [2 6 3]", v)
+	}
+	if got := v.String(); got != "MappedView(int) [2 6 3]" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := collection.Filter(v, func(n int) bool { return n > 2 }); got.Length() != 2 {
+		t.Errorf("Filter() = %v, want 2 elements", got)
+	}
+}
+
+func TestMappedView_ReadOnly(t *testing.T) {
+	v := NewMappedView(list.NewList([]int{1}), func(i int) int { return -i })
+	defer func() {
+		if r := recover(); r != collection.ReadOnlyCollectionError {
+			t.Errorf("Add() recovered %v, want %v", r, collection.ReadOnlyCollectionError)
+		}
+	}()
+	v.Add(2)
+}
+
+func TestMappedView_MatchesSequence(t *testing.T) {
+	ops := collectiontest.OrderedOps[int, collection.OrderedCollection[int]](func(r *rand.Rand) int { return r.Intn(50) })
+	// only the read-only operations
+	var readOnly []collectiontest.Op[collection.OrderedCollection[int]]
+	for _, op := range ops {
+		if op.Name != "Add" {
+			readOnly = append(readOnly, op)
+		}
+	}
+	elements := []int{4, 8, 15, 16, 23, 42}
+	double := func(i int) int { return i * 2 }
+	collectiontest.Check(t,
+		func() collection.OrderedCollection[int] {
+			return sequence.NewSequence(collection.Map(sequence.NewSequence(elements), double))
+		},
+		func() collection.OrderedCollection[int] { return NewMappedView(list.NewList(elements), double) },
+		readOnly, collectiontest.Options[int]{Steps: 100},
+	)
+}